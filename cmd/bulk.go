@@ -0,0 +1,564 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/workflow"
+	"github.com/spf13/cobra"
+)
+
+var bulkCmd = &cobra.Command{
+	Use:   "bulk",
+	Short: "Apply one change to many tickets",
+	Long: `Apply one change to every ticket matching a query, or to ticket IDs read
+from stdin when the query is "-" (one per line; anything after the first word
+is ignored, so ` + "`st list`" + ` output can be piped in):
+
+  st bulk priority P2 tag:api status:open
+  st bulk tags +triaged,-needs-info project:web priority:P4
+  st bulk status backlog updated:>4w
+  st bulk assign run-42 status:open tag:ui
+  st bulk unassign status:open
+  st bulk cancel --reason "superseded by the v2 API" tag:v1
+  st list tag:stale | st bulk cancel -
+
+Queries work as in ` + "`st list`" + `: without project: they cover the current
+project, and DONE and CANCELLED tickets are left out unless a status is
+named. Status changes go through the normal workflow rules; tickets an action
+does not apply to are skipped with the reason. --dry-run prints the preview
+table without changing anything.`,
+}
+
+var (
+	bulkDryRun bool
+	bulkReason string
+)
+
+func init() {
+	bulkCmd.PersistentFlags().BoolVar(&bulkDryRun, "dry-run", false, "preview the changes without applying them")
+
+	statusAction := &cobra.Command{
+		Use:   "status <status> <query|->",
+		Short: "Move matching tickets to a status",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runBulk(bulkStatus, args[0], args[1:])
+		},
+	}
+	priorityAction := &cobra.Command{
+		Use:   "priority <P0-P5> <query|->",
+		Short: "Set the priority of matching tickets",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runBulk(bulkPriority, args[0], args[1:])
+		},
+	}
+	tagsAction := &cobra.Command{
+		Use:   "tags <+a,-b> <query|->",
+		Short: "Add and remove tags on matching tickets",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runBulk(bulkTags, args[0], args[1:])
+		},
+	}
+	assignAction := &cobra.Command{
+		Use:   "assign <agent-id> <query|->",
+		Short: "Assign matching tickets to an agent",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runBulk(bulkAssign, args[0], args[1:])
+		},
+	}
+	unassignAction := &cobra.Command{
+		Use:   "unassign <query|->",
+		Short: "Clear the assignee of matching tickets",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runBulk(bulkUnassign, "", args)
+		},
+	}
+	cancelAction := &cobra.Command{
+		Use:   "cancel <query|->",
+		Short: "Cancel matching tickets",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runBulk(bulkCancel, "", args)
+		},
+	}
+	cancelAction.Flags().StringVar(&bulkReason, "reason", "", "reason recorded on each cancelled ticket")
+
+	bulkCmd.AddCommand(statusAction, priorityAction, tagsAction, assignAction, unassignAction, cancelAction)
+	rootCmd.AddCommand(bulkCmd)
+}
+
+// Bulk actions.
+const (
+	bulkStatus   = "status"
+	bulkPriority = "priority"
+	bulkTags     = "tags"
+	bulkAssign   = "assign"
+	bulkUnassign = "unassign"
+	bulkCancel   = "cancel"
+)
+
+// bulkRun holds what every ticket in a bulk operation shares.
+type bulkRun struct {
+	cfg       *config.Config
+	store     *ticket.Store
+	el        *event.EventLog
+	eventsDir string
+	actor     string
+	runID     string
+	now       time.Time
+
+	action  string
+	value   string
+	project string        // the query's project, if any
+	notes   []string      // follow-on changes, printed after the table
+	status  ticket.Status // target of a status action
+	edit    ticket.Edit   // priority and tags actions
+}
+
+// bulkItem is one selected ticket: the change the action makes to it, or
+// why it is skipped.
+type bulkItem struct {
+	tk     *ticket.Ticket
+	change string
+	skip   string
+}
+
+func runBulk(action, value string, selector []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+
+	b := &bulkRun{
+		cfg:       cfg,
+		store:     ticket.NewStore(projectsDir),
+		el:        event.NewEventLog(eventsDir),
+		eventsDir: eventsDir,
+		actor:     identity.Actor(),
+		runID:     identity.RunID(),
+		now:       time.Now().UTC(),
+		action:    action,
+		value:     value,
+	}
+	if err := b.parseValue(); err != nil {
+		return err
+	}
+
+	tickets, source, err := b.selectTickets(selector)
+	if err != nil {
+		return err
+	}
+
+	items := make([]bulkItem, len(tickets))
+	for i, tk := range tickets {
+		items[i] = bulkItem{tk: tk}
+		change, err := b.plan(tk)
+		if err != nil {
+			items[i].skip = err.Error()
+			continue
+		}
+		items[i].change = change
+	}
+
+	var applied []string
+	var applyErr error
+	if !bulkDryRun {
+		for i := range items {
+			if items[i].skip != "" {
+				continue
+			}
+			if applyErr = b.apply(items[i].tk); applyErr != nil {
+				applyErr = fmt.Errorf("%s: %w", items[i].tk.ID, applyErr)
+				break
+			}
+			applied = append(applied, items[i].tk.ID)
+		}
+		if len(applied) > 0 {
+			_ = b.el.Append(event.Event{
+				TS:      b.now,
+				Event:   event.BulkApplied,
+				Project: b.project,
+				Actor:   b.actor,
+				RunID:   b.runID,
+				Data:    b.summary(source, items, applied),
+			})
+		}
+	}
+
+	if err := b.report(items, applied); err != nil {
+		return err
+	}
+	return applyErr
+}
+
+// parseValue validates the action's argument before any ticket is read.
+func (b *bulkRun) parseValue() error {
+	switch b.action {
+	case bulkStatus:
+		target, err := workflow.StatusFromAlias(strings.ToLower(b.value))
+		if err != nil {
+			return &cliError{Code: codeInvalidArgument, Message: err.Error()}
+		}
+		switch target {
+		case ticket.StatusReview, ticket.StatusBlocked, ticket.StatusCancelled:
+			return &cliError{
+				Code:    codeInvalidArgument,
+				Message: fmt.Sprintf("bulk status cannot move tickets to %s", target),
+				Hint:    "Submit reviews one at a time with `st status review`, block with `st hold`, and cancel with `st bulk cancel`.",
+			}
+		}
+		b.status = target
+	case bulkPriority:
+		p := ticket.Priority(strings.ToUpper(b.value))
+		if !ticket.ValidPriorities[p] {
+			return &cliError{Code: codeInvalidArgument, Message: fmt.Sprintf("invalid priority %q (use P0-P5)", b.value)}
+		}
+		b.edit.Priority = &p
+	case bulkTags:
+		add, remove, err := ticket.ParseTagEdit(b.value)
+		if err != nil {
+			return &cliError{Code: codeInvalidArgument, Message: err.Error(), Hint: "Pass tags as +add,-remove."}
+		}
+		b.edit.AddTags, b.edit.RemoveTags = add, remove
+	case bulkAssign:
+		if strings.TrimSpace(b.value) == "" {
+			return &cliError{Code: codeInvalidArgument, Message: "agent ID cannot be empty"}
+		}
+	}
+	return nil
+}
+
+// selectTickets resolves the selector: "-" reads IDs from stdin, anything
+// else is a query. It also returns a description of the selection for the
+// bulk.applied event.
+func (b *bulkRun) selectTickets(selector []string) ([]*ticket.Ticket, string, error) {
+	if len(selector) == 1 && selector[0] == "-" {
+		ids, err := readTicketIDs(os.Stdin)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(ids) == 0 {
+			return nil, "", &cliError{Code: codeInvalidArgument, Message: "no ticket IDs on stdin"}
+		}
+		var tickets []*ticket.Ticket
+		seen := map[string]bool{}
+		for _, id := range ids {
+			tk, err := b.store.Get(id)
+			if err != nil {
+				return nil, "", fmt.Errorf("get ticket: %w", err)
+			}
+			if !seen[tk.ID] {
+				seen[tk.ID] = true
+				tickets = append(tickets, tk)
+			}
+		}
+		return tickets, "stdin", nil
+	}
+
+	raw := queryFromArgs(selector)
+	expanded, err := ticket.ExpandSaved(raw, b.cfg.Queries)
+	if err != nil {
+		return nil, "", err
+	}
+	query, err := ticket.ParseQuery(expanded, b.now)
+	if err != nil {
+		return nil, "", &cliError{Code: codeInvalidArgument, Message: fmt.Sprintf("parse query: %v", err)}
+	}
+	filter := query.Filter
+	if filter.Project == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			filter.Project = findProjectFromCwd(b.cfg, cwd)
+		}
+	}
+	b.project = filter.Project
+	if len(filter.Statuses) == 0 {
+		filter.Excludes = []ticket.Status{ticket.StatusDone, ticket.StatusCancelled}
+	}
+
+	tickets, err := b.store.List(filter)
+	if err != nil {
+		return nil, "", fmt.Errorf("list tickets: %w", err)
+	}
+	sort.Slice(tickets, func(i, j int) bool {
+		wi, wj := listStatusWeight(tickets[i].Status), listStatusWeight(tickets[j].Status)
+		if wi != wj {
+			return wi < wj
+		}
+		if tickets[i].Priority != tickets[j].Priority {
+			return tickets[i].Priority < tickets[j].Priority
+		}
+		return tickets[i].Updated.After(tickets[j].Updated)
+	})
+	query.SortTickets(tickets)
+	return tickets, raw, nil
+}
+
+// readTicketIDs reads the first word of each non-empty line, skipping the
+// tree markers `st list --tree` prints.
+func readTicketIDs(f *os.File) ([]string, error) {
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(strings.TrimLeft(scanner.Text(), " └"))
+		if len(fields) > 0 {
+			ids = append(ids, fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ticket IDs: %w", err)
+	}
+	return ids, nil
+}
+
+// plan describes the change the action would make to tk, or returns why it
+// does not apply.
+func (b *bulkRun) plan(tk *ticket.Ticket) (string, error) {
+	switch b.action {
+	case bulkStatus:
+		if err := workflow.ValidateTransition(tk.Status, b.status); err != nil {
+			return "", err
+		}
+		if workflow.RequiresAssignee(b.status) && tk.Assignee == "" {
+			return "", fmt.Errorf("%s needs an assignee", b.status)
+		}
+		if workflow.RequiresSections(b.status) {
+			if missing := ticket.MissingSections(tk); len(missing) > 0 {
+				return "", fmt.Errorf("required sections empty: %s", strings.Join(missing, ", "))
+			}
+		}
+		if workflow.RequiresNote(tk.Status, b.status) {
+			hasNote, err := workflow.HasNoteSince(b.eventsDir, tk.ID, tk.Updated)
+			if err != nil {
+				return "", err
+			}
+			if !hasNote {
+				return "", fmt.Errorf("needs a note before leaving %s", tk.Status)
+			}
+		}
+		return fmt.Sprintf("%s → %s", tk.Status, b.status), nil
+
+	case bulkPriority, bulkTags:
+		preview := *tk
+		preview.Tags = append([]string(nil), tk.Tags...)
+		change, err := ticket.ApplyEdit(&preview, b.edit, b.actor, b.runID, b.now)
+		if errors.Is(err, ticket.ErrNoChanges) {
+			return "", errors.New("already up to date")
+		}
+		if err != nil {
+			return "", err
+		}
+		if b.actor == "agent" {
+			if err := checkEditPolicy(b.cfg, change); err != nil {
+				return "", err
+			}
+		}
+		return strings.TrimPrefix(strings.TrimPrefix(change.Diff(), "Priority: "), "Tags: "), nil
+
+	case bulkAssign:
+		if tk.Assignee == b.value {
+			return "", fmt.Errorf("already assigned to %s", b.value)
+		}
+		return fmt.Sprintf("%s → %s", assigneeLabel(tk.Assignee), b.value), nil
+
+	case bulkUnassign:
+		if tk.Assignee == "" {
+			return "", errors.New("not assigned")
+		}
+		if workflow.RequiresAssignee(tk.Status) {
+			return "", fmt.Errorf("%s needs an assignee — use st handoff", tk.Status)
+		}
+		return fmt.Sprintf("%s → (none)", tk.Assignee), nil
+
+	case bulkCancel:
+		if tk.Status == ticket.StatusCancelled {
+			return "", errors.New("already CANCELLED")
+		}
+		return fmt.Sprintf("%s → %s", tk.Status, ticket.StatusCancelled), nil
+	}
+	return "", fmt.Errorf("unknown bulk action %q", b.action)
+}
+
+func assigneeLabel(a string) string {
+	if a == "" {
+		return "(none)"
+	}
+	return a
+}
+
+// apply makes the planned change to tk, saves it and logs the same event the
+// single-ticket command would, marked with "bulk".
+func (b *bulkRun) apply(tk *ticket.Ticket) error {
+	ev := event.Event{TS: b.now, Ticket: tk.ID, Project: tk.Project, Actor: b.actor, RunID: b.runID}
+	from := tk.Status
+
+	switch b.action {
+	case bulkStatus:
+		transitionTicket(b.cfg, tk, b.status, b.actor, b.runID, b.now)
+		ev.Event = "status." + strings.ToLower(string(b.status))
+		ev.Data = map[string]any{"from": string(from)}
+
+	case bulkPriority, bulkTags:
+		change, err := ticket.ApplyEdit(tk, b.edit, b.actor, b.runID, b.now)
+		if err != nil {
+			return err
+		}
+		ev.Event = event.TicketEdited
+		ev.Data = editedData(change)
+
+	case bulkAssign:
+		tk.Assignee = b.value
+		tk.Updated = b.now
+		ticket.AppendSection(tk, "Assigned", b.actor, b.runID, "", map[string]string{"assignee": b.value}, b.now)
+		ev.Event = event.TicketAssigned
+		ev.Data = map[string]any{"assignee": b.value}
+
+	case bulkUnassign:
+		previous := tk.Assignee
+		tk.Assignee = ""
+		tk.Updated = b.now
+		ticket.AppendSection(tk, "Unassigned", b.actor, b.runID, "", map[string]string{"previous": previous}, b.now)
+		ev.Event = event.TicketUnassigned
+		ev.Data = map[string]any{"previous": previous}
+
+	case bulkCancel:
+		tk.Status = ticket.StatusCancelled
+		tk.PriorStatus = nil
+		tk.Assignee = ""
+		tk.Updated = b.now
+		ticket.AppendSection(tk, "Cancelled", b.actor, b.runID, bulkReason, nil, b.now)
+		ev.Event = event.StatusCancelled
+		ev.Data = map[string]any{"from": string(from), "reason": "cancel"}
+		if bulkReason != "" {
+			ev.Data["message"] = bulkReason
+		}
+	}
+
+	if err := b.store.Save(tk); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+	ev.Data["bulk"] = true
+	_ = b.el.Append(ev)
+
+	// Finishing a ticket releases its dependents and may complete its epic.
+	if tk.Status == ticket.StatusDone || tk.Status == ticket.StatusCancelled {
+		b.notes = append(b.notes, releaseDependents(b.cfg, b.store, b.el, tk, b.runID, b.now)...)
+	}
+	return nil
+}
+
+// summary is the bulk.applied event payload.
+func (b *bulkRun) summary(source string, items []bulkItem, applied []string) map[string]any {
+	skipped := map[string]any{}
+	for _, it := range items {
+		if it.skip != "" {
+			skipped[it.tk.ID] = it.skip
+		}
+	}
+	data := map[string]any{
+		"action":   b.action,
+		"selector": source,
+		"tickets":  applied,
+		"count":    len(applied),
+		"skipped":  skipped,
+	}
+	if b.value != "" {
+		data["value"] = b.value
+	}
+	if b.action == bulkCancel && bulkReason != "" {
+		data["message"] = bulkReason
+	}
+	return data
+}
+
+// bulkOutput is the st.bulk schema.
+type bulkOutput struct {
+	Action  string          `json:"action"`
+	Value   string          `json:"value,omitempty"`
+	DryRun  bool            `json:"dry_run"`
+	Tickets []bulkOutputRow `json:"tickets"`
+	Changed int             `json:"changed"`
+	Skipped int             `json:"skipped"`
+}
+
+type bulkOutputRow struct {
+	ticketRef
+	Change  string `json:"change,omitempty"`
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// report prints the preview table (or, once applied, the result) and a
+// count line.
+func (b *bulkRun) report(items []bulkItem, applied []string) error {
+	done := make(map[string]bool, len(applied))
+	for _, id := range applied {
+		done[id] = true
+	}
+	changes, skipped := 0, 0
+	for _, it := range items {
+		if it.skip != "" {
+			skipped++
+		} else if bulkDryRun || done[it.tk.ID] {
+			changes++
+		}
+	}
+
+	if structured() {
+		out := bulkOutput{Action: b.action, Value: b.value, DryRun: bulkDryRun, Tickets: []bulkOutputRow{}, Changed: changes, Skipped: skipped}
+		for _, it := range items {
+			out.Tickets = append(out.Tickets, bulkOutputRow{ticketRef: refTo(it.tk), Change: it.change, Skipped: it.skip})
+		}
+		return writeOutput("bulk", out)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No tickets matched.")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "ID\tTITLE\tCHANGE")
+	for _, it := range items {
+		change := it.change
+		if it.skip != "" {
+			change = "skip: " + it.skip
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", it.tk.ID, truncate(it.tk.Title, 40), change)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if bulkDryRun {
+		fmt.Printf("Dry run: would change %d of %d ticket(s).\n", changes, len(items))
+	} else {
+		fmt.Printf("Changed %d of %d ticket(s).\n", changes, len(items))
+	}
+	for _, note := range b.notes {
+		fmt.Println(note)
+	}
+	return nil
+}
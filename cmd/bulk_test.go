@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestBulk_DryRunChangesNothing(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "first", ticket.StatusOpen)
+	b := env.createTicket(t, "second", ticket.StatusBacklog)
+
+	out, err := env.runCmd(t, "bulk", "priority", "P1", "project:testproject", "--dry-run")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{a.ID, b.ID, "P3 → P1", "Dry run: would change 2 of 2 ticket(s)."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	for _, id := range []string{a.ID, b.ID} {
+		tk, err := env.Store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tk.Priority != ticket.DefaultPriority {
+			t.Errorf("%s priority = %s, want unchanged", id, tk.Priority)
+		}
+	}
+	events, err := event.QueryEvents(env.EventsDir, event.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("dry run logged events: %+v", events)
+	}
+}
+
+func TestBulk_PriorityLogsPerTicketAndSummary(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "first", ticket.StatusOpen)
+	b := env.createTicket(t, "second", ticket.StatusOpen)
+	done := env.createTicket(t, "finished", ticket.StatusDone)
+
+	out, err := env.runCmd(t, "bulk", "priority", "P1", "project:testproject")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Changed 2 of 2 ticket(s).") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	for _, id := range []string{a.ID, b.ID} {
+		tk, err := env.Store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tk.Priority != ticket.PriorityP1 || !strings.Contains(tk.Body, "## Edited") {
+			t.Errorf("%s not edited: %+v", id, tk)
+		}
+	}
+	if tk, _ := env.Store.Get(done.ID); tk.Priority != ticket.DefaultPriority {
+		t.Errorf("DONE ticket was changed without a status filter")
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var edited int
+	var summary *event.Event
+	for i, e := range events {
+		switch e.Event {
+		case event.TicketEdited:
+			edited++
+			if e.Data["bulk"] != true {
+				t.Errorf("ticket.edited not marked bulk: %+v", e.Data)
+			}
+		case event.BulkApplied:
+			summary = &events[i]
+		}
+	}
+	if edited != 2 {
+		t.Errorf("ticket.edited events = %d, want 2", edited)
+	}
+	if summary == nil {
+		t.Fatalf("no bulk.applied event in %+v", events)
+	}
+	if summary.Data["action"] != "priority" || summary.Data["value"] != "P1" || summary.Data["count"] != float64(2) {
+		t.Errorf("bulk.applied data = %+v", summary.Data)
+	}
+}
+
+func TestBulk_StatusSkipsInvalidTransitions(t *testing.T) {
+	env := newTestEnv(t)
+	open := env.createTicket(t, "open one", ticket.StatusOpen)
+	backlog := env.createTicket(t, "backlog one", ticket.StatusBacklog)
+
+	out, err := env.runCmd(t, "bulk", "status", "open", "project:testproject")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "skip: ticket is already OPEN") || !strings.Contains(out, "Changed 1 of 2 ticket(s).") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	tk, err := env.Store.Get(backlog.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != ticket.StatusOpen {
+		t.Errorf("backlog ticket status = %s, want OPEN", tk.Status)
+	}
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: open.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("skipped ticket logged events: %+v", events)
+	}
+
+	if _, err := env.runCmd(t, "bulk", "status", "review", "project:testproject"); err == nil {
+		t.Error("expected bulk status review to be refused")
+	}
+}
+
+func TestBulk_StatusReworkMatchesStatusCommand(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "needs rework", ticket.StatusHumanReview)
+	if _, err := env.runCmd(t, "note", "--ticket", tk.ID, "error path is untested"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.runCmd(t, "bulk", "status", "rework", "status:human-review"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	updated, err := env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != ticket.StatusRework {
+		t.Fatalf("status = %s, want REWORK", updated.Status)
+	}
+	sections := ticket.Sections(updated.Body)
+	rework, ok := ticket.LastSection(sections, statusHeading(ticket.StatusRework))
+	if !ok || rework.Fields["reviewed-by"] == "" {
+		t.Errorf("rework section should record reviewed-by, got %+v", rework)
+	}
+	if _, ok := ticket.LastSection(sections, "State of Play"); !ok {
+		t.Errorf("no State of Play section:\n%s", updated.Body)
+	}
+}
+
+func TestBulk_CancelFromStdin(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "first", ticket.StatusOpen)
+	b := env.createTicket(t, "second", ticket.StatusBacklog)
+	keep := env.createTicket(t, "kept", ticket.StatusOpen)
+
+	in, err := os.CreateTemp(t.TempDir(), "ids-*.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.WriteString(a.ID + "  OPEN  P3  first\n\n└ " + b.ID + "\n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		t.Fatal(err)
+	}
+	origStdin := os.Stdin
+	t.Cleanup(func() { os.Stdin = origStdin })
+	os.Stdin = in
+
+	out, err := env.runCmd(t, "bulk", "cancel", "-", "--reason", "out of scope")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Changed 2 of 2 ticket(s).") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	for _, id := range []string{a.ID, b.ID} {
+		tk, err := env.Store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tk.Status != ticket.StatusCancelled || !strings.Contains(tk.Body, "out of scope") {
+			t.Errorf("%s not cancelled: %+v", id, tk)
+		}
+	}
+	if tk, _ := env.Store.Get(keep.ID); tk.Status != ticket.StatusOpen {
+		t.Errorf("unlisted ticket status = %s", tk.Status)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	last := events[len(events)-1]
+	if last.Event != event.BulkApplied || last.Data["selector"] != "stdin" {
+		t.Errorf("last event = %+v, want bulk.applied from stdin", last)
+	}
+}
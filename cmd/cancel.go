@@ -113,6 +113,7 @@ func runCancel(_ *cobra.Command, args []string) error {
 		})
 		fmt.Printf("Auto-unblocked: %s → %s\n", ut.ID, ut.Status)
 	}
+	rollupParents(cfg, store, el, tk, "", now)
 
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
@@ -183,3 +184,68 @@ func TestCancel_AutoUnblocksDependents(t *testing.T) {
 		t.Errorf("ticket B status = %s, want OPEN", updatedB.Status)
 	}
 }
+
+func TestCancel_RollsUpEpic(t *testing.T) {
+	env := newTestEnv(t)
+
+	epic := env.createTicket(t, "epic", ticket.StatusInProgress)
+	done := env.createTicket(t, "done child", ticket.StatusDone)
+	open := env.createTicket(t, "open child", ticket.StatusOpen)
+	for _, tk := range []*ticket.Ticket{done, open} {
+		tk.Parent = epic.ID
+		if err := env.Store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := env.runCmd(t, "cancel", open.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Epic "+epic.ID+": IN-PROGRESS → REVIEW") {
+		t.Errorf("output = %q, want epic rollup line", out)
+	}
+
+	updated, err := env.Store.Get(epic.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status != ticket.StatusReview {
+		t.Errorf("epic status = %s, want REVIEW", updated.Status)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: epic.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Event != event.StatusReview || events[0].Data["reason"] != "rollup" {
+		t.Errorf("expected a status.review rollup event, got %+v", events)
+	}
+}
+
+func TestCancel_RollupOff(t *testing.T) {
+	env := newTestEnv(t)
+	env.Config.Epics.AutoComplete = config.EpicAutoOff
+	if err := env.Config.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	epic := env.createTicket(t, "epic", ticket.StatusInProgress)
+	child := env.createTicket(t, "child", ticket.StatusDone)
+	child.Parent = epic.ID
+	if err := env.Store.Save(child); err != nil {
+		t.Fatal(err)
+	}
+	other := env.createTicket(t, "other child", ticket.StatusOpen)
+	other.Parent = epic.ID
+	if err := env.Store.Save(other); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := env.runCmd(t, "cancel", other.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated, _ := env.Store.Get(epic.ID); updated.Status != ticket.StatusInProgress {
+		t.Errorf("epic status = %s, want IN-PROGRESS with auto_complete off", updated.Status)
+	}
+}
@@ -96,6 +96,7 @@ func runClose(_ *cobra.Command, args []string) error {
 		})
 		fmt.Printf("Auto-unblocked: %s → %s\n", ut.ID, ut.Status)
 	}
+	rollupParents(cfg, store, el, tk, "", now)
 
 	return nil
 }
@@ -13,6 +13,8 @@ import (
 	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/project"
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // testEnv sets up a temp config, projects dir, and events dir.
@@ -250,17 +252,30 @@ func shouldDefaultToHuman(args []string) bool {
 func resetFlags() {
 	runIDFlag = ""
 	humanFlag = false
+	formatFlag = formatTable
 	statusTicket = ""
 	noteTicket = ""
+	noteFile = ""
 	listProject = ""
 	listStatus = ""
 	listAll = false
+	listTree = false
+	listSave = ""
+	searchProject = ""
+	searchLimit = 10
+	editTitle = ""
+	editPriority = ""
+	editTags = ""
+	editDescription = ""
 	newPriority = "P3"
 	newTags = ""
 	newDependsOn = ""
 	newDescription = ""
 	newProject = ""
 	newTitle = ""
+	newParent = ""
+	newTemplate = ""
+	newSections = nil
 	pickTicket = ""
 	reviewTicket = ""
 	reviewCLI = ""
@@ -272,6 +287,37 @@ func resetFlags() {
 	spawnTimeout = 45 * time.Minute
 	spawnBackend = "claude"
 	spawnDryRun = false
+	rulesLintStrict = false
+	rulesTestTool = "Bash"
+	rulesTestCommand = ""
+	rulesTestFilePath = ""
+	rulesTestURL = ""
+	rulesTestEvent = "PreToolUse"
+	rulesTestProject = ""
+	rulesLintProject = ""
+	rulesReplaySince = "7d"
+	rulesReplayProj = ""
+	rulesSuggestSince = "30d"
+	rulesSuggestProj = ""
+	rulesSuggestMin = 2
+	rulesSuggestLimit = 10
+	statsProject = ""
+	statsSince = "30d"
+	statsLimit = 20
+	learnProject = ""
+	learnTicket = ""
+	learnList = false
+	learnRemove = 0
+	bulkDryRun = false
+	bulkReason = ""
+	moveProject = ""
+	moveForce = false
+
+	// Commands that check Flags().Changed see flags from earlier runs
+	// unless the parsed state is cleared too.
+	for _, c := range []*cobra.Command{editCmd, newCmd, moveCmd} {
+		c.Flags().VisitAll(func(f *pflag.Flag) { f.Changed = false })
+	}
 }
 
 func TestOverride_HappyPath(t *testing.T) {
@@ -72,6 +72,12 @@ func runContext(_ *cobra.Command, _ []string) error {
 		}
 	}
 
+	// Context has always printed bare JSON; --format json|yaml wraps it in
+	// the st.context envelope like the other read commands.
+	if structured() {
+		return writeOutput("context", out)
+	}
+
 	data, err := json.MarshalIndent(out, "", "  ")
 	if err != nil {
 		return fmt.Errorf("marshal context: %w", err)
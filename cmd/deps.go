@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var depsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Show or edit a ticket's dependencies",
+	Long: `Show or edit a ticket's dependencies after creation. Dependencies must exist
+and must not form a cycle. Adding an unresolved dependency blocks the ticket;
+removing the last unresolved one snaps it back to its prior status.
+
+  st deps show st_a1b2c3
+  st deps add st_a1b2c3 st_d4e5f6 st_g7h8i9
+  st deps rm st_a1b2c3 st_d4e5f6`,
+}
+
+var depsAddCmd = &cobra.Command{
+	Use:   "add <ticket-id> <dep-id>...",
+	Short: "Add dependencies to a ticket",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runDepsEdit(args[0], args[1:], false)
+	},
+}
+
+var depsRmCmd = &cobra.Command{
+	Use:   "rm <ticket-id> <dep-id>...",
+	Short: "Remove dependencies from a ticket",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runDepsEdit(args[0], args[1:], true)
+	},
+}
+
+var depsShowCmd = &cobra.Command{
+	Use:   "show <ticket-id>",
+	Short: "Show a ticket's dependencies and dependents",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runDepsShow,
+}
+
+func init() {
+	depsCmd.AddCommand(depsAddCmd)
+	depsCmd.AddCommand(depsRmCmd)
+	depsCmd.AddCommand(depsShowCmd)
+	rootCmd.AddCommand(depsCmd)
+}
+
+func runDepsEdit(id string, ids []string, remove bool) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	tk, err := store.Get(id)
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+
+	deps := slices.Clone(tk.DependsOn)
+	for _, dep := range ids {
+		if d, err := store.Get(dep); err == nil && !slices.Contains(deps, dep) {
+			dep = d.ID
+		}
+		switch {
+		case remove && !slices.Contains(deps, dep):
+			return fmt.Errorf("%s does not depend on %s", tk.ID, dep)
+		case remove:
+			deps = slices.DeleteFunc(deps, func(d string) bool { return d == dep })
+		case slices.Contains(deps, dep):
+			return fmt.Errorf("%s already depends on %s", tk.ID, dep)
+		default:
+			deps = append(deps, dep)
+		}
+	}
+
+	now := time.Now().UTC()
+	actor := identity.Actor()
+	runID := identity.RunID()
+	change, err := ticket.SetDependencies(store, tk, deps, actor, runID, now)
+	if err != nil {
+		return err
+	}
+	tk.Updated = now
+	if err := store.Save(tk); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	fmt.Printf("Dependencies of %s: %s\n", tk.ID, depsSummary(tk.DependsOn))
+	logDepsChange(store, event.NewEventLog(eventsDir), tk, change, actor, runID, now)
+	return nil
+}
+
+// logDepsChange logs a ticket.deps-changed event for a change made by
+// ticket.SetDependencies, plus the status event if it blocked or unblocked
+// the ticket.
+func logDepsChange(store *ticket.Store, el *event.EventLog, tk *ticket.Ticket, change ticket.DepsChange, actor, runID string, now time.Time) {
+	_ = el.Append(event.Event{
+		TS:      now,
+		Event:   event.TicketDepsChanged,
+		Ticket:  tk.ID,
+		Project: tk.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data: map[string]any{
+			"added":      change.Added,
+			"removed":    change.Removed,
+			"depends_on": tk.DependsOn,
+		},
+	})
+
+	switch {
+	case change.Blocked:
+		unresolved, _ := ticket.CheckDependencies(store, tk)
+		_ = el.Append(event.Event{
+			TS:      now,
+			Event:   event.StatusBlocked,
+			Ticket:  tk.ID,
+			Project: tk.Project,
+			Actor:   "st",
+			Data: map[string]any{
+				"reason":       "depends-on",
+				"refs":         tk.DependsOn,
+				"prior_status": string(change.From),
+			},
+		})
+		fmt.Printf("Auto-blocked %s: waiting on %s\n", tk.ID, strings.Join(unresolved, ", "))
+	case change.Unblocked:
+		_ = el.Append(event.Event{
+			TS:      now,
+			Event:   "status." + strings.ToLower(string(tk.Status)),
+			Ticket:  tk.ID,
+			Project: tk.Project,
+			Actor:   "st",
+			Data: map[string]any{
+				"from":   string(ticket.StatusBlocked),
+				"reason": "auto-unblock",
+			},
+		})
+		fmt.Printf("Auto-unblocked %s: BLOCKED → %s\n", tk.ID, tk.Status)
+	}
+}
+
+func runDepsShow(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	tk, err := store.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+	dependents, err := ticket.FindDependents(store, tk.ID)
+	if err != nil {
+		return fmt.Errorf("find dependents: %w", err)
+	}
+	unresolved, err := ticket.CheckDependencies(store, tk)
+	if err != nil {
+		return fmt.Errorf("check dependencies: %w", err)
+	}
+
+	if structured() {
+		out := depsOutput{
+			Ticket:     refTo(tk),
+			DependsOn:  []depsRef{},
+			Dependents: []ticketRef{},
+			Unresolved: nonNil(unresolved),
+		}
+		for _, id := range tk.DependsOn {
+			dep, err := store.Get(id)
+			if err != nil {
+				out.DependsOn = append(out.DependsOn, depsRef{ticketRef: ticketRef{ID: id}, Missing: true})
+				continue
+			}
+			out.DependsOn = append(out.DependsOn, depsRef{ticketRef: refTo(dep)})
+		}
+		for _, d := range dependents {
+			out.Dependents = append(out.Dependents, refTo(d))
+		}
+		return writeOutput("deps", out)
+	}
+
+	fmt.Printf("%s %s [%s]\n", tk.ID, tk.Title, tk.Status)
+
+	fmt.Println("\nDepends on:")
+	if len(tk.DependsOn) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, id := range tk.DependsOn {
+		dep, err := store.Get(id)
+		if err != nil {
+			fmt.Printf("  - %s (missing)\n", id)
+			continue
+		}
+		fmt.Printf("  - %s %s [%s]\n", dep.ID, dep.Title, dep.Status)
+	}
+
+	fmt.Println("\nDependents:")
+	if len(dependents) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, d := range dependents {
+		fmt.Printf("  - %s %s [%s]\n", d.ID, d.Title, d.Status)
+	}
+
+	if len(unresolved) > 0 {
+		fmt.Printf("\nUnresolved: %s\n", strings.Join(unresolved, ", "))
+	}
+	return nil
+}
+
+// depsOutput is the st.deps schema.
+type depsOutput struct {
+	Ticket     ticketRef   `json:"ticket"`
+	DependsOn  []depsRef   `json:"depends_on"`
+	Dependents []ticketRef `json:"dependents"`
+	// Unresolved lists the dependencies not yet DONE.
+	Unresolved []string `json:"unresolved"`
+}
+
+type depsRef struct {
+	ticketRef
+	Missing bool `json:"missing,omitempty"`
+}
+
+func depsSummary(deps []string) string {
+	if len(deps) == 0 {
+		return "(none)"
+	}
+	return strings.Join(deps, ", ")
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestDeps_AddBlocksAndRmUnblocks(t *testing.T) {
+	env := newTestEnv(t)
+	dep := env.createTicket(t, "dependency", ticket.StatusOpen)
+	tk := env.createTicket(t, "work", ticket.StatusOpen)
+
+	out, err := env.runCmd(t, "deps", "add", tk.ID, dep.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Auto-blocked "+tk.ID+": waiting on "+dep.ID) {
+		t.Errorf("output = %q", out)
+	}
+	updated, _ := env.Store.Get(tk.ID)
+	if updated.Status != ticket.StatusBlocked || updated.PriorStatus == nil || *updated.PriorStatus != ticket.StatusOpen {
+		t.Fatalf("expected BLOCKED with prior OPEN, got %s", updated.Status)
+	}
+
+	out, err = env.runCmd(t, "deps", "rm", tk.ID, dep.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Auto-unblocked "+tk.ID+": BLOCKED → OPEN") {
+		t.Errorf("output = %q", out)
+	}
+	updated, _ = env.Store.Get(tk.ID)
+	if updated.Status != ticket.StatusOpen || len(updated.DependsOn) != 0 {
+		t.Errorf("expected OPEN with no deps, got %s %v", updated.Status, updated.DependsOn)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: tk.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var kinds []string
+	for _, e := range events {
+		kinds = append(kinds, e.Event)
+	}
+	want := []string{event.TicketDepsChanged, event.StatusBlocked, event.TicketDepsChanged, event.StatusOpen}
+	if strings.Join(kinds, " ") != strings.Join(want, " ") {
+		t.Errorf("events = %v, want %v", kinds, want)
+	}
+}
+
+func TestDeps_AddRejectsCycle(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "a", ticket.StatusOpen)
+	b := env.createTicket(t, "b", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "deps", "add", a.ID, b.ID); err != nil {
+		t.Fatal(err)
+	}
+	_, err := env.runCmd(t, "deps", "add", b.ID, a.ID)
+	if err == nil || !strings.Contains(err.Error(), b.ID+" → "+a.ID+" → "+b.ID) {
+		t.Errorf("expected a cycle error with the path, got %v", err)
+	}
+	if _, err := env.runCmd(t, "deps", "add", b.ID, a.ID[:6]); err == nil || !strings.Contains(err.Error(), "dependency cycle") {
+		t.Errorf("expected a cycle error for an ID prefix, got %v", err)
+	}
+}
+
+func TestDeps_StoresFullIDForPrefix(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "a", ticket.StatusOpen)
+	b := env.createTicket(t, "b", ticket.StatusDone)
+
+	if _, err := env.runCmd(t, "deps", "add", a.ID, b.ID[:6]); err != nil {
+		t.Fatal(err)
+	}
+	got, err := env.Store.Get(a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.DependsOn) != 1 || got.DependsOn[0] != b.ID {
+		t.Errorf("DependsOn = %v, want [%s]", got.DependsOn, b.ID)
+	}
+	if _, err := env.runCmd(t, "deps", "rm", a.ID, b.ID[:6]); err != nil {
+		t.Errorf("rm by prefix: %v", err)
+	}
+}
+
+func TestDeps_AddMissingAndRmUnknown(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "work", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "deps", "add", tk.ID, "st_zzzzzz"); err == nil {
+		t.Error("expected an error for a missing dependency")
+	}
+	if _, err := env.runCmd(t, "deps", "rm", tk.ID, "st_zzzzzz"); err == nil || !strings.Contains(err.Error(), "does not depend on") {
+		t.Errorf("expected a not-a-dependency error, got %v", err)
+	}
+}
+
+func TestDeps_Show(t *testing.T) {
+	env := newTestEnv(t)
+	dep := env.createTicket(t, "dependency", ticket.StatusOpen)
+	tk := env.createTicket(t, "work", ticket.StatusOpen)
+	if _, err := env.runCmd(t, "deps", "add", tk.ID, dep.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "deps", "show", tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "- "+dep.ID+" dependency [OPEN]") || !strings.Contains(out, "Unresolved: "+dep.ID) {
+		t.Errorf("show output:\n%s", out)
+	}
+
+	out, err = env.runCmd(t, "deps", "show", dep.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "Dependents:\n  - "+tk.ID+" work [BLOCKED]") {
+		t.Errorf("show output:\n%s", out)
+	}
+}
+
+func TestDeps_ShowFormatYAML(t *testing.T) {
+	env := newTestEnv(t)
+	dep := env.createTicket(t, "dependency", ticket.StatusOpen)
+	tk := env.createTicket(t, "work", ticket.StatusOpen)
+	if _, err := env.runCmd(t, "deps", "add", tk.ID, dep.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "--format", "yaml", "deps", "show", tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"schema: st.deps/v1", "depends_on:\n        - id: " + dep.ID, "unresolved:\n        - " + dep.ID} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var editCmd = &cobra.Command{
+	Use:   "edit <ticket-id>",
+	Short: "Change a ticket's title, priority, tags or description",
+	Long: `Edit a ticket's title, priority or tags, or append to its description.
+Each edit appends an Edited section with the before and after values and
+logs a ticket.edited event.
+
+  st edit st_a1b2c3 --priority P2 --tags +api,-ui
+  st edit st_a1b2c3 --append-description "Also covers the admin API."
+
+Agents are bound by the [edit] policy in config: agent_fields limits the
+fields they may change, and they may only lower priority unless
+agent_raise_priority is set.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runEdit,
+}
+
+var (
+	editTitle       string
+	editPriority    string
+	editTags        string
+	editDescription string
+)
+
+func init() {
+	editCmd.Flags().StringVar(&editTitle, "title", "", "new title")
+	editCmd.Flags().StringVarP(&editPriority, "priority", "p", "", "new priority (P0-P5)")
+	editCmd.Flags().StringVar(&editTags, "tags", "", "tags to add or remove, e.g. +api,-ui")
+	editCmd.Flags().StringVar(&editDescription, "append-description", "", "text to append to the description")
+	rootCmd.AddCommand(editCmd)
+}
+
+func runEdit(cmd *cobra.Command, args []string) error {
+	var e ticket.Edit
+	if cmd.Flags().Changed("title") {
+		e.Title = &editTitle
+	}
+	if cmd.Flags().Changed("priority") {
+		p := ticket.Priority(editPriority)
+		e.Priority = &p
+	}
+	if cmd.Flags().Changed("tags") {
+		add, remove, err := ticket.ParseTagEdit(editTags)
+		if err != nil {
+			return &cliError{Code: codeInvalidArgument, Message: err.Error(), Hint: "Pass --tags +add,-remove."}
+		}
+		e.AddTags, e.RemoveTags = add, remove
+	}
+	if cmd.Flags().Changed("append-description") {
+		if strings.TrimSpace(editDescription) == "" {
+			return &cliError{Code: codeInvalidArgument, Message: "description cannot be empty"}
+		}
+		e.AppendDescription = editDescription
+	}
+	if e.Title == nil && e.Priority == nil && e.AddTags == nil && e.RemoveTags == nil && e.AppendDescription == "" {
+		return &cliError{
+			Code:    codeInvalidArgument,
+			Message: "nothing to edit",
+			Hint:    "Pass --title, --priority, --tags or --append-description.",
+		}
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	tk, err := store.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+
+	now := time.Now().UTC()
+	actor := identity.Actor()
+	runID := identity.RunID()
+	change, err := ticket.ApplyEdit(tk, e, actor, runID, now)
+	if errors.Is(err, ticket.ErrNoChanges) {
+		fmt.Printf("%s: nothing changed\n", tk.ID)
+		return nil
+	}
+	if err != nil {
+		return &cliError{Code: codeInvalidArgument, Message: err.Error()}
+	}
+	if actor == "agent" {
+		if err := checkEditPolicy(cfg, change); err != nil {
+			return err
+		}
+	}
+
+	if err := store.Save(tk); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	_ = event.NewEventLog(eventsDir).Append(event.Event{
+		TS:      now,
+		Event:   event.TicketEdited,
+		Ticket:  tk.ID,
+		Project: tk.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    editedData(change),
+	})
+
+	fmt.Printf("Edited %s:\n", tk.ID)
+	for _, line := range strings.Split(change.Diff(), "\n") {
+		if line != "" {
+			fmt.Printf("  %s\n", line)
+		}
+	}
+	return nil
+}
+
+// checkEditPolicy enforces the [edit] agent policy on an edit.
+func checkEditPolicy(cfg *config.Config, change ticket.EditChange) error {
+	for _, field := range change.Fields() {
+		if !cfg.AgentMayEdit(field) {
+			return &cliError{
+				Code:    codePolicyDenied,
+				Message: fmt.Sprintf("agents may not edit the %s of a ticket", field),
+				Hint:    "Ask a human to make this change, or add the field to [edit] agent_fields in config.",
+			}
+		}
+	}
+	if change.RaisesPriority() && !cfg.Edit.AgentRaisePriority {
+		return &cliError{
+			Code:    codePolicyDenied,
+			Message: fmt.Sprintf("agents may not raise priority (%s → %s)", change.PriorityFrom, change.PriorityTo),
+			Hint:    "Leave a note asking a human to reprioritize, or set [edit] agent_raise_priority in config.",
+		}
+	}
+	return nil
+}
+
+// editedData is the ticket.edited event payload: from/to for title and
+// priority, added/removed for tags and the appended description text.
+func editedData(change ticket.EditChange) map[string]any {
+	data := map[string]any{"fields": change.Fields()}
+	if change.TitleTo != "" {
+		data["title"] = map[string]any{"from": change.TitleFrom, "to": change.TitleTo}
+	}
+	if change.PriorityTo != "" {
+		data["priority"] = map[string]any{"from": string(change.PriorityFrom), "to": string(change.PriorityTo)}
+	}
+	if len(change.TagsAdded) > 0 || len(change.TagsRemoved) > 0 {
+		data["tags"] = map[string]any{"added": nonNil(change.TagsAdded), "removed": nonNil(change.TagsRemoved)}
+	}
+	if change.Description != "" {
+		data["description"] = change.Description
+	}
+	return data
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestEdit_HappyPath(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "original title", ticket.StatusOpen)
+
+	out, err := env.runCmd(t, "edit", tk.ID, "--title", "better title", "--priority", "P1", "--tags", "+api,-none", "--append-description", "Covers the admin API too.")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"Edited " + tk.ID, "Title: original title → better title", "Priority: P3 → P1", "Tags: +api"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	updated, err := env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "better title" || updated.Priority != ticket.PriorityP1 || len(updated.Tags) != 1 || updated.Tags[0] != "api" {
+		t.Errorf("ticket = %+v", updated)
+	}
+	if !strings.Contains(updated.Body, "## Edited") || !strings.Contains(updated.Body, "Covers the admin API too.") {
+		t.Errorf("body missing Edited section:\n%s", updated.Body)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: tk.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Event != event.TicketEdited || events[0].Actor != "human" {
+		t.Fatalf("expected one ticket.edited event, got %+v", events)
+	}
+	priority, _ := events[0].Data["priority"].(map[string]any)
+	if priority["from"] != "P3" || priority["to"] != "P1" {
+		t.Errorf("event data = %+v", events[0].Data)
+	}
+}
+
+func TestEdit_Validation(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "validated", ticket.StatusOpen)
+
+	for _, args := range [][]string{
+		{"edit", tk.ID},
+		{"edit", tk.ID, "--priority", "P7"},
+		{"edit", tk.ID, "--title", ""},
+		{"edit", tk.ID, "--tags", "+has space"},
+		{"edit", tk.ID, "--append-description", " "},
+	} {
+		_, err := env.runCmd(t, args...)
+		if err == nil {
+			t.Errorf("%v: expected error", args)
+			continue
+		}
+		if ce := toCLIError(err); ce.Code != codeInvalidArgument {
+			t.Errorf("%v: code = %q, want %s", args, ce.Code, codeInvalidArgument)
+		}
+	}
+
+	out, err := env.runCmd(t, "edit", tk.ID, "--title", "validated")
+	if err != nil || !strings.Contains(out, "nothing changed") {
+		t.Errorf("same title: out = %q, err = %v", out, err)
+	}
+}
+
+func TestEdit_AgentPolicy(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "agent edited", ticket.StatusOpen)
+
+	// Agents may lower priority but not raise it by default.
+	if _, err := env.runCmd(t, "--run-id", "run-1", "edit", tk.ID, "--priority", "P4"); err != nil {
+		t.Fatalf("lowering priority: %v", err)
+	}
+	_, err := env.runCmd(t, "--run-id", "run-1", "edit", tk.ID, "--priority", "P1")
+	if err == nil || toCLIError(err).Code != codePolicyDenied {
+		t.Fatalf("raising priority: err = %v, want policy_denied", err)
+	}
+	if got, _ := env.Store.Get(tk.ID); got.Priority != ticket.PriorityP4 {
+		t.Errorf("priority = %s, want P4 kept", got.Priority)
+	}
+
+	// Humans are not bound by the policy.
+	if _, err := env.runCmd(t, "edit", tk.ID, "--priority", "P1"); err != nil {
+		t.Fatalf("human raising priority: %v", err)
+	}
+
+	env.Config.Edit.AgentFields = []string{"tags", "description"}
+	env.Config.Edit.AgentRaisePriority = true
+	if err := env.Config.Save(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.runCmd(t, "--run-id", "run-1", "edit", tk.ID, "--title", "renamed"); err == nil || !strings.Contains(err.Error(), "title") {
+		t.Errorf("title edit by agent: err = %v, want denied", err)
+	}
+	if _, err := env.runCmd(t, "--run-id", "run-1", "edit", tk.ID, "--tags", "+triaged"); err != nil {
+		t.Errorf("tag edit by agent: %v", err)
+	}
+}
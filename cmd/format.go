@@ -0,0 +1,357 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/spawn"
+	"github.com/boozedog/smoovtask/internal/testrun"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/usage"
+	"github.com/boozedog/smoovtask/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats accepted by --format.
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+)
+
+// schemaVersion is the version of every structured output schema. Fields
+// may be added within a version; renaming or removing one bumps it.
+const schemaVersion = 1
+
+var formatFlag string
+
+func validateFormat() error {
+	switch formatFlag {
+	case formatTable, formatJSON, formatYAML:
+		return nil
+	}
+	return &cliError{
+		Code:    codeInvalidArgument,
+		Message: fmt.Sprintf("unknown format %q", formatFlag),
+		Hint:    "Use --format table, json or yaml.",
+	}
+}
+
+// structured reports whether --format asks for machine-readable output.
+func structured() bool {
+	return formatFlag == formatJSON || formatFlag == formatYAML
+}
+
+// tableOnly rejects --format json|yaml for a read command that has no
+// schema yet, so a consumer gets an error object rather than a table it
+// cannot parse.
+func tableOnly(command string) error {
+	if !structured() {
+		return nil
+	}
+	return &cliError{
+		Code:    codeInvalidArgument,
+		Message: fmt.Sprintf("%s has no structured output", command),
+		Hint:    "Run it with --format table.",
+	}
+}
+
+// envelope wraps structured output. Schema names the shape of Data (or of
+// Error) and its version, e.g. "st.list/v1".
+type envelope struct {
+	Schema string    `json:"schema"`
+	Data   any       `json:"data,omitempty"`
+	Error  *cliError `json:"error,omitempty"`
+}
+
+func schemaName(kind string) string {
+	return fmt.Sprintf("st.%s/v%d", kind, schemaVersion)
+}
+
+// writeOutput writes data in the --format encoding under the named schema.
+func writeOutput(kind string, data any) error {
+	return encode(os.Stdout, envelope{Schema: schemaName(kind), Data: data})
+}
+
+// encode writes v as indented JSON or as YAML with the same keys in the
+// same order, so both formats share one schema.
+func encode(w io.Writer, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal output: %w", err)
+	}
+	if formatFlag == formatYAML {
+		var node yaml.Node
+		if err := yaml.Unmarshal(data, &node); err != nil {
+			return fmt.Errorf("convert output to yaml: %w", err)
+		}
+		blockStyle(&node)
+		if data, err = yaml.Marshal(&node); err != nil {
+			return fmt.Errorf("marshal output: %w", err)
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	_, err = fmt.Fprintln(w, string(data))
+	return err
+}
+
+// blockStyle drops the flow style and quoting JSON decodes with, letting the
+// YAML encoder pick its usual block layout.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
+
+// Error codes in structured error objects.
+const (
+	codeNotFound          = "not_found"
+	codeAmbiguousID       = "ambiguous_id"
+	codeInvalidArgument   = "invalid_argument"
+	codeIdentityRequired  = "identity_required"
+	codeInvalidTransition = "invalid_transition"
+	codeDependencyCycle   = "dependency_cycle"
+	codeWorkerFailed      = "worker_failed"
+	codePolicyDenied      = "policy_denied"
+	codeError             = "error"
+)
+
+// cliError is a failure with a stable code and an optional hint on how to
+// recover, reported as an error object under --format json|yaml.
+type cliError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+func (e *cliError) Error() string { return e.Message }
+
+// errIdentityRequired is returned for agent commands run without an identity.
+var errIdentityRequired = errors.New("run ID required for agent commands — pass --run-id")
+
+// cobraUsageErrors are the message prefixes of cobra's argument and command
+// errors, which are not typed.
+var cobraUsageErrors = []string{
+	"accepts ",
+	"requires at least ",
+	"requires at most ",
+	"unknown command ",
+	"invalid argument ",
+}
+
+// toCLIError classifies err into an error object.
+func toCLIError(err error) *cliError {
+	var ce *cliError
+	if errors.As(err, &ce) {
+		return ce
+	}
+	out := &cliError{Code: codeError, Message: err.Error()}
+	var cycle *ticket.CycleError
+	var transition *workflow.TransitionError
+	switch {
+	case errors.Is(err, ticket.ErrNotFound):
+		out.Code = codeNotFound
+		out.Hint = "Check the ID with `st list --all`."
+	case errors.Is(err, ticket.ErrAmbiguousID):
+		out.Code = codeAmbiguousID
+		out.Hint = "Pass more characters of the ticket ID."
+	case errors.Is(err, errIdentityRequired):
+		out.Code = codeIdentityRequired
+		out.Hint = "Agents pass --run-id <id>; run `st context` to see the current one."
+	case errors.As(err, &transition):
+		out.Code = codeInvalidTransition
+		if allowed := workflow.Allowed(transition.From); len(allowed) > 0 && transition.From != transition.To {
+			names := make([]string, len(allowed))
+			for i, s := range allowed {
+				names[i] = string(s)
+			}
+			out.Hint = fmt.Sprintf("From %s a ticket can move to %s.", transition.From, strings.Join(names, ", "))
+		}
+	case errors.As(err, &cycle):
+		out.Code = codeDependencyCycle
+		out.Hint = "Remove one of the dependencies in the cycle."
+	default:
+		for _, prefix := range cobraUsageErrors {
+			if strings.HasPrefix(err.Error(), prefix) {
+				out.Code = codeInvalidArgument
+				out.Hint = "Run with --help for usage."
+				break
+			}
+		}
+	}
+	return out
+}
+
+// writeError reports err on w: as an error object under --format json|yaml,
+// otherwise as a plain line.
+func writeError(w io.Writer, err error) {
+	if !structured() {
+		_, _ = fmt.Fprintln(w, err)
+		return
+	}
+	if encErr := encode(w, envelope{Schema: schemaName("error"), Error: toCLIError(err)}); encErr != nil {
+		_, _ = fmt.Fprintln(w, err)
+	}
+}
+
+// ticketSummary is the frontmatter of a ticket in structured output.
+type ticketSummary struct {
+	ID          string              `json:"id"`
+	Title       string              `json:"title"`
+	Project     string              `json:"project"`
+	Status      string              `json:"status"`
+	PriorStatus string              `json:"prior_status,omitempty"`
+	Priority    string              `json:"priority"`
+	Assignee    string              `json:"assignee"`
+	Parent      string              `json:"parent,omitempty"`
+	DependsOn   []string            `json:"depends_on"`
+	Relations   map[string][]string `json:"relations,omitempty"`
+	Tags        []string            `json:"tags"`
+	Created     time.Time           `json:"created"`
+	Updated     time.Time           `json:"updated"`
+}
+
+func summarize(tk *ticket.Ticket) ticketSummary {
+	s := ticketSummary{
+		ID:        tk.ID,
+		Title:     tk.Title,
+		Project:   tk.Project,
+		Status:    string(tk.Status),
+		Priority:  string(tk.Priority),
+		Assignee:  tk.Assignee,
+		Parent:    tk.Parent,
+		DependsOn: nonNil(tk.DependsOn),
+		Tags:      nonNil(tk.Tags),
+		Created:   tk.Created,
+		Updated:   tk.Updated,
+	}
+	if tk.PriorStatus != nil {
+		s.PriorStatus = string(*tk.PriorStatus)
+	}
+	for typ, ids := range tk.Relations {
+		if s.Relations == nil {
+			s.Relations = map[string][]string{}
+		}
+		s.Relations[string(typ)] = ids
+	}
+	return s
+}
+
+// nonNil keeps empty lists as [] rather than null in structured output.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// testsSummary is a ticket's last recorded test run.
+type testsSummary struct {
+	Status   string    `json:"status"`
+	Passed   int       `json:"passed"`
+	Failed   int       `json:"failed"`
+	Skipped  int       `json:"skipped"`
+	Command  string    `json:"command,omitempty"`
+	Failures []string  `json:"failures,omitempty"`
+	TS       time.Time `json:"ts"`
+}
+
+func summarizeTests(r testrun.Result) *testsSummary {
+	return &testsSummary{
+		Status:   r.Status,
+		Passed:   r.Passed,
+		Failed:   r.Failed,
+		Skipped:  r.Skipped,
+		Command:  r.Command,
+		Failures: r.Failures,
+		TS:       r.TS,
+	}
+}
+
+// workerSummary is the state of a ticket's most recent spawned worker.
+type workerSummary struct {
+	State          string    `json:"state"`
+	PID            int       `json:"pid"`
+	RunID          string    `json:"run_id"`
+	Started        time.Time `json:"started"`
+	ElapsedSeconds int64     `json:"elapsed_seconds"`
+}
+
+func summarizeWorker(info *spawn.WorkerInfo) *workerSummary {
+	return &workerSummary{
+		State:          string(info.State),
+		PID:            info.PID,
+		RunID:          info.RunID,
+		Started:        info.Started,
+		ElapsedSeconds: int64(info.Elapsed / time.Second),
+	}
+}
+
+// progressSummary counts a parent ticket's finished children.
+type progressSummary struct {
+	Total     int `json:"total"`
+	Done      int `json:"done"`
+	Cancelled int `json:"cancelled"`
+}
+
+// tokenCounts is token usage for a model or a total across models.
+type tokenCounts struct {
+	Tokens           int64 `json:"tokens"`
+	InputTokens      int64 `json:"input_tokens"`
+	OutputTokens     int64 `json:"output_tokens"`
+	CacheReadTokens  int64 `json:"cache_read_tokens"`
+	CacheWriteTokens int64 `json:"cache_write_tokens"`
+	Requests         int   `json:"requests"`
+	Turns            int   `json:"turns"`
+}
+
+func countTokens(u usage.Usage) tokenCounts {
+	return tokenCounts{
+		Tokens:           u.Tokens(),
+		InputTokens:      u.InputTokens,
+		OutputTokens:     u.OutputTokens,
+		CacheReadTokens:  u.CacheReadTokens,
+		CacheWriteTokens: u.CacheCreationTokens,
+		Requests:         u.Requests,
+		Turns:            u.Turns,
+	}
+}
+
+// usageSummary is token usage with estimated cost, totalled and per model.
+// CostPartial is set when some model has no configured price.
+type usageSummary struct {
+	tokenCounts
+	CostUSD     float64      `json:"cost_usd"`
+	CostPartial bool         `json:"cost_partial,omitempty"`
+	Models      []modelUsage `json:"models,omitempty"`
+}
+
+type modelUsage struct {
+	Model string `json:"model"`
+	tokenCounts
+	// CostUSD is omitted for models without a price.
+	CostUSD *float64 `json:"cost_usd,omitempty"`
+}
+
+func summarizeUsage(t usage.Totals, pricing usage.Pricing, perModel bool) usageSummary {
+	s := usageSummary{tokenCounts: countTokens(t.Sum())}
+	s.CostUSD, s.CostPartial = t.Cost(pricing)
+	if !perModel {
+		return s
+	}
+	for _, model := range t.Models() {
+		m := modelUsage{Model: model, tokenCounts: countTokens(t[model])}
+		if c, ok := pricing.Cost(model, t[model]); ok {
+			m.CostUSD = &c
+		}
+		s.Models = append(s.Models, m)
+	}
+	return s
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/workflow"
+	"gopkg.in/yaml.v3"
+)
+
+func TestFormat_RejectsUnknown(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.runCmd(t, "--format", "xml", "list")
+	if err == nil {
+		t.Fatal("expected error for unknown format")
+	}
+	if ce := toCLIError(err); ce.Code != codeInvalidArgument || ce.Hint == "" {
+		t.Errorf("error object = %+v, want invalid_argument with a hint", ce)
+	}
+}
+
+func TestFormat_YAMLMatchesJSON(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "yes: a tricky title", ticket.StatusOpen)
+
+	jsonOut, err := env.runCmd(t, "--format", "json", "show", tk.ID)
+	if err != nil {
+		t.Fatalf("show json: %v", err)
+	}
+	yamlOut, err := env.runCmd(t, "--format", "yaml", "show", tk.ID)
+	if err != nil {
+		t.Fatalf("show yaml: %v", err)
+	}
+
+	var fromJSON, fromYAML map[string]any
+	if err := json.Unmarshal([]byte(jsonOut), &fromJSON); err != nil {
+		t.Fatalf("decode json: %v\n%s", err, jsonOut)
+	}
+	if err := yaml.Unmarshal([]byte(yamlOut), &fromYAML); err != nil {
+		t.Fatalf("decode yaml: %v\n%s", err, yamlOut)
+	}
+	if fromYAML["schema"] != "st.show/v1" {
+		t.Errorf("yaml schema = %v", fromYAML["schema"])
+	}
+	title := fromYAML["data"].(map[string]any)["ticket"].(map[string]any)["title"]
+	if title != "yes: a tricky title" {
+		t.Errorf("yaml title = %v", title)
+	}
+	if fmt.Sprint(fromJSON["data"].(map[string]any)["ticket"].(map[string]any)["id"]) != tk.ID {
+		t.Errorf("json output = %s", jsonOut)
+	}
+	if strings.Contains(yamlOut, "{") {
+		t.Errorf("yaml output uses flow style:\n%s", yamlOut)
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	t.Cleanup(resetFlags)
+	formatFlag = formatJSON
+
+	tests := []struct {
+		err      error
+		code     string
+		wantHint bool
+	}{
+		{fmt.Errorf("get ticket: ticket st_zzzzzz %w", ticket.ErrNotFound), codeNotFound, true},
+		{fmt.Errorf("get ticket: %w", ticket.ErrAmbiguousID), codeAmbiguousID, true},
+		{workflow.ValidateTransition(ticket.StatusOpen, ticket.StatusDone), codeInvalidTransition, true},
+		{&ticket.CycleError{Path: []string{"st_a", "st_b", "st_a"}}, codeDependencyCycle, true},
+		{errIdentityRequired, codeIdentityRequired, true},
+		{fmt.Errorf("accepts 1 arg(s), received 0"), codeInvalidArgument, true},
+		{fmt.Errorf("disk on fire"), codeError, false},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		writeError(&buf, tt.err)
+
+		var got struct {
+			Schema string   `json:"schema"`
+			Error  cliError `json:"error"`
+		}
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Fatalf("decode %q: %v", buf.String(), err)
+		}
+		if got.Schema != "st.error/v1" || got.Error.Code != tt.code || got.Error.Message != tt.err.Error() {
+			t.Errorf("writeError(%v) = %+v, want code %s", tt.err, got, tt.code)
+		}
+		if (got.Error.Hint != "") != tt.wantHint {
+			t.Errorf("writeError(%v) hint = %q", tt.err, got.Error.Hint)
+		}
+	}
+
+	formatFlag = formatTable
+	var buf bytes.Buffer
+	writeError(&buf, fmt.Errorf("plain failure"))
+	if buf.String() != "plain failure\n" {
+		t.Errorf("table error = %q", buf.String())
+	}
+}
+
+func TestFormat_ShowNotFoundIsClassified(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.runCmd(t, "--format", "json", "show", "st_zzzzzz")
+	if err == nil {
+		t.Fatal("expected error for missing ticket")
+	}
+	if ce := toCLIError(err); ce.Code != codeNotFound {
+		t.Errorf("code = %q, want %s", ce.Code, codeNotFound)
+	}
+}
+
+func TestFormat_TableOnlyCommandsRejectStructured(t *testing.T) {
+	env := newTestEnv(t)
+
+	for _, args := range [][]string{
+		{"--format", "json", "rules", "lint"},
+		{"--format", "yaml", "rules", "replay"},
+		{"--format", "json", "rules", "suggest"},
+		{"--format", "json", "rules", "test", "--tool", "Bash", "--command", "ls"},
+		{"--format", "json", "learn", "--list"},
+	} {
+		out, err := env.runCmd(t, args...)
+		if err == nil {
+			t.Errorf("%v succeeded, want an error", args)
+			continue
+		}
+		if ce := toCLIError(err); ce.Code != codeInvalidArgument {
+			t.Errorf("%v: code = %q, want %s", args, ce.Code, codeInvalidArgument)
+		}
+		if out != "" {
+			t.Errorf("%v printed a table:\n%s", args, out)
+		}
+	}
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"fmt"
+	"os"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/handoff"
 	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/spawn"
 	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/boozedog/smoovtask/internal/workflow"
 	"github.com/spf13/cobra"
@@ -96,6 +99,7 @@ func runHandoff(_ *cobra.Command, args []string) error {
 	ticket.AppendSection(tk, "Handed Off", actor, runID, "", map[string]string{
 		"previous-assignee": previousAssignee,
 	}, now)
+	appendStateOfPlay(cfg, tk, handoff.ReasonHandoff, actor, runID, now)
 
 	if err := store.Save(tk); err != nil {
 		return fmt.Errorf("save ticket: %w", err)
@@ -121,6 +125,23 @@ func runHandoff(_ *cobra.Command, args []string) error {
 	})
 
 	fmt.Printf("Handed off %s: %s (%s → OPEN)\n", tk.ID, tk.Title, oldStatus)
+	fmt.Println("Recorded a state-of-play summary for the next agent.")
 
 	return nil
 }
+
+// appendStateOfPlay summarizes the ticket for whoever picks it up next.
+// Commits and relative paths are best-effort and skipped outside the repo.
+func appendStateOfPlay(cfg *config.Config, tk *ticket.Ticket, reason, actor, runID string, now time.Time) {
+	in := handoff.Input{Ticket: tk}
+	if eventsDir, err := cfg.EventsDir(); err == nil {
+		in.Events, _ = event.QueryEvents(eventsDir, event.Query{TicketID: tk.ID})
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if repoRoot, err := spawn.WorktreeRepoRoot(cwd); err == nil {
+			in.Roots = []string{spawn.WorktreePath(repoRoot, tk.ID), repoRoot}
+			in.Commits, _ = spawn.BranchCommits(repoRoot, tk.ID)
+		}
+	}
+	handoff.Append(in, reason, actor, runID, now)
+}
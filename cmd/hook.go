@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -11,10 +10,14 @@ import (
 )
 
 var hookCmd = &cobra.Command{
-	Use:   "hook <event-type>",
-	Short: "Handle Claude Code hook events",
-	Args:  cobra.MinimumNArgs(1),
-	RunE:  runHook,
+	Use:   "hook <event-type> [payload]",
+	Short: "Handle agent CLI hook events",
+	Long: `Handle a hook event from an agent CLI. The event type selects the agent
+adapter (Claude Code hook names, opencode-event/pi-event from the bridge
+plugins, gemini-event or codex-event), which decodes the payload from stdin
+(or the second argument) and encodes the response in the CLI's format.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runHook,
 }
 
 func init() {
@@ -22,341 +25,36 @@ func init() {
 }
 
 func runHook(_ *cobra.Command, args []string) error {
-	eventType := args[0]
-
-	switch eventType {
-	case "opencode-event":
-		// Read the opencode event from stdin
-		eventData, err := io.ReadAll(os.Stdin)
-		if err != nil {
-			return fmt.Errorf("read event data: %w", err)
-		}
-		var event map[string]any
-		if err := json.Unmarshal(eventData, &event); err != nil {
-			return nil // Skip invalid events
-		}
-
-		// Build input from the normalized payload the plugin sends
-		input := &hook.Input{Source: "opencode"}
-		if sid, ok := event["session_id"].(string); ok {
-			input.SessionID = sid
-		}
-		if cwd, ok := event["cwd"].(string); ok {
-			input.CWD = cwd
-		}
-		if toolName, ok := event["tool_name"].(string); ok {
-			input.ToolName = toolName
-		}
-		if toolInput, ok := event["tool_input"].(map[string]any); ok {
-			input.ToolInput = toolInput
-		}
-
-		// Process based on event type
-		eventType, _ := event["type"].(string)
-		switch eventType {
-		case "session.created":
-			if input.SessionID == "" {
-				return nil
-			}
-			out, err := hook.HandleSessionStart(input)
-			if err != nil {
-				return err
-			}
-			return hook.WriteOutput(*out)
-		case "tool.execute.before":
-			out, err := hook.HandlePreTool(input)
-			if err != nil {
-				return err
-			}
-			if out.AdditionalContext != "" || out.Decision != nil {
-				return hook.WriteOutput(out)
-			}
-			return nil
-		case "tool.execute.after":
-			return hook.HandlePostTool(input)
-		case "stop":
-			return hook.HandleStop(input)
-		case "session.idle":
-			return hook.HandleTeammateIdle(input)
-		case "permission.asked":
-			out, err := hook.HandlePermissionRequest(input)
-			if err != nil {
-				return err
-			}
-			if out.Decision != nil {
-				return hook.WriteOutput(out)
-			}
-			return nil
-		case "session.deleted":
-			return hook.HandleSessionEnd(input)
-		default:
-			return nil
-		}
-
-	case "pi-event":
-		eventData, err := io.ReadAll(os.Stdin)
-		if err != nil {
-			return fmt.Errorf("read event data: %w", err)
-		}
-
-		var event map[string]any
-		if err := json.Unmarshal(eventData, &event); err != nil {
-			return nil
-		}
-
-		input := &hook.Input{Source: "pi"}
-		if sessionID, ok := event["session_id"].(string); ok {
-			input.SessionID = sessionID
-		}
-		if cwd, ok := event["cwd"].(string); ok {
-			input.CWD = cwd
-		}
-		if toolName, ok := event["tool_name"].(string); ok {
-			input.ToolName = toolName
-		}
-		if taskPrompt, ok := event["task_prompt"].(string); ok {
-			input.TaskPrompt = taskPrompt
-		}
-
-		eventType, _ := event["type"].(string)
-		switch eventType {
-		case "session_start":
-			out, err := hook.HandleSessionStart(input)
-			if err != nil {
-				return err
-			}
-			return hook.WriteOutput(*out)
-		case "tool_call":
-			out, err := hook.HandlePreTool(input)
-			if err != nil {
-				return err
-			}
-			if out.AdditionalContext != "" || out.Decision != nil {
-				return hook.WriteOutput(out)
-			}
-			return nil
-		case "tool_result", "tool_execution_end":
-			return hook.HandlePostTool(input)
-		case "permission_request":
-			out, err := hook.HandlePermissionRequest(input)
-			if err != nil {
-				return err
-			}
-			if out.Decision != nil {
-				return hook.WriteOutput(out)
-			}
-			return nil
-		case "agent_end", "task_completed":
-			return hook.HandleTaskCompleted(input)
-		case "teammate_idle", "turn_end":
-			return hook.HandleTeammateIdle(input)
-		case "subagent_start":
-			out, err := hook.HandleSubagentStart(input)
-			if err != nil {
-				return err
-			}
-			if out.AdditionalContext != "" {
-				return hook.WriteOutput(out)
-			}
-			return nil
-		case "subagent_stop":
-			return hook.HandleSubagentStop(input)
-		case "session_shutdown":
-			if err := hook.HandleStop(input); err != nil {
-				return err
-			}
-			return hook.HandleSessionEnd(input)
-		case "stop":
-			return hook.HandleStop(input)
-		case "session_end":
-			return hook.HandleSessionEnd(input)
-		default:
-			return nil
-		}
-
-	case "session-start":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		out, err := hook.HandleSessionStart(input)
-		if err != nil {
-			return err
-		}
-		if source == "claude" {
-			fmt.Print(out.AdditionalContext)
-			return nil
-		}
-		return hook.WriteOutput(*out)
-
-	case "subagent-start":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		out, err := hook.HandleSubagentStart(input)
-		if err != nil {
-			return err
-		}
-		if out.AdditionalContext != "" {
-			return hook.WriteOutput(out)
-		}
-		return nil
-
-	case "pre-tool":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		out, err := hook.HandlePreTool(input)
-		if err != nil {
-			return err
-		}
-		if out.Decision != nil {
-			if out.Decision.Behavior == "deny" {
-				if out.Decision.Reason != "" {
-					return fmt.Errorf("%s", out.Decision.Reason)
-				}
-				return fmt.Errorf("tool call blocked by smoovtask")
-			}
-			return hook.WriteOutput(out)
-		}
-		if out.AdditionalContext != "" {
-			return hook.WriteOutput(out)
-		}
+	adapter, ok := hook.AdapterForEvent(args[0])
+	if !ok {
+		// Unknown hook events are silently ignored (don't break the agent CLI)
 		return nil
+	}
 
-	case "post-tool":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		return hook.HandlePostTool(input)
-
-	case "stop":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		return hook.HandleStop(input)
-
-	case "subagent-stop":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		return hook.HandleSubagentStop(input)
-
-	case "task-completed":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		return hook.HandleTaskCompleted(input)
-
-	case "teammate-idle":
-		input, err := hook.ReadInput()
+	// Codex passes its notification as an argument instead of on stdin.
+	var payload []byte
+	if len(args) > 1 {
+		payload = []byte(args[1])
+	} else {
+		data, err := io.ReadAll(os.Stdin)
 		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
+			return fmt.Errorf("read event data: %w", err)
 		}
-		input.Source = source
-		return hook.HandleTeammateIdle(input)
+		payload = data
+	}
+	events, input, err := adapter.Decode(args[0], payload)
+	if err != nil {
+		return err
+	}
 
-	case "permission-request":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		out, err := hook.HandlePermissionRequest(input)
+	for _, event := range events {
+		out, err := hook.Dispatch(event, input)
 		if err != nil {
 			return err
 		}
-		if out.Decision != nil {
-			return hook.WriteOutput(out)
-		}
-		return nil
-
-	case "session-end":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		return hook.HandleSessionEnd(input)
-
-	case "user-prompt-submit":
-		input, err := hook.ReadInput()
-		if err != nil {
-			return fmt.Errorf("read hook input: %w", err)
-		}
-		source := "claude"
-		if os.Getenv("OPENCODE_HOOK") == "1" {
-			source = "opencode"
-		}
-		input.Source = source
-		out, err := hook.HandleUserPrompt(input)
-		if err != nil {
+		if err := adapter.Encode(os.Stdout, event, out); err != nil {
 			return err
 		}
-		if out.AdditionalContext != "" {
-			if source == "claude" {
-				fmt.Print(out.AdditionalContext)
-				return nil
-			}
-			return hook.WriteOutput(*out)
-		}
-		return nil
-
-	default:
-		// Unknown hook events are silently ignored (don't break Claude Code)
-		return nil
 	}
+	return nil
 }
@@ -9,6 +9,7 @@ import (
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
 func testProjectPath(t *testing.T, cfg *config.Config) string {
@@ -77,6 +78,25 @@ func TestRunHook_PreToolBlocksOnDeniedDecision(t *testing.T) {
 	}
 }
 
+func TestRunHook_StopBlocksInProgressTicket(t *testing.T) {
+	env := newTestEnv(t)
+	cwd := testProjectPath(t, env.Config)
+
+	tk := env.createTicket(t, "unfinished", ticket.StatusInProgress)
+	tk.Assignee = "run-claude-stop"
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatalf("save ticket: %v", err)
+	}
+
+	out, err := runHookWithInput(t, "stop", fmt.Sprintf(`{"session_id":"run-claude-stop","cwd":%q}`, cwd))
+	if err != nil {
+		t.Fatalf("run hook: %v", err)
+	}
+	if !strings.Contains(out, `"decision":"block"`) || !strings.Contains(out, tk.ID) {
+		t.Fatalf("output = %q, want block decision for %s", out, tk.ID)
+	}
+}
+
 func runHookWithInput(t *testing.T, eventType, input string) (string, error) {
 	t.Helper()
 
@@ -1,491 +1,55 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
-	"path/filepath"
+	"strings"
 
 	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/hook"
 	"github.com/boozedog/smoovtask/internal/rules"
 	"github.com/boozedog/smoovtask/internal/skills"
+	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/spf13/cobra"
 )
 
 var installCmd = &cobra.Command{
 	Use:   "install",
 	Short: "Install smoovtask hooks, rules, skills, and agent bridges",
-	Long:  `Installs smoovtask into your environment: Claude Code hooks, OpenCode/PI bridge plugins, default rule files, and workflow skills. Existing hooks and settings are preserved.`,
+	Long:  `Installs smoovtask into your environment: Claude Code and Gemini CLI hooks, OpenCode/PI bridge plugins, the Codex CLI notify program, default rule files, and workflow skills. Existing hooks and settings are preserved.`,
 	RunE:  runInstall,
 }
 
 var uninstallCmd = &cobra.Command{
 	Use:   "uninstall",
 	Short: "Remove smoovtask hooks and agent bridges",
-	Long:  `Removes smoovtask hooks from Claude Code settings and deletes OpenCode/PI bridge plugins. Rule files are left intact.`,
+	Long:  `Removes smoovtask hooks from Claude Code and Gemini CLI settings, deletes OpenCode/PI bridge plugins, and unsets the Codex CLI notify program. Rule files are left intact.`,
 	RunE:  runUninstall,
 }
 
 var agents []string
 
 func init() {
-	installCmd.Flags().StringSliceVar(&agents, "agents", []string{"claude"}, "Agents to install for: claude, opencode, pi, both")
-	uninstallCmd.Flags().StringSliceVar(&agents, "agents", []string{"claude"}, "Agents to uninstall for: claude, opencode, pi, both")
+	names := strings.Join(hook.AdapterNames(), ", ")
+	installCmd.Flags().StringSliceVar(&agents, "agents", []string{"claude"}, "Agents to install for: "+names+", all")
+	uninstallCmd.Flags().StringSliceVar(&agents, "agents", []string{"claude"}, "Agents to uninstall for: "+names+", all")
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(uninstallCmd)
 }
 
-// hookEntry represents a single hook command entry.
-type hookEntry struct {
-	Type    string `json:"type"`
-	Command string `json:"command"`
-	Async   bool   `json:"async,omitempty"`
-}
-
-// hookGroup represents a group of hooks with an optional matcher.
-type hookGroup struct {
-	Matcher string      `json:"matcher,omitempty"`
-	Hooks   []hookEntry `json:"hooks"`
-}
-
-// opencodePluginCode is the TypeScript code for the opencode plugin.
-const opencodePluginCode = `import { spawnSync } from 'child_process';
-import { writeFileSync } from 'fs';
-
-function log(msg) {
-  writeFileSync('/tmp/opencode-plugin.log', new Date().toISOString() + ': ' + msg + '\\n', { flag: 'a' });
-}
-
-function dump(label, value) {
-  try {
-    log(label + ': ' + JSON.stringify(value));
-  } catch (e) {
-    log(label + ': [unserializable] ' + String(e));
-  }
-}
-
-function runHookSync(eventJson) {
-  const result = spawnSync('st', ['hook', 'opencode-event'], {
-    input: eventJson,
-    env: { ...process.env, OPENCODE_HOOK: '1' },
-    timeout: 5000,
-  });
-  if (result.stderr && result.stderr.length > 0) {
-    log('stderr: ' + result.stderr.toString());
-  }
-  if (result.status !== 0) {
-    log('Hook failed code ' + result.status);
-    return null;
-  }
-  const trimmed = (result.stdout || '').toString().trim();
-  if (!trimmed) return null;
-  try {
-    return JSON.parse(trimmed);
-  } catch (e) {
-    log('Parse error: ' + e);
-    return null;
-  }
-}
-
-// Cache session-start context so we don't shell out on every LLM turn.
-let cachedContext = null;
-
-export default async ({ client, directory }) => {
-  return {
-    "experimental.chat.system.transform": async (input, output) => {
-      dump('transform.input', input);
-      if (!cachedContext) {
-        log('Running session-start hook for system prompt injection');
-        const event = { type: 'session.created', cwd: directory, session_id: input.sessionID || 'unknown' };
-        const result = runHookSync(JSON.stringify(event));
-        if (result && result.additionalContext) {
-          cachedContext = result.additionalContext;
-          log('Cached context: ' + cachedContext.substring(0, 200));
-        }
-      }
-		if (cachedContext) {
-		  output.system.push(cachedContext);
-		}
-      dump('transform.output', output);
-    },
-    tool: {
-      execute: {
-        before: async (input, output) => {
-          dump('tool.before.input', input);
-          dump('tool.before.output', output);
-          const event = {
-            type: 'tool.execute.before',
-            cwd: directory,
-            tool_name: input.tool || '',
-            tool_input: output.args || {},
-            session_id: input.sessionID || '',
-          };
-          log('Tool before: ' + event.tool_name);
-          const result = runHookSync(JSON.stringify(event));
-          if (!result) return;
-          log('Tool before result: ' + JSON.stringify(result).substring(0, 500));
-
-          // Inject pre-tool context into the session
-          if (result.additionalContext && input.sessionID) {
-            try {
-              await client.session.prompt({
-                path: { id: input.sessionID },
-                body: {
-                  noReply: true,
-                  parts: [{ type: 'text', text: result.additionalContext, synthetic: true }]
-                }
-              });
-              log('Injected pre-tool context into session ' + input.sessionID);
-            } catch (e) {
-              log('Prompt inject error: ' + e);
-            }
-          }
-
-		  if (result.hookSpecificOutput && result.hookSpecificOutput.permissionDecision === 'deny') {
-			return { block: true, reason: result.hookSpecificOutput.permissionDecisionReason || 'Blocked by smoovtask' };
-		  }
-        },
-        after: async (input) => {
-          dump('tool.after.input', input);
-          const event = {
-            type: 'tool.execute.after',
-            cwd: directory,
-            tool_name: input.tool || '',
-            session_id: input.sessionID || '',
-          };
-          log('Tool after: ' + event.tool_name);
-          runHookSync(JSON.stringify(event));
-        }
-      }
-    },
-    stop: async (input) => {
-      dump('stop.input', input);
-      const event = { type: 'stop', cwd: directory, session_id: input.sessionID || '' };
-      log('Stop');
-      runHookSync(JSON.stringify(event));
-    },
-    "experimental.session.compacting": async (input, output) => {
-		dump('compacting.input', input);
-		if (cachedContext) {
-		  log('Injecting cached context into compaction');
-		  output.system.push(cachedContext);
-		}
-		dump('compacting.output', output);
-    },
-    event: async ({ event }) => {
-      dump('event.raw', event);
-      const eventType = event.type || '';
-      log('Event: ' + eventType);
-
-      const props = event.properties || {};
-      const sessionID = props.sessionID || props.sessionId || '';
-      const toolName = props.toolName || props.tool || props.name || '';
-
-      // Normalize event payload to include cwd.
-      const payload = { type: eventType, cwd: directory };
-
-      if (sessionID) payload.session_id = sessionID;
-
-      if (eventType === 'session.created') {
-        const info = props.info || {};
-        payload.session_id = info.id || sessionID || '';
-      }
-
-	  // Fallback for OpenCode variants that expose tool activity only on the
-	  // generic event bus with non-canonical names.
-	  const looksLikeTool = eventType.includes('tool');
-	  const looksBefore = eventType.includes('before') || eventType.endsWith('.start');
-	  const looksAfter = eventType.includes('after') || eventType.includes('result') || eventType.endsWith('.stop');
-	  if (looksLikeTool && (looksBefore || looksAfter)) {
-		payload.type = looksBefore ? 'tool.execute.before' : 'tool.execute.after';
-		payload.tool_name = toolName;
-	  }
-
-	  // Some OpenCode builds emit coarse activity states instead of tool hooks.
-	  // Convert status transitions into synthetic before/after events so st can
-	  // still emit hook.pre-tool/post-tool for board activity indicators.
-	  if (eventType === 'session.status') {
-		let statusType = '';
-		if (typeof props.status === 'string') {
-		  statusType = props.status.toLowerCase();
-		} else if (props.status && typeof props.status === 'object') {
-		  const rawType = props.status.type || props.status.state || '';
-		  if (typeof rawType === 'string') {
-			statusType = rawType.toLowerCase();
-		  }
-		}
-		if (statusType === 'running' || statusType === 'working' || statusType === 'busy') {
-		  payload.type = 'tool.execute.before';
-		  payload.tool_name = toolName || 'opencode';
-		}
-		if (statusType === 'idle' || statusType === 'waiting') {
-		  payload.type = 'tool.execute.after';
-		  payload.tool_name = toolName || 'opencode';
-		}
-	  }
-
-	  // Newer OpenCode builds surface tool lifecycle through message parts.
-	  // Bridge those states into canonical tool.execute.before/after hooks.
-	  if (eventType === 'message.part.updated') {
-		const part = props.part || {};
-		const partType = String(part.type || '').toLowerCase();
-		const partSessionID = part.sessionID || part.sessionId || sessionID;
-		if (partSessionID) payload.session_id = partSessionID;
-
-		let partStatus = '';
-		if (typeof part.state === 'string') {
-		  partStatus = part.state.toLowerCase();
-		} else if (part.state && typeof part.state === 'object') {
-		  const rawPartStatus = part.state.status || part.state.type || '';
-		  if (typeof rawPartStatus === 'string') {
-			partStatus = rawPartStatus.toLowerCase();
-		  }
-		}
-
-		if (partType === 'tool') {
-		  const partToolName = part.tool || toolName || 'opencode';
-		  if (partStatus === 'pending' || partStatus === 'running') {
-			payload.type = 'tool.execute.before';
-			payload.tool_name = partToolName;
-		  }
-		  if (partStatus === 'completed' || partStatus === 'done' || partStatus === 'success' || partStatus === 'failed' || partStatus === 'error' || partStatus === 'cancelled') {
-			payload.type = 'tool.execute.after';
-			payload.tool_name = partToolName;
-		  }
-		}
-	  }
-
-      const handled = ['session.created', 'session.idle', 'permission.asked', 'session.deleted', 'tool.execute.before', 'tool.execute.after'];
-      if (!handled.includes(payload.type)) {
-        const keys = Object.keys(props);
-        if (keys.length > 0) {
-          log('Ignored event props keys: ' + keys.join(','));
-        }
-        return;
-      }
-
-      const result = runHookSync(JSON.stringify(payload));
-      if (!result) return;
-      log('Result: ' + JSON.stringify(result).substring(0, 500));
-
-      // Refresh cached context on session.created
-      if (event.type === 'session.created' && result.additionalContext) {
-        cachedContext = result.additionalContext;
-        log('Updated cached context from session.created');
-      }
-    }
-  };
-};`
-
-// piExtensionCode is the TypeScript code for the pi extension bridge.
-const piExtensionCode = `import { spawnSync } from 'node:child_process';
-import { writeFileSync } from 'node:fs';
-import { basename } from 'node:path';
-
-function log(msg) {
-  writeFileSync('/tmp/pi-extension.log', new Date().toISOString() + ': ' + msg + '\n', { flag: 'a' });
-}
-
-function runHookSync(payload) {
-  const result = spawnSync('st', ['hook', 'pi-event'], {
-    input: JSON.stringify(payload),
-    env: { ...process.env, PI_HOOK: '1' },
-    timeout: 5000,
-  });
-
-  if (result.stderr && result.stderr.length > 0) {
-    log('stderr: ' + result.stderr.toString());
-  }
-  if (result.status !== 0) {
-    log('Hook failed code ' + result.status);
-    return null;
-  }
-
-  const trimmed = (result.stdout || '').toString().trim();
-  if (!trimmed) return null;
-
-  try {
-    return JSON.parse(trimmed);
-  } catch (e) {
-    log('Parse error: ' + e);
-    return null;
-  }
-}
-
-function getSessionID(ctx) {
-  const path = ctx?.sessionManager?.getSessionFile?.();
-  if (typeof path === 'string' && path.length > 0) {
-    const candidate = basename(path, '.jsonl');
-    const parts = candidate.split('_');
-    const suffix = parts[parts.length - 1];
-    const uuidPattern = /^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$/i;
-    if (uuidPattern.test(suffix)) {
-      return suffix;
-    }
-    return candidate;
-  }
-  return 'pi-session';
-}
-
-export default function (pi) {
-  let cachedContext = null;
-
-  pi.on('session_start', async (_event, ctx) => {
-    const payload = {
-      type: 'session_start',
-      session_id: getSessionID(ctx),
-      cwd: ctx.cwd,
-    };
-    const result = runHookSync(payload);
-    if (result && result.additionalContext) {
-      cachedContext = result.additionalContext;
-    }
-  });
-
-  pi.on('before_agent_start', async (event, ctx) => {
-    if (!cachedContext) {
-      const payload = {
-        type: 'session_start',
-        session_id: getSessionID(ctx),
-        cwd: ctx.cwd,
-      };
-      const result = runHookSync(payload);
-      if (result && result.additionalContext) {
-        cachedContext = result.additionalContext;
-      }
-    }
-
-    if (!cachedContext) return;
-
-	return {
-	  systemPrompt: event.systemPrompt + '\n\n' + cachedContext,
-	};
-  });
-
-  pi.on('tool_call', async (event, ctx) => {
-    const payload = {
-      type: 'tool_call',
-      session_id: getSessionID(ctx),
-      cwd: ctx.cwd,
-      tool_name: event.toolName,
-    };
-    const result = runHookSync(payload);
-    if (result && result.additionalContext) {
-      ctx.ui.notify(result.additionalContext, 'warning');
-    }
-    if (result && result.hookSpecificOutput && result.hookSpecificOutput.permissionDecision === 'deny') {
-      return { block: true, reason: result.hookSpecificOutput.permissionDecisionReason || 'Blocked by smoovtask' };
-    }
-  });
-
-  pi.on('tool_result', async (event, ctx) => {
-    runHookSync({
-      type: 'tool_result',
-      session_id: getSessionID(ctx),
-      cwd: ctx.cwd,
-      tool_name: event.toolName,
-    });
-  });
-
-  pi.on('agent_end', async (_event, ctx) => {
-    runHookSync({
-      type: 'agent_end',
-      session_id: getSessionID(ctx),
-      cwd: ctx.cwd,
-    });
-  });
-
-  pi.on('session_shutdown', async (_event, ctx) => {
-    runHookSync({
-      type: 'session_shutdown',
-      session_id: getSessionID(ctx),
-      cwd: ctx.cwd,
-    });
-  });
-}
-`
-
-// smoovtaskHooks returns the hook config that st needs installed.
-func smoovtaskHooks() map[string][]hookGroup {
-	return map[string][]hookGroup{
-		"SessionStart": {
-			{
-				Matcher: "startup|resume|clear|compact",
-				Hooks:   []hookEntry{{Type: "command", Command: "st hook session-start"}},
-			},
-		},
-		"PreToolUse": {
-			{
-				Matcher: "*",
-				Hooks:   []hookEntry{{Type: "command", Command: "st hook pre-tool"}},
-			},
-		},
-		"PostToolUse": {
-			{
-				Matcher: "*",
-				Hooks:   []hookEntry{{Type: "command", Command: "st hook post-tool", Async: true}},
-			},
-		},
-		"SubagentStart": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook subagent-start"}},
-			},
-		},
-		"SubagentStop": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook subagent-stop", Async: true}},
-			},
-		},
-		"TaskCompleted": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook task-completed", Async: true}},
-			},
-		},
-		"TeammateIdle": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook teammate-idle", Async: true}},
-			},
-		},
-		"Stop": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook stop", Async: true}},
-			},
-		},
-		"PermissionRequest": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook permission-request"}},
-			},
-		},
-		"SessionEnd": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook session-end", Async: true}},
-			},
-		},
-		"UserPromptSubmit": {
-			{
-				Hooks: []hookEntry{{Type: "command", Command: "st hook user-prompt-submit", Async: true}},
-			},
-		},
-	}
-}
-
 func runInstall(_ *cobra.Command, _ []string) error {
-	expandedAgents := expandAgents(agents)
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("get home dir: %w", err)
+	}
 
-	for _, agent := range expandedAgents {
-		switch agent {
-		case "claude":
-			if err := installClaudeHooks(); err != nil {
-				return fmt.Errorf("install claude hooks: %w", err)
-			}
-		case "opencode":
-			if err := installOpencodePlugin(); err != nil {
-				return fmt.Errorf("install opencode plugin: %w", err)
-			}
-		case "pi":
-			if err := installPiExtension(); err != nil {
-				return fmt.Errorf("install pi extension: %w", err)
-			}
+	for _, name := range expandAgents(agents) {
+		adapter, err := hook.GetAdapter(name)
+		if err != nil {
+			return err
+		}
+		if err := adapter.Install(home, os.Stdout); err != nil {
+			return fmt.Errorf("install %s hooks: %w", name, err)
 		}
 	}
 
@@ -503,6 +67,15 @@ func runInstall(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("seed default rules: %w", err)
 	}
 
+	// Seed the bug and feature ticket templates if they don't already exist.
+	templatesDir, err := cfg.TemplatesDir()
+	if err != nil {
+		return fmt.Errorf("get templates dir: %w", err)
+	}
+	if err := ticket.SeedTemplates(templatesDir); err != nil {
+		return fmt.Errorf("seed ticket templates: %w", err)
+	}
+
 	// Install st-managed Claude Code skills.
 	if err := skills.Install(); err != nil {
 		return fmt.Errorf("install skills: %w", err)
@@ -512,22 +85,18 @@ func runInstall(_ *cobra.Command, _ []string) error {
 }
 
 func runUninstall(_ *cobra.Command, _ []string) error {
-	expandedAgents := expandAgents(agents)
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("get home dir: %w", err)
+	}
 
-	for _, agent := range expandedAgents {
-		switch agent {
-		case "claude":
-			if err := uninstallClaudeHooks(); err != nil {
-				return fmt.Errorf("uninstall claude hooks: %w", err)
-			}
-		case "opencode":
-			if err := uninstallOpencodePlugin(); err != nil {
-				return fmt.Errorf("uninstall opencode plugin: %w", err)
-			}
-		case "pi":
-			if err := uninstallPiExtension(); err != nil {
-				return fmt.Errorf("uninstall pi extension: %w", err)
-			}
+	for _, name := range expandAgents(agents) {
+		adapter, err := hook.GetAdapter(name)
+		if err != nil {
+			return err
+		}
+		if err := adapter.Uninstall(home, os.Stdout); err != nil {
+			return fmt.Errorf("uninstall %s hooks: %w", name, err)
 		}
 	}
 
@@ -540,326 +109,17 @@ func runUninstall(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+// expandAgents resolves the --agents aliases: "all" is every supported
+// agent, and "both" (predating the PI, Codex and Gemini adapters) keeps
+// meaning the original bridge set.
 func expandAgents(agents []string) []string {
 	for _, a := range agents {
-		if a == "both" {
+		switch a {
+		case "all":
+			return hook.AdapterNames()
+		case "both":
 			return []string{"claude", "opencode", "pi"}
 		}
 	}
 	return agents
 }
-
-func installClaudeHooks() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home dir: %w", err)
-	}
-
-	settingsPath := filepath.Join(home, ".claude", "settings.json")
-
-	// Read existing settings (or start fresh)
-	settings := make(map[string]any)
-	data, err := os.ReadFile(settingsPath)
-	if err == nil {
-		if err := json.Unmarshal(data, &settings); err != nil {
-			return fmt.Errorf("parse %s: %w", settingsPath, err)
-		}
-	} else if !os.IsNotExist(err) {
-		return fmt.Errorf("read %s: %w", settingsPath, err)
-	}
-
-	// Get or create the hooks map
-	existingHooks, _ := settings["hooks"].(map[string]any)
-	if existingHooks == nil {
-		existingHooks = make(map[string]any)
-	}
-
-	wanted := smoovtaskHooks()
-	var installed, skipped []string
-
-	for eventName, groups := range wanted {
-		if hasSmoovtaskHook(existingHooks, eventName) {
-			skipped = append(skipped, eventName)
-			continue
-		}
-
-		// Convert groups to the right type for JSON
-		var groupSlice []any
-		for _, g := range groups {
-			groupSlice = append(groupSlice, marshalHookGroup(g))
-		}
-
-		// Merge with any existing hooks for this event
-		existing, _ := existingHooks[eventName].([]any)
-		existingHooks[eventName] = append(existing, groupSlice...)
-		installed = append(installed, eventName)
-	}
-
-	// Only write settings back if there are changes
-	if len(installed) > 0 {
-		settings["hooks"] = existingHooks
-
-		if err := os.MkdirAll(filepath.Dir(settingsPath), 0o755); err != nil {
-			return fmt.Errorf("create settings dir: %w", err)
-		}
-
-		out, err := json.MarshalIndent(settings, "", "  ")
-		if err != nil {
-			return fmt.Errorf("marshal settings: %w", err)
-		}
-
-		if err := os.WriteFile(settingsPath, append(out, '\n'), 0o644); err != nil {
-			return fmt.Errorf("write %s: %w", settingsPath, err)
-		}
-
-		fmt.Printf("Installed %d Claude hook(s):\n", len(installed))
-		for _, name := range installed {
-			fmt.Printf("  + %s\n", name)
-		}
-		if len(skipped) > 0 {
-			fmt.Printf("Already installed (%d Claude hook(s)):\n", len(skipped))
-			for _, name := range skipped {
-				fmt.Printf("  = %s\n", name)
-			}
-		}
-		fmt.Printf("\nSettings: %s\n", settingsPath)
-	} else {
-		fmt.Println("Claude hooks already installed, no changes needed.")
-	}
-	return nil
-}
-
-// hasSmoovtaskHook checks if an st hook command already exists for the given event.
-func hasSmoovtaskHook(hooks map[string]any, eventName string) bool {
-	groups, ok := hooks[eventName].([]any)
-	if !ok {
-		return false
-	}
-
-	for _, g := range groups {
-		group, ok := g.(map[string]any)
-		if !ok {
-			continue
-		}
-		hookList, ok := group["hooks"].([]any)
-		if !ok {
-			continue
-		}
-		for _, h := range hookList {
-			entry, ok := h.(map[string]any)
-			if !ok {
-				continue
-			}
-			cmd, _ := entry["command"].(string)
-			if len(cmd) >= 7 && cmd[:7] == "st hook" {
-				return true
-			}
-		}
-	}
-	return false
-}
-
-// installOpencodePlugin installs the smoovtask plugin for opencode.
-// Plugins in ~/.config/opencode/plugins/ are auto-loaded at startup,
-// so we just write the .ts file — no config entry needed.
-func installOpencodePlugin() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home dir: %w", err)
-	}
-
-	pluginsDir := filepath.Join(home, ".config", "opencode", "plugins")
-	if err := os.MkdirAll(pluginsDir, 0o755); err != nil {
-		return fmt.Errorf("create plugins dir: %w", err)
-	}
-
-	pluginFile := filepath.Join(pluginsDir, "smoovtask-hooks.ts")
-	if err := os.WriteFile(pluginFile, []byte(opencodePluginCode), 0o644); err != nil {
-		return fmt.Errorf("write plugin file: %w", err)
-	}
-
-	fmt.Printf("Installed opencode plugin: %s\n", pluginFile)
-	return nil
-}
-
-// installPiExtension installs the smoovtask extension for pi.
-// Extensions in ~/.pi/agent/extensions/ are auto-discovered by pi.
-func installPiExtension() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home dir: %w", err)
-	}
-
-	extensionsDir := filepath.Join(home, ".pi", "agent", "extensions")
-	if err := os.MkdirAll(extensionsDir, 0o755); err != nil {
-		return fmt.Errorf("create extensions dir: %w", err)
-	}
-
-	extensionFile := filepath.Join(extensionsDir, "smoovtask-hooks.ts")
-	if err := os.WriteFile(extensionFile, []byte(piExtensionCode), 0o644); err != nil {
-		return fmt.Errorf("write extension file: %w", err)
-	}
-
-	fmt.Printf("Installed pi extension: %s\n", extensionFile)
-	return nil
-}
-
-// uninstallClaudeHooks removes all smoovtask hooks from Claude Code settings.
-func uninstallClaudeHooks() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home dir: %w", err)
-	}
-
-	settingsPath := filepath.Join(home, ".claude", "settings.json")
-
-	data, err := os.ReadFile(settingsPath)
-	if os.IsNotExist(err) {
-		fmt.Println("No Claude settings found, nothing to uninstall.")
-		return nil
-	}
-	if err != nil {
-		return fmt.Errorf("read %s: %w", settingsPath, err)
-	}
-
-	settings := make(map[string]any)
-	if err := json.Unmarshal(data, &settings); err != nil {
-		return fmt.Errorf("parse %s: %w", settingsPath, err)
-	}
-
-	existingHooks, _ := settings["hooks"].(map[string]any)
-	if existingHooks == nil {
-		fmt.Println("No Claude hooks found, nothing to uninstall.")
-		return nil
-	}
-
-	var removed []string
-	for eventName := range existingHooks {
-		groups, ok := existingHooks[eventName].([]any)
-		if !ok {
-			continue
-		}
-
-		var kept []any
-		for _, g := range groups {
-			group, ok := g.(map[string]any)
-			if !ok {
-				kept = append(kept, g)
-				continue
-			}
-			hookList, ok := group["hooks"].([]any)
-			if !ok {
-				kept = append(kept, g)
-				continue
-			}
-			isSmoovtask := false
-			for _, h := range hookList {
-				entry, ok := h.(map[string]any)
-				if !ok {
-					continue
-				}
-				cmd, _ := entry["command"].(string)
-				if len(cmd) >= 7 && cmd[:7] == "st hook" {
-					isSmoovtask = true
-					break
-				}
-			}
-			if !isSmoovtask {
-				kept = append(kept, g)
-			}
-		}
-
-		if len(kept) == 0 {
-			delete(existingHooks, eventName)
-			removed = append(removed, eventName)
-		} else if len(kept) < len(groups) {
-			existingHooks[eventName] = kept
-			removed = append(removed, eventName)
-		}
-	}
-
-	if len(removed) == 0 {
-		fmt.Println("No smoovtask hooks found in Claude settings.")
-		return nil
-	}
-
-	if len(existingHooks) == 0 {
-		delete(settings, "hooks")
-	} else {
-		settings["hooks"] = existingHooks
-	}
-
-	out, err := json.MarshalIndent(settings, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal settings: %w", err)
-	}
-
-	if err := os.WriteFile(settingsPath, append(out, '\n'), 0o644); err != nil {
-		return fmt.Errorf("write %s: %w", settingsPath, err)
-	}
-
-	fmt.Printf("Removed %d Claude hook(s):\n", len(removed))
-	for _, name := range removed {
-		fmt.Printf("  - %s\n", name)
-	}
-	return nil
-}
-
-// uninstallOpencodePlugin removes the smoovtask plugin for opencode.
-func uninstallOpencodePlugin() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home dir: %w", err)
-	}
-
-	pluginFile := filepath.Join(home, ".config", "opencode", "plugins", "smoovtask-hooks.ts")
-	if err := os.Remove(pluginFile); os.IsNotExist(err) {
-		fmt.Println("No opencode plugin found, nothing to uninstall.")
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("remove plugin: %w", err)
-	}
-
-	fmt.Printf("Removed opencode plugin: %s\n", pluginFile)
-	return nil
-}
-
-// uninstallPiExtension removes the smoovtask extension for pi.
-func uninstallPiExtension() error {
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return fmt.Errorf("get home dir: %w", err)
-	}
-
-	extensionFile := filepath.Join(home, ".pi", "agent", "extensions", "smoovtask-hooks.ts")
-	if err := os.Remove(extensionFile); os.IsNotExist(err) {
-		fmt.Println("No pi extension found, nothing to uninstall.")
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("remove extension: %w", err)
-	}
-
-	fmt.Printf("Removed pi extension: %s\n", extensionFile)
-	return nil
-}
-
-// marshalHookGroup converts a hookGroup to a map[string]any for JSON merging.
-func marshalHookGroup(g hookGroup) map[string]any {
-	m := make(map[string]any)
-	if g.Matcher != "" {
-		m["matcher"] = g.Matcher
-	}
-	var hooks []any
-	for _, h := range g.Hooks {
-		entry := map[string]any{
-			"type":    h.Type,
-			"command": h.Command,
-		}
-		if h.Async {
-			entry["async"] = true
-		}
-		hooks = append(hooks, entry)
-	}
-	m["hooks"] = hooks
-	return m
-}
@@ -46,10 +46,10 @@ func resolveCLIName(_ *config.Config, cliOverride string) (string, error) {
 	}
 
 	switch cliName {
-	case "claude", "opencode", "pi":
+	case "claude", "opencode", "pi", "codex", "gemini":
 		return cliName, nil
 	default:
-		return "", fmt.Errorf("unknown cli %q (supported: claude, opencode, pi)", cliName)
+		return "", fmt.Errorf("unknown cli %q (supported: claude, opencode, pi, codex, gemini)", cliName)
 	}
 }
 
@@ -141,3 +141,15 @@ func TestReview_LauncherModeUsesReviewerRole(t *testing.T) {
 		t.Fatal("launchInTmux not called")
 	}
 }
+
+func TestResolveCLIName_CodexAndGemini(t *testing.T) {
+	for _, cli := range []string{"codex", "gemini"} {
+		name, err := resolveCLIName(&config.Config{}, cli)
+		if err != nil {
+			t.Fatalf("resolveCLIName(%q) error = %v", cli, err)
+		}
+		if name != cli {
+			t.Fatalf("resolveCLIName(%q) = %q", cli, name)
+		}
+	}
+}
@@ -14,6 +14,6 @@ var leaderCmd = &cobra.Command{
 }
 
 func init() {
-	leaderCmd.Flags().StringVar(&leaderCLI, "cli", "", "CLI backend override (claude, opencode, pi)")
+	leaderCmd.Flags().StringVar(&leaderCLI, "cli", "", "CLI backend override (claude, opencode, pi, codex, gemini)")
 	rootCmd.AddCommand(leaderCmd)
 }
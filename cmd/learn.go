@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/knowledge"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var learnCmd = &cobra.Command{
+	Use:   "learn [fact]",
+	Short: "Record a durable project learning for future sessions",
+	Long: `Record a durable fact about the project — a build quirk, where something
+lives, a convention — in projects/<name>/knowledge.md. Learnings carry their
+provenance (ticket, run, date) and the most relevant ones are injected at
+session start and by ` + "`st pick`" + `.
+
+  st learn "templ files are generated — run make generate before go build"
+  st learn --list
+  st learn --remove 3`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runLearn,
+}
+
+var (
+	learnProject string
+	learnTicket  string
+	learnList    bool
+	learnRemove  int
+)
+
+func init() {
+	learnCmd.Flags().StringVar(&learnProject, "project", "", "project name (default: detected from the ticket or current directory)")
+	learnCmd.Flags().StringVar(&learnTicket, "ticket", "", "ticket the learning came from (default: current ticket)")
+	learnCmd.Flags().BoolVar(&learnList, "list", false, "list the project's learnings")
+	learnCmd.Flags().IntVar(&learnRemove, "remove", 0, "remove the learning with this number (see --list)")
+	rootCmd.AddCommand(learnCmd)
+}
+
+func runLearn(_ *cobra.Command, args []string) error {
+	if learnList {
+		if err := tableOnly("st learn --list"); err != nil {
+			return err
+		}
+	}
+	if !learnList && learnRemove == 0 && len(args) == 0 {
+		return fmt.Errorf("a learning is required — st learn \"<fact>\" (or --list / --remove <n>)")
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	vaultPath, err := cfg.VaultPath()
+	if err != nil {
+		return fmt.Errorf("get vault path: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+
+	store := ticket.NewStore(projectsDir)
+	runID := identity.RunID()
+	actor := identity.Actor()
+
+	// The source ticket is only provenance; a learning can be recorded
+	// without one.
+	var tk *ticket.Ticket
+	if learnTicket != "" || (runID != "" && len(args) == 1) {
+		tk, err = resolveCurrentTicket(store, cfg, runID, learnTicket)
+		if err != nil && learnTicket != "" {
+			return err
+		}
+	}
+
+	proj := learnProject
+	if proj == "" && tk != nil {
+		proj = tk.Project
+	}
+	if proj == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			proj = findProjectFromCwd(cfg, cwd)
+		}
+	}
+	if proj == "" {
+		return fmt.Errorf("no project detected — run inside a registered project or pass --project")
+	}
+
+	kb, err := knowledge.Load(vaultPath, proj)
+	if err != nil {
+		return err
+	}
+
+	if learnList {
+		entries := kb.Entries()
+		if len(entries) == 0 {
+			fmt.Printf("No learnings recorded for %s yet — add one with `st learn \"<fact>\"`.\n", proj)
+			return nil
+		}
+		fmt.Printf("Learnings for %s (%s):\n\n", proj, knowledge.Path(vaultPath, proj))
+		for i, e := range entries {
+			fmt.Printf("%3d. %s\n", i+1, e.Fact)
+			if p := e.Provenance(); p != "" {
+				fmt.Printf("     (%s)\n", p)
+			}
+		}
+		return nil
+	}
+
+	now := time.Now().UTC()
+	var (
+		evType  string
+		entry   knowledge.Entry
+		message string
+	)
+	if learnRemove != 0 {
+		entry, err = kb.Remove(learnRemove)
+		if err != nil {
+			return err
+		}
+		evType = event.KnowledgeRemoved
+		message = fmt.Sprintf("Removed learning #%d from %s: %s", learnRemove, proj, entry.Fact)
+	} else {
+		entry = knowledge.Entry{Fact: args[0], RunID: runID, Date: now}
+		if tk != nil {
+			entry.Ticket = tk.ID
+		}
+		if err := kb.Add(entry); err != nil {
+			return err
+		}
+		evType = event.KnowledgeAdded
+		message = fmt.Sprintf("Recorded learning for %s: %s", proj, strings.Join(strings.Fields(entry.Fact), " "))
+	}
+	if err := kb.Save(); err != nil {
+		return fmt.Errorf("save knowledge: %w", err)
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	_ = event.NewEventLog(eventsDir).Append(event.Event{
+		TS:      now,
+		Event:   evType,
+		Ticket:  entry.Ticket,
+		Project: proj,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    map[string]any{"fact": entry.Fact},
+	})
+
+	fmt.Println(message)
+	return nil
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/knowledge"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestLearn_AddListRemove(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "build setup", ticket.StatusInProgress)
+	tk.Assignee = "run-learn"
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "--run-id", "run-learn", "learn", "templ files are generated — run make generate before go build")
+	if err != nil {
+		t.Fatalf("learn: %v", err)
+	}
+	if !strings.Contains(out, "Recorded learning for testproject") {
+		t.Errorf("output = %q", out)
+	}
+	if _, err := env.runCmd(t, "learn", "--project", "testproject", "Middleware lives in internal/web/middleware"); err != nil {
+		t.Fatalf("learn --project: %v", err)
+	}
+	if _, err := env.runCmd(t, "learn", "Middleware lives in internal/web/middleware"); err == nil {
+		t.Error("duplicate learning was accepted")
+	}
+
+	data, err := os.ReadFile(knowledge.Path(env.Config.Settings.VaultPath, "testproject"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "— _"+tk.ID+", run run-learn, ") {
+		t.Errorf("missing provenance:\n%s", data)
+	}
+
+	out, err = env.runCmd(t, "learn", "--list")
+	if err != nil {
+		t.Fatalf("learn --list: %v", err)
+	}
+	if !strings.Contains(out, "  1. templ files are generated") || !strings.Contains(out, "  2. Middleware lives") {
+		t.Errorf("list output = %q", out)
+	}
+
+	out, err = env.runCmd(t, "learn", "--remove", "1")
+	if err != nil {
+		t.Fatalf("learn --remove: %v", err)
+	}
+	if !strings.Contains(out, "Removed learning #1") {
+		t.Errorf("remove output = %q", out)
+	}
+	if _, err := env.runCmd(t, "learn", "--remove", "5"); err == nil {
+		t.Error("removing a missing learning succeeded")
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{Project: "testproject"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var added, removed int
+	for _, e := range events {
+		switch e.Event {
+		case event.KnowledgeAdded:
+			added++
+		case event.KnowledgeRemoved:
+			removed++
+		}
+	}
+	if added != 2 || removed != 1 {
+		t.Errorf("knowledge events: added %d, removed %d", added, removed)
+	}
+}
+
+func TestLearn_RequiresFact(t *testing.T) {
+	env := newTestEnv(t)
+	if _, err := env.runCmd(t, "learn"); err == nil || !strings.Contains(err.Error(), "a learning is required") {
+		t.Errorf("error = %v", err)
+	}
+}
+
+func TestPick_PrintsRelevantKnowledge(t *testing.T) {
+	env := newTestEnv(t)
+	kb, err := knowledge.Load(env.Config.Settings.VaultPath, "testproject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fact := range []string{"Rate limiter settings live in config/limits.toml", "Unrelated fact about docs"} {
+		if err := kb.Add(knowledge.Entry{Fact: fact}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := kb.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	tk := env.createTicket(t, "Tune the rate limiter", ticket.StatusOpen)
+	out, err := env.runCmd(t, "pick", tk.ID)
+	if err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	i := strings.Index(out, "--- Project Knowledge ---")
+	if i < 0 {
+		t.Fatalf("missing knowledge section:\n%s", out)
+	}
+	section := out[i:]
+	if strings.Index(section, "Rate limiter settings") > strings.Index(section, "Unrelated fact") {
+		t.Errorf("relevant learning should come first:\n%s", section)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var linkCmd = &cobra.Command{
+	Use:   "link <ticket-id> <relation> <other-id>",
+	Short: "Link two tickets with a typed relation",
+	Long: `Link two tickets with a typed relation: blocks, relates-to, duplicates or
+follow-up-of. The inverse names blocked-by, duplicated-by and followed-up-by
+are accepted too; the relation is then recorded on the other ticket.`,
+	Args: cobra.ExactArgs(3),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runLink(args, false)
+	},
+}
+
+var unlinkCmd = &cobra.Command{
+	Use:   "unlink <ticket-id> <relation> <other-id>",
+	Short: "Remove a typed relation between two tickets",
+	Args:  cobra.ExactArgs(3),
+	RunE: func(_ *cobra.Command, args []string) error {
+		return runLink(args, true)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(linkCmd)
+	rootCmd.AddCommand(unlinkCmd)
+}
+
+func runLink(args []string, remove bool) error {
+	typ, inverse, err := ticket.ParseRelation(args[1])
+	if err != nil {
+		return err
+	}
+	fromID, toID := args[0], args[2]
+	if inverse {
+		fromID, toID = toID, fromID
+	}
+	if fromID == toID {
+		return fmt.Errorf("cannot link %s to itself", fromID)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	from, err := store.Get(fromID)
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+	to, err := store.Get(toID)
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+
+	holder, target := from, to
+	switch {
+	case !remove:
+		if (typ == ticket.RelationRelatesTo && slices.Contains(to.Relations[typ], from.ID)) || !from.AddRelation(typ, to.ID) {
+			return fmt.Errorf("%s already %s %s", from.ID, typ, to.ID)
+		}
+	case from.RemoveRelation(typ, to.ID):
+	case typ == ticket.RelationRelatesTo && to.RemoveRelation(typ, from.ID):
+		// A symmetric relation may have been recorded from the other side.
+		holder, target = to, from
+	default:
+		return fmt.Errorf("%s does not %s %s", from.ID, typ, to.ID)
+	}
+
+	now := time.Now().UTC()
+	actor := identity.Actor()
+	runID := identity.RunID()
+	heading, evType := "Linked", event.TicketLinked
+	if remove {
+		heading, evType = "Unlinked", event.TicketUnlinked
+	}
+	ticket.AppendSection(holder, heading, actor, runID, fmt.Sprintf("%s %s", typ, target.ID), nil, now)
+	if err := store.Save(holder); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	_ = event.NewEventLog(eventsDir).Append(event.Event{
+		TS:      now,
+		Event:   evType,
+		Ticket:  holder.ID,
+		Project: holder.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    map[string]any{"relation": string(typ), "target": target.ID},
+	})
+
+	fmt.Printf("%s: %s %s %s\n", heading, holder.ID, typ, target.ID)
+	return nil
+}
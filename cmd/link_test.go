@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestLink_HappyPath(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "blocker", ticket.StatusOpen)
+	b := env.createTicket(t, "blocked", ticket.StatusOpen)
+
+	out, err := env.runCmd(t, "link", a.ID, "blocks", b.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Linked: "+a.ID+" blocks "+b.ID) {
+		t.Errorf("output = %q", out)
+	}
+
+	updated, err := env.Store.Get(a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := updated.Relations[ticket.RelationBlocks]; len(ids) != 1 || ids[0] != b.ID {
+		t.Errorf("relations = %v", updated.Relations)
+	}
+	if !strings.Contains(updated.Body, "## Linked") {
+		t.Error("expected a Linked section")
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: a.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Event != event.TicketLinked || events[0].Data["relation"] != "blocks" || events[0].Data["target"] != b.ID {
+		t.Errorf("expected a ticket.linked event, got %+v", events)
+	}
+
+	// The inverse is visible from the other ticket.
+	out, err = env.runCmd(t, "show", b.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "- blocked-by "+a.ID+" blocker [OPEN]") {
+		t.Errorf("show should list the inverse link:\n%s", out)
+	}
+
+	if _, err := env.runCmd(t, "link", b.ID, "blocked-by", a.ID); err == nil || !strings.Contains(err.Error(), "already") {
+		t.Errorf("expected a duplicate link error, got %v", err)
+	}
+}
+
+func TestLink_InverseStoredOnOtherTicket(t *testing.T) {
+	env := newTestEnv(t)
+	dup := env.createTicket(t, "dup", ticket.StatusOpen)
+	orig := env.createTicket(t, "original", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "link", orig.ID, "duplicated-by", dup.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	updated, _ := env.Store.Get(dup.ID)
+	if ids := updated.Relations[ticket.RelationDuplicates]; len(ids) != 1 || ids[0] != orig.ID {
+		t.Errorf("duplicates should be stored on %s, got %v", dup.ID, updated.Relations)
+	}
+}
+
+func TestUnlink_SymmetricFromEitherSide(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "a", ticket.StatusOpen)
+	b := env.createTicket(t, "b", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "link", a.ID, "relates-to", b.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.runCmd(t, "link", b.ID, "relates-to", a.ID); err == nil {
+		t.Error("relates-to is symmetric — linking the other way should be a duplicate")
+	}
+	out, err := env.runCmd(t, "unlink", b.ID, "relates-to", a.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Unlinked: "+a.ID+" relates-to "+b.ID) {
+		t.Errorf("output = %q", out)
+	}
+	if updated, _ := env.Store.Get(a.ID); len(updated.Relations) != 0 {
+		t.Errorf("relations = %v, want none", updated.Relations)
+	}
+	if _, err := env.runCmd(t, "unlink", a.ID, "relates-to", b.ID); err == nil {
+		t.Error("expected an error unlinking a missing relation")
+	}
+}
+
+func TestLink_Errors(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "a", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "link", a.ID, "causes", "st_zzzzzz"); err == nil || !strings.Contains(err.Error(), "unknown relation") {
+		t.Errorf("expected an unknown relation error, got %v", err)
+	}
+	if _, err := env.runCmd(t, "link", a.ID, "blocks", "st_zzzzzz"); err == nil {
+		t.Error("expected an error for a missing ticket")
+	}
+	if _, err := env.runCmd(t, "link", a.ID, "blocks", a.ID); err == nil {
+		t.Error("expected an error linking a ticket to itself")
+	}
+}
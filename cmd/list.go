@@ -9,37 +9,80 @@ import (
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/project"
 	"github.com/boozedog/smoovtask/internal/spawn"
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/spf13/cobra"
 )
 
 var listCmd = &cobra.Command{
-	Use:   "list",
+	Use:   "list [query]",
 	Short: "List tickets with optional filters",
-	RunE:  runList,
+	Long: `List tickets, optionally narrowed by a query:
+
+  st list status:open,review priority:<=P2 tag:api assignee:none updated:<7d "rate limit"
+  st list tag:api sort:priority,-updated
+  st list @mine
+
+Keys are status, priority (P1, <=P2, >P3), tag, assignee (a run ID or none),
+project, parent, updated and created (<7d for within 7 days, >2w for older,
+or a date) and sort (priority, status, updated, created, title, id, project; a
+leading - reverses). Other words and quoted phrases match the ID, title or
+body. @name expands a saved query from the [queries] table in the config;
+--save stores the given query under a name.`,
+	RunE: runList,
 }
 
 var (
 	listProject string
 	listStatus  string
 	listAll     bool
+	listTree    bool
+	listSave    string
 )
 
 func init() {
 	listCmd.Flags().StringVar(&listProject, "project", "", "filter by project name")
 	listCmd.Flags().StringVar(&listStatus, "status", "", "filter by status")
 	listCmd.Flags().BoolVar(&listAll, "all", false, "show all tickets including DONE")
+	listCmd.Flags().BoolVar(&listTree, "tree", false, "nest child tickets under their parent")
+	listCmd.Flags().StringVar(&listSave, "save", "", "save the query under this name for use as @name")
 	rootCmd.AddCommand(listCmd)
 }
 
-func runList(_ *cobra.Command, _ []string) error {
+func runList(_ *cobra.Command, args []string) error {
 	cfg, err := config.Load()
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
+	raw := queryFromArgs(args)
+	if listSave != "" {
+		if raw == "" {
+			return fmt.Errorf("--save needs a query to save")
+		}
+		if cfg.Queries == nil {
+			cfg.Queries = map[string]string{}
+		}
+		cfg.Queries[listSave] = raw
+		if err := cfg.Save(); err != nil {
+			return fmt.Errorf("save config: %w", err)
+		}
+		if !structured() {
+			fmt.Printf("Saved query @%s: %s\n", listSave, raw)
+		}
+	}
+	expanded, err := ticket.ExpandSaved(raw, cfg.Queries)
+	if err != nil {
+		return err
+	}
+	query, err := ticket.ParseQuery(expanded, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("parse query: %w", err)
+	}
+
 	projectsDir, err := cfg.ProjectsDir()
 	if err != nil {
 		return fmt.Errorf("get tickets dir: %w", err)
@@ -47,6 +90,9 @@ func runList(_ *cobra.Command, _ []string) error {
 
 	// Auto-detect project from PWD if not specified
 	filterProject := listProject
+	if filterProject == "" {
+		filterProject = query.Filter.Project
+	}
 	if filterProject == "" {
 		cwd, err := os.Getwd()
 		if err == nil {
@@ -57,26 +103,30 @@ func runList(_ *cobra.Command, _ []string) error {
 		}
 	}
 
-	filter := ticket.ListFilter{
-		Project: filterProject,
-		Status:  ticket.Status(strings.ToUpper(listStatus)),
-	}
-	if listStatus == "" {
-		filter.Status = ""
-	}
+	filter := query.Filter
+	filter.Project = filterProject
+	filter.Status = ticket.Status(strings.ToUpper(listStatus))
 
-	// Default: hide DONE and CANCELLED unless --all or --status is explicit
-	if !listAll && listStatus == "" {
+	// Default: hide DONE and CANCELLED unless --all or a status is explicit
+	if !listAll && listStatus == "" && len(filter.Statuses) == 0 {
 		filter.Excludes = []ticket.Status{ticket.StatusDone, ticket.StatusCancelled}
 	}
 
+	// Text terms match bodies too, which only a full parse reads.
 	store := ticket.NewStore(projectsDir)
-	tickets, err := store.ListMeta(filter)
+	list := store.ListMeta
+	if len(filter.Text) > 0 {
+		list = store.List
+	}
+	tickets, err := list(filter)
 	if err != nil {
 		return fmt.Errorf("list tickets: %w", err)
 	}
 
 	if len(tickets) == 0 {
+		if structured() {
+			return writeOutput("list", listOutput{Query: raw, Project: filterProject, Tickets: []listItem{}})
+		}
 		fmt.Println("No tickets found.")
 		return nil
 	}
@@ -93,16 +143,74 @@ func runList(_ *cobra.Command, _ []string) error {
 		}
 		return tickets[i].Updated.After(tickets[j].Updated)
 	})
+	query.SortTickets(tickets)
 
-	// Look up worker info in a single batch (best-effort)
+	// Look up worker info and last test runs in a single batch (best-effort)
 	eventsDir, _ := cfg.EventsDir()
 	var workerStates map[string]*spawn.WorkerInfo
+	var testRuns map[string]testrun.Result
 	if eventsDir != "" {
 		workerStates, _ = spawn.BatchGetWorkerInfo(eventsDir)
+		testRuns = lastTestRuns(eventsDir, filterProject)
+	}
+
+	// Child progress counts finished children, which the filter may hide.
+	var progress map[string]ticket.Progress
+	if listTree {
+		all, err := store.ListMeta(ticket.ListFilter{Project: filterProject})
+		if err != nil {
+			return fmt.Errorf("list tickets: %w", err)
+		}
+		progress = map[string]ticket.Progress{}
+		for _, tk := range all {
+			if children := ticket.Children(all, tk.ID); len(children) > 0 {
+				progress[tk.ID] = ticket.ChildProgress(children)
+			}
+		}
+	}
+
+	// Rows in display order, children nested under their parent with --tree.
+	type row struct {
+		tk    *ticket.Ticket
+		depth int
+	}
+	var rows []row
+	if listTree {
+		var walk func(nodes []*ticket.TreeNode, depth int)
+		walk = func(nodes []*ticket.TreeNode, depth int) {
+			for _, n := range nodes {
+				rows = append(rows, row{n.Ticket, depth})
+				walk(n.Children, depth+1)
+			}
+		}
+		walk(ticket.BuildTree(tickets), 0)
+	} else {
+		for _, tk := range tickets {
+			rows = append(rows, row{tk, 0})
+		}
+	}
+
+	if structured() {
+		out := listOutput{Query: raw, Project: filterProject, Tickets: make([]listItem, 0, len(rows))}
+		for _, r := range rows {
+			item := listItem{ticketSummary: summarize(r.tk), Depth: r.depth}
+			if info, ok := workerStates[r.tk.ID]; ok {
+				item.Worker = summarizeWorker(info)
+			}
+			if tr, ok := testRuns[r.tk.ID]; ok {
+				item.Tests = summarizeTests(tr)
+			}
+			if p, ok := progress[r.tk.ID]; ok {
+				item.Progress = &progressSummary{Total: p.Total, Done: p.Done, Cancelled: p.Cancelled}
+			}
+			out.Tickets = append(out.Tickets, item)
+		}
+		return writeOutput("list", out)
 	}
 
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	for _, tk := range tickets {
+	for _, r := range rows {
+		tk := r.tk
 		worker := ""
 		if info, ok := workerStates[tk.ID]; ok {
 			worker = workerAnnotation(info)
@@ -111,8 +219,18 @@ func runList(_ *cobra.Command, _ []string) error {
 		if worker != "" {
 			status += " " + worker
 		}
+		if tr, ok := testRuns[tk.ID]; ok {
+			status += " " + testAnnotation(tr)
+		}
+		if p, ok := progress[tk.ID]; ok {
+			status += " [" + p.String() + "]"
+		}
+		id := tk.ID
+		if r.depth > 0 {
+			id = strings.Repeat("  ", r.depth-1) + "└ " + id
+		}
 		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n",
-			tk.ID, truncate(tk.Title, 40), status, tk.Priority, tk.Project); err != nil {
+			id, truncate(tk.Title, 40), status, tk.Priority, tk.Project); err != nil {
 			return err
 		}
 	}
@@ -120,6 +238,35 @@ func runList(_ *cobra.Command, _ []string) error {
 	return w.Flush()
 }
 
+// listOutput is the st.list schema.
+type listOutput struct {
+	Query   string     `json:"query"`
+	Project string     `json:"project"`
+	Tickets []listItem `json:"tickets"`
+}
+
+type listItem struct {
+	ticketSummary
+	// Depth nests children under their parent with --tree.
+	Depth    int              `json:"depth,omitempty"`
+	Worker   *workerSummary   `json:"worker,omitempty"`
+	Tests    *testsSummary    `json:"tests,omitempty"`
+	Progress *progressSummary `json:"progress,omitempty"`
+}
+
+// queryFromArgs joins query arguments, re-quoting any the shell unquoted so
+// a phrase like "rate limit" stays one text term.
+func queryFromArgs(args []string) string {
+	parts := make([]string, len(args))
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t") && !strings.Contains(arg, `"`) {
+			arg = `"` + arg + `"`
+		}
+		parts[i] = arg
+	}
+	return strings.Join(parts, " ")
+}
+
 // listStatusWeight returns sort weight: lower = higher in list.
 func listStatusWeight(s ticket.Status) int {
 	switch s {
@@ -163,6 +310,28 @@ func workerAnnotation(info *spawn.WorkerInfo) string {
 	}
 }
 
+// lastTestRuns returns the latest test run per ticket from the past 30 days.
+func lastTestRuns(eventsDir, proj string) map[string]testrun.Result {
+	events, err := event.QueryEvents(eventsDir, event.Query{
+		Project: proj,
+		After:   time.Now().UTC().Add(-30 * 24 * time.Hour),
+	})
+	if err != nil {
+		return nil
+	}
+	return testrun.LatestByTicket(events)
+}
+
+func testAnnotation(r testrun.Result) string {
+	if r.Status == testrun.StatusFail {
+		if r.Failed > 0 {
+			return fmt.Sprintf("[tests: %d failing]", r.Failed)
+		}
+		return "[tests: fail]"
+	}
+	return "[tests: pass]"
+}
+
 func truncate(s string, max int) string {
 	runes := []rune(s)
 	if len(runes) <= max {
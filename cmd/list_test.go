@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
@@ -167,3 +170,184 @@ func TestTruncate(t *testing.T) {
 		})
 	}
 }
+
+func TestList_ShowsLastTestStatus(t *testing.T) {
+	env := newTestEnv(t)
+	failing := env.createTicket(t, "failing ticket", ticket.StatusInProgress)
+	passing := env.createTicket(t, "passing ticket", ticket.StatusInProgress)
+	env.addTestRunEvent(t, failing.ID, time.Now().UTC(), testrun.Result{Runner: testrun.RunnerGo, Status: testrun.StatusFail, Failed: 2})
+	env.addTestRunEvent(t, passing.ID, time.Now().UTC(), testrun.Result{Runner: testrun.RunnerGo, Status: testrun.StatusPass, Passed: 5})
+
+	out, err := env.runCmd(t, "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.Contains(line, failing.ID) && !strings.Contains(line, "[tests: 2 failing]"):
+			t.Errorf("failing ticket line = %q", line)
+		case strings.Contains(line, passing.ID) && !strings.Contains(line, "[tests: pass]"):
+			t.Errorf("passing ticket line = %q", line)
+		}
+	}
+}
+
+func TestList_Tree(t *testing.T) {
+	env := newTestEnvResolved(t)
+
+	epic := env.createTicket(t, "epic", ticket.StatusOpen)
+	child := env.createTicket(t, "child", ticket.StatusInProgress)
+	done := env.createTicket(t, "done child", ticket.StatusDone)
+	for _, tk := range []*ticket.Ticket{child, done} {
+		tk.Parent = epic.ID
+		if err := env.Store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := env.runCmd(t, "list", "--tree")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 rows (DONE hidden), got:\n%s", out)
+	}
+	if !strings.HasPrefix(lines[0], epic.ID) || !strings.Contains(lines[0], "[1/2 done]") {
+		t.Errorf("first row should be the epic with progress, got %q", lines[0])
+	}
+	if !strings.HasPrefix(lines[1], "└ "+child.ID) {
+		t.Errorf("second row should be the nested child, got %q", lines[1])
+	}
+}
+
+func TestList_Query(t *testing.T) {
+	env := newTestEnvResolved(t)
+
+	api := env.createTicket(t, "rate limit the API", ticket.StatusOpen)
+	api.Tags = []string{"api"}
+	api.Priority = ticket.PriorityP1
+	if err := env.Store.Save(api); err != nil {
+		t.Fatal(err)
+	}
+	low := env.createTicket(t, "low priority api work", ticket.StatusOpen)
+	low.Tags = []string{"api"}
+	low.Priority = ticket.PriorityP4
+	if err := env.Store.Save(low); err != nil {
+		t.Fatal(err)
+	}
+	done := env.createTicket(t, "finished api work", ticket.StatusDone)
+	done.Tags = []string{"api"}
+	if err := env.Store.Save(done); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "list", "tag:api", "priority:<=P2", "assignee:none", "rate limit")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, api.ID) || strings.Contains(out, low.ID) || strings.Contains(out, done.ID) {
+		t.Errorf("output = %q, want only %s", out, api.ID)
+	}
+
+	// An explicit status lifts the default DONE/CANCELLED exclusion.
+	out, err = env.runCmd(t, "list", "tag:api", "status:done,open", "sort:-priority")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, done.ID) {
+		t.Errorf("output = %q, want done ticket %s", out, done.ID)
+	}
+	if strings.Index(out, low.ID) > strings.Index(out, api.ID) {
+		t.Errorf("sort:-priority should list P4 before P1:\n%s", out)
+	}
+
+	if _, err := env.runCmd(t, "list", "colour:red"); err == nil || !strings.Contains(err.Error(), "colour") {
+		t.Errorf("expected a query error, got %v", err)
+	}
+}
+
+func TestList_SavedQuery(t *testing.T) {
+	env := newTestEnvResolved(t)
+
+	tagged := env.createTicket(t, "tagged", ticket.StatusOpen)
+	tagged.Tags = []string{"api"}
+	if err := env.Store.Save(tagged); err != nil {
+		t.Fatal(err)
+	}
+	other := env.createTicket(t, "other", ticket.StatusOpen)
+
+	out, err := env.runCmd(t, "list", "--save", "api", "tag:api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Saved query @api") {
+		t.Errorf("output = %q", out)
+	}
+
+	out, err = env.runCmd(t, "list", "@api")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, tagged.ID) || strings.Contains(out, other.ID) {
+		t.Errorf("output = %q, want only %s", out, tagged.ID)
+	}
+
+	if _, err := env.runCmd(t, "list", "@nope"); err == nil {
+		t.Error("expected an error for an unknown saved query")
+	}
+}
+
+func TestList_FormatJSON(t *testing.T) {
+	env := newTestEnvResolved(t)
+	tk := env.createTicket(t, "structured ticket", ticket.StatusOpen)
+	env.createTicket(t, "finished ticket", ticket.StatusDone)
+
+	out, err := env.runCmd(t, "--format", "json", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		Schema string `json:"schema"`
+		Data   struct {
+			Tickets []struct {
+				ID        string   `json:"id"`
+				Title     string   `json:"title"`
+				Status    string   `json:"status"`
+				Priority  string   `json:"priority"`
+				Project   string   `json:"project"`
+				Tags      []string `json:"tags"`
+				DependsOn []string `json:"depends_on"`
+			} `json:"tickets"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if got.Schema != "st.list/v1" {
+		t.Errorf("schema = %q", got.Schema)
+	}
+	if len(got.Data.Tickets) != 1 {
+		t.Fatalf("tickets = %+v, want only the open one", got.Data.Tickets)
+	}
+	item := got.Data.Tickets[0]
+	if item.ID != tk.ID || item.Title != "structured ticket" || item.Status != "OPEN" || item.Priority != "P3" || item.Project != "testproject" {
+		t.Errorf("ticket = %+v", item)
+	}
+	if item.Tags == nil || item.DependsOn == nil {
+		t.Errorf("empty lists should be [], got %s", out)
+	}
+}
+
+func TestList_FormatJSONEmpty(t *testing.T) {
+	env := newTestEnvResolved(t)
+
+	out, err := env.runCmd(t, "--format", "json", "list")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, `"tickets": []`) || strings.Contains(out, "No tickets found") {
+		t.Errorf("output = %s", out)
+	}
+}
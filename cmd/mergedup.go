@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var mergeDupCmd = &cobra.Command{
+	Use:   "merge-dup <duplicate-id> <canonical-id>",
+	Short: "Fold a duplicate ticket into the canonical one",
+	Long: `Merge a duplicate ticket into its canonical ticket:
+
+  - tickets depending on the duplicate depend on the canonical ticket instead
+    (rejected if that would close a dependency cycle)
+  - the duplicate's children become children of the canonical ticket; typed
+    links from other tickets to the duplicate are listed, not changed
+  - the duplicate's description and notes are copied into a Merged Duplicate
+    section on the canonical ticket
+  - the duplicate is cancelled with a "duplicates" link to the canonical one`,
+	Args: cobra.ExactArgs(2),
+	RunE: runMergeDup,
+}
+
+func init() {
+	rootCmd.AddCommand(mergeDupCmd)
+}
+
+func runMergeDup(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	dup, err := store.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+	canonical, err := store.Get(args[1])
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+
+	now := time.Now().UTC()
+	actor := identity.Actor()
+	runID := identity.RunID()
+	result, err := ticket.MergeDuplicate(store, dup, canonical, actor, runID, now)
+	if err != nil {
+		return err
+	}
+
+	// Save the canonical ticket once, even when it was also a dependent.
+	for _, tk := range slices.Concat(result.Dependents, result.Children) {
+		if tk.ID == canonical.ID {
+			continue
+		}
+		if err := store.Save(tk); err != nil {
+			return fmt.Errorf("save ticket %s: %w", tk.ID, err)
+		}
+	}
+	if err := store.Save(canonical); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+	if err := store.Save(dup); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	el := event.NewEventLog(eventsDir)
+	_ = el.Append(event.Event{
+		TS:      now,
+		Event:   event.StatusCancelled,
+		Ticket:  dup.ID,
+		Project: dup.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    map[string]any{"from": string(result.From), "reason": "duplicate", "canonical": canonical.ID},
+	})
+	_ = el.Append(event.Event{
+		TS:      now,
+		Event:   event.TicketLinked,
+		Ticket:  dup.ID,
+		Project: dup.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    map[string]any{"relation": string(ticket.RelationDuplicates), "target": canonical.ID},
+	})
+	dependents := make([]string, len(result.Dependents))
+	for i, tk := range result.Dependents {
+		dependents[i] = tk.ID
+	}
+	children := make([]string, len(result.Children))
+	for i, tk := range result.Children {
+		children[i] = tk.ID
+	}
+	_ = el.Append(event.Event{
+		TS:      now,
+		Event:   event.TicketMerged,
+		Ticket:  canonical.ID,
+		Project: canonical.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    map[string]any{"duplicate": dup.ID, "notes": result.Notes, "dependents": dependents, "children": children},
+	})
+
+	fmt.Printf("Merged %s into %s (%d note(s) copied)\n", dup.ID, canonical.ID, result.Notes)
+	fmt.Printf("%s: %s → %s (duplicates %s)\n", dup.ID, result.From, ticket.StatusCancelled, canonical.ID)
+	for _, tk := range result.Dependents {
+		fmt.Printf("Repointed %s: depends on %s instead of %s\n", tk.ID, canonical.ID, dup.ID)
+		logDepsChange(store, el, tk, result.Changes[tk.ID], actor, runID, now)
+	}
+	for _, tk := range result.Children {
+		fmt.Printf("Reparented %s: parent is now %s\n", tk.ID, canonical.ID)
+	}
+	if len(result.Kept) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: left pointing at %s (update them by hand if needed):\n", dup.ID)
+		for _, ref := range result.Kept {
+			fmt.Fprintf(os.Stderr, "  %s\n", ref)
+		}
+	}
+
+	// The duplicate may have been the last open child of an epic.
+	rollupParents(cfg, store, el, dup, runID, now)
+	return nil
+}
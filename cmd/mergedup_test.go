@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestMergeDup_CancelsCopiesNotesAndRepoints(t *testing.T) {
+	env := newTestEnv(t)
+	dup := env.createTicket(t, "login broken", ticket.StatusOpen)
+	canonical := env.createTicket(t, "login fails on Safari", ticket.StatusOpen)
+	dependent := env.createTicket(t, "ship login page", ticket.StatusBlocked)
+
+	ticket.AppendSection(dup, "Note", "agent", "run-1", "Only reproduces with cookies disabled.", nil, time.Now().UTC())
+	if err := env.Store.Save(dup); err != nil {
+		t.Fatal(err)
+	}
+	dependent.DependsOn = []string{dup.ID}
+	if err := env.Store.Save(dependent); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "merge-dup", dup.ID, canonical.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"Merged " + dup.ID + " into " + canonical.ID + " (1 note(s) copied)",
+		"Repointed " + dependent.ID,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	gotDup, err := env.Store.Get(dup.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotDup.Status != ticket.StatusCancelled {
+		t.Errorf("duplicate status = %s, want CANCELLED", gotDup.Status)
+	}
+	if ids := gotDup.Relations[ticket.RelationDuplicates]; len(ids) != 1 || ids[0] != canonical.ID {
+		t.Errorf("duplicate relations = %v", gotDup.Relations)
+	}
+
+	gotCanonical, err := env.Store.Get(canonical.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## Merged Duplicate", "Merged from " + dup.ID, "Only reproduces with cookies disabled."} {
+		if !strings.Contains(gotCanonical.Body, want) {
+			t.Errorf("canonical body missing %q:\n%s", want, gotCanonical.Body)
+		}
+	}
+
+	gotDependent, err := env.Store.Get(dependent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gotDependent.DependsOn) != 1 || gotDependent.DependsOn[0] != canonical.ID {
+		t.Errorf("dependent DependsOn = %v, want [%s]", gotDependent.DependsOn, canonical.ID)
+	}
+	if gotDependent.Status != ticket.StatusBlocked {
+		t.Errorf("dependent status = %s, want BLOCKED", gotDependent.Status)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	seen := map[string]string{}
+	for _, e := range events {
+		seen[e.Event] = e.Ticket
+	}
+	for name, id := range map[string]string{
+		event.StatusCancelled:   dup.ID,
+		event.TicketLinked:      dup.ID,
+		event.TicketMerged:      canonical.ID,
+		event.TicketDepsChanged: dependent.ID,
+	} {
+		if seen[name] != id {
+			t.Errorf("event %s on %q, want %q", name, seen[name], id)
+		}
+	}
+}
+
+func TestMergeDup_Rejects(t *testing.T) {
+	env := newTestEnv(t)
+	a := env.createTicket(t, "a", ticket.StatusOpen)
+	cancelled := env.createTicket(t, "gone", ticket.StatusCancelled)
+
+	for _, args := range [][]string{
+		{"merge-dup", a.ID, a.ID},
+		{"merge-dup", cancelled.ID, a.ID},
+		{"merge-dup", a.ID, cancelled.ID},
+	} {
+		if _, err := env.runCmd(t, args...); err == nil {
+			t.Errorf("%v succeeded, want error", args)
+		}
+	}
+	got, err := env.Store.Get(a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != ticket.StatusOpen {
+		t.Errorf("status = %s, want unchanged", got.Status)
+	}
+}
+
+func TestMergeDup_ReparentsChildren(t *testing.T) {
+	env := newTestEnv(t)
+	dup := env.createTicket(t, "epic copy", ticket.StatusOpen)
+	canonical := env.createTicket(t, "epic", ticket.StatusOpen)
+	child := env.createTicket(t, "step one", ticket.StatusOpen)
+	child.Parent = dup.ID
+	if err := env.Store.Save(child); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "merge-dup", dup.ID, canonical.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Reparented "+child.ID+": parent is now "+canonical.ID) {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	got, err := env.Store.Get(child.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Parent != canonical.ID {
+		t.Errorf("parent = %q, want %s", got.Parent, canonical.ID)
+	}
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var moveCmd = &cobra.Command{
+	Use:   "move <ticket-id> --project <name>",
+	Short: "Move a ticket to another project",
+	Long: `Move a ticket filed under the wrong project. The ticket file moves into the
+new project's tickets directory and keeps its ID, so links, parents and
+dependencies keep working. Dependency references to or from the ticket that
+are only an ID prefix are rewritten to the full ID. A Moved section and a
+ticket.moved event record the change.
+
+An IN-PROGRESS or REWORK ticket is refused unless --force is given: hooks in
+the old repo would no longer find it and would block the assigned agent's
+writes. Hand it off first (st handoff) where possible.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runMove,
+}
+
+var (
+	moveProject string
+	moveForce   bool
+)
+
+func init() {
+	moveCmd.Flags().StringVar(&moveProject, "project", "", "project to move the ticket to")
+	moveCmd.Flags().BoolVar(&moveForce, "force", false, "move an IN-PROGRESS or REWORK ticket")
+	_ = moveCmd.MarkFlagRequired("project")
+	rootCmd.AddCommand(moveCmd)
+}
+
+func runMove(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	vaultPath, err := cfg.VaultPath()
+	if err != nil {
+		return fmt.Errorf("get vault path: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	names, _ := project.ListProjects(vaultPath)
+	if !slices.Contains(names, moveProject) {
+		return &cliError{
+			Code:    codeInvalidArgument,
+			Message: fmt.Sprintf("unknown project %q", moveProject),
+			Hint:    "Register it with `st init` in its directory first.",
+		}
+	}
+
+	tk, err := store.Get(args[0])
+	if err != nil {
+		return fmt.Errorf("get ticket: %w", err)
+	}
+
+	now := time.Now().UTC()
+	actor := identity.Actor()
+	runID := identity.RunID()
+	result, err := ticket.Move(store, tk, moveProject, moveForce, actor, runID, now)
+	if err != nil {
+		return &cliError{Code: codeInvalidArgument, Message: err.Error()}
+	}
+
+	if err := store.Save(tk); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+	for _, dep := range result.Dependents {
+		if err := store.Save(dep); err != nil {
+			return fmt.Errorf("save ticket %s: %w", dep.ID, err)
+		}
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	data := map[string]any{"from": result.From, "to": result.To}
+	if len(result.Rewritten) > 0 {
+		data["rewritten"] = result.Rewritten
+	}
+	_ = event.NewEventLog(eventsDir).Append(event.Event{
+		TS:      now,
+		Event:   event.TicketMoved,
+		Ticket:  tk.ID,
+		Project: tk.Project,
+		Actor:   actor,
+		RunID:   runID,
+		Data:    data,
+	})
+
+	fmt.Printf("Moved %s: %s → %s\n", tk.ID, result.From, result.To)
+	for id, refs := range result.Rewritten {
+		for old, full := range refs {
+			fmt.Printf("  %s: rewrote dependency %s → %s\n", id, old, full)
+		}
+	}
+	if len(result.Unresolved) > 0 {
+		fmt.Fprintf(os.Stderr, "warning: %s depends on %s, which match no single ticket\n", tk.ID, strings.Join(result.Unresolved, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func (e *testEnv) registerProject(t *testing.T, name string) {
+	t.Helper()
+	if err := project.SaveMeta(e.Config.Settings.VaultPath, name, &project.ProjectMeta{Path: t.TempDir()}); err != nil {
+		t.Fatalf("save project meta: %v", err)
+	}
+}
+
+func TestMove_RelocatesAndRewritesPrefixDeps(t *testing.T) {
+	env := newTestEnv(t)
+	env.registerProject(t, "other")
+	tk := env.createTicket(t, "misfiled", ticket.StatusOpen)
+	dependent := env.createTicket(t, "waits on misfiled", ticket.StatusBlocked)
+	prefix := tk.ID[:len(tk.ID)-2]
+	dependent.DependsOn = []string{prefix}
+	if err := env.Store.Save(dependent); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "move", tk.ID, "--project", "other")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Moved "+tk.ID+": testproject → other") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+
+	moved, err := env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if moved.Project != "other" {
+		t.Errorf("project = %q, want other", moved.Project)
+	}
+	if !strings.Contains(moved.Body, "## Moved") {
+		t.Errorf("missing Moved section:\n%s", moved.Body)
+	}
+	oldFiles, _ := filepath.Glob(filepath.Join(env.ProjectsDir, "testproject", "tickets", "*", "*", "*"+tk.ID+".md"))
+	newFiles, _ := filepath.Glob(filepath.Join(env.ProjectsDir, "other", "tickets", "*", "*", "*"+tk.ID+".md"))
+	if len(oldFiles) != 0 || len(newFiles) != 1 {
+		t.Errorf("old files = %v, new files = %v", oldFiles, newFiles)
+	}
+
+	dep, err := env.Store.Get(dependent.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dep.DependsOn) != 1 || dep.DependsOn[0] != tk.ID {
+		t.Errorf("dependent DependsOn = %v, want [%s]", dep.DependsOn, tk.ID)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: tk.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].Event != event.TicketMoved || events[0].Project != "other" {
+		t.Fatalf("events = %+v", events)
+	}
+	if events[0].Data["from"] != "testproject" || events[0].Data["to"] != "other" {
+		t.Errorf("event data = %v", events[0].Data)
+	}
+}
+
+func TestMove_UnknownProject(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "misfiled", ticket.StatusOpen)
+
+	_, err := env.runCmd(t, "move", tk.ID, "--project", "nope")
+	if err == nil || !strings.Contains(err.Error(), `unknown project "nope"`) {
+		t.Fatalf("err = %v, want unknown project", err)
+	}
+	got, err := env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Project != "testproject" {
+		t.Errorf("project = %q, want unchanged", got.Project)
+	}
+}
+
+func TestMove_SameProject(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "fine where it is", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "move", tk.ID, "--project", "testproject"); err == nil {
+		t.Fatal("expected error moving into the current project")
+	}
+}
+
+func TestMove_ActiveTicketNeedsForce(t *testing.T) {
+	env := newTestEnv(t)
+	env.registerProject(t, "other")
+	tk := env.createTicket(t, "being worked on", ticket.StatusInProgress)
+
+	_, err := env.runCmd(t, "move", tk.ID, "--project", "other")
+	if err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("err = %v, want a refusal mentioning --force", err)
+	}
+	if got, _ := env.Store.Get(tk.ID); got.Project != "testproject" {
+		t.Errorf("project = %q, want unchanged", got.Project)
+	}
+
+	if _, err := env.runCmd(t, "move", tk.ID, "--project", "other", "--force"); err != nil {
+		t.Fatalf("forced move: %v", err)
+	}
+	if got, _ := env.Store.Get(tk.ID); got.Project != "other" {
+		t.Errorf("project = %q, want other", got.Project)
+	}
+}
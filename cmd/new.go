@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 	"time"
 
@@ -18,8 +20,22 @@ var newCmd = &cobra.Command{
 	Use:     "new [title]",
 	Aliases: []string{"create"},
 	Short:   "Create a new ticket for the current project",
-	Args:    cobra.MaximumNArgs(1),
-	RunE:    runNew,
+	Long: `Create a new ticket for the current project.
+
+With --template the ticket starts from a template in the vault:
+templates/<name>.md, or projects/<project>/templates/<name>.md to override
+it for one project. The template's frontmatter sets the default priority and
+tags and lists required sections; its body becomes the description skeleton.
+Fill sections with --section "Heading=text". A ticket with required sections
+left empty is created in BACKLOG and cannot move to OPEN until they are
+filled in.
+
+  st new "Login fails on Safari" --template bug \
+    --section "Steps to reproduce=Open /login in Safari 17" \
+    --section "Expected behavior=Sign-in works" \
+    --section "Actual behavior=Blank page"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runNew,
 }
 
 var (
@@ -29,6 +45,9 @@ var (
 	newDescription string
 	newProject     string
 	newTitle       string
+	newParent      string
+	newTemplate    string
+	newSections    []string
 )
 
 func init() {
@@ -37,11 +56,14 @@ func init() {
 	newCmd.Flags().StringVar(&newTags, "tags", "", "comma-separated tags")
 	newCmd.Flags().StringVar(&newDependsOn, "depends-on", "", "comma-separated ticket IDs this ticket depends on")
 	newCmd.Flags().StringVar(&newProject, "project", "", "project name (defaults to auto-detect from current directory)")
+	newCmd.Flags().StringVar(&newParent, "parent", "", "parent (epic) ticket ID")
 	newCmd.Flags().StringVarP(&newTitle, "title", "t", "", "ticket title (alternative to positional argument)")
+	newCmd.Flags().StringVar(&newTemplate, "template", "", "start from a ticket template, e.g. bug or feature")
+	newCmd.Flags().StringArrayVar(&newSections, "section", nil, "fill a template section: \"Heading=text\" (repeatable)")
 	rootCmd.AddCommand(newCmd)
 }
 
-func runNew(_ *cobra.Command, args []string) error {
+func runNew(cmd *cobra.Command, args []string) error {
 	var title string
 	switch {
 	case newTitle != "":
@@ -62,8 +84,27 @@ func runNew(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("get vault path: %w", err)
 	}
 
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+	store := ticket.NewStore(projectsDir)
+
+	// The parent may be given as an ID prefix; store the full ID, since
+	// Children and the epic rollup compare exact IDs.
+	var parent *ticket.Ticket
+	var parentID string
+	if newParent != "" {
+		parent, err = store.Get(newParent)
+		if err != nil {
+			return fmt.Errorf("parent %s: %w", newParent, err)
+		}
+		parentID = parent.ID
+	}
+
 	var proj string
-	if newProject != "" {
+	switch {
+	case newProject != "":
 		names, _ := project.ListProjects(vaultPath)
 		found := false
 		for _, n := range names {
@@ -76,7 +117,9 @@ func runNew(_ *cobra.Command, args []string) error {
 			return fmt.Errorf("unknown project %q — check `st init` or config", newProject)
 		}
 		proj = newProject
-	} else {
+	case parent != nil:
+		proj = parent.Project
+	default:
 		cwd, err := os.Getwd()
 		if err != nil {
 			return fmt.Errorf("get working directory: %w", err)
@@ -87,15 +130,37 @@ func runNew(_ *cobra.Command, args []string) error {
 		}
 	}
 
+	var tmpl *ticket.Template
+	if newTemplate != "" {
+		if tmpl, err = loadTemplate(cfg, proj, newTemplate); err != nil {
+			return err
+		}
+	}
+	sections, err := parseSectionFlags(newSections)
+	if err != nil {
+		return err
+	}
+	if len(sections) > 0 && tmpl == nil {
+		return &cliError{Code: codeInvalidArgument, Message: "--section needs --template"}
+	}
+
 	priority := ticket.Priority(newPriority)
+	if tmpl != nil && tmpl.Priority != "" && !cmd.Flags().Changed("priority") {
+		priority = tmpl.Priority
+	}
 	if !ticket.ValidPriorities[priority] {
 		return fmt.Errorf("invalid priority %q (use P0-P5)", newPriority)
 	}
 
 	var tags []string
+	if tmpl != nil {
+		tags = append(tags, tmpl.Tags...)
+	}
 	if newTags != "" {
 		for _, t := range strings.Split(newTags, ",") {
-			tags = append(tags, strings.TrimSpace(t))
+			if t = strings.TrimSpace(t); !slices.ContainsFunc(tags, func(have string) bool { return strings.EqualFold(have, t) }) {
+				tags = append(tags, t)
+			}
 		}
 	}
 
@@ -113,6 +178,7 @@ func runNew(_ *cobra.Command, args []string) error {
 		Status:    ticket.StatusOpen,
 		Priority:  priority,
 		DependsOn: dependsOn,
+		Parent:    parentID,
 		Created:   now,
 		Updated:   now,
 		Tags:      tags,
@@ -124,23 +190,30 @@ func runNew(_ *cobra.Command, args []string) error {
 		tk.DependsOn = []string{}
 	}
 
-	projectsDir, err := cfg.ProjectsDir()
-	if err != nil {
-		return fmt.Errorf("get tickets dir: %w", err)
-	}
-
-	store := ticket.NewStore(projectsDir)
 	if err := cfg.EnsureDirs(); err != nil {
 		return fmt.Errorf("ensure dirs: %w", err)
 	}
 
 	actor := identity.Actor()
 	runID := identity.RunID()
+	followUpOf := runTicketID(cfg, store, runID)
+	if followUpOf != "" {
+		tk.AddRelation(ticket.RelationFollowUpOf, followUpOf)
+	}
 	sectionContent := title
 	if newDescription != "" {
 		sectionContent = newDescription
 	}
-	ticket.AppendSection(tk, "Created", actor, runID, sectionContent, nil, now)
+	var createdFields map[string]string
+	var missing []string
+	if tmpl != nil {
+		sectionContent = tmpl.Fill(newDescription, sections)
+		createdFields = ticket.TemplateFields(tmpl)
+	}
+	ticket.AppendSection(tk, "Created", actor, runID, sectionContent, createdFields, now)
+	if missing = ticket.MissingSections(tk); len(missing) > 0 {
+		tk.Status = ticket.StatusBacklog
+	}
 
 	if err := store.Create(tk); err != nil {
 		return fmt.Errorf("create ticket: %w", err)
@@ -156,6 +229,15 @@ func runNew(_ *cobra.Command, args []string) error {
 	if newDescription != "" {
 		evData["description"] = newDescription
 	}
+	if parentID != "" {
+		evData["parent"] = parentID
+	}
+	if followUpOf != "" {
+		evData["follow_up_of"] = followUpOf
+	}
+	if tmpl != nil {
+		evData["template"] = tmpl.Name
+	}
 	_ = el.Append(event.Event{
 		TS:      now,
 		Event:   event.TicketCreated,
@@ -167,6 +249,13 @@ func runNew(_ *cobra.Command, args []string) error {
 	})
 
 	fmt.Printf("Created %s: %s\n", tk.ID, title)
+	if followUpOf != "" {
+		fmt.Printf("Linked as follow-up-of %s\n", followUpOf)
+	}
+	if len(missing) > 0 {
+		fmt.Printf("Left in BACKLOG — required sections still empty: %s\n", strings.Join(missing, ", "))
+		fmt.Printf("Fill them in, then run `st status open %s`.\n", tk.ID)
+	}
 
 	// Auto-block if any dependencies are not DONE
 	if len(dependsOn) > 0 {
@@ -174,8 +263,8 @@ func runNew(_ *cobra.Command, args []string) error {
 		if checkErr != nil {
 			fmt.Fprintf(os.Stderr, "warning: dependency check failed: %v\n", checkErr)
 		} else if len(unresolved) > 0 {
-			openStatus := ticket.StatusOpen
-			tk.PriorStatus = &openStatus
+			priorStatus := tk.Status
+			tk.PriorStatus = &priorStatus
 			tk.Status = ticket.StatusBlocked
 			tk.Updated = now
 
@@ -194,7 +283,7 @@ func runNew(_ *cobra.Command, args []string) error {
 				Data: map[string]any{
 					"reason":       "depends-on",
 					"refs":         dependsOn,
-					"prior_status": "OPEN",
+					"prior_status": string(priorStatus),
 				},
 			})
 
@@ -204,3 +293,81 @@ func runNew(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// loadTemplate loads the named ticket template for proj, listing the
+// available ones in the error if there is no such template.
+func loadTemplate(cfg *config.Config, proj, name string) (*ticket.Template, error) {
+	dirs, err := cfg.TemplateDirs(proj)
+	if err != nil {
+		return nil, fmt.Errorf("get templates dir: %w", err)
+	}
+	tmpl, err := ticket.LoadTemplate(name, dirs...)
+	if errors.Is(err, ticket.ErrTemplateNotFound) {
+		hint := "Add templates as markdown files under templates/ in the vault."
+		if names, _ := ticket.ListTemplates(dirs...); len(names) > 0 {
+			hint = "Available templates: " + strings.Join(names, ", ") + "."
+		}
+		return nil, &cliError{Code: codeNotFound, Message: err.Error(), Hint: hint}
+	}
+	if err != nil {
+		return nil, &cliError{Code: codeInvalidArgument, Message: err.Error()}
+	}
+	return tmpl, nil
+}
+
+// parseSectionFlags parses --section "Heading=text" values.
+func parseSectionFlags(values []string) (map[string]string, error) {
+	sections := map[string]string{}
+	for _, v := range values {
+		heading, text, ok := strings.Cut(v, "=")
+		if !ok || strings.TrimSpace(heading) == "" || strings.TrimSpace(text) == "" {
+			return nil, &cliError{
+				Code:    codeInvalidArgument,
+				Message: fmt.Sprintf("invalid section %q", v),
+				Hint:    `Pass --section "Heading=text".`,
+			}
+		}
+		sections[strings.TrimSpace(heading)] = text
+	}
+	return sections, nil
+}
+
+// runTicketID returns the ticket the run is working on — the one assigned to
+// it in IN-PROGRESS or REWORK, or else the last one it submitted for review in
+// the past day — so tickets it creates are linked as follow-ups.
+func runTicketID(cfg *config.Config, store *ticket.Store, runID string) string {
+	if runID == "" {
+		return ""
+	}
+	tickets, err := store.ListMeta(ticket.ListFilter{})
+	if err != nil {
+		return ""
+	}
+	var active []string
+	for _, tk := range tickets {
+		if tk.Assignee == runID && (tk.Status == ticket.StatusInProgress || tk.Status == ticket.StatusRework) {
+			active = append(active, tk.ID)
+		}
+	}
+	if len(active) == 1 {
+		return active[0]
+	}
+	if len(active) > 1 {
+		return ""
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return ""
+	}
+	events, err := event.QueryEvents(eventsDir, event.Query{RunID: runID, After: time.Now().UTC().Add(-24 * time.Hour)})
+	if err != nil {
+		return ""
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Event == event.StatusReview && events[i].Ticket != "" {
+			return events[i].Ticket
+		}
+	}
+	return ""
+}
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
@@ -452,3 +453,179 @@ func TestNew_DescriptionFlag(t *testing.T) {
 		t.Errorf("ticket body should contain the description, got:\n%s", full.Body)
 	}
 }
+
+func TestNew_ParentFlag(t *testing.T) {
+	env := newTestEnv(t)
+	epic := env.createTicket(t, "epic", ticket.StatusOpen)
+
+	// Run from outside the project: the parent's project is used.
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.runCmd(t, "new", "child", "--parent", epic.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	children := ticket.Children(mustList(t, env), epic.ID)
+	if len(children) != 1 || children[0].Project != "testproject" {
+		t.Fatalf("children = %+v, want one child in testproject", children)
+	}
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: children[0].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 || events[0].Data["parent"] != epic.ID {
+		t.Errorf("ticket.created event should record the parent, got %+v", events)
+	}
+}
+
+func TestNew_ParentPrefixStoresFullID(t *testing.T) {
+	env := newTestEnv(t)
+	epic := env.createTicket(t, "epic", ticket.StatusOpen)
+
+	if _, err := env.runCmd(t, "new", "child", "--parent", epic.ID[:6]); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	children := ticket.Children(mustList(t, env), epic.ID)
+	if len(children) != 1 || children[0].Parent != epic.ID {
+		t.Fatalf("children = %+v, want one child with parent %s", children, epic.ID)
+	}
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: children[0].ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) == 0 || events[0].Data["parent"] != epic.ID {
+		t.Errorf("ticket.created event should record the full parent ID, got %+v", events)
+	}
+}
+
+func TestNew_ParentNotFound(t *testing.T) {
+	env := newTestEnv(t)
+
+	_, err := env.runCmd(t, "new", "child", "--parent", "st_nope01")
+	if err == nil || !strings.Contains(err.Error(), "parent st_nope01") {
+		t.Fatalf("expected a parent error, got %v", err)
+	}
+}
+
+func mustList(t *testing.T, env *testEnv) []*ticket.Ticket {
+	t.Helper()
+	tickets, err := env.Store.ListMeta(ticket.ListFilter{})
+	if err != nil {
+		t.Fatalf("list tickets: %v", err)
+	}
+	return tickets
+}
+
+func TestNew_FollowUpOfActiveTicket(t *testing.T) {
+	env := newTestEnvResolved(t)
+	src := env.createTicket(t, "source", ticket.StatusInProgress)
+	src.Assignee = "run-follow"
+	if err := env.Store.Save(src); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "--run-id", "run-follow", "new", "improvement")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Linked as follow-up-of "+src.ID) {
+		t.Errorf("output = %q", out)
+	}
+
+	for _, tk := range mustList(t, env) {
+		if tk.Title != "improvement" {
+			continue
+		}
+		if ids := tk.Relations[ticket.RelationFollowUpOf]; len(ids) != 1 || ids[0] != src.ID {
+			t.Errorf("relations = %v, want follow-up-of %s", tk.Relations, src.ID)
+		}
+		return
+	}
+	t.Fatal("new ticket not found")
+}
+
+func TestNew_FollowUpOfSubmittedTicket(t *testing.T) {
+	env := newTestEnvResolved(t)
+	src := env.createTicket(t, "source", ticket.StatusReview)
+	if err := env.EventLog.Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.StatusReview,
+		Ticket:  src.ID,
+		Project: "testproject",
+		RunID:   "run-submitted",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "--run-id", "run-submitted", "new", "improvement")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Linked as follow-up-of "+src.ID) {
+		t.Errorf("output = %q", out)
+	}
+}
+
+func TestNew_Template(t *testing.T) {
+	env := newTestEnvResolved(t)
+	templatesDir, err := env.Config.TemplatesDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ticket.SeedTemplates(templatesDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Required sections left empty: created in BACKLOG with template defaults.
+	out, err := env.runCmd(t, "new", "login fails", "--template", "bug", "--tags", "auth",
+		"--section", "Steps to reproduce=Open /login in Safari")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Left in BACKLOG") || !strings.Contains(out, "Expected behavior, Actual behavior") {
+		t.Errorf("output = %q", out)
+	}
+	tickets, err := env.Store.List(ticket.ListFilter{})
+	if err != nil || len(tickets) != 1 {
+		t.Fatalf("tickets = %v, err = %v", tickets, err)
+	}
+	tk := tickets[0]
+	if tk.Status != ticket.StatusBacklog || tk.Priority != ticket.PriorityP2 || strings.Join(tk.Tags, ",") != "bug,auth" {
+		t.Errorf("ticket = %+v", tk)
+	}
+	if !strings.Contains(tk.Body, "### Steps to reproduce\n\nOpen /login in Safari") {
+		t.Errorf("body = %q", tk.Body)
+	}
+
+	// OPEN is refused until the sections are filled in.
+	if _, err := env.runCmd(t, "status", "--ticket", tk.ID, "open"); err == nil || !strings.Contains(err.Error(), "Expected behavior") {
+		t.Fatalf("err = %v, want missing sections", err)
+	}
+	if _, err := env.runCmd(t, "edit", tk.ID, "--append-description", "### Expected behavior\n\nSigned in.\n\n### Actual behavior\n\nBlank page."); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.runCmd(t, "status", "--ticket", tk.ID, "open"); err != nil {
+		t.Fatalf("status open after filling sections: %v", err)
+	}
+
+	// All required sections given: created OPEN; --priority overrides the template.
+	out, err = env.runCmd(t, "new", "signup fails", "--template", "bug", "-p", "P1",
+		"--section", "Steps to reproduce=Sign up",
+		"--section", "Expected behavior=Account created",
+		"--section", "Actual behavior=500")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Contains(out, "BACKLOG") {
+		t.Errorf("output = %q", out)
+	}
+
+	if _, err := env.runCmd(t, "new", "x", "--template", "chore"); err == nil || !strings.Contains(toCLIError(err).Hint, "bug, feature") {
+		t.Errorf("err = %v, want unknown template listing bug, feature", err)
+	}
+	if _, err := env.runCmd(t, "new", "x", "--section", "a=b"); err == nil {
+		t.Error("expected --section without --template to fail")
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/guidance"
+	"github.com/boozedog/smoovtask/internal/handoff"
 	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/knowledge"
 	"github.com/boozedog/smoovtask/internal/spawn"
 	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/boozedog/smoovtask/internal/workflow"
@@ -143,11 +145,27 @@ func runPick(_ *cobra.Command, args []string) error {
 	}
 	fmt.Println()
 
+	if summary, ok := ticket.LastSection(ticket.Sections(tk.Body), handoff.Heading); ok {
+		fmt.Printf("--- State of Play (%s, %s) ---\n", summary.Fields["reason"], summary.TS.Format("2006-01-02 15:04"))
+		fmt.Println(summary.Content)
+		fmt.Println("Start here; the full history is in the ticket body below.")
+		fmt.Println()
+	}
+
 	if tk.Body != "" {
 		fmt.Printf("--- Ticket Body ---\n")
 		fmt.Println(tk.Body)
 	}
 
+	if vaultPath, err := cfg.VaultPath(); err == nil {
+		query := tk.Title + "\n" + strings.Join(tk.Tags, " ") + "\n" + tk.Body
+		if learnings := knowledge.Context(vaultPath, tk.Project, query, cfg.KnowledgeBudget()); learnings != "" {
+			fmt.Printf("--- Project Knowledge ---\n")
+			fmt.Print(learnings)
+			fmt.Printf("Record new durable facts with `st learn \"<fact>\"`.\n\n")
+		}
+	}
+
 	fmt.Printf("--- Commit Rules ---\n")
 	fmt.Println(guidance.CommitRules())
 	fmt.Println()
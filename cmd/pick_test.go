@@ -250,3 +250,33 @@ func runGitCmd(t *testing.T, dir string, args ...string) {
 		t.Fatalf("git %v: %v\n%s", args, err, out)
 	}
 }
+
+func TestPick_PrintsStateOfPlay(t *testing.T) {
+	env := newTestEnv(t)
+
+	tk := env.createTicket(t, "handed over", ticket.StatusInProgress)
+	tk.Assignee = "test-session-1"
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatalf("save ticket: %v", err)
+	}
+	if _, err := env.runCmd(t, "--run-id", "test-session-1", "note", "--ticket", tk.ID, "Wired the limiter into the router."); err != nil {
+		t.Fatalf("note: %v", err)
+	}
+	if _, err := env.runCmd(t, "--run-id", "test-session-1", "handoff", tk.ID); err != nil {
+		t.Fatalf("handoff: %v", err)
+	}
+
+	out, err := env.runCmd(t, "--run-id", "test-session-2", "pick", tk.ID)
+	if err != nil {
+		t.Fatalf("pick: %v", err)
+	}
+	if !strings.Contains(out, "--- State of Play (handoff,") {
+		t.Errorf("output missing state of play header:\n%s", out)
+	}
+	if !strings.Contains(out, "Wired the limiter into the router.") {
+		t.Errorf("output missing summarized note:\n%s", out)
+	}
+	if strings.Index(out, "--- State of Play") > strings.Index(out, "--- Ticket Body ---") {
+		t.Error("state of play should be printed before the ticket body")
+	}
+}
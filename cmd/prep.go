@@ -12,6 +12,7 @@ import (
 	"github.com/boozedog/smoovtask/internal/guidance"
 	"github.com/boozedog/smoovtask/internal/spawn"
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/touched"
 	"github.com/spf13/cobra"
 )
 
@@ -91,6 +92,47 @@ func runPrep(_ *cobra.Command, args []string) error {
 	return runPrepBatch(cfg, store, repoRoot, baseBranch, cwd)
 }
 
+// prepOutput is the st.prep schema. Mode is "single" or "batch"; Worktree
+// and Commands are empty when there is nothing to merge.
+type prepOutput struct {
+	Mode       string     `json:"mode"`
+	BaseBranch string     `json:"base_branch"`
+	Tickets    []prepItem `json:"tickets"`
+	// Skipped lists batch tickets with no commits beyond the base branch.
+	Skipped []ticketRef `json:"skipped,omitempty"`
+	// BaseConflicts lists files changed on both the base and work branch.
+	BaseConflicts []string `json:"base_conflicts,omitempty"`
+	// Conflicts lists files changed by more than one batch ticket.
+	Conflicts      []prepConflict `json:"conflicts,omitempty"`
+	ActiveOverlaps []prepOverlap  `json:"active_overlaps,omitempty"`
+	Worktree       string         `json:"worktree,omitempty"`
+	PRBranch       string         `json:"pr_branch,omitempty"`
+	Commands       []string       `json:"commands,omitempty"`
+}
+
+type prepItem struct {
+	ticketRef
+	Branch        string   `json:"branch"`
+	Commits       int      `json:"commits"`
+	DiffStat      string   `json:"diff_stat"`
+	Files         []string `json:"files,omitempty"`
+	CommitMessage string   `json:"commit_message"`
+}
+
+type prepConflict struct {
+	File    string   `json:"file"`
+	Tickets []string `json:"tickets"`
+}
+
+// prepOverlap is a file changed by a batch ticket that an active ticket has
+// also edited.
+type prepOverlap struct {
+	File         string `json:"file"`
+	Ticket       string `json:"ticket"`
+	ActiveTicket string `json:"active_ticket"`
+	ActiveStatus string `json:"active_status"`
+}
+
 // runPrepSingle handles `st prep <ticket-id>` — single-ticket analysis.
 func runPrepSingle(store *ticket.Store, repoRoot, baseBranch, ticketID string) error {
 	tk, err := store.Get(ticketID)
@@ -111,7 +153,11 @@ func runPrepSingle(store *ticket.Store, repoRoot, baseBranch, ticketID string) e
 	if err != nil {
 		return fmt.Errorf("count commits: %w", err)
 	}
+	out := prepOutput{Mode: "single", BaseBranch: baseBranch, Tickets: []prepItem{}}
 	if commits == 0 {
+		if structured() {
+			return writeOutput("prep", out)
+		}
 		fmt.Printf("Ticket %s (%s) has no commits beyond %s — nothing to merge.\n", tk.ID, tk.Title, baseBranch)
 		return nil
 	}
@@ -120,6 +166,37 @@ func runPrepSingle(store *ticket.Store, repoRoot, baseBranch, ticketID string) e
 	if err != nil {
 		return fmt.Errorf("diff stat: %w", err)
 	}
+	commitMsg := suggestCommitMessage(tk)
+	out.Tickets = append(out.Tickets, prepItem{
+		ticketRef:     refTo(tk),
+		Branch:        workBranch,
+		Commits:       commits,
+		DiffStat:      diffStat,
+		CommitMessage: commitMsg,
+	})
+
+	conflictFiles, err := detectConflictRisk(repoRoot, baseBranch, workBranch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: could not check conflict risk: %v\n", err)
+	}
+	out.BaseConflicts = conflictFiles
+
+	// Create PR worktree
+	prWorktreeID := "pr-" + tk.ID
+	out.PRBranch = "pr/" + tk.ID
+	out.Worktree, err = createPRWorktree(repoRoot, prWorktreeID, out.PRBranch, baseBranch)
+	if err != nil {
+		return err
+	}
+	out.Commands = []string{
+		fmt.Sprintf("cd %q", out.Worktree),
+		fmt.Sprintf("git merge --squash %s && git commit -m %q", workBranch, commitMsg),
+		fmt.Sprintf("git push -u origin %s", out.PRBranch),
+		fmt.Sprintf("gh pr create --base %s", baseBranch),
+	}
+	if structured() {
+		return writeOutput("prep", out)
+	}
 
 	fmt.Println("=== Single Ticket ===")
 	fmt.Printf("Ticket:      %s — %s\n", tk.ID, tk.Title)
@@ -128,10 +205,7 @@ func runPrepSingle(store *ticket.Store, repoRoot, baseBranch, ticketID string) e
 	fmt.Println()
 	fmt.Println(diffStat)
 
-	conflictFiles, err := detectConflictRisk(repoRoot, baseBranch, workBranch)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not check conflict risk: %v\n", err)
-	} else if len(conflictFiles) > 0 {
+	if len(conflictFiles) > 0 {
 		fmt.Println()
 		fmt.Println("--- Conflict Risk ---")
 		fmt.Println("These files were modified on both the base and work branch:")
@@ -140,28 +214,19 @@ func runPrepSingle(store *ticket.Store, repoRoot, baseBranch, ticketID string) e
 		}
 	}
 
-	// Create PR worktree
-	prWorktreeID := "pr-" + tk.ID
-	prPath, err := createPRWorktree(repoRoot, prWorktreeID, "pr/"+tk.ID, baseBranch)
-	if err != nil {
-		return err
-	}
-
-	commitMsg := suggestCommitMessage(tk)
-
 	fmt.Println()
 	fmt.Println("=== PR Worktree Created ===")
-	fmt.Printf("Path: %s\n", prPath)
+	fmt.Printf("Path: %s\n", out.Worktree)
 	fmt.Println()
 	fmt.Println("⚠️  " + guidance.PRCommitRules())
 	fmt.Println()
 	fmt.Println("Enter the worktree and run:")
-	fmt.Printf("  cd %q\n", prPath)
-	fmt.Printf("  git merge --squash %s && git commit -m %q\n", workBranch, commitMsg)
+	fmt.Printf("  %s\n", out.Commands[0])
+	fmt.Printf("  %s\n", out.Commands[1])
 	fmt.Println()
 	fmt.Println("Then push and create PR:")
-	fmt.Printf("  git push -u origin pr/%s\n", tk.ID)
-	fmt.Printf("  gh pr create --base %s\n", baseBranch)
+	fmt.Printf("  %s\n", out.Commands[2])
+	fmt.Printf("  %s\n", out.Commands[3])
 
 	return nil
 }
@@ -175,35 +240,25 @@ func runPrepBatch(cfg *config.Config, store *ticket.Store, repoRoot, baseBranch,
 	if err != nil {
 		return fmt.Errorf("find mergeable tickets: %w", err)
 	}
+	out := prepOutput{Mode: "batch", BaseBranch: baseBranch, Tickets: []prepItem{}}
 	if len(mergeable) == 0 {
+		if structured() {
+			return writeOutput("prep", out)
+		}
 		fmt.Println("No mergeable tickets found.")
 		fmt.Println("Mergeable = status REVIEW, HUMAN-REVIEW, or DONE with an st/<id> branch.")
 		return nil
 	}
 
-	// Sort by dependency order
-	tickets := sortByDependencyOrder(mergeable)
-
-	// Filter to tickets with actual commits and collect per-ticket info
-	type ticketInfo struct {
-		tk         *ticket.Ticket
-		workBranch string
-		commits    int
-		diffStat   string
-		files      []string // files changed by this ticket
-	}
-
-	var included []ticketInfo
-	var skipped []string
-
-	for _, tk := range tickets {
+	// Sort by dependency order, keeping tickets with actual commits.
+	for _, tk := range sortByDependencyOrder(mergeable) {
 		workBranch := spawn.BranchName(tk.ID)
 		commits, err := gitCommitCount(repoRoot, baseBranch, workBranch)
 		if err != nil {
 			return fmt.Errorf("count commits for %s: %w", tk.ID, err)
 		}
 		if commits == 0 {
-			skipped = append(skipped, fmt.Sprintf("%s (%s)", tk.ID, tk.Title))
+			out.Skipped = append(out.Skipped, refTo(tk))
 			continue
 		}
 
@@ -217,88 +272,181 @@ func runPrepBatch(cfg *config.Config, store *ticket.Store, repoRoot, baseBranch,
 			return fmt.Errorf("changed files for %s: %w", tk.ID, err)
 		}
 
-		included = append(included, ticketInfo{
-			tk:         tk,
-			workBranch: workBranch,
-			commits:    commits,
-			diffStat:   diffStat,
-			files:      files,
+		out.Tickets = append(out.Tickets, prepItem{
+			ticketRef:     refTo(tk),
+			Branch:        workBranch,
+			Commits:       commits,
+			DiffStat:      diffStat,
+			Files:         files,
+			CommitMessage: suggestCommitMessage(tk),
 		})
 	}
 
-	if len(included) == 0 {
+	if len(out.Tickets) == 0 {
+		if structured() {
+			return writeOutput("prep", out)
+		}
 		fmt.Println("No tickets have commits beyond the base branch — nothing to merge.")
 		return nil
 	}
 
+	// Cross-ticket conflict detection
+	fileOwners := make(map[string][]string) // file -> list of ticket IDs
+	for _, info := range out.Tickets {
+		for _, f := range info.Files {
+			fileOwners[f] = append(fileOwners[f], info.ID)
+		}
+	}
+	for f, owners := range fileOwners {
+		if len(owners) > 1 {
+			out.Conflicts = append(out.Conflicts, prepConflict{File: f, Tickets: owners})
+		}
+	}
+	sort.Slice(out.Conflicts, func(i, j int) bool { return out.Conflicts[i].File < out.Conflicts[j].File })
+
+	// Overlap with tickets still being worked on, from their edited files.
+	changed := make(map[string][]string, len(out.Tickets))
+	for _, info := range out.Tickets {
+		changed[info.ID] = info.Files
+	}
+	out.ActiveOverlaps = activeOverlaps(cfg, store, proj, repoRoot, changed)
+
+	// Create PR worktree
+	ts := time.Now().UTC().Format("20060102-150405")
+	prWorktreeID := "pr-" + ts
+	out.PRBranch = "pr/batch-" + ts
+	out.Worktree, err = createPRWorktree(repoRoot, prWorktreeID, out.PRBranch, baseBranch)
+	if err != nil {
+		return err
+	}
+	out.Commands = []string{fmt.Sprintf("cd %q", out.Worktree)}
+	for _, info := range out.Tickets {
+		firstLine := strings.SplitN(info.CommitMessage, "\n", 2)[0]
+		out.Commands = append(out.Commands, fmt.Sprintf("git merge --squash %s && git commit -m %q", info.Branch, firstLine))
+	}
+	out.Commands = append(out.Commands,
+		fmt.Sprintf("git push -u origin %s", out.PRBranch),
+		fmt.Sprintf("gh pr create --base %s", baseBranch))
+	if structured() {
+		return writeOutput("prep", out)
+	}
+
 	// Header
-	fmt.Printf("=== PR Preparation — %d ticket(s) ===\n", len(included))
+	fmt.Printf("=== PR Preparation — %d ticket(s) ===\n", len(out.Tickets))
 	fmt.Printf("Base branch: %s\n", baseBranch)
-	if len(skipped) > 0 {
+	if len(out.Skipped) > 0 {
+		skipped := make([]string, len(out.Skipped))
+		for i, ref := range out.Skipped {
+			skipped[i] = fmt.Sprintf("%s (%s)", ref.ID, ref.Title)
+		}
 		fmt.Printf("Skipped (%d, no changes): %s\n", len(skipped), strings.Join(skipped, ", "))
 	}
 	fmt.Println()
 
 	// Per-ticket analysis
-	for _, info := range included {
-		fmt.Printf("--- %s: %s (%d commit(s)) ---\n", info.tk.ID, info.tk.Title, info.commits)
-		fmt.Println(info.diffStat)
+	for _, info := range out.Tickets {
+		fmt.Printf("--- %s: %s (%d commit(s)) ---\n", info.ID, info.Title, info.Commits)
+		fmt.Println(info.DiffStat)
 		fmt.Println()
 	}
 
-	// Cross-ticket conflict detection
-	fileOwners := make(map[string][]string) // file -> list of ticket IDs
-	for _, info := range included {
-		for _, f := range info.files {
-			fileOwners[f] = append(fileOwners[f], info.tk.ID)
-		}
-	}
-	var conflictFiles []string
-	for f, owners := range fileOwners {
-		if len(owners) > 1 {
-			conflictFiles = append(conflictFiles, fmt.Sprintf("  %s — %s", f, strings.Join(owners, ", ")))
-		}
-	}
-	if len(conflictFiles) > 0 {
-		sort.Strings(conflictFiles)
+	if len(out.Conflicts) > 0 {
 		fmt.Println("--- Cross-Ticket Conflict Risk ---")
 		fmt.Println("These files are modified by multiple tickets:")
-		for _, line := range conflictFiles {
-			fmt.Println(line)
+		for _, c := range out.Conflicts {
+			fmt.Printf("  %s — %s\n", c.File, strings.Join(c.Tickets, ", "))
 		}
 		fmt.Println()
 	}
 
-	// Create PR worktree
-	ts := time.Now().UTC().Format("20060102-150405")
-	prWorktreeID := "pr-" + ts
-	prBranch := "pr/batch-" + ts
-	prPath, err := createPRWorktree(repoRoot, prWorktreeID, prBranch, baseBranch)
-	if err != nil {
-		return err
+	if len(out.ActiveOverlaps) > 0 {
+		fmt.Println("--- Overlap With Active Tickets ---")
+		fmt.Println("These files are also being edited by IN-PROGRESS/REWORK tickets (expect conflicts when they merge):")
+		for _, o := range out.ActiveOverlaps {
+			fmt.Printf("  %s — %s, active %s (%s)\n", o.File, o.Ticket, o.ActiveTicket, o.ActiveStatus)
+		}
+		fmt.Println()
 	}
 
 	fmt.Println("=== PR Worktree Created ===")
-	fmt.Printf("Path: %s\n", prPath)
+	fmt.Printf("Path: %s\n", out.Worktree)
 	fmt.Println()
 	fmt.Println("⚠️  " + guidance.PRCommitRules())
 	fmt.Println()
 	fmt.Println("Enter the worktree and squash-merge each ticket:")
-	fmt.Printf("  cd %q\n", prPath)
+	fmt.Printf("  %s\n", out.Commands[0])
 	fmt.Println()
-	for _, info := range included {
-		commitMsg := suggestCommitMessage(info.tk)
-		firstLine := strings.SplitN(commitMsg, "\n", 2)[0]
-		fmt.Printf("  git merge --squash %s && git commit -m %q\n", info.workBranch, firstLine)
+	merges := out.Commands[1 : len(out.Commands)-2]
+	for _, c := range merges {
+		fmt.Printf("  %s\n", c)
 	}
 	fmt.Println()
 	fmt.Println("Then push and create PR:")
-	fmt.Printf("  git push -u origin %s\n", prBranch)
-	fmt.Printf("  gh pr create --base %s\n", baseBranch)
+	for _, c := range out.Commands[len(out.Commands)-2:] {
+		fmt.Printf("  %s\n", c)
+	}
 
 	return nil
 }
 
+// activeOverlaps lists the files changed by the batch tickets that active
+// (IN-PROGRESS or REWORK) tickets have edited, per the hook.pre-tool log.
+func activeOverlaps(cfg *config.Config, store *ticket.Store, proj, repoRoot string, changed map[string][]string) []prepOverlap {
+	all, err := store.ListMeta(ticket.ListFilter{Project: proj})
+	if err != nil {
+		return nil
+	}
+	var active []*ticket.Ticket
+	var since time.Time
+	for _, tk := range all {
+		if tk.Status != ticket.StatusInProgress && tk.Status != ticket.StatusRework {
+			continue
+		}
+		active = append(active, tk)
+		if since.IsZero() || tk.Created.Before(since) {
+			since = tk.Created
+		}
+	}
+	if len(active) == 0 {
+		return nil
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return nil
+	}
+	sets, err := touched.Load(eventsDir, proj, repoRoot, since)
+	if err != nil {
+		return nil
+	}
+
+	var overlaps []prepOverlap
+	for batchID, files := range changed {
+		for _, tk := range active {
+			edited := make(map[string]bool, len(sets[tk.ID]))
+			for _, f := range sets[tk.ID] {
+				edited[f] = true
+			}
+			for _, f := range files {
+				if edited[f] {
+					overlaps = append(overlaps, prepOverlap{File: f, Ticket: batchID, ActiveTicket: tk.ID, ActiveStatus: string(tk.Status)})
+				}
+			}
+		}
+	}
+	sort.Slice(overlaps, func(i, j int) bool {
+		a, b := overlaps[i], overlaps[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Ticket != b.Ticket {
+			return a.Ticket < b.Ticket
+		}
+		return a.ActiveTicket < b.ActiveTicket
+	})
+	return overlaps
+}
+
 // mergeableTickets returns tickets that can be batch-merged:
 // status is REVIEW, HUMAN-REVIEW, or DONE, and an st/<id> branch exists.
 func mergeableTickets(store *ticket.Store, project, repoRoot string) ([]*ticket.Ticket, error) {
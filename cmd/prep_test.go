@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
@@ -304,6 +305,37 @@ func TestPrepBatch_CrossTicketConflict(t *testing.T) {
 	}
 }
 
+func TestPrepBatch_OverlapWithActiveTickets(t *testing.T) {
+	env := newTestEnv(t)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("get wd: %v", err)
+	}
+	runGitCmd(t, wd, "branch", "-M", "master")
+
+	ready := createTicketWithBranch(t, env, wd, "ready to merge", ticket.StatusReview, "shared.go", "package one\n")
+	active := env.createTicket(t, "still in progress", ticket.StatusInProgress)
+	_ = env.EventLog.Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.HookPreTool,
+		Ticket:  active.ID,
+		Project: "testproject",
+		Data:    map[string]any{"tool": "Edit", "file_path": filepath.Join(wd, ".worktrees", active.ID, "shared.go")},
+	})
+
+	out, err := env.runCmd(t, "--human", "prep")
+	if err != nil {
+		t.Fatalf("unexpected error: %v\noutput: %s", err, out)
+	}
+	if !strings.Contains(out, "--- Overlap With Active Tickets ---") {
+		t.Errorf("output missing active overlap section: %s", out)
+	}
+	if !strings.Contains(out, "shared.go — "+ready.ID+", active "+active.ID+" (IN-PROGRESS)") {
+		t.Errorf("output missing overlap line: %s", out)
+	}
+}
+
 func TestPrepBatch_SingleTicketStillWorks(t *testing.T) {
 	env := newTestEnv(t)
 
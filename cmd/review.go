@@ -28,7 +28,7 @@ var (
 
 func init() {
 	reviewCmd.Flags().StringVar(&reviewTicket, "ticket", "", "ticket ID to review")
-	reviewCmd.Flags().StringVar(&reviewCLI, "cli", "", "CLI backend override (claude, opencode, pi) for launcher mode")
+	reviewCmd.Flags().StringVar(&reviewCLI, "cli", "", "CLI backend override (claude, opencode, pi, codex, gemini) for launcher mode")
 	rootCmd.AddCommand(reviewCmd)
 }
 
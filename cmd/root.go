@@ -23,6 +23,10 @@ var rootCmd = &cobra.Command{
 	SilenceErrors: true,
 	SilenceUsage:  true,
 	PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+		if err := validateFormat(); err != nil {
+			return err
+		}
+
 		identity.SetRunID(runIDFlag)
 		identity.SetHuman(humanFlag)
 
@@ -35,7 +39,7 @@ var rootCmd = &cobra.Command{
 		}
 
 		if !humanFlag && runIDFlag == "" {
-			return fmt.Errorf("run ID required for agent commands — pass --run-id")
+			return errIdentityRequired
 		}
 
 		return nil
@@ -47,7 +51,12 @@ func isIdentityExempt(cmd *cobra.Command) bool {
 		return true
 	}
 
-	if cmd.Name() == "hook" || cmd.Name() == "help" || cmd.Name() == "assign" || cmd.Name() == "init" || cmd.Name() == "show" || cmd.Name() == "web" || cmd.Name() == "leader" || cmd.Name() == "work" || cmd.Name() == "review" || cmd.Name() == "prep" || cmd.Name() == "install" || cmd.Name() == "uninstall" {
+	if cmd.Name() == "hook" || cmd.Name() == "help" || cmd.Name() == "assign" || cmd.Name() == "init" || cmd.Name() == "show" || cmd.Name() == "web" || cmd.Name() == "leader" || cmd.Name() == "work" || cmd.Name() == "review" || cmd.Name() == "prep" || cmd.Name() == "install" || cmd.Name() == "uninstall" || cmd.Name() == "stats" {
+		return true
+	}
+
+	// Rule tooling is read-only and human-facing.
+	if cmd.Parent().Name() == "rules" {
 		return true
 	}
 
@@ -58,12 +67,17 @@ func init() {
 	rootCmd.PersistentFlags().StringVar(&runIDFlag, "run-id", "", "run ID for this agent session")
 	rootCmd.PersistentFlags().BoolVar(&humanFlag, "human", false, "mark command as human/manual activity")
 	_ = rootCmd.PersistentFlags().MarkHidden("human")
+	rootCmd.PersistentFlags().StringVar(&formatFlag, "format", formatTable, "output format: table, json or yaml")
+	rootCmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &cliError{Code: codeInvalidArgument, Message: err.Error(), Hint: "Run with --help for usage."}
+	})
 }
 
-// Execute runs the root command and exits on error.
+// Execute runs the root command and exits on error. Under --format
+// json|yaml the error is written to stderr as an error object.
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
+		writeError(os.Stderr, err)
 		os.Exit(1)
 	}
 }
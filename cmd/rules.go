@@ -0,0 +1,449 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/rules"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Inspect and debug auto-approve rulesets",
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Evaluate a single tool request and print the full rule trace",
+	Args:  cobra.NoArgs,
+	RunE:  runRulesTest,
+}
+
+var rulesReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Re-evaluate historical tool requests and report decisions that would change",
+	Args:  cobra.NoArgs,
+	RunE:  runRulesReplay,
+}
+
+var rulesSuggestCmd = &cobra.Command{
+	Use:   "suggest",
+	Short: "Suggest allow rules for commands you keep approving by hand",
+	Long:  `Clusters Bash commands from permission requests and unmatched rule decisions into generalized patterns, ranks them by frequency and risk, and prints the YAML to add to a rules file (e.g. user-allowlist.md).`,
+	Args:  cobra.NoArgs,
+	RunE:  runRulesSuggest,
+}
+
+var rulesLintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Validate rule files and detect broad, broken or shadowed rules",
+	Long:  `Loads every rule file in the rules directory and reports schema errors, invalid or catastrophic regexes, overly broad allow rules, and rules shadowed by earlier rules. Project overlays (projects/<name>/rules) are linted layered over the global rules: the detected or --project project, or every project with an overlay when none is given. Exits non-zero when errors are found (or warnings, with --strict).`,
+	Args:  cobra.NoArgs,
+	RunE:  runRulesLint,
+}
+
+var (
+	rulesLintStrict   bool
+	rulesLintProject  string
+	rulesTestTool     string
+	rulesTestCommand  string
+	rulesTestFilePath string
+	rulesTestURL      string
+	rulesTestEvent    string
+	rulesTestProject  string
+	rulesReplaySince  string
+	rulesReplayProj   string
+	rulesSuggestSince string
+	rulesSuggestProj  string
+	rulesSuggestMin   int
+	rulesSuggestLimit int
+)
+
+func init() {
+	rulesTestCmd.Flags().StringVar(&rulesTestTool, "tool", "Bash", "tool name (Bash, Read, Edit, WebFetch, ...)")
+	rulesTestCmd.Flags().StringVar(&rulesTestCommand, "command", "", "Bash command to evaluate")
+	rulesTestCmd.Flags().StringVar(&rulesTestFilePath, "file-path", "", "file path to evaluate")
+	rulesTestCmd.Flags().StringVar(&rulesTestURL, "url", "", "URL to evaluate")
+	rulesTestCmd.Flags().StringVar(&rulesTestEvent, "event", "PreToolUse", "hook event name")
+	rulesTestCmd.Flags().StringVar(&rulesTestProject, "project", "", "evaluate with this project's rule overlay (default: detect from cwd)")
+
+	rulesReplayCmd.Flags().StringVar(&rulesReplaySince, "since", "7d", "how far back to replay (e.g. 7d, 12h)")
+	rulesReplayCmd.Flags().StringVar(&rulesReplayProj, "project", "", "only replay events for this project")
+
+	rulesSuggestCmd.Flags().StringVar(&rulesSuggestSince, "since", "30d", "how far back to mine approvals (e.g. 30d, 12h)")
+	rulesSuggestCmd.Flags().StringVar(&rulesSuggestProj, "project", "", "only mine events for this project")
+	rulesSuggestCmd.Flags().IntVar(&rulesSuggestMin, "min", 2, "minimum approvals a suggestion must cover")
+	rulesSuggestCmd.Flags().IntVar(&rulesSuggestLimit, "limit", 10, "maximum suggestions to print (0 for all)")
+
+	rulesLintCmd.Flags().BoolVar(&rulesLintStrict, "strict", false, "treat warnings as errors")
+	rulesLintCmd.Flags().StringVar(&rulesLintProject, "project", "", "lint this project's rule overlay (default: detect from cwd, else every overlay)")
+
+	rulesCmd.AddCommand(rulesLintCmd)
+	rulesCmd.AddCommand(rulesTestCmd)
+	rulesCmd.AddCommand(rulesReplayCmd)
+	rulesCmd.AddCommand(rulesSuggestCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
+
+func runRulesLint(_ *cobra.Command, _ []string) error {
+	if err := tableOnly("st rules lint"); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	rulesDir, err := cfg.RulesDir()
+	if err != nil {
+		return fmt.Errorf("get rules dir: %w", err)
+	}
+
+	overlays, err := lintOverlays(cfg)
+	if err != nil {
+		return err
+	}
+	report, err := rules.LintLayers(rulesDir, overlays)
+	if err != nil {
+		return err
+	}
+
+	var errs, warns int
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, f := range report.Findings {
+		if f.Severity == rules.SeverityError {
+			errs++
+		} else {
+			warns++
+		}
+		where := f.File
+		if f.Rule != "" {
+			where += ":" + f.Rule
+		}
+		if f.Project != "" {
+			where += " (project " + f.Project + ")"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", f.Severity, where, f.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Linted %d rules in %d files: %d errors, %d warnings\n", report.Rules, report.Files, errs, warns)
+
+	if errs > 0 || (rulesLintStrict && warns > 0) {
+		return fmt.Errorf("rules lint failed")
+	}
+	return nil
+}
+
+func runRulesTest(_ *cobra.Command, _ []string) error {
+	if err := tableOnly("st rules test"); err != nil {
+		return err
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	rulesDir, err := cfg.RulesDir()
+	if err != nil {
+		return fmt.Errorf("get rules dir: %w", err)
+	}
+
+	toolInput := map[string]any{}
+	if rulesTestCommand != "" {
+		toolInput["command"] = rulesTestCommand
+	}
+	if rulesTestFilePath != "" {
+		toolInput["file_path"] = rulesTestFilePath
+	}
+	if rulesTestURL != "" {
+		toolInput["url"] = rulesTestURL
+	}
+
+	proj := rulesTestProject
+	if proj == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			proj = findProjectFromCwd(cfg, cwd)
+		}
+	}
+	projectRulesDir, err := projectRulesDir(cfg, proj)
+	if err != nil {
+		return err
+	}
+
+	tr, err := rules.EvaluateTrace(rulesDir, projectRulesDir, proj, rulesTestEvent, rulesTestTool, toolInput)
+	if err != nil {
+		return err
+	}
+	if proj != "" {
+		fmt.Printf("Project: %s\n", proj)
+	}
+
+	printRulesTrace(tr)
+	return nil
+}
+
+func printRulesTrace(tr *rules.Trace) {
+	fmt.Printf("Request: %s (%s)\n", tr.Tool, tr.Event)
+	if tr.Command != "" {
+		fmt.Printf("  command:   %s\n", tr.Command)
+	}
+	if tr.FilePath != "" {
+		fmt.Printf("  file_path: %s\n", tr.FilePath)
+	}
+	if tr.URL != "" {
+		fmt.Printf("  url:       %s\n", tr.URL)
+	}
+
+	if len(tr.SubCommands) > 1 {
+		fmt.Println("\nSub-commands:")
+		for i, part := range tr.SubCommands {
+			fmt.Printf("  %d. %s\n", i+1, strings.TrimSpace(part))
+		}
+	}
+
+	fmt.Println("\nTrace:")
+	if len(tr.Steps) == 0 {
+		fmt.Println("  (no rules loaded)")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, s := range tr.Steps {
+			rule := s.Rule
+			if rule == "" {
+				rule = "*"
+			}
+			line := fmt.Sprintf("  P%d\t%s\t%s/%s\t%s\t%s", s.Priority, s.Origin, s.Ruleset, rule, s.Action, s.Outcome)
+			if len(tr.SubCommands) > 1 {
+				line += "\t" + truncate(s.Command, 40)
+			}
+			if s.Detail != "" {
+				line += "\t" + s.Detail
+			}
+			_, _ = fmt.Fprintln(w, line)
+		}
+		_ = w.Flush()
+	}
+
+	if tr.Pipeline != nil {
+		verdict := "passed"
+		if tr.Pipeline.Denied {
+			verdict = "denied: " + tr.Pipeline.Reason
+		}
+		fmt.Printf("\nBash pipeline: %s\n", verdict)
+	}
+
+	fmt.Printf("\nDecision: %s", strings.ToUpper(string(tr.Result.Decision)))
+	if tr.Result.Ruleset != "" {
+		fmt.Printf(" (%s/%s)", tr.Result.Ruleset, tr.Result.Rule)
+	}
+	if tr.Result.Reason != "" {
+		fmt.Printf(" — %s", tr.Result.Reason)
+	}
+	fmt.Println()
+}
+
+func runRulesReplay(_ *cobra.Command, _ []string) error {
+	if err := tableOnly("st rules replay"); err != nil {
+		return err
+	}
+	since, err := parseSince(rulesReplaySince)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	rulesDir, err := cfg.RulesDir()
+	if err != nil {
+		return fmt.Errorf("get rules dir: %w", err)
+	}
+	layers, err := projectLayers(cfg, rulesDir)
+	if err != nil {
+		return err
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	after := time.Now().UTC().Add(-since)
+	events, err := event.QueryEvents(eventsDir, event.Query{After: after, Project: rulesReplayProj})
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
+	}
+
+	report := rules.Replay(layers, events)
+
+	changed := report.Evaluated - report.Unchanged
+	fmt.Printf("Replayed %d requests since %s: %d unchanged, %d would change\n",
+		report.Evaluated, after.Local().Format("2006-01-02 15:04"), report.Unchanged, changed)
+	if len(report.Changes) == 0 {
+		return nil
+	}
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "OLD\tNEW\tCOUNT\tTOOL\tSUBJECT\tRULE")
+	for _, c := range report.Changes {
+		rule := "-"
+		if c.Rule != "" {
+			rule = c.Ruleset + "/" + c.Rule
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\n",
+			c.Old, c.New, c.Count, c.Tool, truncate(c.Subject, 60), rule)
+	}
+	return w.Flush()
+}
+
+func runRulesSuggest(_ *cobra.Command, _ []string) error {
+	if err := tableOnly("st rules suggest"); err != nil {
+		return err
+	}
+	since, err := parseSince(rulesSuggestSince)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	rulesDir, err := cfg.RulesDir()
+	if err != nil {
+		return fmt.Errorf("get rules dir: %w", err)
+	}
+	layers, err := projectLayers(cfg, rulesDir)
+	if err != nil {
+		return err
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	after := time.Now().UTC().Add(-since)
+	events, err := event.QueryEvents(eventsDir, event.Query{After: after, Project: rulesSuggestProj})
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
+	}
+
+	suggestions := rules.Suggest(layers, events, rules.SuggestOptions{MinCount: rulesSuggestMin})
+	if len(suggestions) == 0 {
+		fmt.Printf("No suggestions — no command was approved by hand at least %d times since %s.\n",
+			rulesSuggestMin, after.Local().Format("2006-01-02 15:04"))
+		return nil
+	}
+	if rulesSuggestLimit > 0 && len(suggestions) > rulesSuggestLimit {
+		suggestions = suggestions[:rulesSuggestLimit]
+	}
+
+	for i, sg := range suggestions {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("%d. %s  (%d approvals, %s risk, last %s)\n",
+			i+1, sg.Pattern, sg.Count, sg.Risk, sg.LastSeen.Local().Format("2006-01-02 15:04"))
+		for _, ex := range sg.Examples {
+			fmt.Printf("     $ %s\n", truncate(ex, 80))
+		}
+		fmt.Println()
+		fmt.Print(sg.YAML())
+	}
+
+	fmt.Printf("\nAdd a rule under `rules:` in a file in %s, then run `st rules lint`.\n", rulesDir)
+	return nil
+}
+
+// projectLayers returns a resolver for the rulesets in effect for each
+// project (global rules plus the project's overlay).
+func projectLayers(cfg *config.Config, rulesDir string) (rules.LayerResolver, error) {
+	layers, err := rules.ProjectLayers(rulesDir, func(proj string) string {
+		dir, _ := cfg.ProjectRulesDir(proj)
+		return dir
+	})
+	if err != nil {
+		return nil, fmt.Errorf("load rulesets: %w", err)
+	}
+	return layers, nil
+}
+
+// lintOverlays returns the rule overlay directories st rules lint checks:
+// the --project or detected project's, or else every project's that exists.
+func lintOverlays(cfg *config.Config) (map[string]string, error) {
+	proj := rulesLintProject
+	if proj == "" {
+		if cwd, err := os.Getwd(); err == nil {
+			proj = findProjectFromCwd(cfg, cwd)
+		}
+	}
+	if proj != "" {
+		dir, err := projectRulesDir(cfg, proj)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]string{proj: dir}, nil
+	}
+
+	vaultPath, err := cfg.VaultPath()
+	if err != nil {
+		return nil, fmt.Errorf("get vault path: %w", err)
+	}
+	names, err := project.ListProjects(vaultPath)
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+	overlays := make(map[string]string)
+	for _, name := range names {
+		dir, err := projectRulesDir(cfg, name)
+		if err != nil {
+			return nil, err
+		}
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			overlays[name] = dir
+		}
+	}
+	return overlays, nil
+}
+
+// projectRulesDir returns the rules overlay directory for proj, or "" when
+// no project is given.
+func projectRulesDir(cfg *config.Config, proj string) (string, error) {
+	if proj == "" {
+		return "", nil
+	}
+	dir, err := cfg.ProjectRulesDir(proj)
+	if err != nil {
+		return "", fmt.Errorf("get project rules dir: %w", err)
+	}
+	return dir, nil
+}
+
+// parseSince parses a lookback window such as "7d", "12h" or "90m".
+func parseSince(s string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid duration %q — use e.g. 7d, 12h", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid duration %q — use e.g. 7d, 12h", s)
+	}
+	return d, nil
+}
@@ -0,0 +1,280 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+// writeTestRules writes a ruleset file into the test vault's rules dir.
+func (e *testEnv) writeTestRules(t *testing.T, name, content string) {
+	t.Helper()
+	rulesDir, err := e.Config.RulesDir()
+	if err != nil {
+		t.Fatalf("rules dir: %v", err)
+	}
+	if err := os.MkdirAll(rulesDir, 0o755); err != nil {
+		t.Fatalf("create rules dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(rulesDir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("write rules: %v", err)
+	}
+}
+
+const testAllowGoRules = `name: go
+priority: 50
+event: PreToolUse
+rules:
+  - name: allow-go-test
+    match:
+      tool: Bash
+      command: ^go\s+test\b
+    action: allow
+    message: go test is safe
+`
+
+func TestRulesTest_PrintsTrace(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	out, err := env.runCmd(t, "rules", "test", "--command", "go test ./...")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"Request: Bash (PreToolUse)", "go/allow-go-test", "matched", "Decision: ALLOW (go/allow-go-test)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output = %q, want substring %q", out, want)
+		}
+	}
+}
+
+func TestRulesTest_NoMatchAsks(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	out, err := env.runCmd(t, "rules", "test", "--command", "make build")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "no-match") || !strings.Contains(out, "Decision: ASK") {
+		t.Errorf("output = %q, want no-match trace and ASK decision", out)
+	}
+}
+
+func TestRulesTest_ProjectOverlay(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	overlayDir, err := env.Config.ProjectRulesDir("testproject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(overlayDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	overlay := "name: local\npriority: 50\nevent: PreToolUse\ndisable:\n  - go/allow-go-test\nrules: []\n"
+	if err := os.WriteFile(filepath.Join(overlayDir, "local.yaml"), []byte(overlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The project is detected from the cwd, so its overlay applies.
+	out, err := env.runCmd(t, "rules", "test", "--command", "go test ./...")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Project: testproject") || !strings.Contains(out, "Decision: ASK") {
+		t.Errorf("output = %q, want overlay to disable allow-go-test", out)
+	}
+
+	resetFlags()
+	out, err = env.runCmd(t, "rules", "test", "--project", "other", "--command", "go test ./...")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Decision: ALLOW") {
+		t.Errorf("output = %q, want global rule for other project", out)
+	}
+}
+
+func TestRulesReplay_ReportsChanges(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	_ = env.EventLog.Append(event.Event{
+		TS:      time.Now().UTC().Add(-time.Hour),
+		Event:   event.HookPermissionReq,
+		Project: "testproject",
+		RunID:   "run-1",
+		Data:    map[string]any{"tool": "Bash", "command": "go test ./internal/..."},
+	})
+	_ = env.EventLog.Append(event.Event{
+		TS:      time.Now().UTC().Add(-30 * 24 * time.Hour),
+		Event:   event.HookPermissionReq,
+		Project: "testproject",
+		RunID:   "run-2",
+		Data:    map[string]any{"tool": "Bash", "command": "go test ./old/..."},
+	})
+
+	out, err := env.runCmd(t, "rules", "replay", "--since", "7d")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Replayed 1 requests") || !strings.Contains(out, "1 would change") {
+		t.Errorf("output = %q, want 1 replayed request that would change", out)
+	}
+	if !strings.Contains(out, "go test ./internal/...") || strings.Contains(out, "./old/") {
+		t.Errorf("output = %q, want only the recent request", out)
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"7d", 7 * 24 * time.Hour, false},
+		{"12h", 12 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"xd", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := parseSince(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseSince(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseSince(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestRulesLint_Clean(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	out, err := env.runCmd(t, "rules", "lint")
+	if err != nil {
+		t.Fatalf("unexpected error: %v (output %q)", err, out)
+	}
+	if !strings.Contains(out, "Linted 1 rules in 1 files: 0 errors, 0 warnings") {
+		t.Errorf("output = %q, want clean summary", out)
+	}
+}
+
+func TestRulesLint_ShadowedRuleFails(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+	env.writeTestRules(t, "deny.yaml", `name: deny
+priority: 10
+event: PreToolUse
+rules:
+  - name: deny-go-test-race
+    match:
+      tool: Bash
+      command: ^go\s+test\s+-race
+    action: deny
+`)
+
+	out, err := env.runCmd(t, "rules", "lint")
+	if err == nil {
+		t.Fatalf("expected lint failure, output %q", out)
+	}
+	if !strings.Contains(out, "deny.yaml:deny-go-test-race") || !strings.Contains(out, "unreachable") {
+		t.Errorf("output = %q, want unreachable finding for deny-go-test-race", out)
+	}
+}
+
+func TestRulesLint_StrictFailsOnWarnings(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "broad.yaml", "name: broad\nrules:\n  - name: all-bash\n    match:\n      tool: Bash\n    action: allow\n")
+
+	if _, err := env.runCmd(t, "rules", "lint"); err != nil {
+		t.Fatalf("warnings alone should not fail: %v", err)
+	}
+	if _, err := env.runCmd(t, "rules", "lint", "--strict"); err == nil {
+		t.Fatal("expected --strict to fail on warnings")
+	}
+}
+
+func TestRulesLint_BrokenOverlayFails(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	overlayDir, err := env.Config.ProjectRulesDir("testproject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(overlayDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	bad := "name: local\nrules:\n  - name: redos\n    match:\n      command: (a+)+$\n    action: deny\n"
+	if err := os.WriteFile(filepath.Join(overlayDir, "local.yaml"), []byte(bad), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "rules", "lint")
+	if err == nil {
+		t.Fatalf("expected lint failure, output %q", out)
+	}
+	if !strings.Contains(out, "local.yaml (project testproject)") || !strings.Contains(out, "fall back to the global rules") {
+		t.Errorf("output = %q, want the broken overlay reported", out)
+	}
+
+	resetFlags()
+	if out, err := env.runCmd(t, "rules", "lint", "--project", "other"); err != nil {
+		t.Errorf("other project has no overlay: %v (output %q)", err, out)
+	}
+}
+
+func TestRulesSuggest_PrintsYAML(t *testing.T) {
+	env := newTestEnv(t)
+	env.writeTestRules(t, "go.yaml", testAllowGoRules)
+
+	for i, cmd := range []string{"go run ./cmd/st list", "go run ./cmd/web", "go test ./...", "go test ./cmd"} {
+		_ = env.EventLog.Append(event.Event{
+			TS:      time.Now().UTC().Add(-time.Duration(10-i) * time.Minute),
+			Event:   event.HookPermissionReq,
+			Project: "testproject",
+			RunID:   "run-1",
+			Data:    map[string]any{"tool": "Bash", "command": cmd},
+		})
+	}
+
+	out, err := env.runCmd(t, "rules", "suggest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`1. ^go\s+run\s+\./cmd/\S+  (2 approvals, low risk`,
+		"$ go run ./cmd/web",
+		"  - name: allow-go-run-cmd",
+		`command: ^go\s+run\s+\./cmd/\S+`,
+		"action: allow",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output = %q, want substring %q", out, want)
+		}
+	}
+	if strings.Contains(out, "go test") {
+		t.Errorf("output = %q, should skip commands already allowed", out)
+	}
+}
+
+func TestRulesSuggest_NoSuggestions(t *testing.T) {
+	env := newTestEnv(t)
+
+	out, err := env.runCmd(t, "rules", "suggest")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "No suggestions") {
+		t.Errorf("output = %q, want no suggestions message", out)
+	}
+}
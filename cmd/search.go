@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/spf13/cobra"
+)
+
+var searchCmd = &cobra.Command{
+	Use:   "search <terms>",
+	Short: "Full-text search over ticket titles, bodies and notes",
+	Long: `Search every ticket's title, body and notes, ranked by relevance, with a
+snippet of the best-matching section. All terms must match; a quoted phrase
+must appear as written. Query keys from ` + "`st list`" + ` narrow the results:
+
+  st search token bucket
+  st search '"rate limit"' status:done project:api`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+var (
+	searchProject string
+	searchLimit   int
+)
+
+func init() {
+	searchCmd.Flags().StringVar(&searchProject, "project", "", "only search this project")
+	searchCmd.Flags().IntVar(&searchLimit, "limit", 10, "maximum number of results (0 for all)")
+	rootCmd.AddCommand(searchCmd)
+}
+
+func runSearch(_ *cobra.Command, args []string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+
+	expanded, err := ticket.ExpandSaved(queryFromArgs(args), cfg.Queries)
+	if err != nil {
+		return err
+	}
+	query, err := ticket.ParseQuery(expanded, time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("parse query: %w", err)
+	}
+	if len(query.Filter.Text) == 0 {
+		return fmt.Errorf("no search terms — use st list to filter without text")
+	}
+	if searchProject != "" {
+		query.Filter.Project = searchProject
+	}
+
+	results, err := ticket.NewStore(projectsDir).Search(query, searchLimit)
+	if err != nil {
+		return fmt.Errorf("search: %w", err)
+	}
+	if structured() {
+		out := searchOutput{Query: expanded, Results: make([]searchItem, 0, len(results))}
+		for _, r := range results {
+			out.Results = append(out.Results, searchItem{
+				Ticket:  summarize(r.Ticket),
+				Score:   r.Score,
+				Section: r.Section,
+				Snippet: r.Snippet,
+			})
+		}
+		return writeOutput("search", out)
+	}
+	if len(results) == 0 {
+		fmt.Println("No matches.")
+		return nil
+	}
+
+	for i, r := range results {
+		if i > 0 {
+			fmt.Println()
+		}
+		tk := r.Ticket
+		fmt.Printf("%s  %s  [%s]  %s\n", tk.ID, tk.Title, tk.Status, tk.Project)
+		if r.Snippet != "" && r.Section != "Title" {
+			fmt.Printf("  %s: %s\n", r.Section, r.Snippet)
+		}
+	}
+	return nil
+}
+
+// searchOutput is the st.search schema. Results are ranked best first.
+type searchOutput struct {
+	Query   string       `json:"query"`
+	Results []searchItem `json:"results"`
+}
+
+type searchItem struct {
+	Ticket  ticketSummary `json:"ticket"`
+	Score   float64       `json:"score"`
+	Section string        `json:"section,omitempty"`
+	Snippet string        `json:"snippet,omitempty"`
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestSearch_RankedSnippets(t *testing.T) {
+	env := newTestEnv(t)
+
+	noted := env.createTicket(t, "Rate limit the API", ticket.StatusDone)
+	ticket.AppendSection(noted, "Note", "agent", "run-1", "Went with a token bucket per API key.", nil, time.Now().UTC())
+	if err := env.Store.Save(noted); err != nil {
+		t.Fatal(err)
+	}
+	titled := env.createTicket(t, "Token bucket tuning", ticket.StatusOpen)
+	env.createTicket(t, "Unrelated", ticket.StatusOpen)
+
+	out, err := env.runCmd(t, "search", "token", "bucket")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Note: Went with a token bucket per API key.") {
+		t.Errorf("expected the note snippet:\n%s", out)
+	}
+	if strings.Index(out, titled.ID) > strings.Index(out, noted.ID) {
+		t.Errorf("title match should rank first:\n%s", out)
+	}
+	if strings.Contains(out, "Unrelated") {
+		t.Errorf("unexpected match:\n%s", out)
+	}
+
+	out, err = env.runCmd(t, "search", "token", "status:open")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out, noted.ID) || !strings.Contains(out, titled.ID) {
+		t.Errorf("status filter not applied:\n%s", out)
+	}
+
+	out, err = env.runCmd(t, "search", "flamingo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "No matches.") {
+		t.Errorf("output = %q", out)
+	}
+
+	if _, err := env.runCmd(t, "search", "status:open"); err == nil {
+		t.Error("expected an error without search terms")
+	}
+}
@@ -2,10 +2,16 @@ package cmd
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/usage"
 	"github.com/spf13/cobra"
 )
 
@@ -49,11 +55,229 @@ func runShow(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("get ticket: %w", err)
 	}
 
+	if structured() {
+		return writeOutput("show", buildShowOutput(cfg, store, tk))
+	}
+
 	data, err := ticket.Render(tk)
 	if err != nil {
 		return fmt.Errorf("render ticket: %w", err)
 	}
 
-	_, err = os.Stdout.Write(data)
-	return err
+	if _, err := os.Stdout.Write(data); err != nil {
+		return err
+	}
+
+	if all, err := store.ListMeta(ticket.ListFilter{}); err == nil {
+		printTicketHierarchy(os.Stdout, tk, all)
+		printTicketLinks(os.Stdout, tk, all)
+	}
+
+	// Test runs and token usage recorded by hooks (best-effort).
+	if eventsDir, err := cfg.EventsDir(); err == nil {
+		events, _ := event.QueryEvents(eventsDir, event.Query{TicketID: tk.ID})
+		if last, ok := testrun.LatestByTicket(events)[tk.ID]; ok {
+			printTicketTests(os.Stdout, last)
+		}
+		totals := usage.Summarize(events, usage.ByTicket)[tk.ID]
+		printTicketUsage(os.Stdout, totals, usage.NewPricing(cfg.Usage.Prices))
+	}
+	return nil
+}
+
+// showOutput is the st.show schema.
+type showOutput struct {
+	Ticket   ticketSummary `json:"ticket"`
+	Body     string        `json:"body"`
+	Sections []showSection `json:"sections"`
+	Parent   *ticketRef    `json:"parent,omitempty"`
+	Children []childNode   `json:"children"`
+	Links    []linkSummary `json:"links"`
+	Tests    *testsSummary `json:"tests,omitempty"`
+	Usage    *usageSummary `json:"usage,omitempty"`
+}
+
+type showSection struct {
+	Heading string            `json:"heading"`
+	TS      time.Time         `json:"ts"`
+	Actor   string            `json:"actor,omitempty"`
+	Session string            `json:"session,omitempty"`
+	Fields  map[string]string `json:"fields,omitempty"`
+	Content string            `json:"content"`
+}
+
+// ticketRef identifies a related ticket.
+type ticketRef struct {
+	ID     string `json:"id"`
+	Title  string `json:"title"`
+	Status string `json:"status"`
+}
+
+type childNode struct {
+	ticketRef
+	Children []childNode `json:"children,omitempty"`
+}
+
+// linkSummary is a typed relation from the shown ticket's side. Missing is
+// set when the other ticket no longer exists.
+type linkSummary struct {
+	Type    string `json:"type"`
+	Ticket  string `json:"ticket"`
+	Title   string `json:"title,omitempty"`
+	Status  string `json:"status,omitempty"`
+	Missing bool   `json:"missing,omitempty"`
+}
+
+func refTo(tk *ticket.Ticket) ticketRef {
+	return ticketRef{ID: tk.ID, Title: tk.Title, Status: string(tk.Status)}
+}
+
+// buildShowOutput collects what runShow prints: the ticket, its hierarchy,
+// links, last test run and token usage (the last three best-effort).
+func buildShowOutput(cfg *config.Config, store *ticket.Store, tk *ticket.Ticket) showOutput {
+	out := showOutput{
+		Ticket:   summarize(tk),
+		Body:     tk.Body,
+		Sections: []showSection{},
+		Children: []childNode{},
+		Links:    []linkSummary{},
+	}
+	for _, sec := range ticket.Sections(tk.Body) {
+		out.Sections = append(out.Sections, showSection{
+			Heading: sec.Heading,
+			TS:      sec.TS,
+			Actor:   sec.Actor,
+			Session: sec.Session,
+			Fields:  sec.Fields,
+			Content: sec.Content,
+		})
+	}
+
+	if all, err := store.ListMeta(ticket.ListFilter{}); err == nil {
+		byID := make(map[string]*ticket.Ticket, len(all))
+		for _, t := range all {
+			byID[t.ID] = t
+		}
+		if p, ok := byID[tk.Parent]; ok {
+			ref := refTo(p)
+			out.Parent = &ref
+		} else if tk.Parent != "" {
+			out.Parent = &ticketRef{ID: tk.Parent}
+		}
+
+		var convert func(nodes []*ticket.TreeNode) []childNode
+		convert = func(nodes []*ticket.TreeNode) []childNode {
+			children := make([]childNode, 0, len(nodes))
+			for _, n := range nodes {
+				children = append(children, childNode{ticketRef: refTo(n.Ticket), Children: convert(n.Children)})
+			}
+			return children
+		}
+		if node := findTreeNode(ticket.BuildTree(all), tk.ID); node != nil {
+			out.Children = convert(node.Children)
+		}
+
+		for _, l := range ticket.Links(all, tk) {
+			ls := linkSummary{Type: l.Label(), Ticket: l.Ticket}
+			if other, ok := byID[l.Ticket]; ok {
+				ls.Title, ls.Status = other.Title, string(other.Status)
+			} else {
+				ls.Missing = true
+			}
+			out.Links = append(out.Links, ls)
+		}
+	}
+
+	if eventsDir, err := cfg.EventsDir(); err == nil {
+		events, _ := event.QueryEvents(eventsDir, event.Query{TicketID: tk.ID})
+		if last, ok := testrun.LatestByTicket(events)[tk.ID]; ok {
+			out.Tests = summarizeTests(last)
+		}
+		if totals := usage.Summarize(events, usage.ByTicket)[tk.ID]; len(totals) > 0 {
+			u := summarizeUsage(totals, usage.NewPricing(cfg.Usage.Prices), true)
+			out.Usage = &u
+		}
+	}
+	return out
+}
+
+// printTicketHierarchy writes the ticket's parent and its tree of children
+// with their progress.
+func printTicketHierarchy(w io.Writer, tk *ticket.Ticket, all []*ticket.Ticket) {
+	children := ticket.Children(all, tk.ID)
+	if tk.Parent == "" && len(children) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprint(w, "\n## Hierarchy\n\n")
+	if tk.Parent != "" {
+		line := tk.Parent
+		for _, p := range all {
+			if p.ID == tk.Parent {
+				line = fmt.Sprintf("%s %s [%s]", p.ID, p.Title, p.Status)
+				break
+			}
+		}
+		_, _ = fmt.Fprintf(w, "Parent: %s\n", line)
+	}
+	if len(children) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Children: %s\n", ticket.ChildProgress(children))
+	var walk func(nodes []*ticket.TreeNode, depth int)
+	walk = func(nodes []*ticket.TreeNode, depth int) {
+		for _, n := range nodes {
+			_, _ = fmt.Fprintf(w, "%s- %s %s [%s]\n", strings.Repeat("  ", depth), n.Ticket.ID, n.Ticket.Title, n.Ticket.Status)
+			walk(n.Children, depth+1)
+		}
+	}
+	if node := findTreeNode(ticket.BuildTree(all), tk.ID); node != nil {
+		walk(node.Children, 0)
+	}
+}
+
+// findTreeNode returns the node for id anywhere in nodes, or nil.
+func findTreeNode(nodes []*ticket.TreeNode, id string) *ticket.TreeNode {
+	for _, n := range nodes {
+		if n.Ticket.ID == id {
+			return n
+		}
+		if found := findTreeNode(n.Children, id); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// printTicketLinks writes the ticket's typed relations in both directions.
+func printTicketLinks(w io.Writer, tk *ticket.Ticket, all []*ticket.Ticket) {
+	links := ticket.Links(all, tk)
+	if len(links) == 0 {
+		return
+	}
+	byID := make(map[string]*ticket.Ticket, len(all))
+	for _, t := range all {
+		byID[t.ID] = t
+	}
+
+	_, _ = fmt.Fprint(w, "\n## Links\n\n")
+	for _, l := range links {
+		if other, ok := byID[l.Ticket]; ok {
+			_, _ = fmt.Fprintf(w, "- %s %s %s [%s]\n", l.Label(), other.ID, other.Title, other.Status)
+		} else {
+			_, _ = fmt.Fprintf(w, "- %s %s (missing)\n", l.Label(), l.Ticket)
+		}
+	}
+}
+
+// printTicketTests writes the ticket's last test run and its failing tests.
+func printTicketTests(w io.Writer, r testrun.Result) {
+	_, _ = fmt.Fprintf(w, "\n## Tests\n\nLast run: %s at %s\n", r.Summary(), r.TS.Local().Format("2006-01-02 15:04"))
+	if r.Command != "" {
+		_, _ = fmt.Fprintf(w, "Command: %s\n", r.Command)
+	}
+	for _, name := range r.Failures {
+		_, _ = fmt.Fprintf(w, "- FAIL %s\n", name)
+	}
 }
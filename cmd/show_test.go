@@ -1,9 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
@@ -38,3 +41,104 @@ func TestShow_TicketNotFound(t *testing.T) {
 		t.Fatal("expected error for missing ticket")
 	}
 }
+
+func TestShow_LastTestRun(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "tested ticket", ticket.StatusInProgress)
+	env.addTestRunEvent(t, tk.ID, time.Now().UTC(), testrun.Result{
+		Runner: testrun.RunnerGo, Command: "go test ./...", Status: testrun.StatusFail,
+		Passed: 9, Failed: 1, Failures: []string{"TestParse"},
+	})
+
+	out, err := env.runCmd(t, "show", tk.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{"## Tests", "FAIL (go test: 9 passed, 1 failed)", "Command: go test ./...", "- FAIL TestParse"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestShow_Hierarchy(t *testing.T) {
+	env := newTestEnv(t)
+
+	epic := env.createTicket(t, "epic", ticket.StatusInProgress)
+	child := env.createTicket(t, "child", ticket.StatusDone)
+	grandchild := env.createTicket(t, "grandchild", ticket.StatusOpen)
+	child.Parent = epic.ID
+	grandchild.Parent = child.ID
+	for _, tk := range []*ticket.Ticket{child, grandchild} {
+		if err := env.Store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := env.runCmd(t, "show", epic.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		"## Hierarchy",
+		"Children: 1/1 done",
+		"- " + child.ID + " child [DONE]",
+		"  - " + grandchild.ID + " grandchild [OPEN]",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	out, err = env.runCmd(t, "show", child.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "Parent: "+epic.ID+" epic [IN-PROGRESS]") {
+		t.Errorf("output missing parent line:\n%s", out)
+	}
+}
+
+func TestShow_FormatJSON(t *testing.T) {
+	env := newTestEnv(t)
+	parent := env.createTicket(t, "epic", ticket.StatusOpen)
+	tk := env.createTicket(t, "child work", ticket.StatusOpen)
+	tk.Parent = parent.ID
+	ticket.AppendSection(tk, "Note", "human", "", "looked into it", nil, time.Now().UTC())
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := env.runCmd(t, "--format", "json", "show", tk.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got struct {
+		Schema string `json:"schema"`
+		Data   struct {
+			Ticket   struct{ ID, Parent string } `json:"ticket"`
+			Sections []struct {
+				Heading string `json:"heading"`
+				Content string `json:"content"`
+			} `json:"sections"`
+			Parent *struct{ ID, Title, Status string } `json:"parent"`
+			Links  []any                               `json:"links"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	if got.Schema != "st.show/v1" || got.Data.Ticket.ID != tk.ID {
+		t.Errorf("output = %s", out)
+	}
+	if got.Data.Parent == nil || got.Data.Parent.ID != parent.ID || got.Data.Parent.Title != "epic" {
+		t.Errorf("parent = %+v", got.Data.Parent)
+	}
+	last := got.Data.Sections[len(got.Data.Sections)-1]
+	if last.Heading != "Note" || last.Content != "looked into it" {
+		t.Errorf("sections = %+v", got.Data.Sections)
+	}
+	if got.Data.Links == nil {
+		t.Errorf("links should be [], got %s", out)
+	}
+}
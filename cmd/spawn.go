@@ -35,7 +35,7 @@ var (
 
 func init() {
 	spawnCmd.Flags().DurationVar(&spawnTimeout, "timeout", 45*time.Minute, "worker timeout (e.g. 45m, 1h)")
-	spawnCmd.Flags().StringVar(&spawnBackend, "backend", "claude", "AI backend to use")
+	spawnCmd.Flags().StringVar(&spawnBackend, "backend", "claude", "AI backend to use (claude, codex, gemini)")
 	spawnCmd.Flags().BoolVar(&spawnDryRun, "dry-run", false, "print the prompt without launching")
 	spawnCmd.Flags().StringVar(&spawnBase, "base", "", "git ref to branch from (default: HEAD of main repo)")
 	rootCmd.AddCommand(spawnCmd)
@@ -73,6 +73,17 @@ func runSpawn(_ *cobra.Command, args []string) error {
 
 	if spawnDryRun {
 		prompt := spawn.BuildPrompt(tk, "<run-id>", repoRoot)
+		if structured() {
+			return writeOutput("spawn", spawnOutput{
+				Ticket:         refTo(tk),
+				DryRun:         true,
+				Prompt:         prompt,
+				Worktree:       spawn.WorktreePath(repoRoot, tk.ID),
+				Branch:         spawn.BranchName(tk.ID),
+				Backend:        spawnBackend,
+				TimeoutSeconds: int64(spawnTimeout / time.Second),
+			})
+		}
 		fmt.Println("--- Dry Run: Prompt ---")
 		fmt.Println(prompt)
 		fmt.Println("--- End Prompt ---")
@@ -99,6 +110,32 @@ func runSpawn(_ *cobra.Command, args []string) error {
 		return err
 	}
 
+	// Structured output describes the worker as soon as it starts; the exit
+	// status (and an error object on failure) reports how it finished.
+	if structured() {
+		if err := writeOutput("spawn", spawnOutput{
+			Ticket:         refTo(tk),
+			PID:            result.PID,
+			RunID:          result.RunID,
+			Worktree:       result.WorktreePath,
+			Branch:         result.Branch,
+			Log:            result.LogPath,
+			Backend:        spawnBackend,
+			TimeoutSeconds: int64(spawnTimeout / time.Second),
+			TmuxWindow:     result.TmuxWindow,
+		}); err != nil {
+			return err
+		}
+		if err := result.Wait(); err != nil {
+			return &cliError{
+				Code:    codeWorkerFailed,
+				Message: fmt.Sprintf("worker exited with error: %v", err),
+				Hint:    "Check log: " + result.LogPath,
+			}
+		}
+		return nil
+	}
+
 	fmt.Printf("Spawned worker for %s: %s\n", tk.ID, tk.Title)
 	fmt.Printf("  PID:      %d\n", result.PID)
 	fmt.Printf("  Worktree: %s\n", result.WorktreePath)
@@ -120,3 +157,19 @@ func runSpawn(_ *cobra.Command, args []string) error {
 	fmt.Printf("Worker completed successfully.\n")
 	return nil
 }
+
+// spawnOutput is the st.spawn schema. A dry run carries the prompt instead
+// of the worker's PID, run ID and log.
+type spawnOutput struct {
+	Ticket         ticketRef `json:"ticket"`
+	DryRun         bool      `json:"dry_run,omitempty"`
+	Prompt         string    `json:"prompt,omitempty"`
+	PID            int       `json:"pid,omitempty"`
+	RunID          string    `json:"run_id,omitempty"`
+	Worktree       string    `json:"worktree"`
+	Branch         string    `json:"branch"`
+	Log            string    `json:"log,omitempty"`
+	Backend        string    `json:"backend"`
+	TimeoutSeconds int64     `json:"timeout_seconds"`
+	TmuxWindow     string    `json:"tmux_window,omitempty"`
+}
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/usage"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show token usage and estimated cost per project and ticket",
+	Long: `Show token usage recorded from agent transcripts, totalled per project
+and per ticket, with cost estimates from the model prices in config
+([usage.prices."<model-prefix>"] overrides the built-in list prices).`,
+	Args: cobra.NoArgs,
+	RunE: runStats,
+}
+
+var (
+	statsProject string
+	statsSince   string
+	statsLimit   int
+)
+
+func init() {
+	statsCmd.Flags().StringVar(&statsProject, "project", "", "only count usage for this project")
+	statsCmd.Flags().StringVar(&statsSince, "since", "30d", "how far back to count (e.g. 30d, 12h)")
+	statsCmd.Flags().IntVar(&statsLimit, "limit", 20, "maximum tickets to list (0 for all)")
+	rootCmd.AddCommand(statsCmd)
+}
+
+func runStats(_ *cobra.Command, _ []string) error {
+	since, err := parseSince(statsSince)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return fmt.Errorf("get tickets dir: %w", err)
+	}
+
+	after := time.Now().UTC().Add(-since)
+	events, err := event.QueryEvents(eventsDir, event.Query{After: after, Project: statsProject})
+	if err != nil {
+		return fmt.Errorf("query events: %w", err)
+	}
+
+	byProject := usage.Summarize(events, func(ev event.Event) string {
+		if ev.Project == "" {
+			return "(none)"
+		}
+		return ev.Project
+	})
+	if structured() {
+		return writeOutput("stats", buildStatsOutput(cfg, projectsDir, after, byProject, events))
+	}
+	if len(byProject) == 0 {
+		fmt.Printf("No token usage recorded since %s.\n", after.Local().Format("2006-01-02 15:04"))
+		return nil
+	}
+	byTicket := usage.Summarize(events, usage.ByTicket)
+	pricing := usage.NewPricing(cfg.Usage.Prices)
+
+	fmt.Printf("Token usage since %s (costs are estimates)\n\n", after.Local().Format("2006-01-02 15:04"))
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PROJECT\tTOKENS\tINPUT\tOUTPUT\tCACHE READ\tCACHE WRITE\tTURNS\tCOST")
+	grand := make(usage.Totals)
+	for _, name := range sortedByCost(byProject, pricing) {
+		t := byProject[name]
+		writeUsageRow(w, name, t, pricing)
+		for model, u := range t {
+			grand.Add(model, u)
+		}
+	}
+	if len(byProject) > 1 {
+		writeUsageRow(w, "TOTAL", grand, pricing)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if len(byTicket) == 0 {
+		return nil
+	}
+	store := ticket.NewStore(projectsDir)
+	ids := sortedByCost(byTicket, pricing)
+	if statsLimit > 0 && len(ids) > statsLimit {
+		ids = ids[:statsLimit]
+	}
+
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "TICKET\tTITLE\tTOKENS\tINPUT\tOUTPUT\tCACHE READ\tCACHE WRITE\tTURNS\tCOST")
+	for _, id := range ids {
+		title := ""
+		if tk, err := store.Get(id); err == nil {
+			title = truncate(tk.Title, 40)
+		}
+		writeUsageRow(w, id+"\t"+title, byTicket[id], pricing)
+	}
+	return w.Flush()
+}
+
+// statsOutput is the st.stats schema. Both lists are ordered by descending
+// estimated cost; Tickets is capped by --limit.
+type statsOutput struct {
+	Since    time.Time      `json:"since"`
+	Projects []projectUsage `json:"projects"`
+	Total    usageSummary   `json:"total"`
+	Tickets  []ticketUsage  `json:"tickets"`
+}
+
+type projectUsage struct {
+	Project string `json:"project"`
+	usageSummary
+}
+
+type ticketUsage struct {
+	Ticket string `json:"ticket"`
+	Title  string `json:"title"`
+	usageSummary
+}
+
+func buildStatsOutput(cfg *config.Config, projectsDir string, after time.Time, byProject map[string]usage.Totals, events []event.Event) statsOutput {
+	pricing := usage.NewPricing(cfg.Usage.Prices)
+	out := statsOutput{Since: after, Projects: []projectUsage{}, Tickets: []ticketUsage{}}
+	grand := make(usage.Totals)
+	for _, name := range sortedByCost(byProject, pricing) {
+		t := byProject[name]
+		out.Projects = append(out.Projects, projectUsage{Project: name, usageSummary: summarizeUsage(t, pricing, true)})
+		for model, u := range t {
+			grand.Add(model, u)
+		}
+	}
+	out.Total = summarizeUsage(grand, pricing, true)
+
+	byTicket := usage.Summarize(events, usage.ByTicket)
+	store := ticket.NewStore(projectsDir)
+	ids := sortedByCost(byTicket, pricing)
+	if statsLimit > 0 && len(ids) > statsLimit {
+		ids = ids[:statsLimit]
+	}
+	for _, id := range ids {
+		tu := ticketUsage{Ticket: id, usageSummary: summarizeUsage(byTicket[id], pricing, true)}
+		if tk, err := store.Get(id); err == nil {
+			tu.Title = tk.Title
+		}
+		out.Tickets = append(out.Tickets, tu)
+	}
+	return out
+}
+
+// writeUsageRow writes label followed by token columns and estimated cost.
+func writeUsageRow(w io.Writer, label string, t usage.Totals, pricing usage.Pricing) {
+	sum := t.Sum()
+	_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n",
+		label,
+		usage.FormatTokens(sum.Tokens()),
+		usage.FormatTokens(sum.InputTokens),
+		usage.FormatTokens(sum.OutputTokens),
+		usage.FormatTokens(sum.CacheReadTokens),
+		usage.FormatTokens(sum.CacheCreationTokens),
+		sum.Turns,
+		usage.FormatCost(t.Cost(pricing)))
+}
+
+// sortedByCost returns the keys of groups ordered by descending estimated
+// cost, then descending tokens.
+func sortedByCost(groups map[string]usage.Totals, pricing usage.Pricing) []string {
+	keys := make([]string, 0, len(groups))
+	costs := make(map[string]float64, len(groups))
+	for k, t := range groups {
+		keys = append(keys, k)
+		costs[k], _ = t.Cost(pricing)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if costs[keys[i]] != costs[keys[j]] {
+			return costs[keys[i]] > costs[keys[j]]
+		}
+		ti, tj := groups[keys[i]].Sum().Tokens(), groups[keys[j]].Sum().Tokens()
+		if ti != tj {
+			return ti > tj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// printTicketUsage writes a usage summary for one ticket, one line per model.
+// Nothing is written when the ticket has no recorded usage.
+func printTicketUsage(w io.Writer, t usage.Totals, pricing usage.Pricing) {
+	if len(t) == 0 {
+		return
+	}
+	sum := t.Sum()
+	_, _ = fmt.Fprintf(w, "\n## Usage\n\n%s tokens over %d turns (%d requests), est. %s\n",
+		usage.FormatTokens(sum.Tokens()), sum.Turns, sum.Requests, usage.FormatCost(t.Cost(pricing)))
+	for _, model := range t.Models() {
+		u := t[model]
+		cost := "no price"
+		if c, ok := pricing.Cost(model, u); ok {
+			cost = usage.FormatCost(c, false)
+		}
+		_, _ = fmt.Fprintf(w, "- %s: %s in, %s out, %s cache read, %s cache write — %s\n",
+			model,
+			usage.FormatTokens(u.InputTokens),
+			usage.FormatTokens(u.OutputTokens),
+			usage.FormatTokens(u.CacheReadTokens),
+			usage.FormatTokens(u.CacheCreationTokens),
+			cost)
+	}
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/usage"
+)
+
+func (e *testEnv) addUsageEvent(t *testing.T, ticketID, model string, u usage.Usage) {
+	t.Helper()
+	if err := e.EventLog.Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.UsageRecorded,
+		Ticket:  ticketID,
+		Project: "testproject",
+		Actor:   "agent",
+		RunID:   "run-usage",
+		Data:    u.Data(model),
+	}); err != nil {
+		t.Fatalf("append usage event: %v", err)
+	}
+}
+
+func TestStats_ProjectAndTicketTotals(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "expensive ticket", ticket.StatusInProgress)
+
+	env.addUsageEvent(t, tk.ID, "claude-sonnet-4-5", usage.Usage{InputTokens: 1_000_000, OutputTokens: 100_000, Requests: 3, Turns: 2})
+	env.addUsageEvent(t, tk.ID, "claude-sonnet-4-5", usage.Usage{InputTokens: 500, OutputTokens: 500, Requests: 1, Turns: 1})
+	env.addUsageEvent(t, "", "claude-haiku-4-5", usage.Usage{InputTokens: 1000, Requests: 1})
+
+	out, err := env.runCmd(t, "stats")
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+
+	for _, want := range []string{"testproject", tk.ID, "expensive ticket", "1.1M", "$4.51"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStats_NoUsage(t *testing.T) {
+	env := newTestEnv(t)
+
+	out, err := env.runCmd(t, "stats", "--since", "7d")
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if !strings.Contains(out, "No token usage recorded") {
+		t.Errorf("output = %q, want no-usage message", out)
+	}
+}
+
+func TestShow_Usage(t *testing.T) {
+	env := newTestEnv(t)
+	tk := env.createTicket(t, "usage ticket", ticket.StatusInProgress)
+	env.addUsageEvent(t, tk.ID, "claude-opus-4-5", usage.Usage{InputTokens: 2000, OutputTokens: 1000, CacheReadTokens: 10_000, Requests: 4, Turns: 2})
+
+	out, err := env.runCmd(t, "show", tk.ID)
+	if err != nil {
+		t.Fatalf("show: %v", err)
+	}
+	for _, want := range []string{"## Usage", "13.0k tokens over 2 turns (4 requests)", "claude-opus-4-5: 2.0k in, 1.0k out"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
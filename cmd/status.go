@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,9 +10,14 @@ import (
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/handoff"
 	"github.com/boozedog/smoovtask/internal/identity"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/secrets"
 	"github.com/boozedog/smoovtask/internal/spawn"
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/verify"
 	"github.com/boozedog/smoovtask/internal/workflow"
 	"github.com/spf13/cobra"
 )
@@ -77,6 +83,12 @@ func runStatus(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("cannot move to %s — ticket has no assignee. Run `st pick %s` first", targetStatus, tk.ID)
 	}
 
+	if workflow.RequiresSections(targetStatus) {
+		if err := requireSections(tk, targetStatus); err != nil {
+			return err
+		}
+	}
+
 	if workflow.RequiresNote(tk.Status, targetStatus) {
 		evDir, evErr := cfg.EventsDir()
 		if evErr != nil {
@@ -102,32 +114,19 @@ func runStatus(_ *cobra.Command, args []string) error {
 		if err := requireCleanWorktree(tk.ID); err != nil {
 			return err
 		}
-	}
-
-	now := time.Now().UTC()
-	oldStatus := tk.Status
-	tk.Status = targetStatus
-	tk.Updated = now
-
-	// Clear assignee when submitting for agent review — the reviewer will claim it via `st review`.
-	// Clear assignee when handing off to human review — this is now a separate queue.
-	// Clear assignee when moving to backlog — ticket is being deprioritized.
-	if targetStatus == ticket.StatusReview || targetStatus == ticket.StatusHumanReview || targetStatus == ticket.StatusBacklog {
-		tk.Assignee = ""
-	}
-
-	heading := statusHeading(targetStatus)
-
-	var sectionFields map[string]string
-	if (oldStatus == ticket.StatusHumanReview || oldStatus == ticket.StatusReview) && (targetStatus == ticket.StatusDone || targetStatus == ticket.StatusRework) {
-		reviewedBy := runID
-		if reviewedBy == "" {
-			reviewedBy = actor
+		if err := requireNoSecrets(cfg, tk, actor, runID); err != nil {
+			return err
+		}
+		if err := requirePassingTests(cfg, tk); err != nil {
+			return err
+		}
+		if err := requireVerification(cfg, store, tk, actor, runID); err != nil {
+			return err
 		}
-		sectionFields = map[string]string{"reviewed-by": reviewedBy}
 	}
 
-	ticket.AppendSection(tk, heading, actor, runID, "", sectionFields, now)
+	now := time.Now().UTC()
+	oldStatus := transitionTicket(cfg, tk, targetStatus, actor, runID, now)
 
 	if err := store.Save(tk); err != nil {
 		return fmt.Errorf("save ticket: %w", err)
@@ -171,7 +170,7 @@ func runStatus(_ *cobra.Command, args []string) error {
 		fmt.Println("- Performance concerns or tech debt")
 		fmt.Println("- Documentation gaps")
 		fmt.Println()
-		fmt.Printf("If so, create tickets for them now:\n")
+		fmt.Printf("If so, create tickets for them now (they are linked as follow-up-of %s):\n", tk.ID)
 		fmt.Printf("  st new \"<improvement title>\" -p P3 -d \"<description>\" --run-id %s\n", runID)
 		fmt.Println()
 		fmt.Println("If nothing stood out, you're done — no action needed.")
@@ -179,28 +178,123 @@ func runStatus(_ *cobra.Command, args []string) error {
 
 	// Auto-unblock dependents when a ticket moves to DONE
 	if targetStatus == ticket.StatusDone {
-		unblocked, unblockedErr := ticket.AutoUnblock(store, tk.ID)
-		if unblockedErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: auto-unblock check failed: %v\n", unblockedErr)
-		}
-		for _, ut := range unblocked {
-			snapStatus := "status." + strings.ToLower(string(ut.Status))
-			_ = el.Append(event.Event{
-				TS:      now,
-				Event:   snapStatus,
-				Ticket:  ut.ID,
-				Project: ut.Project,
-				Actor:   "st",
-				RunID:   runID,
-				Data:    map[string]any{"from": string(ticket.StatusBlocked), "reason": "auto-unblock"},
-			})
-			fmt.Printf("Auto-unblocked: %s → %s\n", ut.ID, ut.Status)
+		for _, msg := range releaseDependents(cfg, store, el, tk, runID, now) {
+			fmt.Println(msg)
 		}
 	}
 
 	return nil
 }
 
+// transitionTicket moves tk to status with the bookkeeping every status
+// change gets, from `st status` or `st bulk status`: the assignee is
+// released when the ticket leaves the worker's hands, a review outcome
+// records who reviewed it, and REWORK appends the state of play for the
+// next session. It returns the previous status; tk is not saved.
+func transitionTicket(cfg *config.Config, tk *ticket.Ticket, status ticket.Status, actor, runID string, now time.Time) ticket.Status {
+	from := tk.Status
+	tk.Status = status
+	tk.Updated = now
+
+	// Clear assignee when submitting for agent review — the reviewer will claim it via `st review`.
+	// Clear assignee when handing off to human review — this is now a separate queue.
+	// Clear assignee when moving to backlog — ticket is being deprioritized.
+	if status == ticket.StatusReview || status == ticket.StatusHumanReview || status == ticket.StatusBacklog {
+		tk.Assignee = ""
+	}
+
+	var sectionFields map[string]string
+	if (from == ticket.StatusHumanReview || from == ticket.StatusReview) && (status == ticket.StatusDone || status == ticket.StatusRework) {
+		reviewedBy := runID
+		if reviewedBy == "" {
+			reviewedBy = actor
+		}
+		sectionFields = map[string]string{"reviewed-by": reviewedBy}
+	}
+
+	ticket.AppendSection(tk, statusHeading(status), actor, runID, "", sectionFields, now)
+	if status == ticket.StatusRework {
+		appendStateOfPlay(cfg, tk, handoff.ReasonRework, actor, runID, now)
+	}
+	return from
+}
+
+// releaseDependents auto-unblocks the dependents of a finished ticket and
+// rolls up its epic, logging the status events. It returns a line per
+// unblocked ticket for the caller to report.
+func releaseDependents(cfg *config.Config, store *ticket.Store, el *event.EventLog, tk *ticket.Ticket, runID string, now time.Time) []string {
+	unblocked, err := ticket.AutoUnblock(store, tk.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: auto-unblock check failed: %v\n", err)
+	}
+	var msgs []string
+	for _, ut := range unblocked {
+		_ = el.Append(event.Event{
+			TS:      now,
+			Event:   "status." + strings.ToLower(string(ut.Status)),
+			Ticket:  ut.ID,
+			Project: ut.Project,
+			Actor:   "st",
+			RunID:   runID,
+			Data:    map[string]any{"from": string(ticket.StatusBlocked), "reason": "auto-unblock"},
+		})
+		msgs = append(msgs, fmt.Sprintf("Auto-unblocked: %s → %s", ut.ID, ut.Status))
+	}
+	rollupParents(cfg, store, el, tk, runID, now)
+	return msgs
+}
+
+// requireSections refuses to move tk to status while required sections of
+// its template are empty.
+func requireSections(tk *ticket.Ticket, status ticket.Status) error {
+	missing := ticket.MissingSections(tk)
+	if len(missing) == 0 {
+		return nil
+	}
+	return &cliError{
+		Code:    codeInvalidTransition,
+		Message: fmt.Sprintf("cannot move to %s — required sections are empty: %s", status, strings.Join(missing, ", ")),
+		Hint:    fmt.Sprintf("Edit the ticket file, or run `st edit %s --append-description` with a \"### <section>\" heading above each one.", tk.ID),
+	}
+}
+
+// rollupParents moves tk's parent epic to the configured epics.auto_complete
+// status once all its children are finished, logging a status event for each
+// epic moved.
+func rollupParents(cfg *config.Config, store *ticket.Store, el *event.EventLog, tk *ticket.Ticket, runID string, now time.Time) {
+	if tk.Parent == "" {
+		return
+	}
+	var target ticket.Status
+	switch cfg.EpicAutoComplete() {
+	case config.EpicAutoReview:
+		target = ticket.StatusReview
+	case config.EpicAutoDone:
+		target = ticket.StatusDone
+	default:
+		return
+	}
+
+	changes, err := ticket.AutoCompleteParents(store, tk, target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "warning: epic rollup failed: %v\n", err)
+	}
+	for _, c := range changes {
+		_ = el.Append(event.Event{
+			TS:      now,
+			Event:   "status." + strings.ToLower(string(c.Ticket.Status)),
+			Ticket:  c.Ticket.ID,
+			Project: c.Ticket.Project,
+			Actor:   "st",
+			RunID:   runID,
+			Data:    map[string]any{"from": string(c.From), "reason": "rollup"},
+		})
+		if !structured() {
+			fmt.Printf("Epic %s: %s → %s (all children finished)\n", c.Ticket.ID, c.From, c.Ticket.Status)
+		}
+	}
+}
+
 // requireCleanWorktree verifies the ticket's worktree exists and has no uncommitted changes.
 func requireCleanWorktree(ticketID string) error {
 	cwd, err := os.Getwd()
@@ -229,6 +323,174 @@ func requireCleanWorktree(ticketID string) error {
 	return nil
 }
 
+// requireNoSecrets scans the ticket branch diff for credentials and
+// high-entropy strings, honouring the project's secrets_ignore patterns. A
+// hit is logged as a denied hook.rule-decision event.
+func requireNoSecrets(cfg *config.Config, tk *ticket.Ticket, actor, runID string) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+	repoRoot, err := spawn.WorktreeRepoRoot(cwd)
+	if err != nil {
+		return fmt.Errorf("cannot determine repo root: %w", err)
+	}
+	diff, err := spawn.BranchDiff(repoRoot, tk.ID)
+	if err != nil {
+		return fmt.Errorf("scan branch for secrets: %w", err)
+	}
+
+	var ignore []string
+	if vaultPath, err := cfg.VaultPath(); err == nil {
+		if meta, err := project.LoadMeta(vaultPath, tk.Project); err == nil && meta != nil {
+			ignore = meta.SecretsIgnore
+		}
+	}
+
+	findings := secrets.ScanDiff(diff, ignore)
+	if len(findings) == 0 {
+		return nil
+	}
+
+	if eventsDir, err := cfg.EventsDir(); err == nil {
+		_ = event.NewEventLog(eventsDir).Append(event.Event{
+			TS:      time.Now().UTC(),
+			Event:   event.HookRuleDecision,
+			Ticket:  tk.ID,
+			Project: tk.Project,
+			Actor:   actor,
+			RunID:   runID,
+			Data: map[string]any{
+				"tool":      "st status review",
+				"decision":  "deny",
+				"ruleset":   "secret-scan",
+				"rule":      findings[0].Kind,
+				"reason":    findings[0].String(),
+				"file_path": findings[0].File,
+				"line":      findings[0].Line,
+				"findings":  len(findings),
+			},
+		})
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "cannot move to REVIEW — possible secrets on branch %s:\n", spawn.BranchName(tk.ID))
+	for _, f := range findings {
+		fmt.Fprintf(&b, "  %s\n", f)
+	}
+	fmt.Fprintf(&b, "Remove them from the branch history, or add test fixture paths to `secrets_ignore` in projects/%s/project.md", tk.Project)
+	return errors.New(b.String())
+}
+
+// requirePassingTests enforces the project's require_tests setting: the
+// last test run recorded for the ticket since the worktree's last commit must
+// have passed.
+func requirePassingTests(cfg *config.Config, tk *ticket.Ticket) error {
+	vaultPath, err := cfg.VaultPath()
+	if err != nil {
+		return nil
+	}
+	meta, err := project.LoadMeta(vaultPath, tk.Project)
+	if err != nil || meta == nil || !meta.RequireTests {
+		return nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+	repoRoot, err := spawn.WorktreeRepoRoot(cwd)
+	if err != nil {
+		return fmt.Errorf("cannot determine repo root: %w", err)
+	}
+	wtPath := spawn.WorktreePath(repoRoot, tk.ID)
+	committed, err := spawn.LastCommitTime(wtPath)
+	if err != nil {
+		return fmt.Errorf("check last commit: %w", err)
+	}
+
+	eventsDir, err := cfg.EventsDir()
+	if err != nil {
+		return fmt.Errorf("get events dir: %w", err)
+	}
+	events, err := event.QueryEvents(eventsDir, event.Query{TicketID: tk.ID, After: committed})
+	if err != nil {
+		return fmt.Errorf("query test runs: %w", err)
+	}
+	last, ok := testrun.LatestByTicket(events)[tk.ID]
+	if !ok {
+		return fmt.Errorf("cannot move to REVIEW — project %s requires a passing test run since the last commit in %s. Run the tests first", tk.Project, wtPath)
+	}
+	if last.Status != testrun.StatusPass {
+		var b strings.Builder
+		fmt.Fprintf(&b, "cannot move to REVIEW — the last test run failed: %s", last.Summary())
+		for _, name := range last.Failures {
+			fmt.Fprintf(&b, "\n  FAIL %s", name)
+		}
+		b.WriteString("\nFix the failures, commit, and re-run the tests")
+		return errors.New(b.String())
+	}
+	return nil
+}
+
+// requireVerification runs the project's verify commands in the ticket
+// worktree. The outcome is logged as a verify.passed or verify.failed event
+// and recorded on the ticket; a failure is saved immediately and refuses the
+// transition, a pass is saved along with the status change.
+func requireVerification(cfg *config.Config, store *ticket.Store, tk *ticket.Ticket, actor, runID string) error {
+	vaultPath, err := cfg.VaultPath()
+	if err != nil {
+		return nil
+	}
+	meta, err := project.LoadMeta(vaultPath, tk.Project)
+	if err != nil || meta == nil || len(meta.Verify) == 0 {
+		return nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("get working directory: %w", err)
+	}
+	repoRoot, err := spawn.WorktreeRepoRoot(cwd)
+	if err != nil {
+		return fmt.Errorf("cannot determine repo root: %w", err)
+	}
+	wtPath := spawn.WorktreePath(repoRoot, tk.ID)
+
+	fmt.Printf("Verifying %s in %s...\n", tk.ID, wtPath)
+	result := verify.Run(wtPath, meta.Verify, 0)
+	fmt.Printf("Verification: %s\n", result.Summary())
+
+	now := time.Now().UTC()
+	evType := event.VerifyPassed
+	heading := "Verification Passed"
+	if !result.Passed() {
+		evType = event.VerifyFailed
+		heading = "Verification Failed"
+	}
+	if eventsDir, err := cfg.EventsDir(); err == nil {
+		_ = event.NewEventLog(eventsDir).Append(event.Event{
+			TS:      now,
+			Event:   evType,
+			Ticket:  tk.ID,
+			Project: tk.Project,
+			Actor:   actor,
+			RunID:   runID,
+			Data:    result.Data(),
+		})
+	}
+	ticket.AppendSection(tk, heading, actor, runID, result.Report(), nil, now)
+
+	if result.Passed() {
+		return nil
+	}
+	if err := store.Save(tk); err != nil {
+		return fmt.Errorf("save ticket: %w", err)
+	}
+	failed, _ := result.Failed()
+	return fmt.Errorf("cannot move to REVIEW — verification failed: %s\n%s\nFix the failure, commit, and submit again", result.Summary(), verify.Excerpt(failed.Output))
+}
+
 // statusHeading converts a status to a human-readable section heading.
 func statusHeading(s ticket.Status) string {
 	headings := map[ticket.Status]string{
@@ -2,11 +2,15 @@ package cmd
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
@@ -263,3 +267,264 @@ func TestStatus_MultipleActiveTickets(t *testing.T) {
 		t.Errorf("error = %q, want substring %q", err.Error(), "multiple active tickets")
 	}
 }
+
+// commitInWorktree writes a file on the ticket branch and commits it.
+func commitInWorktree(t *testing.T, ticketID, name, content string) {
+	t.Helper()
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wtPath := filepath.Join(cwd, ".worktrees", ticketID)
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(wtPath, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(wtPath, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{{"add", "-A"}, {"commit", "-m", "add " + name}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = wtPath
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s: %v", args, out, err)
+		}
+	}
+}
+
+func TestStatus_ReviewBlockedBySecretOnBranch(t *testing.T) {
+	env := newTestEnv(t)
+
+	tk := env.createTicket(t, "secret test", ticket.StatusInProgress)
+	tk.Assignee = "test-session-status"
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatalf("save ticket: %v", err)
+	}
+	env.addNoteEvent(t, tk.ID)
+	env.ensureCleanWorktree(t, tk.ID)
+
+	fakeKey := "AKIA" + "Q3EGRKJ5EXAMPLE7"
+	commitInWorktree(t, tk.ID, "config/aws.go", "package config\n\nconst key = \""+fakeKey+"\"\n")
+
+	_, err := env.runCmd(t, "--run-id", "test-session-status", "status", "review")
+	if err == nil {
+		t.Fatal("expected review to be blocked by secret on branch")
+	}
+	if !strings.Contains(err.Error(), "config/aws.go:3: AWS access key") {
+		t.Errorf("error = %q, want file:line of the secret", err.Error())
+	}
+	if strings.Contains(err.Error(), fakeKey) {
+		t.Errorf("error leaks the secret: %q", err.Error())
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: tk.ID})
+	if err != nil {
+		t.Fatalf("query events: %v", err)
+	}
+	var decision *event.Event
+	for i := range events {
+		if events[i].Event == event.HookRuleDecision {
+			decision = &events[i]
+		}
+	}
+	if decision == nil || decision.Data["ruleset"] != "secret-scan" || decision.Data["decision"] != "deny" {
+		t.Errorf("rule-decision event = %+v, want secret-scan deny", decision)
+	}
+
+	// Ignoring the path as a fixture lets the review through.
+	meta, err := project.LoadMeta(env.Config.Settings.VaultPath, "testproject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.SecretsIgnore = []string{"config/**"}
+	if err := project.SaveMeta(env.Config.Settings.VaultPath, "testproject", meta); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := env.runCmd(t, "--run-id", "test-session-status", "status", "review"); err != nil {
+		t.Fatalf("unexpected error with secrets_ignore: %v", err)
+	}
+}
+
+func (e *testEnv) addTestRunEvent(t *testing.T, ticketID string, ts time.Time, r testrun.Result) {
+	t.Helper()
+	if err := e.EventLog.Append(event.Event{
+		TS:      ts,
+		Event:   event.TestRun,
+		Ticket:  ticketID,
+		Project: "testproject",
+		Actor:   "agent",
+		RunID:   "test-session-status",
+		Data:    r.Data(nil),
+	}); err != nil {
+		t.Fatalf("append test.run event: %v", err)
+	}
+}
+
+func TestStatus_ReviewRequiresPassingTests(t *testing.T) {
+	env := newTestEnv(t)
+
+	tk := env.createTicket(t, "tested work", ticket.StatusInProgress)
+	tk.Assignee = "test-session-status"
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatalf("save ticket: %v", err)
+	}
+	env.addNoteEvent(t, tk.ID)
+	env.ensureCleanWorktree(t, tk.ID)
+
+	meta, err := project.LoadMeta(env.Config.Settings.VaultPath, "testproject")
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta.RequireTests = true
+	if err := project.SaveMeta(env.Config.Settings.VaultPath, "testproject", meta); err != nil {
+		t.Fatal(err)
+	}
+
+	// A passing run from before the last commit does not count.
+	pass := testrun.Result{Runner: testrun.RunnerGo, Command: "go test ./...", Status: testrun.StatusPass, Passed: 4}
+	env.addTestRunEvent(t, tk.ID, time.Now().UTC().Add(-time.Hour), pass)
+	commitInWorktree(t, tk.ID, "feature.go", "package feature\n")
+
+	_, err = env.runCmd(t, "--run-id", "test-session-status", "status", "review")
+	if err == nil || !strings.Contains(err.Error(), "requires a passing test run since the last commit") {
+		t.Fatalf("error = %v, want missing test run", err)
+	}
+
+	fail := testrun.Result{Runner: testrun.RunnerGo, Status: testrun.StatusFail, Passed: 3, Failed: 1, Failures: []string{"TestFeature"}}
+	env.addTestRunEvent(t, tk.ID, time.Now().UTC().Add(time.Second), fail)
+	_, err = env.runCmd(t, "--run-id", "test-session-status", "status", "review")
+	if err == nil || !strings.Contains(err.Error(), "FAIL TestFeature") {
+		t.Fatalf("error = %v, want failing test listed", err)
+	}
+
+	env.addTestRunEvent(t, tk.ID, time.Now().UTC().Add(2*time.Second), pass)
+	if _, err := env.runCmd(t, "--run-id", "test-session-status", "status", "review"); err != nil {
+		t.Fatalf("unexpected error after passing run: %v", err)
+	}
+}
+
+func TestStatus_ReviewRunsVerification(t *testing.T) {
+	env := newTestEnv(t)
+
+	tk := env.createTicket(t, "verified work", ticket.StatusInProgress)
+	tk.Assignee = "test-session-status"
+	if err := env.Store.Save(tk); err != nil {
+		t.Fatalf("save ticket: %v", err)
+	}
+	env.addNoteEvent(t, tk.ID)
+	env.ensureCleanWorktree(t, tk.ID)
+
+	setVerify := func(commands ...string) {
+		t.Helper()
+		meta := &project.ProjectMeta{Verify: commands}
+		if err := project.SaveMeta(env.Config.Settings.VaultPath, "testproject", meta); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	setVerify("test -f ok.txt || { echo 'ok.txt missing' >&2; exit 2; }")
+	_, err := env.runCmd(t, "--run-id", "test-session-status", "status", "review")
+	if err == nil || !strings.Contains(err.Error(), "verification failed") || !strings.Contains(err.Error(), "ok.txt missing") {
+		t.Fatalf("error = %v, want verification failure with excerpt", err)
+	}
+
+	got, err := env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != ticket.StatusInProgress {
+		t.Errorf("status = %s, want IN-PROGRESS", got.Status)
+	}
+	if !strings.Contains(got.Body, "## Verification Failed") || !strings.Contains(got.Body, "ok.txt missing") {
+		t.Errorf("ticket body missing failure section:\n%s", got.Body)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{TicketID: tk.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var failed bool
+	for _, e := range events {
+		if e.Event == event.VerifyFailed {
+			failed = true
+			if e.Data["exit_code"] != float64(2) {
+				t.Errorf("verify.failed exit_code = %v, want 2", e.Data["exit_code"])
+			}
+		}
+	}
+	if !failed {
+		t.Error("missing verify.failed event")
+	}
+
+	commitInWorktree(t, tk.ID, "ok.txt", "ok\n")
+	env.addNoteEvent(t, tk.ID)
+	if _, err := env.runCmd(t, "--run-id", "test-session-status", "status", "review"); err != nil {
+		t.Fatalf("unexpected error after fix: %v", err)
+	}
+
+	got, err = env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != ticket.StatusReview || !strings.Contains(got.Body, "## Verification Passed") {
+		t.Errorf("status = %s, body:\n%s", got.Status, got.Body)
+	}
+	events, _ = event.QueryEvents(env.EventsDir, event.Query{TicketID: tk.ID})
+	var passed bool
+	for _, e := range events {
+		passed = passed || e.Event == event.VerifyPassed
+	}
+	if !passed {
+		t.Error("missing verify.passed event")
+	}
+}
+
+func TestStatus_ReworkAppendsStateOfPlay(t *testing.T) {
+	env := newTestEnv(t)
+
+	tk := env.createTicket(t, "rate limiting", ticket.StatusReview)
+	env.ensureCleanWorktree(t, tk.ID)
+	commitInWorktree(t, tk.ID, "limit/limit.go", "package limit\n")
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = env.EventLog.Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.HookPreTool,
+		Ticket:  tk.ID,
+		Project: "testproject",
+		Actor:   "agent",
+		Data:    map[string]any{"tool": "Write", "file_path": filepath.Join(cwd, ".worktrees", tk.ID, "limit", "limit.go")},
+	})
+
+	if _, err := env.runCmd(t, "note", "--ticket", tk.ID, "Missing tests for the burst path.\nShould 429s include Retry-After?"); err != nil {
+		t.Fatalf("note: %v", err)
+	}
+	if _, err := env.runCmd(t, "status", "rework", "--ticket", tk.ID); err != nil {
+		t.Fatalf("status rework: %v", err)
+	}
+
+	updated, err := env.Store.Get(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	summary, ok := ticket.LastSection(ticket.Sections(updated.Body), "State of Play")
+	if !ok {
+		t.Fatalf("no State of Play section:\n%s", updated.Body)
+	}
+	if summary.Fields["reason"] != "rework" {
+		t.Errorf("reason = %q, want rework", summary.Fields["reason"])
+	}
+	for _, want := range []string{
+		"add limit/limit.go",
+		"### Reviewer findings",
+		"Missing tests for the burst path.",
+		"- Should 429s include Retry-After?",
+		"### Files touched\n- limit/limit.go",
+	} {
+		if !strings.Contains(summary.Content, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary.Content)
+		}
+	}
+}
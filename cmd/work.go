@@ -14,6 +14,6 @@ var workCmd = &cobra.Command{
 }
 
 func init() {
-	workCmd.Flags().StringVar(&workCLI, "cli", "", "CLI backend override (claude, opencode, pi)")
+	workCmd.Flags().StringVar(&workCLI, "cli", "", "CLI backend override (claude, opencode, pi, codex, gemini)")
 	rootCmd.AddCommand(workCmd)
 }
@@ -12,7 +12,16 @@ import (
 
 // Config holds the global smoovtask configuration.
 type Config struct {
-	Settings SettingsConfig `toml:"settings"`
+	Settings  SettingsConfig  `toml:"settings"`
+	Usage     UsageConfig     `toml:"usage,omitempty"`
+	Hooks     HooksConfig     `toml:"hooks,omitempty"`
+	Knowledge KnowledgeConfig `toml:"knowledge,omitempty"`
+	Epics     EpicsConfig     `toml:"epics,omitempty"`
+	Edit      EditConfig      `toml:"edit,omitempty"`
+
+	// Queries holds saved ticket queries by name, used as @name in
+	// `st list` and the web list view.
+	Queries map[string]string `toml:"queries,omitempty"`
 }
 
 // SettingsConfig holds global settings.
@@ -21,6 +30,131 @@ type SettingsConfig struct {
 	EventsPath string `toml:"events_path,omitempty"`
 }
 
+// UsageConfig holds token accounting settings.
+type UsageConfig struct {
+	// Prices overrides or extends the built-in model prices, keyed by model
+	// name prefix (e.g. "claude-sonnet-4").
+	Prices map[string]ModelPrice `toml:"prices,omitempty"`
+}
+
+// ModelPrice is a model's price in USD per million tokens.
+type ModelPrice struct {
+	Input      float64 `toml:"input"`
+	Output     float64 `toml:"output"`
+	CacheRead  float64 `toml:"cache_read"`
+	CacheWrite float64 `toml:"cache_write"`
+}
+
+// HooksConfig holds agent hook settings.
+type HooksConfig struct {
+	// StopMaxReprompts caps how many times per session the stop hook blocks
+	// an agent from ending with unfinished ticket work. 0 uses the default;
+	// a negative value disables blocking.
+	StopMaxReprompts int `toml:"stop_max_reprompts,omitempty"`
+
+	// SubagentContextBudget caps the ticket context (in bytes) injected when
+	// a subagent starts. 0 uses the default.
+	SubagentContextBudget int `toml:"subagent_context_budget,omitempty"`
+}
+
+// DefaultStopMaxReprompts is the stop hook re-prompt limit when none is
+// configured.
+const DefaultStopMaxReprompts = 3
+
+// StopMaxReprompts returns the configured stop hook re-prompt limit; 0 means
+// the stop hook never blocks.
+func (c *Config) StopMaxReprompts() int {
+	switch n := c.Hooks.StopMaxReprompts; {
+	case n < 0:
+		return 0
+	case n == 0:
+		return DefaultStopMaxReprompts
+	default:
+		return n
+	}
+}
+
+// DefaultSubagentContextBudget is the subagent context size limit in bytes
+// when none is configured.
+const DefaultSubagentContextBudget = 6000
+
+// SubagentContextBudget returns the configured subagent context size limit.
+func (c *Config) SubagentContextBudget() int {
+	if n := c.Hooks.SubagentContextBudget; n > 0 {
+		return n
+	}
+	return DefaultSubagentContextBudget
+}
+
+// KnowledgeConfig holds project knowledge base settings.
+type KnowledgeConfig struct {
+	// Budget caps the learnings (in bytes) injected at session start and by
+	// `st pick`. 0 uses the default.
+	Budget int `toml:"budget,omitempty"`
+}
+
+// DefaultKnowledgeBudget is the injected learnings size limit in bytes when
+// none is configured.
+const DefaultKnowledgeBudget = 2000
+
+// KnowledgeBudget returns the configured injected learnings size limit.
+func (c *Config) KnowledgeBudget() int {
+	if n := c.Knowledge.Budget; n > 0 {
+		return n
+	}
+	return DefaultKnowledgeBudget
+}
+
+// EpicsConfig holds parent/child ticket settings.
+type EpicsConfig struct {
+	// AutoComplete is where a parent ticket moves once all its children are
+	// finished: "review" (the default), "done" or "off".
+	AutoComplete string `toml:"auto_complete,omitempty"`
+}
+
+// Epic auto-complete modes.
+const (
+	EpicAutoReview = "review"
+	EpicAutoDone   = "done"
+	EpicAutoOff    = "off"
+)
+
+// EpicAutoComplete returns the configured epic auto-complete mode, falling
+// back to EpicAutoReview for empty or unknown values.
+func (c *Config) EpicAutoComplete() string {
+	switch mode := strings.ToLower(strings.TrimSpace(c.Epics.AutoComplete)); mode {
+	case EpicAutoDone, EpicAutoOff:
+		return mode
+	default:
+		return EpicAutoReview
+	}
+}
+
+// EditConfig holds the policy for agents changing tickets with `st edit`.
+// Humans may always edit every field.
+type EditConfig struct {
+	// AgentFields lists the fields agents may edit: title, priority, tags
+	// and description. Empty allows all of them.
+	AgentFields []string `toml:"agent_fields,omitempty"`
+
+	// AgentRaisePriority lets agents make a ticket more urgent. By default
+	// they may only lower its priority.
+	AgentRaisePriority bool `toml:"agent_raise_priority,omitempty"`
+}
+
+// AgentMayEdit reports whether agents may edit the named ticket field.
+func (c *Config) AgentMayEdit(field string) bool {
+	if len(c.Edit.AgentFields) == 0 {
+		return true
+	}
+	for _, f := range c.Edit.AgentFields {
+		if strings.EqualFold(strings.TrimSpace(f), field) {
+			return true
+		}
+	}
+	return false
+}
+
 // DefaultDir returns the default config directory (~/.smoovtask).
 // If SMOOVBRAIN_DIR is set, uses that path instead.
 func DefaultDir() (string, error) {
@@ -143,6 +277,26 @@ func (c *Config) EventsDir() (string, error) {
 	return filepath.Join(dir, "events"), nil
 }
 
+// UsageDir returns the directory holding per-session transcript ingest
+// state (~/.smoovtask/usage/).
+func (c *Config) UsageDir() (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "usage"), nil
+}
+
+// OverlapDir returns the directory holding per-project overlap check markers
+// (~/.smoovtask/overlap/).
+func (c *Config) OverlapDir() (string, error) {
+	dir, err := DefaultDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "overlap"), nil
+}
+
 // RulesDir returns the rules directory path (in the vault).
 func (c *Config) RulesDir() (string, error) {
 	vault, err := c.VaultPath()
@@ -152,6 +306,43 @@ func (c *Config) RulesDir() (string, error) {
 	return filepath.Join(vault, "rules"), nil
 }
 
+// ProjectRulesDir returns the project-scoped rules overlay directory
+// (<vault>/projects/<name>/rules).
+func (c *Config) ProjectRulesDir(name string) (string, error) {
+	projects, err := c.ProjectsDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(projects, name, "rules"), nil
+}
+
+// TemplatesDir returns the ticket templates directory path (in the vault).
+func (c *Config) TemplatesDir() (string, error) {
+	vault, err := c.VaultPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(vault, "templates"), nil
+}
+
+// TemplateDirs returns the directories searched for ticket templates, the
+// project's own (<vault>/projects/<name>/templates) first so it overrides
+// the vault-wide one. With no project only the vault-wide one is returned.
+func (c *Config) TemplateDirs(project string) ([]string, error) {
+	dir, err := c.TemplatesDir()
+	if err != nil {
+		return nil, err
+	}
+	if project == "" {
+		return []string{dir}, nil
+	}
+	projects, err := c.ProjectsDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{filepath.Join(projects, project, "templates"), dir}, nil
+}
+
 // EnsureDirs creates the vault projects dir and events dir if they don't exist.
 func (c *Config) EnsureDirs() error {
 	projects, err := c.ProjectsDir()
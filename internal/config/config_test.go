@@ -181,3 +181,169 @@ func TestEnsureDirs(t *testing.T) {
 		t.Errorf("projects dir not created: %v", err)
 	}
 }
+
+func TestProjectRulesDir(t *testing.T) {
+	cfg := &Config{Settings: SettingsConfig{VaultPath: "/vault"}}
+
+	got, err := cfg.ProjectRulesDir("api")
+	if err != nil {
+		t.Fatalf("ProjectRulesDir() error = %v", err)
+	}
+	want := filepath.Join("/vault", "projects", "api", "rules")
+	if got != want {
+		t.Errorf("ProjectRulesDir() = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateDirs(t *testing.T) {
+	cfg := &Config{Settings: SettingsConfig{VaultPath: "/vault"}}
+
+	got, err := cfg.TemplateDirs("api")
+	if err != nil {
+		t.Fatalf("TemplateDirs() error = %v", err)
+	}
+	want := []string{filepath.Join("/vault", "projects", "api", "templates"), filepath.Join("/vault", "templates")}
+	if len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("TemplateDirs(api) = %q, want %q", got, want)
+	}
+
+	got, err = cfg.TemplateDirs("")
+	if err != nil {
+		t.Fatalf("TemplateDirs() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != want[1] {
+		t.Errorf("TemplateDirs(\"\") = %q, want %q", got, want[1:])
+	}
+}
+
+func TestLoadUsagePrices(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	data := `[settings]
+vault_path = "~/vault"
+
+[usage.prices."claude-sonnet-4"]
+input = 2.5
+output = 12
+cache_read = 0.25
+cache_write = 3
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	got, ok := cfg.Usage.Prices["claude-sonnet-4"]
+	if !ok {
+		t.Fatalf("Prices = %v, want claude-sonnet-4 entry", cfg.Usage.Prices)
+	}
+	want := ModelPrice{Input: 2.5, Output: 12, CacheRead: 0.25, CacheWrite: 3}
+	if got != want {
+		t.Errorf("price = %+v, want %+v", got, want)
+	}
+}
+
+func TestStopMaxReprompts(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{0, DefaultStopMaxReprompts},
+		{5, 5},
+		{-1, 0},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Hooks: HooksConfig{StopMaxReprompts: tt.configured}}
+		if got := cfg.StopMaxReprompts(); got != tt.want {
+			t.Errorf("StopMaxReprompts() with %d = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+}
+
+func TestSubagentContextBudget(t *testing.T) {
+	tests := []struct {
+		configured int
+		want       int
+	}{
+		{0, DefaultSubagentContextBudget},
+		{-5, DefaultSubagentContextBudget},
+		{12000, 12000},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Hooks: HooksConfig{SubagentContextBudget: tt.configured}}
+		if got := cfg.SubagentContextBudget(); got != tt.want {
+			t.Errorf("SubagentContextBudget() with %d = %d, want %d", tt.configured, got, tt.want)
+		}
+	}
+}
+
+func TestKnowledgeBudget(t *testing.T) {
+	if got := (&Config{}).KnowledgeBudget(); got != DefaultKnowledgeBudget {
+		t.Errorf("KnowledgeBudget() = %d, want default %d", got, DefaultKnowledgeBudget)
+	}
+	cfg := &Config{Knowledge: KnowledgeConfig{Budget: 500}}
+	if got := cfg.KnowledgeBudget(); got != 500 {
+		t.Errorf("KnowledgeBudget() = %d, want 500", got)
+	}
+}
+
+func TestEpicAutoComplete(t *testing.T) {
+	tests := []struct {
+		configured, want string
+	}{
+		{"", EpicAutoReview},
+		{"review", EpicAutoReview},
+		{"Done", EpicAutoDone},
+		{"off", EpicAutoOff},
+		{"bogus", EpicAutoReview},
+	}
+	for _, tt := range tests {
+		cfg := &Config{Epics: EpicsConfig{AutoComplete: tt.configured}}
+		if got := cfg.EpicAutoComplete(); got != tt.want {
+			t.Errorf("EpicAutoComplete() with %q = %q, want %q", tt.configured, got, tt.want)
+		}
+	}
+}
+
+func TestLoadSavedQueries(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.toml")
+	data := `[settings]
+vault_path = "~/vault"
+
+[queries]
+mine = "assignee:run-1 status:in-progress"
+"api-urgent" = "tag:api priority:<=P1"
+`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadFrom(path)
+	if err != nil {
+		t.Fatalf("LoadFrom: %v", err)
+	}
+	if got := cfg.Queries["api-urgent"]; got != "tag:api priority:<=P1" {
+		t.Errorf("Queries[api-urgent] = %q", got)
+	}
+	if len(cfg.Queries) != 2 {
+		t.Errorf("Queries = %v", cfg.Queries)
+	}
+}
+
+func TestAgentMayEdit(t *testing.T) {
+	cfg := &Config{}
+	if !cfg.AgentMayEdit("title") {
+		t.Error("AgentMayEdit(title) = false with no policy, want true")
+	}
+
+	cfg.Edit.AgentFields = []string{"tags", " Description"}
+	for field, want := range map[string]bool{"tags": true, "description": true, "title": false, "priority": false} {
+		if got := cfg.AgentMayEdit(field); got != want {
+			t.Errorf("AgentMayEdit(%q) = %v, want %v", field, got, want)
+		}
+	}
+}
@@ -8,10 +8,17 @@ import (
 
 // Event type constants.
 const (
-	TicketCreated  = "ticket.created"
-	TicketAssigned = "ticket.assigned"
-	TicketNote     = "ticket.note"
-	TicketHandoff  = "ticket.handoff"
+	TicketCreated     = "ticket.created"
+	TicketAssigned    = "ticket.assigned"
+	TicketNote        = "ticket.note"
+	TicketHandoff     = "ticket.handoff"
+	TicketLinked      = "ticket.linked"
+	TicketUnlinked    = "ticket.unlinked"
+	TicketDepsChanged = "ticket.deps-changed"
+	TicketEdited      = "ticket.edited"
+	TicketUnassigned  = "ticket.unassigned"
+	TicketMoved       = "ticket.moved"
+	TicketMerged      = "ticket.merged"
 
 	StatusBacklog     = "status.backlog"
 	StatusOpen        = "status.open"
@@ -34,6 +41,19 @@ const (
 	HookRuleDecision  = "hook.rule-decision"
 	HookSessionEnd    = "hook.session-end"
 	HookUserPrompt    = "hook.user-prompt"
+
+	UsageRecorded = "usage.recorded"
+	TestRun       = "test.run"
+
+	VerifyPassed = "verify.passed"
+	VerifyFailed = "verify.failed"
+
+	KnowledgeAdded   = "knowledge.added"
+	KnowledgeRemoved = "knowledge.removed"
+
+	FilesOverlap = "files.overlap"
+
+	BulkApplied = "bulk.applied"
 )
 
 // Event represents a single event in the system log.
@@ -44,7 +64,7 @@ type Event struct {
 	Project string         `json:"project"`
 	Actor   string         `json:"actor"`
 	RunID   string         `json:"run_id"`
-	Source  string         `json:"source,omitempty"` // "claude", "opencode", or "pi"
+	Source  string         `json:"source,omitempty"` // "claude", "opencode", "pi", "codex", or "gemini"
 	Data    map[string]any `json:"data"`
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -16,6 +17,7 @@ type Query struct {
 	TicketID string
 	Project  string
 	RunID    string
+	Events   []string // event types to keep; empty keeps all
 	After    time.Time
 	Before   time.Time
 }
@@ -148,6 +150,9 @@ func matches(e Event, q Query) bool {
 	if q.RunID != "" && e.RunID != q.RunID {
 		return false
 	}
+	if len(q.Events) > 0 && !slices.Contains(q.Events, e.Event) {
+		return false
+	}
 	if !q.After.IsZero() && e.TS.Before(q.After) {
 		return false
 	}
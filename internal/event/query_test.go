@@ -112,6 +112,26 @@ func TestQueryByProject(t *testing.T) {
 	}
 }
 
+func TestQueryByEventType(t *testing.T) {
+	dir := t.TempDir()
+	setupTestEvents(t, dir)
+
+	events, err := QueryEvents(dir, Query{Events: []string{StatusInProgress, HookPostTool}})
+	if err != nil {
+		t.Fatalf("QueryEvents: %v", err)
+	}
+
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want 3", len(events))
+	}
+
+	for _, e := range events {
+		if e.Event != StatusInProgress && e.Event != HookPostTool {
+			t.Errorf("unexpected event %q", e.Event)
+		}
+	}
+}
+
 func TestQueryBySession(t *testing.T) {
 	dir := t.TempDir()
 	setupTestEvents(t, dir)
@@ -0,0 +1,259 @@
+// Package handoff builds "state of play" summaries for tickets changing
+// hands, so the next agent does not have to read the whole append-only body.
+package handoff
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/testrun"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/touched"
+)
+
+// Heading is the ticket section heading summaries are appended under.
+const Heading = "State of Play"
+
+// Summary reasons, recorded in the section's reason field.
+const (
+	ReasonRework  = "rework"
+	ReasonHandoff = "handoff"
+)
+
+// Caps keep the summary short enough to read at a glance.
+const (
+	maxNotes     = 10
+	maxNoteLen   = 200
+	maxCommits   = 20
+	maxQuestions = 10
+	maxFiles     = 30
+)
+
+// Input is what a summary is built from.
+type Input struct {
+	Ticket *ticket.Ticket
+	// Events are the ticket's events in chronological order.
+	Events []event.Event
+	// Commits are the ticket branch's commits, oldest first.
+	Commits []string
+	// Roots are directories (worktree, repo root) file paths are shown
+	// relative to.
+	Roots []string
+}
+
+// Summarize renders the state-of-play markdown for the ticket: notes since
+// the previous summary, commits on the ticket branch, outstanding reviewer
+// findings, open questions, files touched and the last test run.
+func Summarize(in Input) string {
+	sections := ticket.Sections(in.Ticket.Body)
+	findings, hasFindings := outstandingFindings(sections)
+	notes := recentNotes(sections, findings, hasFindings)
+
+	var b strings.Builder
+	b.WriteString("### Done so far\n")
+	if len(notes) == 0 {
+		b.WriteString("No notes since the last summary.\n")
+	}
+	for _, s := range notes {
+		fmt.Fprintf(&b, "- %s (%s): %s\n", s.TS.Format("2006-01-02 15:04"), s.Actor, firstParagraph(s.Content))
+	}
+
+	fmt.Fprintf(&b, "\n### Commits on st/%s\n", in.Ticket.ID)
+	if len(in.Commits) == 0 {
+		b.WriteString("None.\n")
+	}
+	commits := in.Commits
+	if len(commits) > maxCommits {
+		fmt.Fprintf(&b, "- … %d earlier\n", len(commits)-maxCommits)
+		commits = commits[len(commits)-maxCommits:]
+	}
+	for _, c := range commits {
+		fmt.Fprintf(&b, "- %s\n", c)
+	}
+
+	if hasFindings {
+		fmt.Fprintf(&b, "\n### Reviewer findings (%s, %s)\n%s\n", findings.Actor, findings.TS.Format("2006-01-02 15:04"), findings.Content)
+	}
+
+	var texts []string
+	for _, s := range notes {
+		texts = append(texts, s.Content)
+	}
+	if hasFindings {
+		texts = append(texts, findings.Content)
+	}
+	if questions := OpenQuestions(texts); len(questions) > 0 {
+		b.WriteString("\n### Open questions\n")
+		for _, q := range questions {
+			fmt.Fprintf(&b, "- %s\n", q)
+		}
+	}
+
+	if files := FilesTouched(in.Events, in.Roots); len(files) > 0 {
+		b.WriteString("\n### Files touched\n")
+		shown := files
+		if len(shown) > maxFiles {
+			shown = shown[:maxFiles]
+		}
+		for _, f := range shown {
+			fmt.Fprintf(&b, "- %s\n", f)
+		}
+		if len(files) > maxFiles {
+			fmt.Fprintf(&b, "- … and %d more\n", len(files)-maxFiles)
+		}
+	}
+
+	var last *testrun.Result
+	for _, ev := range in.Events {
+		if r, ok := testrun.FromEvent(ev); ok {
+			last = &r
+		}
+	}
+	if last != nil {
+		fmt.Fprintf(&b, "\nLast test run: %s at %s\n", last.Summary(), last.TS.Format("2006-01-02 15:04"))
+	}
+
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// Append adds a state-of-play section summarizing in to its ticket.
+func Append(in Input, reason, actor, runID string, ts time.Time) {
+	ticket.AppendSection(in.Ticket, Heading, actor, runID, Summarize(in), map[string]string{"reason": reason}, ts)
+}
+
+// outstandingFindings returns the latest review findings unless the ticket
+// has been resubmitted for review since.
+func outstandingFindings(sections []ticket.Section) (ticket.Section, bool) {
+	findings, ok := ticket.ReworkFindings(sections)
+	if !ok {
+		return ticket.Section{}, false
+	}
+	for i := len(sections) - 1; i >= 0; i-- {
+		switch sections[i].Heading {
+		case "Rework":
+			return findings, true
+		case "Review Requested", "Human Review Requested":
+			return ticket.Section{}, false
+		}
+	}
+	return ticket.Section{}, false
+}
+
+// recentNotes returns the notes written since the previous summary, minus
+// the reviewer findings, capped to the newest maxNotes.
+func recentNotes(sections []ticket.Section, findings ticket.Section, hasFindings bool) []ticket.Section {
+	start := 0
+	for i := len(sections) - 1; i >= 0; i-- {
+		if sections[i].Heading == Heading {
+			start = i + 1
+			break
+		}
+	}
+	var notes []ticket.Section
+	for _, s := range sections[start:] {
+		if s.Heading != "Note" || s.Content == "" {
+			continue
+		}
+		if hasFindings && s.TS.Equal(findings.TS) && s.Content == findings.Content {
+			continue
+		}
+		notes = append(notes, s)
+	}
+	if len(notes) > maxNotes {
+		notes = notes[len(notes)-maxNotes:]
+	}
+	return notes
+}
+
+// firstParagraph returns the first paragraph of s on one line, shortened to
+// maxNoteLen.
+func firstParagraph(s string) string {
+	if i := strings.Index(s, "\n\n"); i >= 0 {
+		s = s[:i]
+	}
+	s = strings.Join(strings.Fields(s), " ")
+	if len(s) > maxNoteLen {
+		cut := maxNoteLen
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		s = s[:cut] + "…"
+	}
+	return s
+}
+
+// OpenQuestions extracts question lines from texts: lines ending in "?" or
+// starting with TODO, Q: or "Open question".
+func OpenQuestions(texts []string) []string {
+	var questions []string
+	seen := map[string]bool{}
+	for _, text := range texts {
+		for _, line := range strings.Split(text, "\n") {
+			line = strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "-*>"))
+			if line == "" || seen[line] || !isQuestion(line) {
+				continue
+			}
+			seen[line] = true
+			questions = append(questions, line)
+			if len(questions) == maxQuestions {
+				return questions
+			}
+		}
+	}
+	return questions
+}
+
+func isQuestion(line string) bool {
+	lower := strings.ToLower(line)
+	return strings.HasSuffix(line, "?") ||
+		strings.HasPrefix(lower, "todo") ||
+		strings.HasPrefix(lower, "q:") ||
+		strings.HasPrefix(lower, "open question")
+}
+
+// FilesTouched returns the files named in the events' hook.pre-tool
+// file_path data, relative to the first matching root. Edited files come
+// first; files that were only read are marked "(read only)".
+func FilesTouched(events []event.Event, roots []string) []string {
+	edited := map[string]bool{}
+	for _, ev := range events {
+		if ev.Event != event.HookPreTool {
+			continue
+		}
+		path, _ := ev.Data["file_path"].(string)
+		if path == "" {
+			continue
+		}
+		path = relativeTo(path, roots)
+		tool, _ := ev.Data["tool"].(string)
+		edited[path] = edited[path] || touched.IsEdit(tool)
+	}
+
+	var mod, read []string
+	for path, e := range edited {
+		if e {
+			mod = append(mod, path)
+		} else {
+			read = append(read, path+" (read only)")
+		}
+	}
+	sort.Strings(mod)
+	sort.Strings(read)
+	return append(mod, read...)
+}
+
+func relativeTo(path string, roots []string) string {
+	for _, root := range roots {
+		if root == "" {
+			continue
+		}
+		if rel, err := filepath.Rel(root, path); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
@@ -0,0 +1,96 @@
+package handoff
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/testrun"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestSummarize(t *testing.T) {
+	ts := time.Date(2026, 2, 25, 10, 0, 0, 0, time.UTC)
+	tk := &ticket.Ticket{ID: "st_hand01"}
+	ticket.AppendSection(tk, "Created", "human", "", "Add rate limiting.", nil, ts)
+	ticket.AppendSection(tk, "In Progress", "agent", "run-1", "", nil, ts.Add(time.Minute))
+	ticket.AppendSection(tk, "Note", "agent", "run-1", "Implemented the limiter.\n\nDetails follow.", nil, ts.Add(2*time.Minute))
+	ticket.AppendSection(tk, "Review Requested", "agent", "run-1", "", nil, ts.Add(3*time.Minute))
+	ticket.AppendSection(tk, "Note", "reviewer", "run-2", "No test for bursts.\n- Should the window be configurable?", nil, ts.Add(4*time.Minute))
+	ticket.AppendSection(tk, "Rework", "reviewer", "run-2", "", nil, ts.Add(5*time.Minute))
+
+	run := testrun.Result{Runner: testrun.RunnerGo, Status: testrun.StatusFail, Passed: 3, Failed: 1}
+	events := []event.Event{
+		{Event: event.HookPreTool, Ticket: tk.ID, Data: map[string]any{"tool": "Edit", "file_path": "/repo/.worktrees/st_hand01/limit.go"}},
+		{Event: event.HookPreTool, Ticket: tk.ID, Data: map[string]any{"tool": "Read", "file_path": "/repo/router.go"}},
+		{Event: event.HookPreTool, Ticket: tk.ID, Data: map[string]any{"tool": "Read", "file_path": "/repo/.worktrees/st_hand01/limit.go"}},
+		{TS: ts.Add(3 * time.Minute), Event: event.TestRun, Ticket: tk.ID, Data: run.Data(nil)},
+	}
+
+	got := Summarize(Input{
+		Ticket:  tk,
+		Events:  events,
+		Commits: []string{"abc1234 Add limiter"},
+		Roots:   []string{"/repo/.worktrees/st_hand01", "/repo"},
+	})
+
+	for _, want := range []string{
+		"### Done so far\n- 2026-02-25 10:02 (agent): Implemented the limiter.\n",
+		"### Commits on st/st_hand01\n- abc1234 Add limiter\n",
+		"### Reviewer findings (reviewer, 2026-02-25 10:04)\nNo test for bursts.",
+		"### Open questions\n- Should the window be configurable?\n",
+		"### Files touched\n- limit.go\n- router.go (read only)\n",
+		"Last test run: FAIL",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("summary missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "Details follow") {
+		t.Error("notes should be cut to their first paragraph")
+	}
+	if strings.Contains(got, "(reviewer, 2026-02-25 10:04): No test") {
+		t.Error("the findings note should not be repeated under Done so far")
+	}
+}
+
+func TestSummarizeSinceLastSummary(t *testing.T) {
+	ts := time.Date(2026, 2, 25, 10, 0, 0, 0, time.UTC)
+	tk := &ticket.Ticket{ID: "st_hand02"}
+	ticket.AppendSection(tk, "Note", "agent", "run-1", "First attempt.", nil, ts)
+	Append(Input{Ticket: tk}, ReasonHandoff, "agent", "run-1", ts.Add(time.Minute))
+
+	got := Summarize(Input{Ticket: tk})
+	if !strings.Contains(got, "No notes since the last summary.") || !strings.Contains(got, "### Commits on st/st_hand02\nNone.") {
+		t.Errorf("summary = %q", got)
+	}
+
+	s, ok := ticket.LastSection(ticket.Sections(tk.Body), Heading)
+	if !ok || s.Fields["reason"] != ReasonHandoff || !strings.Contains(s.Content, "First attempt.") {
+		t.Errorf("appended section = %+v, ok = %v", s, ok)
+	}
+}
+
+func TestSummarizeResolvedFindings(t *testing.T) {
+	ts := time.Date(2026, 2, 25, 10, 0, 0, 0, time.UTC)
+	tk := &ticket.Ticket{ID: "st_hand03"}
+	ticket.AppendSection(tk, "Note", "reviewer", "run-2", "Fix the typo.", nil, ts)
+	ticket.AppendSection(tk, "Rework", "reviewer", "run-2", "", nil, ts.Add(time.Minute))
+	ticket.AppendSection(tk, "Review Requested", "agent", "run-3", "", nil, ts.Add(2*time.Minute))
+
+	if got := Summarize(Input{Ticket: tk}); strings.Contains(got, "Reviewer findings") {
+		t.Errorf("findings addressed by a resubmission should be dropped:\n%s", got)
+	}
+}
+
+func TestOpenQuestions(t *testing.T) {
+	got := OpenQuestions([]string{
+		"Done.\n- Is the cache needed?\n* TODO: drop the shim\nQ: which timeout\nnot a question",
+		"Is the cache needed?",
+	})
+	want := []string{"Is the cache needed?", "TODO: drop the shim", "Q: which timeout"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("OpenQuestions() = %q, want %q", got, want)
+	}
+}
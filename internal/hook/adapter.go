@@ -0,0 +1,165 @@
+package hook
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Canonical hook events dispatched to the handlers. Adapters translate each
+// agent CLI's native events into these.
+const (
+	EventSessionStart      = "session-start"
+	EventPreTool           = "pre-tool"
+	EventPostTool          = "post-tool"
+	EventSubagentStart     = "subagent-start"
+	EventSubagentStop      = "subagent-stop"
+	EventTaskCompleted     = "task-completed"
+	EventTeammateIdle      = "teammate-idle"
+	EventPermissionRequest = "permission-request"
+	EventStop              = "stop"
+	EventSessionEnd        = "session-end"
+	EventUserPrompt        = "user-prompt"
+)
+
+// Adapter translates between an agent CLI's native hook protocol and the
+// smoovtask hook handlers, and installs the hooks or bridge assets that make
+// the CLI call `st hook`.
+type Adapter interface {
+	// Name returns the agent identifier (e.g. "claude"), also recorded as the
+	// event source.
+	Name() string
+
+	// Accepts reports whether nativeEvent (the `st hook <event-type>`
+	// argument) belongs to this adapter.
+	Accepts(nativeEvent string) bool
+
+	// Decode parses a native event payload into the canonical events to run,
+	// in order, and their shared input. No events means the payload is ignored.
+	Decode(nativeEvent string, payload []byte) ([]string, *Input, error)
+
+	// Encode writes the handler output for a canonical event as the CLI's
+	// native response. An error is reported by `st hook` as a failed hook.
+	Encode(w io.Writer, event string, out Output) error
+
+	// Install writes the CLI's hook configuration or bridge plugin under home.
+	Install(home string, w io.Writer) error
+
+	// Uninstall removes what Install added, leaving unrelated settings intact.
+	Uninstall(home string, w io.Writer) error
+}
+
+// registry lists the supported agent CLIs. To add one, implement Adapter and
+// append it here.
+var registry = []Adapter{
+	&ClaudeAdapter{},
+	&OpencodeAdapter{},
+	&PiAdapter{},
+	&CodexAdapter{},
+	&GeminiAdapter{},
+}
+
+// Adapters returns all registered adapters.
+func Adapters() []Adapter {
+	return append([]Adapter(nil), registry...)
+}
+
+// AdapterNames returns the names of all registered adapters.
+func AdapterNames() []string {
+	names := make([]string, len(registry))
+	for i, a := range registry {
+		names[i] = a.Name()
+	}
+	return names
+}
+
+// GetAdapter returns an adapter by name.
+func GetAdapter(name string) (Adapter, error) {
+	for _, a := range registry {
+		if a.Name() == name {
+			return a, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown agent %q (available: %s)", name, strings.Join(AdapterNames(), ", "))
+}
+
+// AdapterForEvent returns the adapter that accepts nativeEvent.
+func AdapterForEvent(nativeEvent string) (Adapter, bool) {
+	for _, a := range registry {
+		if a.Accepts(nativeEvent) {
+			return a, true
+		}
+	}
+	return nil, false
+}
+
+// Dispatch runs the handler for a canonical event.
+func Dispatch(event string, input *Input) (Output, error) {
+	switch event {
+	case EventSessionStart:
+		return deref(HandleSessionStart(input))
+	case EventPreTool:
+		return HandlePreTool(input)
+	case EventPostTool:
+		return Output{}, HandlePostTool(input)
+	case EventSubagentStart:
+		return HandleSubagentStart(input)
+	case EventSubagentStop:
+		return Output{}, HandleSubagentStop(input)
+	case EventTaskCompleted:
+		return Output{}, HandleTaskCompleted(input)
+	case EventTeammateIdle:
+		return Output{}, HandleTeammateIdle(input)
+	case EventPermissionRequest:
+		return HandlePermissionRequest(input)
+	case EventStop:
+		return HandleStop(input)
+	case EventSessionEnd:
+		return Output{}, HandleSessionEnd(input)
+	case EventUserPrompt:
+		return deref(HandleUserPrompt(input))
+	default:
+		return Output{}, nil
+	}
+}
+
+func deref(out *Output, err error) (Output, error) {
+	if out == nil {
+		return Output{}, err
+	}
+	return *out, err
+}
+
+// encodeJSON writes out as JSON for bridge plugins when the event produced
+// something the plugin acts on. Session start always answers, so the plugin
+// can cache an empty context.
+func encodeJSON(w io.Writer, event string, out Output) error {
+	switch event {
+	case EventSessionStart:
+		return WriteOutputTo(w, out)
+	case EventPreTool:
+		if out.AdditionalContext != "" || out.Decision != nil {
+			return WriteOutputTo(w, out)
+		}
+	case EventPermissionRequest:
+		if out.Decision != nil {
+			return WriteOutputTo(w, out)
+		}
+	case EventSubagentStart, EventUserPrompt:
+		if out.AdditionalContext != "" {
+			return WriteOutputTo(w, out)
+		}
+	}
+	return nil
+}
+
+// bridgeInput builds an Input from the normalized payload bridge plugins send.
+func bridgeInput(source string, event map[string]any) *Input {
+	input := &Input{Source: source, Raw: event}
+	input.SessionID, _ = event["session_id"].(string)
+	input.CWD, _ = event["cwd"].(string)
+	input.ToolName, _ = event["tool_name"].(string)
+	input.ToolInput, _ = event["tool_input"].(map[string]any)
+	input.TaskPrompt, _ = event["task_prompt"].(string)
+	return input
+}
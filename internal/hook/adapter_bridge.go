@@ -0,0 +1,63 @@
+package hook
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// bridges holds the TypeScript plugins that forward OpenCode and PI
+// lifecycle events to `st hook`.
+//
+//go:embed bridges/*.ts
+var bridges embed.FS
+
+// BridgeSource returns the embedded bridge plugin source for an agent.
+func BridgeSource(agent string) (string, error) {
+	data, err := bridges.ReadFile("bridges/" + agent + ".ts")
+	if err != nil {
+		return "", fmt.Errorf("read %s bridge: %w", agent, err)
+	}
+	return string(data), nil
+}
+
+// bridgeEvent is the decoded payload a bridge plugin sends; nil when the
+// payload is not valid JSON (bridges skip invalid events silently).
+func bridgeEvent(payload []byte) map[string]any {
+	var event map[string]any
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil
+	}
+	return event
+}
+
+// installBridge writes the embedded bridge for agent to path.
+func installBridge(agent, path, label string, w io.Writer) error {
+	src, err := BridgeSource(agent)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create %s dir: %w", label, err)
+	}
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		return fmt.Errorf("write %s file: %w", label, err)
+	}
+	_, _ = fmt.Fprintf(w, "Installed %s %s: %s\n", agent, label, path)
+	return nil
+}
+
+// uninstallBridge removes the bridge file at path.
+func uninstallBridge(agent, path, label string, w io.Writer) error {
+	if err := os.Remove(path); os.IsNotExist(err) {
+		_, _ = fmt.Fprintf(w, "No %s %s found, nothing to uninstall.\n", agent, label)
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("remove %s: %w", label, err)
+	}
+	_, _ = fmt.Fprintf(w, "Removed %s %s: %s\n", agent, label, path)
+	return nil
+}
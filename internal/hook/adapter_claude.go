@@ -0,0 +1,173 @@
+package hook
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ClaudeAdapter speaks Claude Code's hook protocol: one `st hook <event>`
+// command per hook event, configured in ~/.claude/settings.json.
+type ClaudeAdapter struct{}
+
+func (a *ClaudeAdapter) Name() string { return "claude" }
+
+// claudeEvents maps `st hook` arguments to canonical events.
+var claudeEvents = map[string]string{
+	"session-start":      EventSessionStart,
+	"pre-tool":           EventPreTool,
+	"post-tool":          EventPostTool,
+	"subagent-start":     EventSubagentStart,
+	"subagent-stop":      EventSubagentStop,
+	"task-completed":     EventTaskCompleted,
+	"teammate-idle":      EventTeammateIdle,
+	"permission-request": EventPermissionRequest,
+	"stop":               EventStop,
+	"session-end":        EventSessionEnd,
+	"user-prompt-submit": EventUserPrompt,
+}
+
+func (a *ClaudeAdapter) Accepts(nativeEvent string) bool {
+	_, ok := claudeEvents[nativeEvent]
+	return ok
+}
+
+func (a *ClaudeAdapter) Decode(nativeEvent string, payload []byte) ([]string, *Input, error) {
+	event, ok := claudeEvents[nativeEvent]
+	if !ok {
+		return nil, nil, nil
+	}
+	input, err := ReadInputFrom(bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read hook input: %w", err)
+	}
+	input.Source = a.source()
+	return []string{event}, input, nil
+}
+
+// source is "claude", or "opencode" when the Claude-style commands are run
+// by a legacy OpenCode plugin (OPENCODE_HOOK=1), which expects JSON output.
+func (a *ClaudeAdapter) source() string {
+	if os.Getenv("OPENCODE_HOOK") == "1" {
+		return "opencode"
+	}
+	return "claude"
+}
+
+func (a *ClaudeAdapter) Encode(w io.Writer, event string, out Output) error {
+	if a.source() != "claude" {
+		return encodeJSON(w, event, out)
+	}
+
+	switch event {
+	case EventSessionStart, EventUserPrompt:
+		// Plain stdout is added to the session context.
+		_, err := io.WriteString(w, out.AdditionalContext)
+		return err
+	case EventPreTool:
+		if out.Decision != nil && out.Decision.Behavior == "deny" {
+			if out.Decision.Reason != "" {
+				return fmt.Errorf("%s", out.Decision.Reason)
+			}
+			return fmt.Errorf("tool call blocked by smoovtask")
+		}
+		if out.Decision != nil || out.AdditionalContext != "" {
+			return WriteOutputTo(w, out)
+		}
+	case EventPermissionRequest:
+		if out.Decision != nil {
+			return WriteOutputTo(w, out)
+		}
+	case EventSubagentStart:
+		if out.AdditionalContext != "" {
+			return WriteOutputTo(w, out)
+		}
+	case EventStop:
+		if out.StopDecision != "" {
+			return WriteOutputTo(w, out)
+		}
+	}
+	return nil
+}
+
+// claudeHooks returns the hook config that st needs installed.
+func claudeHooks() map[string][]hookGroup {
+	return map[string][]hookGroup{
+		"SessionStart": {
+			{
+				Matcher: "startup|resume|clear|compact",
+				Hooks:   []hookEntry{{Type: "command", Command: "st hook session-start"}},
+			},
+		},
+		"PreToolUse": {
+			{
+				Matcher: "*",
+				Hooks:   []hookEntry{{Type: "command", Command: "st hook pre-tool"}},
+			},
+		},
+		"PostToolUse": {
+			{
+				Matcher: "*",
+				Hooks:   []hookEntry{{Type: "command", Command: "st hook post-tool", Async: true}},
+			},
+		},
+		"SubagentStart": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook subagent-start"}},
+			},
+		},
+		"SubagentStop": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook subagent-stop", Async: true}},
+			},
+		},
+		"TaskCompleted": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook task-completed", Async: true}},
+			},
+		},
+		"TeammateIdle": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook teammate-idle", Async: true}},
+			},
+		},
+		"Stop": {
+			{
+				// Synchronous: the stop hook may block an unfinished session.
+				Hooks: []hookEntry{{Type: "command", Command: "st hook stop"}},
+			},
+		},
+		"PermissionRequest": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook permission-request"}},
+			},
+		},
+		"SessionEnd": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook session-end", Async: true}},
+			},
+		},
+		"UserPromptSubmit": {
+			{
+				Hooks: []hookEntry{{Type: "command", Command: "st hook user-prompt-submit", Async: true}},
+			},
+		},
+	}
+}
+
+func claudeSettingsPath(home string) string {
+	return filepath.Join(home, ".claude", "settings.json")
+}
+
+// Install merges the smoovtask hooks into ~/.claude/settings.json, keeping
+// existing settings and hooks.
+func (a *ClaudeAdapter) Install(home string, w io.Writer) error {
+	return installSettingsHooks(claudeSettingsPath(home), "Claude", claudeHooks(), w)
+}
+
+// Uninstall removes all smoovtask hooks from Claude Code settings.
+func (a *ClaudeAdapter) Uninstall(home string, w io.Writer) error {
+	return uninstallSettingsHooks(claudeSettingsPath(home), "Claude", w)
+}
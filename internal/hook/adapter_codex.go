@@ -0,0 +1,129 @@
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CodexAdapter handles Codex CLI's notify program, which runs
+// `st hook codex-event <json>` after every agent turn. Codex has no blocking
+// hooks, so it only feeds activity tracking and nothing is written back.
+type CodexAdapter struct{}
+
+func (a *CodexAdapter) Name() string { return "codex" }
+
+func (a *CodexAdapter) Accepts(nativeEvent string) bool {
+	return nativeEvent == "codex-event"
+}
+
+// codexEvents maps Codex notification types to canonical events. A finished
+// turn leaves the agent waiting for the user.
+var codexEvents = map[string]string{
+	"agent-turn-complete": EventTeammateIdle,
+}
+
+func (a *CodexAdapter) Decode(_ string, payload []byte) ([]string, *Input, error) {
+	var notification map[string]any
+	if err := json.Unmarshal(payload, &notification); err != nil {
+		return nil, nil, nil
+	}
+	input := &Input{Source: a.Name(), Raw: notification}
+	input.SessionID, _ = notification["thread-id"].(string)
+	input.CWD, _ = notification["cwd"].(string)
+
+	kind, _ := notification["type"].(string)
+	event, ok := codexEvents[kind]
+	if !ok {
+		return nil, input, nil
+	}
+	return []string{event}, input, nil
+}
+
+func (a *CodexAdapter) Encode(io.Writer, string, Output) error {
+	return nil
+}
+
+// codexNotify is the top-level config.toml line that points notify at st.
+const codexNotify = `notify = ["st", "hook", "codex-event"]`
+
+func codexConfigPath(home string) string {
+	return filepath.Join(home, ".codex", "config.toml")
+}
+
+// codexNotifyLine returns the index of the top-level notify line, or -1.
+func codexNotifyLine(lines []string) int {
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			return -1
+		}
+		if key, _, ok := strings.Cut(trimmed, "="); ok && strings.TrimSpace(key) == "notify" {
+			return i
+		}
+	}
+	return -1
+}
+
+// Install sets notify in ~/.codex/config.toml. The file is edited line by
+// line to keep comments; an existing notify program is left alone.
+func (a *CodexAdapter) Install(home string, w io.Writer) error {
+	path := codexConfigPath(home)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	lines := strings.Split(string(data), "\n")
+
+	if i := codexNotifyLine(lines); i >= 0 {
+		if strings.Contains(lines[i], "codex-event") {
+			_, _ = fmt.Fprintln(w, "Codex notify already installed, no changes needed.")
+			return nil
+		}
+		_, _ = fmt.Fprintf(w, "Codex already has a notify program in %s (%s); leaving it. Have it also run `st hook codex-event <json>` to track Codex sessions.\n",
+			path, strings.TrimSpace(lines[i]))
+		return nil
+	}
+
+	// Top-level keys must precede the first table.
+	content := codexNotify + "\n"
+	if len(data) > 0 {
+		content += string(data)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create codex config dir: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	_, _ = fmt.Fprintf(w, "Installed codex notify: %s\n", path)
+	return nil
+}
+
+// Uninstall removes the st notify line from ~/.codex/config.toml.
+func (a *CodexAdapter) Uninstall(home string, w io.Writer) error {
+	path := codexConfigPath(home)
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		_, _ = fmt.Fprintln(w, "No codex config found, nothing to uninstall.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	lines := strings.Split(string(data), "\n")
+	i := codexNotifyLine(lines)
+	if i < 0 || !strings.Contains(lines[i], "codex-event") {
+		_, _ = fmt.Fprintln(w, "No smoovtask notify found in codex config.")
+		return nil
+	}
+	lines = append(lines[:i], lines[i+1:]...)
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	_, _ = fmt.Fprintf(w, "Removed codex notify: %s\n", path)
+	return nil
+}
@@ -0,0 +1,138 @@
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+)
+
+// GeminiAdapter speaks Gemini CLI's hook protocol. Every hook in
+// ~/.gemini/settings.json runs `st hook gemini-event`; the event is named by
+// hook_event_name in the stdin payload.
+type GeminiAdapter struct{}
+
+func (a *GeminiAdapter) Name() string { return "gemini" }
+
+func (a *GeminiAdapter) Accepts(nativeEvent string) bool {
+	return nativeEvent == "gemini-event"
+}
+
+// geminiEvents maps Gemini hook events to canonical events. AfterAgent runs
+// when the agent finishes its turn, like Claude Code's Stop.
+var geminiEvents = map[string]string{
+	"SessionStart": EventSessionStart,
+	"BeforeTool":   EventPreTool,
+	"AfterTool":    EventPostTool,
+	"BeforeAgent":  EventUserPrompt,
+	"AfterAgent":   EventStop,
+	"SessionEnd":   EventSessionEnd,
+}
+
+// geminiTools maps Gemini built-in tools to the Claude Code tool names the
+// handlers and rules understand.
+var geminiTools = map[string]string{
+	"run_shell_command":   "Bash",
+	"write_file":          "Write",
+	"replace":             "Edit",
+	"read_file":           "Read",
+	"glob":                "Glob",
+	"search_file_content": "Grep",
+	"web_fetch":           "WebFetch",
+	"google_web_search":   "WebSearch",
+}
+
+func (a *GeminiAdapter) Decode(_ string, payload []byte) ([]string, *Input, error) {
+	input, err := ReadInputFrom(bytes.NewReader(payload))
+	if err != nil {
+		return nil, nil, fmt.Errorf("read hook input: %w", err)
+	}
+	event, ok := geminiEvents[input.HookEventName]
+	if !ok {
+		return nil, input, nil
+	}
+	input.Source = a.Name()
+	// Gemini transcripts are not Claude Code JSONL; skip usage ingestion.
+	input.TranscriptPath = ""
+	if name, ok := geminiTools[input.ToolName]; ok {
+		input.ToolName = name
+	}
+	if path, ok := input.ToolInput["absolute_path"]; ok {
+		if _, exists := input.ToolInput["file_path"]; !exists {
+			input.ToolInput["file_path"] = path
+		}
+	}
+	return []string{event}, input, nil
+}
+
+// geminiOutput is Gemini CLI's hook response.
+type geminiOutput struct {
+	Decision           string            `json:"decision,omitempty"`
+	Reason             string            `json:"reason,omitempty"`
+	HookSpecificOutput *geminiHookOutput `json:"hookSpecificOutput,omitempty"`
+}
+
+type geminiHookOutput struct {
+	HookEventName     string `json:"hookEventName"`
+	AdditionalContext string `json:"additionalContext,omitempty"`
+}
+
+func (a *GeminiAdapter) Encode(w io.Writer, event string, out Output) error {
+	var resp geminiOutput
+	switch event {
+	case EventSessionStart, EventUserPrompt:
+		if out.AdditionalContext == "" {
+			return nil
+		}
+		native := "SessionStart"
+		if event == EventUserPrompt {
+			native = "BeforeAgent"
+		}
+		resp.HookSpecificOutput = &geminiHookOutput{HookEventName: native, AdditionalContext: out.AdditionalContext}
+	case EventPreTool:
+		// Gemini has no "ask"; anything but deny or allow defers to its own
+		// confirmation prompt.
+		if out.Decision == nil || (out.Decision.Behavior != "deny" && out.Decision.Behavior != "allow") {
+			return nil
+		}
+		resp.Decision = out.Decision.Behavior
+		resp.Reason = out.Decision.Reason
+	case EventStop:
+		if out.StopDecision == "" {
+			return nil
+		}
+		// A denied AfterAgent sends the reason back to the agent as a new turn.
+		resp.Decision = "deny"
+		resp.Reason = out.Reason
+	default:
+		return nil
+	}
+	return json.NewEncoder(w).Encode(resp)
+}
+
+// geminiHooks returns the hook config that st needs installed.
+func geminiHooks() map[string][]hookGroup {
+	hooks := make(map[string][]hookGroup, len(geminiEvents))
+	for native := range geminiEvents {
+		hooks[native] = []hookGroup{{
+			Hooks: []hookEntry{{Type: "command", Command: "st hook gemini-event"}},
+		}}
+	}
+	return hooks
+}
+
+func geminiSettingsPath(home string) string {
+	return filepath.Join(home, ".gemini", "settings.json")
+}
+
+// Install merges the smoovtask hooks into ~/.gemini/settings.json, keeping
+// existing settings and hooks.
+func (a *GeminiAdapter) Install(home string, w io.Writer) error {
+	return installSettingsHooks(geminiSettingsPath(home), "Gemini", geminiHooks(), w)
+}
+
+// Uninstall removes all smoovtask hooks from Gemini CLI settings.
+func (a *GeminiAdapter) Uninstall(home string, w io.Writer) error {
+	return uninstallSettingsHooks(geminiSettingsPath(home), "Gemini", w)
+}
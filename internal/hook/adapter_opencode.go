@@ -0,0 +1,59 @@
+package hook
+
+import (
+	"io"
+	"path/filepath"
+)
+
+// OpencodeAdapter speaks the protocol of the OpenCode bridge plugin, which
+// sends normalized events to `st hook opencode-event`.
+type OpencodeAdapter struct{}
+
+func (a *OpencodeAdapter) Name() string { return "opencode" }
+
+func (a *OpencodeAdapter) Accepts(nativeEvent string) bool {
+	return nativeEvent == "opencode-event"
+}
+
+// opencodeEvents maps bridge event types to canonical events.
+var opencodeEvents = map[string]string{
+	"session.created":     EventSessionStart,
+	"tool.execute.before": EventPreTool,
+	"tool.execute.after":  EventPostTool,
+	"stop":                EventStop,
+	"session.idle":        EventTeammateIdle,
+	"permission.asked":    EventPermissionRequest,
+	"session.deleted":     EventSessionEnd,
+}
+
+func (a *OpencodeAdapter) Decode(_ string, payload []byte) ([]string, *Input, error) {
+	event := bridgeEvent(payload)
+	if event == nil {
+		return nil, nil, nil
+	}
+	input := bridgeInput(a.Name(), event)
+	eventType, _ := event["type"].(string)
+	canonical, ok := opencodeEvents[eventType]
+	if !ok || (canonical == EventSessionStart && input.SessionID == "") {
+		return nil, input, nil
+	}
+	return []string{canonical}, input, nil
+}
+
+func (a *OpencodeAdapter) Encode(w io.Writer, event string, out Output) error {
+	return encodeJSON(w, event, out)
+}
+
+// opencodePluginPath is where OpenCode auto-loads plugins from at startup,
+// so no config entry is needed.
+func opencodePluginPath(home string) string {
+	return filepath.Join(home, ".config", "opencode", "plugins", "smoovtask-hooks.ts")
+}
+
+func (a *OpencodeAdapter) Install(home string, w io.Writer) error {
+	return installBridge(a.Name(), opencodePluginPath(home), "plugin", w)
+}
+
+func (a *OpencodeAdapter) Uninstall(home string, w io.Writer) error {
+	return uninstallBridge(a.Name(), opencodePluginPath(home), "plugin", w)
+}
@@ -0,0 +1,60 @@
+package hook
+
+import (
+	"io"
+	"path/filepath"
+)
+
+// PiAdapter speaks the protocol of the PI bridge extension, which sends
+// normalized events to `st hook pi-event`.
+type PiAdapter struct{}
+
+func (a *PiAdapter) Name() string { return "pi" }
+
+func (a *PiAdapter) Accepts(nativeEvent string) bool {
+	return nativeEvent == "pi-event"
+}
+
+// piEvents maps bridge event types to canonical events.
+var piEvents = map[string][]string{
+	"session_start":      {EventSessionStart},
+	"tool_call":          {EventPreTool},
+	"tool_result":        {EventPostTool},
+	"tool_execution_end": {EventPostTool},
+	"permission_request": {EventPermissionRequest},
+	"agent_end":          {EventTaskCompleted},
+	"task_completed":     {EventTaskCompleted},
+	"teammate_idle":      {EventTeammateIdle},
+	"turn_end":           {EventTeammateIdle},
+	"subagent_start":     {EventSubagentStart},
+	"subagent_stop":      {EventSubagentStop},
+	"session_shutdown":   {EventStop, EventSessionEnd},
+	"stop":               {EventStop},
+	"session_end":        {EventSessionEnd},
+}
+
+func (a *PiAdapter) Decode(_ string, payload []byte) ([]string, *Input, error) {
+	event := bridgeEvent(payload)
+	if event == nil {
+		return nil, nil, nil
+	}
+	eventType, _ := event["type"].(string)
+	return piEvents[eventType], bridgeInput(a.Name(), event), nil
+}
+
+func (a *PiAdapter) Encode(w io.Writer, event string, out Output) error {
+	return encodeJSON(w, event, out)
+}
+
+// piExtensionPath is where PI auto-discovers extensions.
+func piExtensionPath(home string) string {
+	return filepath.Join(home, ".pi", "agent", "extensions", "smoovtask-hooks.ts")
+}
+
+func (a *PiAdapter) Install(home string, w io.Writer) error {
+	return installBridge(a.Name(), piExtensionPath(home), "extension", w)
+}
+
+func (a *PiAdapter) Uninstall(home string, w io.Writer) error {
+	return uninstallBridge(a.Name(), piExtensionPath(home), "extension", w)
+}
@@ -0,0 +1,284 @@
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Agent CLIs with a JSON settings file (Claude Code, Gemini CLI) configure
+// hooks as event name -> groups of {matcher, hooks: [{type, command}]}.
+
+// hookEntry represents a single hook command entry.
+type hookEntry struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
+	Async   bool   `json:"async,omitempty"`
+}
+
+// hookGroup represents a group of hooks with an optional matcher.
+type hookGroup struct {
+	Matcher string      `json:"matcher,omitempty"`
+	Hooks   []hookEntry `json:"hooks"`
+}
+
+// installSettingsHooks merges the wanted st hooks into a JSON settings
+// file, keeping existing settings and hooks. label names the agent in output.
+func installSettingsHooks(settingsPath, label string, wanted map[string][]hookGroup, w io.Writer) error {
+
+	// Read existing settings (or start fresh)
+	settings := make(map[string]any)
+	data, err := os.ReadFile(settingsPath)
+	if err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("parse %s: %w", settingsPath, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("read %s: %w", settingsPath, err)
+	}
+
+	// Get or create the hooks map
+	existingHooks, _ := settings["hooks"].(map[string]any)
+	if existingHooks == nil {
+		existingHooks = make(map[string]any)
+	}
+
+	var installed, updated, skipped []string
+
+	for eventName, groups := range wanted {
+		if hasSmoovtaskHook(existingHooks, eventName) {
+			if syncSmoovtaskHookAsync(existingHooks, eventName, groups) {
+				updated = append(updated, eventName)
+			} else {
+				skipped = append(skipped, eventName)
+			}
+			continue
+		}
+
+		// Convert groups to the right type for JSON
+		var groupSlice []any
+		for _, g := range groups {
+			groupSlice = append(groupSlice, marshalHookGroup(g))
+		}
+
+		// Merge with any existing hooks for this event
+		existing, _ := existingHooks[eventName].([]any)
+		existingHooks[eventName] = append(existing, groupSlice...)
+		installed = append(installed, eventName)
+	}
+
+	// Only write settings back if there are changes
+	if len(installed) == 0 && len(updated) == 0 {
+		_, _ = fmt.Fprintln(w, label+" hooks already installed, no changes needed.")
+		return nil
+	}
+
+	settings["hooks"] = existingHooks
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0o755); err != nil {
+		return fmt.Errorf("create settings dir: %w", err)
+	}
+	out, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal settings: %w", err)
+	}
+	if err := os.WriteFile(settingsPath, append(out, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", settingsPath, err)
+	}
+
+	if len(installed) > 0 {
+		_, _ = fmt.Fprintf(w, "Installed %d %s hook(s):\n", len(installed), label)
+		for _, name := range installed {
+			_, _ = fmt.Fprintf(w, "  + %s\n", name)
+		}
+	}
+	if len(updated) > 0 {
+		_, _ = fmt.Fprintf(w, "Updated %d %s hook(s):\n", len(updated), label)
+		for _, name := range updated {
+			_, _ = fmt.Fprintf(w, "  ~ %s\n", name)
+		}
+	}
+	if len(skipped) > 0 {
+		_, _ = fmt.Fprintf(w, "Already installed (%d %s hook(s)):\n", len(skipped), label)
+		for _, name := range skipped {
+			_, _ = fmt.Fprintf(w, "  = %s\n", name)
+		}
+	}
+	_, _ = fmt.Fprintf(w, "\nSettings: %s\n", settingsPath)
+	return nil
+}
+
+// uninstallSettingsHooks removes all st hook commands from a settings file.
+func uninstallSettingsHooks(settingsPath, label string, w io.Writer) error {
+
+	data, err := os.ReadFile(settingsPath)
+	if os.IsNotExist(err) {
+		_, _ = fmt.Fprintln(w, "No "+label+" settings found, nothing to uninstall.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read %s: %w", settingsPath, err)
+	}
+
+	settings := make(map[string]any)
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return fmt.Errorf("parse %s: %w", settingsPath, err)
+	}
+
+	existingHooks, _ := settings["hooks"].(map[string]any)
+	if existingHooks == nil {
+		_, _ = fmt.Fprintln(w, "No "+label+" hooks found, nothing to uninstall.")
+		return nil
+	}
+
+	var removed []string
+	for eventName := range existingHooks {
+		groups, ok := existingHooks[eventName].([]any)
+		if !ok {
+			continue
+		}
+
+		var kept []any
+		for _, g := range groups {
+			if !isSmoovtaskGroup(g) {
+				kept = append(kept, g)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(existingHooks, eventName)
+			removed = append(removed, eventName)
+		} else if len(kept) < len(groups) {
+			existingHooks[eventName] = kept
+			removed = append(removed, eventName)
+		}
+	}
+
+	if len(removed) == 0 {
+		_, _ = fmt.Fprintln(w, "No smoovtask hooks found in "+label+" settings.")
+		return nil
+	}
+
+	if len(existingHooks) == 0 {
+		delete(settings, "hooks")
+	} else {
+		settings["hooks"] = existingHooks
+	}
+
+	out, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal settings: %w", err)
+	}
+	if err := os.WriteFile(settingsPath, append(out, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", settingsPath, err)
+	}
+
+	_, _ = fmt.Fprintf(w, "Removed %d %s hook(s):\n", len(removed), label)
+	for _, name := range removed {
+		_, _ = fmt.Fprintf(w, "  - %s\n", name)
+	}
+	return nil
+}
+
+// hasSmoovtaskHook checks if an st hook command already exists for the given event.
+func hasSmoovtaskHook(hooks map[string]any, eventName string) bool {
+	groups, ok := hooks[eventName].([]any)
+	if !ok {
+		return false
+	}
+	for _, g := range groups {
+		if isSmoovtaskGroup(g) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSmoovtaskGroup reports whether a settings hook group runs an st hook command.
+func isSmoovtaskGroup(g any) bool {
+	group, ok := g.(map[string]any)
+	if !ok {
+		return false
+	}
+	hookList, ok := group["hooks"].([]any)
+	if !ok {
+		return false
+	}
+	for _, h := range hookList {
+		entry, ok := h.(map[string]any)
+		if !ok {
+			continue
+		}
+		cmd, _ := entry["command"].(string)
+		if strings.HasPrefix(cmd, "st hook") {
+			return true
+		}
+	}
+	return false
+}
+
+// syncSmoovtaskHookAsync updates the async flag of installed st hook
+// commands for eventName to match the wanted groups (e.g. the stop hook
+// became synchronous). Returns true if anything changed.
+func syncSmoovtaskHookAsync(hooks map[string]any, eventName string, wanted []hookGroup) bool {
+	wantAsync := make(map[string]bool)
+	for _, g := range wanted {
+		for _, h := range g.Hooks {
+			wantAsync[h.Command] = h.Async
+		}
+	}
+
+	groups, _ := hooks[eventName].([]any)
+	changed := false
+	for _, g := range groups {
+		group, ok := g.(map[string]any)
+		if !ok {
+			continue
+		}
+		hookList, _ := group["hooks"].([]any)
+		for _, h := range hookList {
+			entry, ok := h.(map[string]any)
+			if !ok {
+				continue
+			}
+			cmd, _ := entry["command"].(string)
+			want, ok := wantAsync[cmd]
+			if !ok {
+				continue
+			}
+			if async, _ := entry["async"].(bool); async == want {
+				continue
+			}
+			if want {
+				entry["async"] = true
+			} else {
+				delete(entry, "async")
+			}
+			changed = true
+		}
+	}
+	return changed
+}
+
+// marshalHookGroup converts a hook group to a map[string]any for JSON merging.
+func marshalHookGroup(g hookGroup) map[string]any {
+	m := make(map[string]any)
+	if g.Matcher != "" {
+		m["matcher"] = g.Matcher
+	}
+	var hooks []any
+	for _, h := range g.Hooks {
+		entry := map[string]any{
+			"type":    h.Type,
+			"command": h.Command,
+		}
+		if h.Async {
+			entry["async"] = true
+		}
+		hooks = append(hooks, entry)
+	}
+	m["hooks"] = hooks
+	return m
+}
@@ -0,0 +1,444 @@
+package hook
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var updateGolden = flag.Bool("update", false, "rewrite adapter golden files")
+
+func TestAdapterGolden(t *testing.T) {
+	deny := Output{Decision: &Decision{HookEventName: "PreToolUse", Behavior: "deny", Reason: "rm -rf is not allowed"}}
+	allow := Output{Decision: &Decision{HookEventName: "PermissionRequest", Behavior: "allow"}}
+	context := Output{AdditionalContext: "<smoovtask>ctx</smoovtask>"}
+	block := Output{StopDecision: "block", Reason: "BLOCKED: commit first"}
+
+	tests := []struct {
+		adapter string
+		name    string
+		native  string
+		payload string
+		legacy  bool // OPENCODE_HOOK=1
+		out     Output
+	}{
+		{"claude", "session-start", "session-start", `{"session_id":"s1","cwd":"/repo","source":"startup"}`, false, context},
+		{"claude", "pre-tool-deny", "pre-tool", `{"session_id":"s1","cwd":"/repo","tool_name":"Bash","tool_input":{"command":"rm -rf /"}}`, false, deny},
+		{"claude", "pre-tool-context", "pre-tool", `{"session_id":"s1","cwd":"/repo","tool_name":"Edit","tool_input":{"file_path":"a.go"}}`, false, context},
+		{"claude", "post-tool", "post-tool", `{"session_id":"s1","cwd":"/repo","tool_name":"Bash","tool_response":{"stdout":"ok"}}`, false, context},
+		{"claude", "permission-request", "permission-request", `{"session_id":"s1","tool_name":"Bash"}`, false, allow},
+		{"claude", "subagent-start", "subagent-start", `{"session_id":"s1","task_prompt":"work on st_abc123"}`, false, context},
+		{"claude", "stop-block", "stop", `{"session_id":"s1","cwd":"/repo"}`, false, block},
+		{"claude", "stop-allow", "stop", `{"session_id":"s1","cwd":"/repo"}`, false, Output{}},
+		{"claude", "user-prompt", "user-prompt-submit", `{"session_id":"s1","prompt":"hi"}`, false, context},
+		{"claude", "legacy-opencode", "session-start", `{"session_id":"s1","cwd":"/repo"}`, true, context},
+		{"claude", "unknown", "notification", `{}`, false, context},
+
+		{"opencode", "session-created", "opencode-event", `{"type":"session.created","session_id":"s2","cwd":"/repo"}`, false, context},
+		{"opencode", "session-created-no-id", "opencode-event", `{"type":"session.created","cwd":"/repo"}`, false, context},
+		{"opencode", "tool-before-deny", "opencode-event", `{"type":"tool.execute.before","session_id":"s2","cwd":"/repo","tool_name":"bash","tool_input":{"command":"rm -rf /"}}`, false, deny},
+		{"opencode", "tool-after", "opencode-event", `{"type":"tool.execute.after","session_id":"s2","tool_name":"bash"}`, false, Output{}},
+		{"opencode", "permission-asked", "opencode-event", `{"type":"permission.asked","session_id":"s2","tool_name":"bash"}`, false, allow},
+		{"opencode", "session-idle", "opencode-event", `{"type":"session.idle","session_id":"s2"}`, false, Output{}},
+		{"opencode", "session-deleted", "opencode-event", `{"type":"session.deleted","session_id":"s2"}`, false, Output{}},
+		{"opencode", "invalid-json", "opencode-event", `not json`, false, context},
+
+		{"pi", "session-start", "pi-event", `{"type":"session_start","session_id":"s3","cwd":"/repo"}`, false, Output{}},
+		{"pi", "tool-call-context", "pi-event", `{"type":"tool_call","session_id":"s3","tool_name":"bash","tool_input":{"command":"go test ./..."}}`, false, context},
+		{"pi", "tool-result", "pi-event", `{"type":"tool_execution_end","session_id":"s3","tool_name":"bash"}`, false, Output{}},
+		{"pi", "subagent-start", "pi-event", `{"type":"subagent_start","session_id":"s3","task_prompt":"review st_abc123"}`, false, context},
+		{"pi", "turn-end", "pi-event", `{"type":"turn_end","session_id":"s3"}`, false, Output{}},
+		{"pi", "agent-end", "pi-event", `{"type":"agent_end","session_id":"s3"}`, false, Output{}},
+		{"pi", "session-shutdown", "pi-event", `{"type":"session_shutdown","session_id":"s3"}`, false, block},
+		{"pi", "unknown-type", "pi-event", `{"type":"model_select","session_id":"s3"}`, false, context},
+
+		{"codex", "turn-complete", "codex-event", `{"type":"agent-turn-complete","thread-id":"t4","turn-id":"12","cwd":"/repo","input-messages":["fix it"],"last-assistant-message":"done"}`, false, block},
+		{"codex", "unknown-type", "codex-event", `{"type":"approval-requested","thread-id":"t4"}`, false, context},
+		{"codex", "invalid-json", "codex-event", `not json`, false, context},
+
+		{"gemini", "session-start", "gemini-event", `{"hook_event_name":"SessionStart","session_id":"g5","cwd":"/repo","source":"startup","transcript_path":"/tmp/g5.json"}`, false, context},
+		{"gemini", "before-tool-deny", "gemini-event", `{"hook_event_name":"BeforeTool","session_id":"g5","cwd":"/repo","tool_name":"run_shell_command","tool_input":{"command":"rm -rf /"}}`, false, deny},
+		{"gemini", "before-tool-context", "gemini-event", `{"hook_event_name":"BeforeTool","session_id":"g5","tool_name":"read_file","tool_input":{"absolute_path":"/repo/a.go"}}`, false, context},
+		{"gemini", "after-tool", "gemini-event", `{"hook_event_name":"AfterTool","session_id":"g5","tool_name":"replace","tool_input":{"file_path":"/repo/a.go"},"tool_response":{"llmContent":"ok"}}`, false, Output{}},
+		{"gemini", "before-agent", "gemini-event", `{"hook_event_name":"BeforeAgent","session_id":"g5","prompt":"continue"}`, false, context},
+		{"gemini", "after-agent-block", "gemini-event", `{"hook_event_name":"AfterAgent","session_id":"g5","cwd":"/repo"}`, false, block},
+		{"gemini", "session-end", "gemini-event", `{"hook_event_name":"SessionEnd","session_id":"g5","reason":"exit"}`, false, Output{}},
+		{"gemini", "notification", "gemini-event", `{"hook_event_name":"Notification","session_id":"g5"}`, false, context},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.adapter+"/"+tt.name, func(t *testing.T) {
+			if tt.legacy {
+				t.Setenv("OPENCODE_HOOK", "1")
+			} else {
+				t.Setenv("OPENCODE_HOOK", "")
+			}
+			adapter, err := GetAdapter(tt.adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got bytes.Buffer
+			events, input, err := adapter.Decode(tt.native, []byte(tt.payload))
+			if err != nil {
+				fmt.Fprintf(&got, "decode error: %v\n", err)
+			}
+			fmt.Fprintf(&got, "accepts: %v\n", adapter.Accepts(tt.native))
+			fmt.Fprintf(&got, "events: %s\n", strings.Join(events, ", "))
+			if input != nil {
+				data, err := json.Marshal(input)
+				if err != nil {
+					t.Fatal(err)
+				}
+				fmt.Fprintf(&got, "input: %s\n", data)
+			}
+			for _, event := range events {
+				fmt.Fprintf(&got, "--- %s\n", event)
+				if err := adapter.Encode(&got, event, tt.out); err != nil {
+					fmt.Fprintf(&got, "error: %v\n", err)
+				}
+			}
+
+			path := filepath.Join("testdata", "adapters", tt.adapter, tt.name+".golden")
+			if *updateGolden {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(path, got.Bytes(), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("read golden file (run with -update to create): %v", err)
+			}
+			if got.String() != string(want) {
+				t.Errorf("output mismatch for %s\ngot:\n%s\nwant:\n%s", path, got.String(), want)
+			}
+		})
+	}
+}
+
+func TestAdapterForEvent(t *testing.T) {
+	tests := map[string]string{
+		"session-start":      "claude",
+		"user-prompt-submit": "claude",
+		"opencode-event":     "opencode",
+		"pi-event":           "pi",
+		"codex-event":        "codex",
+		"gemini-event":       "gemini",
+	}
+	for native, want := range tests {
+		a, ok := AdapterForEvent(native)
+		if !ok || a.Name() != want {
+			t.Errorf("AdapterForEvent(%q) = %v, %v; want %s", native, a, ok, want)
+		}
+	}
+	if _, ok := AdapterForEvent("notification"); ok {
+		t.Error("AdapterForEvent(notification) should not match")
+	}
+}
+
+func TestGetAdapterUnknown(t *testing.T) {
+	_, err := GetAdapter("cursor")
+	if err == nil || !strings.Contains(err.Error(), "available: claude, opencode, pi, codex, gemini") {
+		t.Fatalf("GetAdapter(cursor) error = %v", err)
+	}
+}
+
+func TestBridgeAdaptersInstallUninstall(t *testing.T) {
+	home := t.TempDir()
+	for _, tt := range []struct {
+		adapter Adapter
+		path    string
+		marker  string
+	}{
+		{&OpencodeAdapter{}, opencodePluginPath(home), "'hook', 'opencode-event'"},
+		{&PiAdapter{}, piExtensionPath(home), "'hook', 'pi-event'"},
+	} {
+		var out bytes.Buffer
+		if err := tt.adapter.Install(home, &out); err != nil {
+			t.Fatalf("%s install: %v", tt.adapter.Name(), err)
+		}
+		data, err := os.ReadFile(tt.path)
+		if err != nil {
+			t.Fatalf("read %s bridge: %v", tt.adapter.Name(), err)
+		}
+		if !strings.Contains(string(data), tt.marker) {
+			t.Errorf("%s bridge missing %q", tt.adapter.Name(), tt.marker)
+		}
+
+		out.Reset()
+		if err := tt.adapter.Uninstall(home, &out); err != nil {
+			t.Fatalf("%s uninstall: %v", tt.adapter.Name(), err)
+		}
+		if !strings.Contains(out.String(), "Removed") {
+			t.Errorf("%s uninstall output = %q", tt.adapter.Name(), out.String())
+		}
+		if _, err := os.Stat(tt.path); !os.IsNotExist(err) {
+			t.Errorf("%s bridge still present after uninstall", tt.adapter.Name())
+		}
+
+		out.Reset()
+		if err := tt.adapter.Uninstall(home, &out); err != nil {
+			t.Fatalf("%s second uninstall: %v", tt.adapter.Name(), err)
+		}
+		if !strings.Contains(out.String(), "nothing to uninstall") {
+			t.Errorf("%s second uninstall output = %q", tt.adapter.Name(), out.String())
+		}
+	}
+}
+
+func TestClaudeInstall_MakesStopHookSynchronous(t *testing.T) {
+	home := t.TempDir()
+	settingsPath := claudeSettingsPath(home)
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	old := `{"hooks":{"Stop":[{"hooks":[{"type":"command","command":"st hook stop","async":true}]}]}}`
+	if err := os.WriteFile(settingsPath, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := (&ClaudeAdapter{}).Install(home, &out); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("read settings: %v", err)
+	}
+	var settings struct {
+		Hooks map[string][]hookGroup `json:"hooks"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("parse settings: %v", err)
+	}
+	stop := settings.Hooks["Stop"]
+	if len(stop) != 1 || len(stop[0].Hooks) != 1 {
+		t.Fatalf("Stop hooks = %+v, want the single existing entry", stop)
+	}
+	if stop[0].Hooks[0].Async {
+		t.Error("stop hook still async after install")
+	}
+	if len(settings.Hooks["PostToolUse"]) != 1 {
+		t.Error("missing hooks should still be installed")
+	}
+	if !strings.Contains(out.String(), "~ Stop") {
+		t.Errorf("output = %q, want updated Stop hook listed", out.String())
+	}
+
+	out.Reset()
+	if err := (&ClaudeAdapter{}).Uninstall(home, &out); err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	data, err = os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatalf("read settings: %v", err)
+	}
+	if strings.Contains(string(data), "st hook") {
+		t.Errorf("settings still contain st hooks after uninstall: %s", data)
+	}
+}
+
+func TestHookBridgesDoNotWrapContextTwice(t *testing.T) {
+	opencode, err := BridgeSource("opencode")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pi, err := BridgeSource("pi")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(opencode, "<smoovtask>") {
+		t.Fatal("opencode plugin should not add wrapper tags; hook context is already wrapped")
+	}
+	if strings.Contains(pi, "<smoovtask>") {
+		t.Fatal("pi extension should not add wrapper tags; hook context is already wrapped")
+	}
+	if !strings.Contains(opencode, "output.system.push(cachedContext)") {
+		t.Fatal("opencode plugin should push cachedContext directly")
+	}
+	if !strings.Contains(pi, "event.systemPrompt + '\\n\\n' + cachedContext") {
+		t.Fatal("pi extension should append cachedContext directly")
+	}
+	if !strings.Contains(opencode, "result.hookSpecificOutput.permissionDecision === 'deny'") {
+		t.Fatal("opencode plugin should block denied pre-tool decisions")
+	}
+	if !strings.Contains(opencode, "return { block: true, reason: result.hookSpecificOutput.permissionDecisionReason || 'Blocked by smoovtask' }") {
+		t.Fatal("opencode plugin should return block payload on denied decision")
+	}
+	if !strings.Contains(opencode, "tool.execute.before', 'tool.execute.after") {
+		t.Fatal("opencode plugin should include tool event fallback in handled event list")
+	}
+	if !strings.Contains(opencode, "const looksLikeTool = eventType.includes('tool')") {
+		t.Fatal("opencode plugin should detect tool-like events from generic event bus")
+	}
+	if !strings.Contains(opencode, "payload.type = looksBefore ? 'tool.execute.before' : 'tool.execute.after'") {
+		t.Fatal("opencode plugin should normalize tool fallback events to canonical hook types")
+	}
+	if !strings.Contains(opencode, "payload.tool_name = toolName") {
+		t.Fatal("opencode plugin should include normalized tool name for fallback events")
+	}
+	if !strings.Contains(opencode, "if (eventType === 'session.status')") {
+		t.Fatal("opencode plugin should synthesize tool activity from session.status events")
+	}
+	if !strings.Contains(opencode, "props.status && typeof props.status === 'object'") {
+		t.Fatal("opencode plugin should parse object-form session.status payloads")
+	}
+	if !strings.Contains(opencode, "statusType === 'running' || statusType === 'working' || statusType === 'busy'") {
+		t.Fatal("opencode plugin should treat active session statuses as pre-tool")
+	}
+	if !strings.Contains(opencode, "statusType === 'idle' || statusType === 'waiting'") {
+		t.Fatal("opencode plugin should treat idle session statuses as post-tool")
+	}
+	if !strings.Contains(opencode, "if (eventType === 'message.part.updated')") {
+		t.Fatal("opencode plugin should bridge message part updates into tool activity")
+	}
+	if !strings.Contains(opencode, "if (partType === 'tool')") {
+		t.Fatal("opencode plugin should only synthesize tool hooks for tool message parts")
+	}
+	if !strings.Contains(opencode, "partStatus === 'pending' || partStatus === 'running'") {
+		t.Fatal("opencode plugin should map pending/running tool parts to pre-tool")
+	}
+	if !strings.Contains(opencode, "partStatus === 'completed' || partStatus === 'done' || partStatus === 'success' || partStatus === 'failed' || partStatus === 'error' || partStatus === 'cancelled'") {
+		t.Fatal("opencode plugin should map terminal tool part statuses to post-tool")
+	}
+	if !strings.Contains(pi, "result.hookSpecificOutput.permissionDecision === 'deny'") {
+		t.Fatal("pi extension should block denied pre-tool decisions")
+	}
+}
+
+func TestGeminiInstallUninstall(t *testing.T) {
+	home := t.TempDir()
+	settingsPath := geminiSettingsPath(home)
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(settingsPath, []byte(`{"theme":"Dracula"}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := &GeminiAdapter{}
+	var out bytes.Buffer
+	if err := adapter.Install(home, &out); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings struct {
+		Theme string                 `json:"theme"`
+		Hooks map[string][]hookGroup `json:"hooks"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("parse settings: %v", err)
+	}
+	if settings.Theme != "Dracula" {
+		t.Error("existing settings not preserved")
+	}
+	for native := range geminiEvents {
+		groups := settings.Hooks[native]
+		if len(groups) != 1 || groups[0].Hooks[0].Command != "st hook gemini-event" {
+			t.Errorf("%s hooks = %+v", native, groups)
+		}
+	}
+
+	out.Reset()
+	if err := adapter.Install(home, &out); err != nil {
+		t.Fatalf("second Install: %v", err)
+	}
+	if !strings.Contains(out.String(), "Gemini hooks already installed") {
+		t.Errorf("second install output = %q", out.String())
+	}
+
+	if err := adapter.Uninstall(home, io.Discard); err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	data, err = os.ReadFile(settingsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "st hook") || !strings.Contains(string(data), "Dracula") {
+		t.Errorf("settings after uninstall = %s", data)
+	}
+}
+
+func TestCodexInstallUninstall(t *testing.T) {
+	home := t.TempDir()
+	configPath := codexConfigPath(home)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existing := "# my config\nmodel = \"o3\"\n\n[profiles.fast]\nmodel = \"gpt-5-mini\"\n"
+	if err := os.WriteFile(configPath, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	adapter := &CodexAdapter{}
+	if err := adapter.Install(home, io.Discard); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := codexNotify + "\n" + existing; string(data) != want {
+		t.Errorf("config after install = %q, want %q", data, want)
+	}
+
+	var out bytes.Buffer
+	if err := adapter.Install(home, &out); err != nil {
+		t.Fatalf("second Install: %v", err)
+	}
+	if !strings.Contains(out.String(), "already installed") {
+		t.Errorf("second install output = %q", out.String())
+	}
+
+	if err := adapter.Uninstall(home, io.Discard); err != nil {
+		t.Fatalf("Uninstall: %v", err)
+	}
+	data, err = os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != existing {
+		t.Errorf("config after uninstall = %q, want %q", data, existing)
+	}
+}
+
+func TestCodexInstallKeepsForeignNotify(t *testing.T) {
+	home := t.TempDir()
+	configPath := codexConfigPath(home)
+	if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	existing := "notify = [\"notify-send\", \"codex\"]\n"
+	if err := os.WriteFile(configPath, []byte(existing), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := (&CodexAdapter{}).Install(home, &out); err != nil {
+		t.Fatalf("Install: %v", err)
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != existing {
+		t.Errorf("config rewritten to %q", data)
+	}
+	if !strings.Contains(out.String(), "already has a notify program") {
+		t.Errorf("output = %q", out.String())
+	}
+}
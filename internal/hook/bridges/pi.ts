@@ -0,0 +1,125 @@
+import { spawnSync } from 'node:child_process';
+import { writeFileSync } from 'node:fs';
+import { basename } from 'node:path';
+
+function log(msg) {
+  writeFileSync('/tmp/pi-extension.log', new Date().toISOString() + ': ' + msg + '\n', { flag: 'a' });
+}
+
+function runHookSync(payload) {
+  const result = spawnSync('st', ['hook', 'pi-event'], {
+    input: JSON.stringify(payload),
+    env: { ...process.env, PI_HOOK: '1' },
+    timeout: 5000,
+  });
+
+  if (result.stderr && result.stderr.length > 0) {
+    log('stderr: ' + result.stderr.toString());
+  }
+  if (result.status !== 0) {
+    log('Hook failed code ' + result.status);
+    return null;
+  }
+
+  const trimmed = (result.stdout || '').toString().trim();
+  if (!trimmed) return null;
+
+  try {
+    return JSON.parse(trimmed);
+  } catch (e) {
+    log('Parse error: ' + e);
+    return null;
+  }
+}
+
+function getSessionID(ctx) {
+  const path = ctx?.sessionManager?.getSessionFile?.();
+  if (typeof path === 'string' && path.length > 0) {
+    const candidate = basename(path, '.jsonl');
+    const parts = candidate.split('_');
+    const suffix = parts[parts.length - 1];
+    const uuidPattern = /^[0-9a-f]{8}-[0-9a-f]{4}-[1-5][0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$/i;
+    if (uuidPattern.test(suffix)) {
+      return suffix;
+    }
+    return candidate;
+  }
+  return 'pi-session';
+}
+
+export default function (pi) {
+  let cachedContext = null;
+
+  pi.on('session_start', async (_event, ctx) => {
+    const payload = {
+      type: 'session_start',
+      session_id: getSessionID(ctx),
+      cwd: ctx.cwd,
+    };
+    const result = runHookSync(payload);
+    if (result && result.additionalContext) {
+      cachedContext = result.additionalContext;
+    }
+  });
+
+  pi.on('before_agent_start', async (event, ctx) => {
+    if (!cachedContext) {
+      const payload = {
+        type: 'session_start',
+        session_id: getSessionID(ctx),
+        cwd: ctx.cwd,
+      };
+      const result = runHookSync(payload);
+      if (result && result.additionalContext) {
+        cachedContext = result.additionalContext;
+      }
+    }
+
+    if (!cachedContext) return;
+
+	return {
+	  systemPrompt: event.systemPrompt + '\n\n' + cachedContext,
+	};
+  });
+
+  pi.on('tool_call', async (event, ctx) => {
+    const payload = {
+      type: 'tool_call',
+      session_id: getSessionID(ctx),
+      cwd: ctx.cwd,
+      tool_name: event.toolName,
+    };
+    const result = runHookSync(payload);
+    if (result && result.additionalContext) {
+      ctx.ui.notify(result.additionalContext, 'warning');
+    }
+    if (result && result.hookSpecificOutput && result.hookSpecificOutput.permissionDecision === 'deny') {
+      return { block: true, reason: result.hookSpecificOutput.permissionDecisionReason || 'Blocked by smoovtask' };
+    }
+  });
+
+  pi.on('tool_result', async (event, ctx) => {
+    runHookSync({
+      type: 'tool_result',
+      session_id: getSessionID(ctx),
+      cwd: ctx.cwd,
+      tool_name: event.toolName,
+    });
+  });
+
+  pi.on('agent_end', async (_event, ctx) => {
+    runHookSync({
+      type: 'agent_end',
+      session_id: getSessionID(ctx),
+      cwd: ctx.cwd,
+    });
+  });
+
+  pi.on('session_shutdown', async (_event, ctx) => {
+    runHookSync({
+      type: 'session_shutdown',
+      session_id: getSessionID(ctx),
+      cwd: ctx.cwd,
+    });
+  });
+}
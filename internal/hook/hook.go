@@ -41,6 +41,11 @@ type Output struct {
 
 	// Decision for permission hooks.
 	Decision *Decision `json:"hookSpecificOutput,omitempty"`
+
+	// StopDecision "block" keeps a stopping session going; Reason tells the
+	// agent what to do before it may stop.
+	StopDecision string `json:"decision,omitempty"`
+	Reason       string `json:"reason,omitempty"`
 }
 
 // Decision represents a permission decision.
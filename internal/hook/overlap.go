@@ -0,0 +1,224 @@
+package hook
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/touched"
+	"golang.org/x/sys/unix"
+)
+
+// overlapWarning warns when files edited for ticketID overlap those edited
+// for another active (IN-PROGRESS or REWORK) ticket in the project. Each
+// overlap is logged as a files.overlap event for the warned ticket, so a
+// file is reported to each ticket once — the other ticket's agent gets its
+// warning on its own next tool call.
+//
+// It runs on every pre-tool call, so a per-project marker counts the edits
+// logged for the project and the count each ticket was last checked at; the
+// scan is skipped when no edit has been logged since.
+func overlapWarning(cfg *config.Config, el *event.EventLog, eventsDir, proj, ticketID string, input *Input) string {
+	dir, err := cfg.OverlapDir()
+	if err != nil {
+		return ""
+	}
+	isEdit := touched.IsEdit(input.ToolName) && input.ToolInput["file_path"] != nil
+	var edits int
+	fresh := false
+	err = updateOverlapMarker(dir, proj, func(m *overlapMarker) {
+		if isEdit {
+			m.Edits++
+		}
+		edits = m.Edits
+		checked, ok := m.Checked[ticketID]
+		fresh = ok && checked == edits
+	})
+	if err != nil || fresh {
+		return ""
+	}
+
+	warning := overlapScan(cfg, el, eventsDir, proj, ticketID, input)
+
+	// An edit logged during the scan keeps the ticket due for another check.
+	_ = updateOverlapMarker(dir, proj, func(m *overlapMarker) {
+		if m.Checked == nil {
+			m.Checked = map[string]int{}
+		}
+		if edits > m.Checked[ticketID] {
+			m.Checked[ticketID] = edits
+		}
+	})
+	return warning
+}
+
+// overlapMarker is the per-project state behind overlapWarning's fast path.
+type overlapMarker struct {
+	Edits   int            `json:"edits"`
+	Checked map[string]int `json:"checked,omitempty"`
+}
+
+// updateOverlapMarker applies fn to the project's marker under an exclusive
+// lock and writes it back.
+func updateOverlapMarker(dir, proj string, fn func(m *overlapMarker)) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(dir, filepath.Base(proj)+".json")
+	lock, err := os.OpenFile(path+".lock", os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer lock.Close()
+	if err := unix.Flock(int(lock.Fd()), unix.LOCK_EX); err != nil {
+		return err
+	}
+	defer unix.Flock(int(lock.Fd()), unix.LOCK_UN)
+
+	var m overlapMarker
+	if data, err := os.ReadFile(path); err == nil {
+		// A corrupt marker only costs one full scan.
+		_ = json.Unmarshal(data, &m)
+	}
+	fn(&m)
+	data, err := json.Marshal(&m)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// overlapScan does the work of overlapWarning: it compares the files edited
+// for each active ticket since they were picked up.
+func overlapScan(cfg *config.Config, el *event.EventLog, eventsDir, proj, ticketID string, input *Input) string {
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return ""
+	}
+	// List keeps the bodies, so pickup times come from the same read.
+	tickets, err := ticket.NewStore(projectsDir).List(ticket.ListFilter{
+		Project:  proj,
+		Statuses: []ticket.Status{ticket.StatusInProgress, ticket.StatusRework},
+	})
+	if err != nil {
+		return ""
+	}
+
+	byID := map[string]*ticket.Ticket{}
+	var active []string
+	for _, tk := range tickets {
+		byID[tk.ID] = tk
+		active = append(active, tk.ID)
+	}
+	if len(active) < 2 || byID[ticketID] == nil {
+		return ""
+	}
+
+	// Only edits made since the active tickets were first picked up can
+	// overlap, so the scan starts there rather than at ticket creation.
+	since := time.Now().UTC()
+	for _, tk := range tickets {
+		if started := workStarted(tk); started.Before(since) {
+			since = started
+		}
+	}
+
+	root := ""
+	if vaultPath, err := cfg.VaultPath(); err == nil {
+		if meta, err := project.LoadMeta(vaultPath, proj); err == nil {
+			root = meta.Path
+		}
+	}
+
+	events, err := event.QueryEvents(eventsDir, event.Query{
+		Project: proj,
+		Events:  []string{event.HookPreTool, event.FilesOverlap},
+		After:   since,
+	})
+	if err != nil {
+		return ""
+	}
+	overlaps := touched.With(touched.ByTicket(events, root), ticketID, active)
+	if len(overlaps) == 0 {
+		return ""
+	}
+
+	reported := map[string]bool{}
+	for _, ev := range events {
+		if ev.Event != event.FilesOverlap || ev.Ticket != ticketID {
+			continue
+		}
+		other, _ := ev.Data["other"].(string)
+		for _, f := range stringsFromData(ev.Data["files"]) {
+			reported[other+"\x00"+f] = true
+		}
+	}
+
+	var lines []string
+	for _, o := range overlaps {
+		var fresh []string
+		for _, f := range o.Files {
+			if !reported[o.Other+"\x00"+f] {
+				fresh = append(fresh, f)
+			}
+		}
+		if len(fresh) == 0 {
+			continue
+		}
+		_ = el.Append(event.Event{
+			TS:      time.Now().UTC(),
+			Event:   event.FilesOverlap,
+			Ticket:  ticketID,
+			Project: proj,
+			Actor:   "agent",
+			RunID:   input.SessionID,
+			Source:  input.Source,
+			Data:    map[string]any{"other": o.Other, "files": fresh},
+		})
+		other := byID[o.Other]
+		lines = append(lines, fmt.Sprintf("- %s (%s, %s): %s", other.ID, other.Title, other.Status, strings.Join(fresh, ", ")))
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("WARNING: files edited for %s are also being edited by another active ticket:\n%s\n"+
+		"Expect merge conflicts. Keep changes to these files minimal, check the other ticket with `st show <ticket-id>`, "+
+		"and record how you coordinated in a note.", ticketID, strings.Join(lines, "\n"))
+}
+
+// workStarted returns when tk first went IN-PROGRESS, from its In Progress
+// sections, or its creation time when it has none.
+func workStarted(tk *ticket.Ticket) time.Time {
+	for _, sec := range ticket.Sections(tk.Body) {
+		if sec.Heading == "In Progress" && !sec.TS.IsZero() {
+			return sec.TS
+		}
+	}
+	return tk.Created
+}
+
+// stringsFromData converts a decoded JSON array (or a []string written in
+// the same process) to strings.
+func stringsFromData(v any) []string {
+	switch vals := v.(type) {
+	case []string:
+		return vals
+	case []any:
+		out := make([]string, 0, len(vals))
+		for _, val := range vals {
+			if s, ok := val.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,175 @@
+package hook
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
+)
+
+func TestHandlePreToolWarnsOnOverlap(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	store := ticket.NewStore(env.projectsDir(t))
+	now := time.Now().UTC()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "st_ovl001", Title: "Rate limiting", Project: "test-project", Status: ticket.StatusInProgress, Assignee: "sess-a", Priority: ticket.PriorityP2, Created: now.Add(-time.Hour), Updated: now},
+		{ID: "st_ovl002", Title: "Router cleanup", Project: "test-project", Status: ticket.StatusInProgress, Assignee: "sess-b", Priority: ticket.PriorityP2, Created: now.Add(-time.Hour), Updated: now},
+	} {
+		if err := store.Create(tk); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+
+	write := func(session, ticketID, rel string) Output {
+		t.Helper()
+		out, err := HandlePreTool(&Input{
+			SessionID: session,
+			CWD:       projectPath,
+			ToolName:  "Edit",
+			ToolInput: map[string]any{"file_path": filepath.Join(projectPath, ".worktrees", ticketID, rel)},
+		})
+		if err != nil {
+			t.Fatalf("HandlePreTool() error: %v", err)
+		}
+		return out
+	}
+
+	if out := write("sess-a", "st_ovl001", "router.go"); out.AdditionalContext != "" {
+		t.Fatalf("unexpected warning before any overlap: %q", out.AdditionalContext)
+	}
+	if out := write("sess-b", "st_ovl002", "other.go"); out.AdditionalContext != "" {
+		t.Fatalf("unexpected warning for disjoint files: %q", out.AdditionalContext)
+	}
+
+	out := write("sess-b", "st_ovl002", "router.go")
+	if !strings.Contains(out.AdditionalContext, "st_ovl001 (Rate limiting, IN-PROGRESS): router.go") {
+		t.Errorf("writer not warned about overlap:\n%s", out.AdditionalContext)
+	}
+
+	// The other agent is warned on its next tool call, whatever the tool.
+	out, err := HandlePreTool(&Input{SessionID: "sess-a", CWD: projectPath, ToolName: "Read"})
+	if err != nil {
+		t.Fatalf("HandlePreTool() error: %v", err)
+	}
+	if !strings.Contains(out.AdditionalContext, "st_ovl002 (Router cleanup, IN-PROGRESS): router.go") {
+		t.Errorf("other agent not warned about overlap:\n%s", out.AdditionalContext)
+	}
+
+	// Each overlap is reported once per ticket.
+	if out := write("sess-b", "st_ovl002", "router.go"); out.AdditionalContext != "" {
+		t.Errorf("overlap reported twice: %q", out.AdditionalContext)
+	}
+
+	events, err := event.QueryEvents(env.EventsDir, event.Query{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var overlaps int
+	for _, ev := range events {
+		if ev.Event == event.FilesOverlap {
+			overlaps++
+		}
+	}
+	if overlaps != 2 {
+		t.Errorf("files.overlap events = %d, want 2", overlaps)
+	}
+}
+
+func TestHandlePreToolOverlapIgnoresEditsBeforePickup(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	store := ticket.NewStore(env.projectsDir(t))
+	now := time.Now().UTC()
+	created := now.Add(-30 * 24 * time.Hour)
+	for _, id := range []string{"st_ovl101", "st_ovl102"} {
+		tk := &ticket.Ticket{ID: id, Title: "Ticket " + id, Project: "test-project", Status: ticket.StatusInProgress, Assignee: "sess-" + id, Priority: ticket.PriorityP2, Created: created, Updated: now}
+		ticket.AppendSection(tk, "In Progress", "agent", "sess-"+id, "", nil, now.Add(-time.Hour))
+		if err := store.Create(tk); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+
+	// An edit logged for st_ovl101 weeks before either ticket was picked up.
+	el := event.NewEventLog(env.EventsDir)
+	if err := el.Append(event.Event{
+		TS:      created.Add(time.Hour),
+		Event:   event.HookPreTool,
+		Ticket:  "st_ovl101",
+		Project: "test-project",
+		Data:    map[string]any{"tool": "Edit", "file_path": filepath.Join(projectPath, ".worktrees", "st_ovl101", "router.go")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := HandlePreTool(&Input{
+		SessionID: "sess-st_ovl102",
+		CWD:       projectPath,
+		ToolName:  "Edit",
+		ToolInput: map[string]any{"file_path": filepath.Join(projectPath, ".worktrees", "st_ovl102", "router.go")},
+	})
+	if err != nil {
+		t.Fatalf("HandlePreTool() error: %v", err)
+	}
+	if out.AdditionalContext != "" {
+		t.Errorf("warned about an edit made before the tickets were picked up:\n%s", out.AdditionalContext)
+	}
+}
+
+func TestHandlePreToolOverlapSkipsScanWithoutNewEdits(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	store := ticket.NewStore(env.projectsDir(t))
+	now := time.Now().UTC()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "st_ovl201", Title: "Rate limiting", Project: "test-project", Status: ticket.StatusInProgress, Assignee: "sess-a", Priority: ticket.PriorityP2, Created: now.Add(-time.Hour), Updated: now},
+		{ID: "st_ovl202", Title: "Router cleanup", Project: "test-project", Status: ticket.StatusInProgress, Assignee: "sess-b", Priority: ticket.PriorityP2, Created: now.Add(-time.Hour), Updated: now},
+	} {
+		if err := store.Create(tk); err != nil {
+			t.Fatalf("create ticket: %v", err)
+		}
+	}
+
+	call := func(session, tool string, toolInput map[string]any) Output {
+		t.Helper()
+		out, err := HandlePreTool(&Input{SessionID: session, CWD: projectPath, ToolName: tool, ToolInput: toolInput})
+		if err != nil {
+			t.Fatalf("HandlePreTool() error: %v", err)
+		}
+		return out
+	}
+	edit := func(ticketID, rel string) map[string]any {
+		return map[string]any{"file_path": filepath.Join(projectPath, ".worktrees", ticketID, rel)}
+	}
+
+	if out := call("sess-a", "Edit", edit("st_ovl201", "router.go")); out.AdditionalContext != "" {
+		t.Fatalf("unexpected warning: %q", out.AdditionalContext)
+	}
+
+	// An edit that bypassed the hook does not bump the marker, so the next
+	// call skips the scan and cannot see it.
+	if err := event.NewEventLog(env.EventsDir).Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.HookPreTool,
+		Ticket:  "st_ovl202",
+		Project: "test-project",
+		Data:    map[string]any{"tool": "Edit", "file_path": filepath.Join(projectPath, ".worktrees", "st_ovl202", "router.go")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if out := call("sess-a", "Read", nil); out.AdditionalContext != "" {
+		t.Fatalf("scan ran without a new edit: %q", out.AdditionalContext)
+	}
+
+	// The next hooked edit in the project makes the ticket due again.
+	call("sess-b", "Edit", edit("st_ovl202", "other.go"))
+	if out := call("sess-a", "Read", nil); !strings.Contains(out.AdditionalContext, "st_ovl202 (Router cleanup, IN-PROGRESS): router.go") {
+		t.Errorf("want overlap warning after a new edit, got %q", out.AdditionalContext)
+	}
+}
@@ -1,13 +1,16 @@
 package hook
 
 import (
+	"strings"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/testrun"
 )
 
-// HandlePostTool logs a post-tool event to the JSONL event log.
+// HandlePostTool logs a post-tool event to the JSONL event log and
+// periodically records transcript token usage.
 func HandlePostTool(input *Input) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -30,15 +33,72 @@ func HandlePostTool(input *Input) error {
 		}
 	}
 
+	ticketID := lookupActiveTicket(cfg, proj, input.SessionID)
+
 	el := event.NewEventLog(eventsDir)
-	return el.Append(event.Event{
+	recordUsage(cfg, el, input, proj, ticketID, false)
+	if err := el.Append(event.Event{
 		TS:      time.Now().UTC(),
 		Event:   event.HookPostTool,
-		Ticket:  lookupActiveTicket(cfg, proj, input.SessionID),
+		Ticket:  ticketID,
 		Project: proj,
 		Actor:   "agent",
 		RunID:   input.SessionID,
 		Source:  input.Source,
 		Data:    data,
+	}); err != nil {
+		return err
+	}
+
+	if input.ToolName == "Bash" {
+		return recordTestRun(el, input, proj, ticketID)
+	}
+	return nil
+}
+
+// toolOutputKeys are the tool response fields holding command output across
+// agent CLIs.
+var toolOutputKeys = []string{"stdout", "stderr", "output", "llmContent", "error"}
+
+// recordTestRun logs a test.run event when a Bash command ran a known test
+// runner and its outcome can be told from the exit code or output.
+func recordTestRun(el *event.EventLog, input *Input, proj, ticketID string) error {
+	command, _ := input.ToolInput["command"].(string)
+	runner := testrun.Detect(command)
+	if runner == "" {
+		return nil
+	}
+
+	var output strings.Builder
+	for _, key := range toolOutputKeys {
+		if s, ok := input.ToolResponse[key].(string); ok && s != "" {
+			output.WriteString(s)
+			output.WriteString("\n")
+		}
+	}
+	result := testrun.Parse(runner, output.String())
+	result.Command = command
+	if len(result.Command) > 200 {
+		result.Command = result.Command[:200]
+	}
+
+	var exitCode *int
+	if code, ok := input.ToolResponse["exit_code"].(float64); ok {
+		n := int(code)
+		exitCode = &n
+	}
+	if !result.Judge(exitCode) {
+		return nil
+	}
+
+	return el.Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.TestRun,
+		Ticket:  ticketID,
+		Project: proj,
+		Actor:   "agent",
+		RunID:   input.SessionID,
+		Source:  input.Source,
+		Data:    result.Data(exitCode),
 	})
 }
@@ -143,3 +143,67 @@ func TestHandlePostToolNoConfig(t *testing.T) {
 		t.Fatalf("HandlePostTool() should not error on missing config, got: %v", err)
 	}
 }
+
+func TestHandlePostToolLogsTestRun(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	tk := createStopTicket(t, env, "sess-tests")
+
+	input := &Input{
+		SessionID: "sess-tests",
+		CWD:       projectPath,
+		ToolName:  "Bash",
+		ToolInput: map[string]any{"command": "go test -v ./..."},
+		ToolResponse: map[string]any{
+			"stdout": "--- PASS: TestA (0.00s)\n--- FAIL: TestB (0.00s)\nFAIL\n",
+			"stderr": "",
+		},
+	}
+	if err := HandlePostTool(input); err != nil {
+		t.Fatalf("HandlePostTool() error: %v", err)
+	}
+
+	events := readTodayEvents(t, env.EventsDir)
+	var run *event.Event
+	for i := range events {
+		if events[i].Event == event.TestRun {
+			run = &events[i]
+		}
+	}
+	if run == nil {
+		t.Fatal("no test.run event logged")
+	}
+	if run.Ticket != tk.ID {
+		t.Errorf("test.run ticket = %q, want %q", run.Ticket, tk.ID)
+	}
+	if run.Data["runner"] != "go" || run.Data["status"] != "fail" {
+		t.Errorf("test.run data = %v, want failed go run", run.Data)
+	}
+	if run.Data["passed"] != float64(1) || run.Data["failed"] != float64(1) {
+		t.Errorf("test.run counts = %v/%v, want 1/1", run.Data["passed"], run.Data["failed"])
+	}
+	if failures, _ := run.Data["failures"].([]any); len(failures) != 1 || failures[0] != "TestB" {
+		t.Errorf("test.run failures = %v, want [TestB]", run.Data["failures"])
+	}
+}
+
+func TestHandlePostToolIgnoresNonTestCommands(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	input := &Input{
+		SessionID:    "sess-build",
+		CWD:          projectPath,
+		ToolName:     "Bash",
+		ToolInput:    map[string]any{"command": "go build ./..."},
+		ToolResponse: map[string]any{"exit_code": float64(0)},
+	}
+	if err := HandlePostTool(input); err != nil {
+		t.Fatalf("HandlePostTool() error: %v", err)
+	}
+	for _, ev := range readTodayEvents(t, env.EventsDir) {
+		if ev.Event == event.TestRun {
+			t.Fatalf("unexpected test.run event for a build: %+v", ev)
+		}
+	}
+}
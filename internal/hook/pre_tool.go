@@ -21,8 +21,9 @@ var writingTools = map[string]bool{
 }
 
 // HandlePreTool logs a pre-tool event and warns if a writing tool is used
-// without an active ticket.
-func HandlePreTool(input *Input) (Output, error) {
+// without an active ticket, or if the ticket's edits overlap another active
+// ticket's.
+func HandlePreTool(input *Input) (out Output, err error) {
 	cfg, err := config.Load()
 	if err != nil {
 		return Output{}, nil // Don't fail on config errors for async hooks
@@ -79,9 +80,21 @@ func HandlePreTool(input *Input) (Output, error) {
 		Data:    data,
 	})
 
+	// Attach overlap warnings to whatever decision is returned below.
+	if ticketID != "" {
+		if warning := overlapWarning(cfg, el, eventsDir, proj, ticketID, input); warning != "" {
+			defer func() {
+				if out.AdditionalContext == "" {
+					out.AdditionalContext = wrapAdditionalContext(warning)
+				}
+			}()
+		}
+	}
+
 	// Hard-block writing tools when no active ticket is assigned to the run.
 	if writingTools[input.ToolName] && ticketID == "" && proj != "" {
 		msg := missingTicketWriteBlockMessage(input.SessionID)
+		logBuiltinDenial(el, input, ticketID, proj, "missing-ticket", msg, nil)
 		return Output{
 			AdditionalContext: msg,
 			Decision: &Decision{
@@ -94,6 +107,25 @@ func HandlePreTool(input *Input) (Output, error) {
 
 	// Hard-block git commit commands that contain attribution trailers.
 	if msg := rejectCommitAttribution(input); msg != "" {
+		logBuiltinDenial(el, input, ticketID, proj, "commit-attribution", msg, nil)
+		return Output{
+			Decision: &Decision{
+				HookEventName: "PreToolUse",
+				Behavior:      "deny",
+				Reason:        msg,
+			},
+		}, nil
+	}
+
+	// Hard-block writes containing credentials or high-entropy secrets.
+	if findings := scanWriteForSecrets(cfg, proj, input); len(findings) > 0 {
+		msg := secretBlockMessage(proj, findings)
+		logBuiltinDenial(el, input, ticketID, proj, findings[0].Kind, findings[0].String(), map[string]any{
+			"ruleset":   secretScanRuleset,
+			"file_path": findings[0].File,
+			"line":      findings[0].Line,
+			"findings":  len(findings),
+		})
 		return Output{
 			Decision: &Decision{
 				HookEventName: "PreToolUse",
@@ -109,7 +141,12 @@ func HandlePreTool(input *Input) (Output, error) {
 		return Output{}, nil
 	}
 
-	result := rules.Evaluate(rulesDir, "PreToolUse", input.ToolName, input.ToolInput)
+	var projectRulesDir string
+	if proj != "" {
+		projectRulesDir, _ = cfg.ProjectRulesDir(proj)
+	}
+
+	result := rules.EvaluateLayers(rulesDir, projectRulesDir, proj, "PreToolUse", input.ToolName, input.ToolInput)
 	if result == nil {
 		return Output{}, nil
 	}
@@ -121,6 +158,7 @@ func HandlePreTool(input *Input) (Output, error) {
 			"ruleset":  result.Ruleset,
 			"rule":     result.Rule,
 			"reason":   result.Reason,
+			"origin":   result.Origin,
 		}
 		// Include the command context for display in the Rules UI.
 		switch input.ToolName {
@@ -167,6 +205,43 @@ func HandlePreTool(input *Input) (Output, error) {
 	}
 }
 
+// builtinRuleset is the ruleset name logged on hook.rule-decision events for
+// denials by the hook's own checks rather than the rules.
+const builtinRuleset = "st-hook"
+
+// logBuiltinDenial logs a hook.rule-decision event for a denial made by a
+// built-in hook check. The event is marked "builtin" so rule replays skip
+// it; extra adds to or overrides the event data.
+func logBuiltinDenial(el *event.EventLog, input *Input, ticketID, proj, rule, reason string, extra map[string]any) {
+	data := map[string]any{
+		"tool":     input.ToolName,
+		"decision": string(rules.ActionDeny),
+		"ruleset":  builtinRuleset,
+		"rule":     rule,
+		"reason":   reason,
+		"builtin":  true,
+	}
+	if cmd, ok := input.ToolInput["command"].(string); ok && input.ToolName == "Bash" {
+		data["command"] = cmd
+	}
+	if fp, ok := input.ToolInput["file_path"].(string); ok {
+		data["file_path"] = fp
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	_ = el.Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   event.HookRuleDecision,
+		Ticket:  ticketID,
+		Project: proj,
+		Actor:   "agent",
+		RunID:   input.SessionID,
+		Source:  input.Source,
+		Data:    data,
+	})
+}
+
 func missingTicketWriteBlockMessage(runID string) string {
 	if runID == "" {
 		return "BLOCKED: write/edit tools require an active smoovtask ticket assigned to this run. " +
@@ -74,7 +74,7 @@ func TestHandlePreToolNoConfig(t *testing.T) {
 
 func TestHandlePreToolBlocksWriteWithoutTicket(t *testing.T) {
 	projectPath := t.TempDir()
-	setupTestEnv(t, projectPath)
+	env := setupTestEnv(t, projectPath)
 
 	input := &Input{
 		SessionID: "sess-no-ticket",
@@ -105,6 +105,13 @@ func TestHandlePreToolBlocksWriteWithoutTicket(t *testing.T) {
 	if !strings.Contains(out.Decision.Reason, "st pick") {
 		t.Error("decision reason should include remediation")
 	}
+
+	// The denial is logged as a built-in decision so rule replays skip it.
+	events := readTodayEvents(t, env.EventsDir)
+	last := events[len(events)-1]
+	if last.Event != event.HookRuleDecision || last.Data["rule"] != "missing-ticket" || last.Data["builtin"] != true {
+		t.Errorf("last event = %+v, want a built-in missing-ticket rule decision", last)
+	}
 }
 
 func TestHandlePreToolNoWarningWithActiveTicket(t *testing.T) {
@@ -450,6 +457,80 @@ rules:
 	}
 }
 
+func TestHandlePreToolProjectRuleOverlay(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	rulesDir := env.rulesDir(t)
+	overlayDir := filepath.Join(env.projectsDir(t), "test-project", "rules")
+	for _, d := range []string{rulesDir, overlayDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	global := `name: global-tools
+priority: 50
+event: PreToolUse
+rules:
+  - name: allow-git
+    match:
+      tool: Bash
+      command: "^git\\s+"
+    action: allow
+`
+	overlay := `name: cargo
+priority: 50
+event: PreToolUse
+disable:
+  - allow-git
+rules:
+  - name: allow-cargo
+    match:
+      tool: Bash
+      command: "^cargo\\s+test\\b"
+    action: allow
+`
+	if err := os.WriteFile(filepath.Join(rulesDir, "01-test.yaml"), []byte(global), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(overlayDir, "cargo.yaml"), []byte(overlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := HandlePreTool(&Input{
+		SessionID: "sess-overlay",
+		CWD:       projectPath,
+		ToolName:  "Bash",
+		ToolInput: map[string]any{"command": "cargo test"},
+	})
+	if err != nil {
+		t.Fatalf("HandlePreTool() error: %v", err)
+	}
+	if out.Decision == nil || out.Decision.Behavior != "allow" {
+		t.Fatalf("expected project overlay to allow cargo test, got %+v", out.Decision)
+	}
+
+	events := readTodayEvents(t, env.EventsDir)
+	decision := events[len(events)-1]
+	if decision.Event != event.HookRuleDecision || decision.Data["origin"] != "test-project" {
+		t.Errorf("decision event = %s origin %v, want rule-decision from test-project", decision.Event, decision.Data["origin"])
+	}
+
+	// The overlay disables the global git rule for this project.
+	out, err = HandlePreTool(&Input{
+		SessionID: "sess-overlay",
+		CWD:       projectPath,
+		ToolName:  "Bash",
+		ToolInput: map[string]any{"command": "git status"},
+	})
+	if err != nil {
+		t.Fatalf("HandlePreTool() error: %v", err)
+	}
+	if out.Decision != nil {
+		t.Errorf("expected passthrough for disabled global rule, got %+v", out.Decision)
+	}
+}
+
 func TestRejectCommitAttribution(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,103 @@
+package hook
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/secrets"
+	"github.com/boozedog/smoovtask/internal/touched"
+)
+
+// secretScanRuleset is the ruleset name logged on hook.rule-decision events
+// for writes denied by the secret scanner.
+const secretScanRuleset = "secret-scan"
+
+// scanWriteForSecrets scans the content a Write, Edit or MultiEdit call would
+// write. Files matching the project's secrets_ignore patterns are skipped.
+func scanWriteForSecrets(cfg *config.Config, proj string, input *Input) []secrets.Finding {
+	filePath, _ := input.ToolInput["file_path"].(string)
+	if filePath == "" {
+		return nil
+	}
+
+	var ignore []string
+	root := input.CWD
+	if proj != "" {
+		if vaultPath, err := cfg.VaultPath(); err == nil {
+			if meta, err := project.LoadMeta(vaultPath, proj); err == nil && meta != nil {
+				ignore = meta.SecretsIgnore
+				if meta.Path != "" {
+					root = meta.Path
+				}
+			}
+		}
+	}
+	// Paths inside a ticket worktree are made relative to the worktree, so
+	// secrets_ignore patterns match where agents actually edit.
+	rel := filepath.ToSlash(touched.Relative(filePath, root))
+	if secrets.Ignored(rel, ignore) {
+		return nil
+	}
+
+	switch input.ToolName {
+	case "Write":
+		content, _ := input.ToolInput["content"].(string)
+		return secrets.ScanContent(rel, content, 1)
+	case "Edit":
+		oldStr, _ := input.ToolInput["old_string"].(string)
+		newStr, _ := input.ToolInput["new_string"].(string)
+		return secrets.ScanContent(rel, newStr, editStartLine(filePath, oldStr))
+	case "MultiEdit":
+		edits, _ := input.ToolInput["edits"].([]any)
+		var findings []secrets.Finding
+		for _, e := range edits {
+			edit, ok := e.(map[string]any)
+			if !ok {
+				continue
+			}
+			oldStr, _ := edit["old_string"].(string)
+			newStr, _ := edit["new_string"].(string)
+			findings = append(findings, secrets.ScanContent(rel, newStr, editStartLine(filePath, oldStr))...)
+		}
+		return findings
+	}
+	return nil
+}
+
+// editStartLine returns the line in filePath where oldStr starts, so findings
+// in the replacement point at the right place. Defaults to 1.
+func editStartLine(filePath, oldStr string) int {
+	if oldStr == "" {
+		return 1
+	}
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return 1
+	}
+	idx := strings.Index(string(data), oldStr)
+	if idx < 0 {
+		return 1
+	}
+	return strings.Count(string(data[:idx]), "\n") + 1
+}
+
+// secretBlockMessage explains a secret-scan denial and how to resolve it.
+func secretBlockMessage(proj string, findings []secrets.Finding) string {
+	var b strings.Builder
+	b.WriteString("BLOCKED: possible secret in content being written:\n")
+	for _, f := range findings {
+		fmt.Fprintf(&b, "  %s\n", f)
+	}
+	b.WriteString("Load credentials from the environment or a secrets manager instead of hardcoding them.")
+	if proj != "" {
+		fmt.Fprintf(&b, " If this is a test fixture, add its path to `secrets_ignore` in projects/%s/project.md", proj)
+		b.WriteString(" or mark the line with `st:ignore-secret`.")
+	} else {
+		b.WriteString(" If this is a test fixture, mark the line with `st:ignore-secret`.")
+	}
+	return b.String()
+}
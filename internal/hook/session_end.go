@@ -7,7 +7,8 @@ import (
 	"github.com/boozedog/smoovtask/internal/event"
 )
 
-// HandleSessionEnd logs a session end event.
+// HandleSessionEnd logs a session end event, recording any transcript token
+// usage not yet ingested.
 func HandleSessionEnd(input *Input) error {
 	cfg, err := config.Load()
 	if err != nil {
@@ -21,11 +22,14 @@ func HandleSessionEnd(input *Input) error {
 
 	proj := detectProject(cfg, input.CWD)
 
+	ticketID := lookupActiveTicket(cfg, proj, input.SessionID)
+
 	el := event.NewEventLog(eventsDir)
+	recordUsage(cfg, el, input, proj, ticketID, true)
 	return el.Append(event.Event{
 		TS:      time.Now().UTC(),
 		Event:   event.HookSessionEnd,
-		Ticket:  lookupActiveTicket(cfg, proj, input.SessionID),
+		Ticket:  ticketID,
 		Project: proj,
 		Actor:   "agent",
 		RunID:   input.SessionID,
@@ -9,6 +9,7 @@ import (
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/guidance"
+	"github.com/boozedog/smoovtask/internal/knowledge"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
@@ -63,11 +64,12 @@ func HandleSessionStart(input *Input) (*Output, error) {
 	if err != nil {
 		return nil, fmt.Errorf("get events dir: %w", err)
 	}
+	activeID := lookupActiveTicket(cfg, proj, input.SessionID)
 	el := event.NewEventLog(eventsDir)
 	_ = el.Append(event.Event{
 		TS:      time.Now().UTC(),
 		Event:   event.HookSessionStart,
-		Ticket:  lookupActiveTicket(cfg, proj, input.SessionID),
+		Ticket:  activeID,
 		Project: proj,
 		Actor:   "agent",
 		RunID:   input.SessionID,
@@ -94,6 +96,12 @@ func HandleSessionStart(input *Input) (*Output, error) {
 	b.WriteString("\n\n")
 	b.WriteString(quickRefForRole(role))
 
+	if learnings := sessionKnowledge(cfg, store, proj, activeID); learnings != "" {
+		b.WriteString("\n## Project Knowledge\n")
+		b.WriteString("Learnings recorded by earlier sessions (`st learn \"<fact>\"` to add one):\n")
+		b.WriteString(learnings)
+	}
+
 	// Detect recently handed-off tickets (plan-mode-exit) and inject pickup instructions.
 	if pickup := recentHandoffPickup(store, proj, input.SessionID); pickup != "" {
 		b.WriteString("\n")
@@ -103,6 +111,22 @@ func HandleSessionStart(input *Input) (*Output, error) {
 	return &Output{AdditionalContext: wrapAdditionalContext(b.String())}, nil
 }
 
+// sessionKnowledge returns the project learnings most relevant to the
+// session's active ticket, or the newest ones when there is none.
+func sessionKnowledge(cfg *config.Config, store *ticket.Store, proj, activeID string) string {
+	vaultPath, err := cfg.VaultPath()
+	if err != nil {
+		return ""
+	}
+	query := ""
+	if activeID != "" {
+		if tk, err := store.Get(activeID); err == nil {
+			query = tk.Title + "\n" + strings.Join(tk.Tags, " ") + "\n" + tk.Body
+		}
+	}
+	return knowledge.Context(vaultPath, proj, query, cfg.KnowledgeBudget())
+}
+
 // recentHandoffPickup checks for OPEN tickets with no assignee that were
 // updated within the last 5 minutes — these are likely plan-mode handoffs
 // waiting for the new build session to pick them up.
@@ -151,6 +175,11 @@ func noteGuidance() string {
 		"then run `st note --file <ticket-id>-note.md --ticket <ticket-id> --run-id <run-id>` (the file is deleted after reading)\n"
 }
 
+// learnGuidance returns the instruction for recording project learnings.
+func learnGuidance() string {
+	return "- `st learn \"<fact>\" --run-id <run-id>`   record a durable project fact (build quirk, where code lives) for future sessions\n"
+}
+
 func quickRefGeneric() string {
 	return "## Review Semantics\n" +
 		"`st status review` moves work to `REVIEW` (agentic review queue), and `st status human-review` moves it to `HUMAN-REVIEW` (human sign-off queue).\n" +
@@ -171,6 +200,7 @@ func quickRefGeneric() string {
 		"- `st status rework --run-id <run-id>`        send back for changes\n\n" +
 		"## Always\n" +
 		noteGuidance() +
+		learnGuidance() +
 		"- `st show <ticket-id> --run-id <run-id>`   view full ticket details\n" +
 		"- `st context --run-id <run-id>`            check current session context\n\n" +
 		"Run `st --help` for more.\n"
@@ -198,6 +228,7 @@ func quickRefImplementer() string {
 		"- Always commit all changes in the ticket worktree before requesting review — `st status review` will reject uncommitted work\n\n" +
 		"## Always\n" +
 		noteGuidance() +
+		learnGuidance() +
 		"- `st show <ticket-id> --run-id <run-id>`   view full ticket details\n" +
 		"- `st context --run-id <run-id>`            check current session context\n\n" +
 		"Run `st --help` for more.\n"
@@ -211,6 +242,7 @@ func quickRefReviewer() string {
 		"- `st status rework --run-id <run-id>`        send back for changes\n\n" +
 		"## Always\n" +
 		noteGuidance() +
+		learnGuidance() +
 		"- `st show <ticket-id> --run-id <run-id>`   view full ticket details\n" +
 		"- `st context --run-id <run-id>`            check current session context\n\n" +
 		"Run `st --help` for more.\n"
@@ -1,11 +1,13 @@
 package hook
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/knowledge"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
@@ -110,6 +112,9 @@ func TestHandleSessionStartMinimalOutput(t *testing.T) {
 	if !strings.Contains(ctx, "st handoff <ticket-id> --run-id <run-id>") {
 		t.Error("missing handoff command in implementing quick reference")
 	}
+	if !strings.Contains(ctx, "st learn \"<fact>\" --run-id <run-id>") {
+		t.Error("missing learn command in quick reference")
+	}
 	if !strings.Contains(ctx, "st --help") {
 		t.Error("missing help reference")
 	}
@@ -261,3 +266,28 @@ func TestHandleSessionStartRoleAwareWorker(t *testing.T) {
 		t.Error("worker context should not include implementer commands")
 	}
 }
+
+func TestHandleSessionStartInjectsKnowledge(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	kb, err := knowledge.Load(filepath.Join(env.Home, "vault"), "test-project")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := kb.Add(knowledge.Entry{Fact: "Run make generate before go build", Ticket: "st_kn0001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := kb.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := HandleSessionStart(&Input{SessionID: "sess-kb", CWD: projectPath})
+	if err != nil {
+		t.Fatalf("HandleSessionStart() error: %v", err)
+	}
+	ctx := out.AdditionalContext
+	if !strings.Contains(ctx, "## Project Knowledge") || !strings.Contains(ctx, "- Run make generate before go build — _st_kn0001_") {
+		t.Errorf("missing project knowledge:\n%s", ctx)
+	}
+}
@@ -1,34 +1,158 @@
 package hook
 
 import (
+	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/guidance"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/spawn"
+	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
-// HandleStop logs a session stop event.
-func HandleStop(input *Input) error {
+// HandleStop logs a session stop event, recording transcript token usage
+// first. When the session's ticket is still in progress with uncommitted
+// changes, no notes since pick, or not yet submitted or handed off, the stop
+// is blocked with instructions — at most StopMaxReprompts times per session.
+func HandleStop(input *Input) (Output, error) {
 	cfg, err := config.Load()
 	if err != nil {
-		return nil
+		return Output{}, nil
 	}
 
 	eventsDir, err := cfg.EventsDir()
 	if err != nil {
-		return nil
+		return Output{}, nil
 	}
 
 	proj := detectProject(cfg, input.CWD)
 
+	ticketID := lookupActiveTicket(cfg, proj, input.SessionID)
+
 	el := event.NewEventLog(eventsDir)
-	return el.Append(event.Event{
+	recordUsage(cfg, el, input, proj, ticketID, true)
+
+	var out Output
+	var data map[string]any
+	if reason, reprompt := stopHygiene(cfg, eventsDir, input, proj, ticketID); reason != "" {
+		out = Output{StopDecision: "block", Reason: reason}
+		data = map[string]any{"blocked": true, "reprompt": reprompt}
+	}
+
+	return out, el.Append(event.Event{
 		TS:      time.Now().UTC(),
 		Event:   event.HookStop,
-		Ticket:  lookupActiveTicket(cfg, proj, input.SessionID),
+		Ticket:  ticketID,
 		Project: proj,
 		Actor:   "agent",
 		RunID:   input.SessionID,
 		Source:  input.Source,
+		Data:    data,
 	})
 }
+
+// stopHygiene returns the instructions that block the stop, and which
+// re-prompt this is, or "" when the session may stop. Only Claude Code and
+// Gemini CLI honour a blocking stop decision.
+func stopHygiene(cfg *config.Config, eventsDir string, input *Input, proj, ticketID string) (string, int) {
+	if ticketID == "" || (input.Source != "claude" && input.Source != "gemini") {
+		return "", 0
+	}
+	limit := cfg.StopMaxReprompts()
+	if limit == 0 {
+		return "", 0
+	}
+
+	runEvents, err := event.QueryEvents(eventsDir, event.Query{RunID: input.SessionID})
+	if err != nil {
+		return "", 0
+	}
+	blocked := 0
+	for _, ev := range runEvents {
+		if ev.Event == event.HookStop && ev.Data["blocked"] == true {
+			blocked++
+		}
+	}
+	if blocked >= limit {
+		return "", 0
+	}
+
+	projectsDir, err := cfg.ProjectsDir()
+	if err != nil {
+		return "", 0
+	}
+	tk, err := ticket.NewStore(projectsDir).Get(ticketID)
+	if err != nil {
+		return "", 0
+	}
+
+	var steps []string
+	if wt := ticketWorktree(cfg, proj, input.CWD, ticketID); wt != "" {
+		if clean, err := spawn.WorktreeIsClean(wt); err == nil && !clean {
+			steps = append(steps, fmt.Sprintf("Commit your changes — the worktree %s has uncommitted changes.", wt))
+		}
+	}
+	if !hasNoteSincePick(eventsDir, ticketID) {
+		note := strings.NewReplacer("<ticket-id>", ticketID, "<run-id>", input.SessionID).Replace(guidance.NoteHowTo())
+		steps = append(steps, "Add a note summarizing your progress — there are no notes since you picked the ticket. "+note)
+	}
+	steps = append(steps, fmt.Sprintf(
+		"Submit with `st status review --ticket %s --run-id %s` if the work is complete, "+
+			"or return the ticket with `st handoff --ticket %s --run-id %s`.",
+		ticketID, input.SessionID, ticketID, input.SessionID))
+
+	reprompt := blocked + 1
+	var b strings.Builder
+	fmt.Fprintf(&b, "BLOCKED: ticket %s (%s) is still %s and assigned to this session. Before stopping:\n",
+		ticketID, tk.Title, tk.Status)
+	for i, step := range steps {
+		fmt.Fprintf(&b, "%d. %s\n", i+1, step)
+	}
+	fmt.Fprintf(&b, "(stop re-prompt %d of %d)", reprompt, limit)
+	return b.String(), reprompt
+}
+
+// ticketWorktree returns the ticket's worktree path, or "" if it has none.
+func ticketWorktree(cfg *config.Config, proj, cwd, ticketID string) string {
+	repoRoot := ""
+	if vaultPath, err := cfg.VaultPath(); err == nil {
+		if meta, err := project.LoadMeta(vaultPath, proj); err == nil && meta != nil {
+			repoRoot = meta.Path
+		}
+	}
+	if repoRoot == "" {
+		root, err := spawn.WorktreeRepoRoot(cwd)
+		if err != nil {
+			return ""
+		}
+		repoRoot = root
+	}
+	wt := spawn.WorktreePath(repoRoot, ticketID)
+	if _, err := os.Stat(wt); err != nil {
+		return ""
+	}
+	return wt
+}
+
+// hasNoteSincePick reports whether a note was added after the ticket last
+// moved to IN-PROGRESS or REWORK.
+func hasNoteSincePick(eventsDir, ticketID string) bool {
+	events, err := event.QueryEvents(eventsDir, event.Query{TicketID: ticketID})
+	if err != nil {
+		return true
+	}
+	noted := false
+	for _, ev := range events {
+		switch ev.Event {
+		case event.StatusInProgress, event.StatusRework:
+			noted = false
+		case event.TicketNote:
+			noted = true
+		}
+	}
+	return noted
+}
@@ -1,9 +1,15 @@
 package hook
 
 import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
 func TestHandleStop(t *testing.T) {
@@ -15,7 +21,7 @@ func TestHandleStop(t *testing.T) {
 		CWD:       projectPath,
 	}
 
-	if err := HandleStop(input); err != nil {
+	if _, err := HandleStop(input); err != nil {
 		t.Fatalf("HandleStop() error: %v", err)
 	}
 
@@ -31,7 +37,7 @@ func TestHandleStopNoProject(t *testing.T) {
 		CWD:       "/some/unknown/path",
 	}
 
-	if err := HandleStop(input); err != nil {
+	if _, err := HandleStop(input); err != nil {
 		t.Fatalf("HandleStop() error: %v", err)
 	}
 
@@ -47,7 +53,167 @@ func TestHandleStopNoConfig(t *testing.T) {
 		CWD:       "/tmp",
 	}
 
-	if err := HandleStop(input); err != nil {
+	if _, err := HandleStop(input); err != nil {
 		t.Fatalf("HandleStop() should not error on missing config, got: %v", err)
 	}
 }
+
+// createStopTicket creates an in-progress ticket assigned to sessionID.
+func createStopTicket(t *testing.T, env testEnv, sessionID string) *ticket.Ticket {
+	t.Helper()
+	store := ticket.NewStore(env.projectsDir(t))
+	tk := &ticket.Ticket{
+		ID:       "st_stop01",
+		Title:    "Unfinished work",
+		Project:  "test-project",
+		Status:   ticket.StatusInProgress,
+		Assignee: sessionID,
+		Priority: ticket.PriorityP2,
+		Created:  time.Now().UTC(),
+		Updated:  time.Now().UTC(),
+	}
+	if err := store.Create(tk); err != nil {
+		t.Fatalf("create ticket: %v", err)
+	}
+	return tk
+}
+
+func TestHandleStopBlocksUnfinishedTicket(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	tk := createStopTicket(t, env, "sess-block")
+
+	// A worktree with an uncommitted file.
+	wt := filepath.Join(projectPath, ".worktrees", tk.ID)
+	if err := os.MkdirAll(wt, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "init", "-q", wt).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(wt, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	input := &Input{SessionID: "sess-block", CWD: projectPath, Source: "claude"}
+	out, err := HandleStop(input)
+	if err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	if out.StopDecision != "block" {
+		t.Fatalf("StopDecision = %q, want block", out.StopDecision)
+	}
+	for _, want := range []string{tk.ID, "uncommitted changes", "no notes since you picked", "st status review", "st handoff", "re-prompt 1 of 3"} {
+		if !strings.Contains(out.Reason, want) {
+			t.Errorf("reason missing %q:\n%s", want, out.Reason)
+		}
+	}
+
+	ev := readTodayEvent(t, env.EventsDir)
+	assertEvent(t, ev, event.HookStop, "sess-block", "test-project")
+	if ev.Ticket != tk.ID || ev.Data["blocked"] != true {
+		t.Errorf("stop event = %+v, want blocked stop for %s", ev, tk.ID)
+	}
+}
+
+func TestHandleStopRepromptLimit(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	createStopTicket(t, env, "sess-limit")
+
+	input := &Input{SessionID: "sess-limit", CWD: projectPath, Source: "claude"}
+	for i := 1; i <= 3; i++ {
+		out, err := HandleStop(input)
+		if err != nil {
+			t.Fatalf("HandleStop() #%d error: %v", i, err)
+		}
+		if out.StopDecision != "block" {
+			t.Fatalf("stop #%d not blocked", i)
+		}
+	}
+
+	out, err := HandleStop(input)
+	if err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	if out.StopDecision != "" {
+		t.Errorf("stop after limit blocked again: %q", out.Reason)
+	}
+}
+
+func TestHandleStopAllowsNotedTicketWithoutWorktree(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	tk := createStopTicket(t, env, "sess-noted")
+
+	el := event.NewEventLog(env.EventsDir)
+	for _, name := range []string{event.StatusInProgress, event.TicketNote} {
+		if err := el.Append(event.Event{TS: time.Now().UTC(), Event: name, Ticket: tk.ID, Project: "test-project", Actor: "agent", RunID: "sess-noted"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, err := HandleStop(&Input{SessionID: "sess-noted", CWD: projectPath, Source: "claude"})
+	if err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	// Still in progress, so the agent must submit or hand off.
+	if out.StopDecision != "block" {
+		t.Fatal("expected stop to be blocked while the ticket is in progress")
+	}
+	if strings.Contains(out.Reason, "uncommitted") || strings.Contains(out.Reason, "no notes") {
+		t.Errorf("reason should only ask to submit or hand off:\n%s", out.Reason)
+	}
+}
+
+func TestHandleStopBlockingDisabled(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	createStopTicket(t, env, "sess-off")
+
+	configPath := filepath.Join(env.ConfigDir, "config.toml")
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, []byte("\n[hooks]\nstop_max_reprompts = -1\n")...)
+	if err := os.WriteFile(configPath, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := HandleStop(&Input{SessionID: "sess-off", CWD: projectPath, Source: "claude"})
+	if err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	if out.StopDecision != "" {
+		t.Errorf("stop blocked with blocking disabled: %q", out.Reason)
+	}
+}
+
+func TestHandleStopNonClaudeNeverBlocks(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	createStopTicket(t, env, "sess-oc")
+
+	out, err := HandleStop(&Input{SessionID: "sess-oc", CWD: projectPath, Source: "opencode"})
+	if err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	if out.StopDecision != "" {
+		t.Errorf("opencode stop blocked: %q", out.Reason)
+	}
+}
+
+func TestHandleStopBlocksGemini(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	createStopTicket(t, env, "sess-gem")
+
+	out, err := HandleStop(&Input{SessionID: "sess-gem", CWD: projectPath, Source: "gemini"})
+	if err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	if out.StopDecision != "block" {
+		t.Errorf("gemini stop not blocked, want block for unfinished ticket")
+	}
+}
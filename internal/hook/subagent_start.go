@@ -3,21 +3,32 @@ package hook
 import (
 	"fmt"
 	"regexp"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/guidance"
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
 
 var ticketIDPattern = regexp.MustCompile(`st_[a-zA-Z0-9]{6}`)
 
+// maxSubagentTickets caps how many referenced tickets get full context; the
+// rest are listed by ID.
+const maxSubagentTickets = 5
+
+// acceptanceHeadingRe matches an "Acceptance Criteria" heading or bold label.
+var acceptanceHeadingRe = regexp.MustCompile(`(?i)^\s*(?:#{1,6}\s*|\*\*)?acceptance criteria\b`)
+
 // HandleSubagentStart processes the SubagentStart hook.
-// It parses the task prompt for a ticket ID and injects ticket metadata.
+// It parses the task prompt for ticket IDs and injects each ticket's context:
+// metadata, worktree, dependencies, the latest review findings, acceptance
+// criteria, description and recent notes, within the configured size budget.
 // Workflow directives are intentionally omitted — the parent agent's task
 // prompt is the subagent's primary directive.
 func HandleSubagentStart(input *Input) (Output, error) {
-	// Try to extract a ticket ID from the task prompt
-	ticketID := ticketIDPattern.FindString(input.TaskPrompt)
-	if ticketID == "" {
+	ids := uniqueTicketIDs(input.TaskPrompt)
+	if len(ids) == 0 {
 		return Output{}, nil
 	}
 
@@ -32,17 +43,249 @@ func HandleSubagentStart(input *Input) (Output, error) {
 	}
 
 	store := ticket.NewStore(projectsDir)
-	tk, err := store.Get(ticketID)
-	if err != nil {
-		// Ticket not found — don't fail, just skip context injection
+	var tickets []*ticket.Ticket
+	for _, id := range ids {
+		tk, err := store.Get(id)
+		if err != nil {
+			// Ticket not found — don't fail, just skip it
+			continue
+		}
+		tickets = append(tickets, tk)
+	}
+	if len(tickets) == 0 {
 		return Output{}, nil
 	}
 
-	ctx := fmt.Sprintf(
-		"smoovtask ticket context: %s — %s (project: %s, priority: %s, status: %s)\n"+
-			"Log progress: write your note to `%s-note.md` in the current directory using the Write tool, then run `st note --file %s-note.md --ticket %s --run-id <your-run-id>` (the file is deleted after reading)",
-		tk.ID, tk.Title, tk.Project, tk.Priority, tk.Status, tk.ID, tk.ID, tk.ID,
-	)
+	return Output{AdditionalContext: subagentContext(cfg, store, input.CWD, tickets)}, nil
+}
+
+// uniqueTicketIDs returns the ticket IDs in s in order of first mention.
+func uniqueTicketIDs(s string) []string {
+	var ids []string
+	seen := map[string]bool{}
+	for _, id := range ticketIDPattern.FindAllString(s, -1) {
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// subagentContext renders the context for tickets, splitting the size budget
+// evenly between them after the shared commit rules.
+func subagentContext(cfg *config.Config, store *ticket.Store, cwd string, tickets []*ticket.Ticket) string {
+	shown, extra := tickets, []*ticket.Ticket(nil)
+	if len(shown) > maxSubagentTickets {
+		shown, extra = tickets[:maxSubagentTickets], tickets[maxSubagentTickets:]
+	}
+
+	var footer strings.Builder
+	if len(extra) > 0 {
+		ids := make([]string, len(extra))
+		for i, tk := range extra {
+			ids[i] = tk.ID
+		}
+		fmt.Fprintf(&footer, "\nAlso referenced (run `st show <id>` for details): %s\n", strings.Join(ids, ", "))
+	}
+	footer.WriteString("\nCommit rules: ")
+	footer.WriteString(guidance.CommitRules())
+	footer.WriteString("\n")
+
+	perTicket := (cfg.SubagentContextBudget() - footer.Len()) / len(shown)
+
+	var b strings.Builder
+	for i, tk := range shown {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		worktree := ticketWorktree(cfg, tk.Project, cwd, tk.ID)
+		b.WriteString(ticketContext(store, tk, worktree, perTicket))
+	}
+	b.WriteString(footer.String())
+	return b.String()
+}
+
+// contextPart is an optional block of ticket context, added in priority
+// order while the budget lasts.
+type contextPart struct {
+	title string
+	text  string
+}
+
+// omittedReserve keeps room for the note listing parts dropped for size.
+const omittedReserve = 120
+
+// minPartSize is the smallest useful slice of an optional part; below it the
+// part is omitted rather than truncated.
+const minPartSize = 80
+
+// ticketContext renders one ticket's context within budget bytes. The
+// header, worktree, dependencies and note instructions are always included;
+// review findings, acceptance criteria, description and notes follow in that
+// order, truncated to fit.
+func ticketContext(store *ticket.Store, tk *ticket.Ticket, worktree string, budget int) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "smoovtask ticket context: %s — %s (project: %s, priority: %s, status: %s)\n",
+		tk.ID, tk.Title, tk.Project, tk.Priority, tk.Status)
+	if worktree != "" {
+		fmt.Fprintf(&b, "Worktree: %s (branch st/%s) — work and commit there\n", worktree, tk.ID)
+	}
+	if deps := dependencySummary(store, tk); deps != "" {
+		fmt.Fprintf(&b, "Dependencies: %s\n", deps)
+	}
+	fmt.Fprintf(&b, "Log progress: write your note to `%s-note.md` in the current directory using the Write tool, then run `st note --file %s-note.md --ticket %s --run-id <your-run-id>` (the file is deleted after reading)\n",
+		tk.ID, tk.ID, tk.ID)
+
+	remaining := budget - b.Len() - omittedReserve
+	var omitted []string
+	for _, part := range ticketContextParts(tk) {
+		header := fmt.Sprintf("\n### %s\n", part.title)
+		room := remaining - len(header) - 1
+		if room < minPartSize {
+			omitted = append(omitted, strings.ToLower(part.title))
+			continue
+		}
+		text := truncateContext(part.text, room)
+		b.WriteString(header)
+		b.WriteString(text)
+		b.WriteString("\n")
+		remaining -= len(header) + len(text) + 1
+	}
+	if len(omitted) > 0 {
+		fmt.Fprintf(&b, "\n(Omitted for size: %s — run `st show %s` for the full ticket.)\n", strings.Join(omitted, ", "), tk.ID)
+	}
+	return b.String()
+}
+
+// ticketContextParts extracts the optional context blocks from the ticket
+// body, most important first.
+func ticketContextParts(tk *ticket.Ticket) []contextPart {
+	sections := ticket.Sections(tk.Body)
+	var parts []contextPart
+
+	findings, hasFindings := ticket.ReworkFindings(sections)
+	if hasFindings {
+		parts = append(parts, contextPart{
+			title: fmt.Sprintf("Latest review findings (rework requested %s)", findings.TS.Format("2006-01-02 15:04")),
+			text:  findings.Content,
+		})
+	}
+
+	var criteria string
+	for _, s := range sections {
+		if c := acceptanceCriteria(s.Content); c != "" {
+			criteria = c
+		}
+	}
+	if criteria != "" {
+		parts = append(parts, contextPart{title: "Acceptance criteria", text: criteria})
+	}
+
+	if created, ok := ticket.LastSection(sections, "Created"); ok {
+		desc := created.Content
+		if criteria != "" {
+			desc = removeAcceptanceCriteria(desc)
+		}
+		if desc != "" && desc != tk.Title {
+			parts = append(parts, contextPart{title: "Description", text: desc})
+		}
+	}
+
+	var notes []string
+	for i := len(sections) - 1; i >= 0; i-- {
+		s := sections[i]
+		if s.Heading != "Note" || s.Content == "" || (hasFindings && s.TS.Equal(findings.TS) && s.Content == findings.Content) {
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("- %s (%s): %s", s.TS.Format("2006-01-02 15:04"), s.Actor, s.Content))
+	}
+	if len(notes) > 0 {
+		parts = append(parts, contextPart{title: "Recent notes (newest first)", text: strings.Join(notes, "\n")})
+	}
+	return parts
+}
+
+// acceptanceCriteria returns the block following an "Acceptance Criteria"
+// heading or bold label, up to the next heading.
+func acceptanceCriteria(content string) string {
+	start, end := acceptanceBlock(content)
+	if start < 0 {
+		return ""
+	}
+	lines := strings.Split(content, "\n")
+	return strings.TrimSpace(strings.Join(lines[start+1:end], "\n"))
+}
+
+// removeAcceptanceCriteria strips the acceptance criteria block from content.
+func removeAcceptanceCriteria(content string) string {
+	start, end := acceptanceBlock(content)
+	if start < 0 {
+		return content
+	}
+	lines := strings.Split(content, "\n")
+	return strings.TrimSpace(strings.Join(append(lines[:start:start], lines[end:]...), "\n"))
+}
+
+// acceptanceBlock returns the line range [start, end) of the acceptance
+// criteria block, or -1 if content has none.
+func acceptanceBlock(content string) (int, int) {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if !acceptanceHeadingRe.MatchString(line) {
+			continue
+		}
+		end := len(lines)
+		for j := i + 1; j < len(lines); j++ {
+			if strings.HasPrefix(strings.TrimSpace(lines[j]), "#") {
+				end = j
+				break
+			}
+		}
+		return i, end
+	}
+	return -1, -1
+}
+
+// dependencySummary lists the ticket's dependencies with their statuses;
+// unresolved ones include their title.
+func dependencySummary(store *ticket.Store, tk *ticket.Ticket) string {
+	var deps []string
+	for _, id := range tk.DependsOn {
+		dep, err := store.Get(id)
+		switch {
+		case err != nil:
+			deps = append(deps, id+" (not found)")
+		case dep.Status == ticket.StatusDone || dep.Status == ticket.StatusCancelled:
+			deps = append(deps, fmt.Sprintf("%s (%s)", id, dep.Status))
+		default:
+			deps = append(deps, fmt.Sprintf("%s (%s: %s)", id, dep.Status, dep.Title))
+		}
+	}
+	return strings.Join(deps, ", ")
+}
 
-	return Output{AdditionalContext: ctx}, nil
+// truncationMarker ends text cut to fit the context budget.
+const truncationMarker = "\n… (truncated)"
+
+// truncateContext cuts s to at most n bytes, preferring a line or word
+// boundary in the second half, and marks the cut.
+func truncateContext(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	cut := n - len(truncationMarker)
+	if cut <= 0 {
+		return ""
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	head := s[:cut]
+	if i := strings.LastIndexByte(head, '\n'); i > cut/2 {
+		head = head[:i]
+	} else if i := strings.LastIndexByte(head, ' '); i > cut/2 {
+		head = head[:i]
+	}
+	return strings.TrimRight(head, " \n") + truncationMarker
 }
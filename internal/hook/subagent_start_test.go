@@ -1,9 +1,12 @@
 package hook
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/boozedog/smoovtask/internal/ticket"
 )
@@ -151,3 +154,118 @@ func TestHandleSubagentStartNoTicket(t *testing.T) {
 		t.Errorf("expected empty context, got: %q", out.AdditionalContext)
 	}
 }
+
+func TestUniqueTicketIDs(t *testing.T) {
+	got := uniqueTicketIDs("Fix st_aaaaa1 then st_bbbbb2; st_aaaaa1 again")
+	if strings.Join(got, ",") != "st_aaaaa1,st_bbbbb2" {
+		t.Errorf("uniqueTicketIDs() = %v", got)
+	}
+}
+
+func TestHandleSubagentStartRichContext(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+	store := ticket.NewStore(env.projectsDir(t))
+	now := time.Now().UTC()
+
+	dep := &ticket.Ticket{ID: "st_dep001", Title: "Schema migration", Project: "test-project", Status: ticket.StatusInProgress, Priority: ticket.PriorityP2, Created: now, Updated: now}
+	if err := store.Create(dep); err != nil {
+		t.Fatal(err)
+	}
+
+	tk := &ticket.Ticket{
+		ID: "st_rich01", Title: "Add rate limiting", Project: "test-project",
+		Status: ticket.StatusRework, Priority: ticket.PriorityP1,
+		DependsOn: []string{"st_dep001", "st_gone01"}, Created: now, Updated: now,
+	}
+	ticket.AppendSection(tk, "Created", "human", "", "Limit requests per API key.\n\n## Acceptance Criteria\n- 429 after 100 req/min\n- Retry-After header", nil, now)
+	ticket.AppendSection(tk, "Note", "agent", "run-1", "Added token bucket middleware.", nil, now.Add(time.Minute))
+	ticket.AppendSection(tk, "Note", "agent", "run-2", "Retry-After header is missing on 429 responses.", nil, now.Add(2*time.Minute))
+	ticket.AppendSection(tk, "Rework", "agent", "run-2", "", nil, now.Add(2*time.Minute))
+	if err := store.Create(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	other := &ticket.Ticket{ID: "st_othr01", Title: "Update docs", Project: "test-project", Status: ticket.StatusOpen, Priority: ticket.PriorityP3, Created: now, Updated: now}
+	if err := store.Create(other); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(projectPath, ".worktrees", "st_rich01"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := HandleSubagentStart(&Input{
+		CWD:        projectPath,
+		TaskPrompt: "Address the review on st_rich01, then update docs in st_othr01 (see st_rich01)",
+	})
+	if err != nil {
+		t.Fatalf("HandleSubagentStart() error: %v", err)
+	}
+
+	ctx := out.AdditionalContext
+	for _, want := range []string{
+		"smoovtask ticket context: st_rich01 — Add rate limiting",
+		"Worktree: " + filepath.Join(projectPath, ".worktrees", "st_rich01"),
+		"Dependencies: st_dep001 (IN-PROGRESS: Schema migration), st_gone01 (not found)",
+		"### Latest review findings",
+		"Retry-After header is missing on 429 responses.",
+		"### Acceptance criteria\n- 429 after 100 req/min\n- Retry-After header",
+		"### Description\nLimit requests per API key.\n",
+		"Added token bucket middleware.",
+		"smoovtask ticket context: st_othr01 — Update docs",
+		"--ticket st_othr01",
+		"commit.gpgsign=false",
+	} {
+		if !strings.Contains(ctx, want) {
+			t.Errorf("context missing %q:\n%s", want, ctx)
+		}
+	}
+	if strings.Count(ctx, "smoovtask ticket context: st_rich01") != 1 {
+		t.Errorf("st_rich01 repeated in context:\n%s", ctx)
+	}
+	// The findings note is not repeated under recent notes.
+	if strings.Count(ctx, "Retry-After header is missing") != 1 {
+		t.Errorf("findings duplicated in notes:\n%s", ctx)
+	}
+	if strings.Index(ctx, "### Latest review findings") > strings.Index(ctx, "### Description") {
+		t.Error("review findings should come before the description")
+	}
+}
+
+func TestTicketContextBudget(t *testing.T) {
+	setupTestEnv(t, t.TempDir())
+	now := time.Now().UTC()
+	tk := &ticket.Ticket{ID: "st_big001", Title: "Big ticket", Project: "test-project", Status: ticket.StatusInProgress, Priority: ticket.PriorityP2, Created: now, Updated: now}
+	ticket.AppendSection(tk, "Created", "human", "", strings.Repeat("Long description line.\n", 200), nil, now)
+	for i := 0; i < 20; i++ {
+		ticket.AppendSection(tk, "Note", "agent", "run-1", strings.Repeat("progress ", 30), nil, now.Add(time.Duration(i)*time.Minute))
+	}
+
+	ctx := ticketContext(nil, tk, "", 1500)
+	if len(ctx) > 1500 {
+		t.Errorf("context is %d bytes, want <= 1500", len(ctx))
+	}
+	if !strings.Contains(ctx, "--ticket st_big001") {
+		t.Error("note instructions dropped under budget")
+	}
+	if !strings.Contains(ctx, truncationMarker) {
+		t.Errorf("description not truncated:\n%s", ctx)
+	}
+	if !strings.Contains(ctx, "Omitted for size: recent notes (newest first)") {
+		t.Errorf("missing omitted notice:\n%s", ctx)
+	}
+}
+
+func TestTruncateContext(t *testing.T) {
+	if got := truncateContext("short", 100); got != "short" {
+		t.Errorf("truncateContext(short) = %q", got)
+	}
+	got := truncateContext("first line\nsecond line\nthird line that is long", 40)
+	if got != "first line\nsecond line"+truncationMarker {
+		t.Errorf("truncateContext() = %q", got)
+	}
+	if got := truncateContext(strings.Repeat("é", 50), 40); !strings.HasSuffix(got, truncationMarker) || !utf8.ValidString(got) {
+		t.Errorf("truncateContext(multibyte) = %q", got)
+	}
+}
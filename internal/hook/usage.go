@@ -0,0 +1,75 @@
+package hook
+
+import (
+	"os"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/usage"
+)
+
+// Post-tool ingestion is throttled: the transcript is only re-read once it
+// has grown by usageIngestBytes or usageIngestInterval has passed.
+const (
+	usageIngestBytes    = 256 << 10
+	usageIngestInterval = 5 * time.Minute
+)
+
+// recordUsage ingests new transcript lines for the session and logs one
+// usage.recorded event per model, attributed to the active ticket and run.
+// Unless force is set, ingestion is skipped while the transcript has grown
+// little since the last ingest. The session's state stays locked for the
+// whole ingest, since post-tool, stop and session-end hooks can overlap.
+// Errors are ignored: usage accounting must not break the agent.
+func recordUsage(cfg *config.Config, el *event.EventLog, input *Input, proj, ticketID string, force bool) {
+	if input.TranscriptPath == "" || input.SessionID == "" {
+		return
+	}
+	stateDir, err := cfg.UsageDir()
+	if err != nil {
+		return
+	}
+	unlock, err := usage.LockState(stateDir, input.SessionID)
+	if err != nil {
+		return
+	}
+	defer unlock()
+	state, err := usage.LoadState(stateDir, input.SessionID)
+	if err != nil {
+		state = &usage.State{}
+	}
+
+	if !force {
+		info, err := os.Stat(input.TranscriptPath)
+		if err != nil {
+			return
+		}
+		grown := info.Size() - state.Offset
+		if grown < usageIngestBytes && time.Since(state.LastIngest) < usageIngestInterval {
+			return
+		}
+	}
+
+	totals, err := usage.ReadTranscript(input.TranscriptPath, state)
+	if err != nil {
+		return
+	}
+
+	now := time.Now().UTC()
+	for _, model := range totals.Models() {
+		_ = el.Append(event.Event{
+			TS:      now,
+			Event:   event.UsageRecorded,
+			Ticket:  ticketID,
+			Project: proj,
+			Actor:   "agent",
+			RunID:   input.SessionID,
+			Source:  input.Source,
+			Data:    totals[model].Data(model),
+		})
+	}
+
+	state.LastIngest = now
+	_ = usage.SaveState(stateDir, input.SessionID, state)
+}
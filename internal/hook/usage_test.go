@@ -0,0 +1,136 @@
+package hook
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+const usageTranscript = `{"type":"user","message":{"role":"user","content":"hello"}}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-5","content":[{"type":"text","text":"hi"}],"usage":{"input_tokens":12,"output_tokens":34,"cache_creation_input_tokens":56,"cache_read_input_tokens":78}}}
+`
+
+func usageEvents(events []event.Event) []event.Event {
+	var out []event.Event
+	for _, ev := range events {
+		if ev.Event == event.UsageRecorded {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
+
+func TestHandleStopRecordsUsage(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	transcript := filepath.Join(t.TempDir(), "sess.jsonl")
+	if err := os.WriteFile(transcript, []byte(usageTranscript), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	input := &Input{SessionID: "sess-usage", CWD: projectPath, TranscriptPath: transcript, Source: "claude"}
+
+	if _, err := HandleStop(input); err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+
+	events := readTodayEvents(t, env.EventsDir)
+	recorded := usageEvents(events)
+	if len(recorded) != 1 {
+		t.Fatalf("usage events = %d, want 1", len(recorded))
+	}
+	ev := recorded[0]
+	assertEvent(t, ev, event.UsageRecorded, "sess-usage", "test-project")
+	if ev.Data["model"] != "claude-sonnet-4-5" || ev.Data["output_tokens"] != float64(34) || ev.Data["turns"] != float64(1) {
+		t.Errorf("usage data = %v", ev.Data)
+	}
+	if events[len(events)-1].Event != event.HookStop {
+		t.Errorf("last event = %q, want %q", events[len(events)-1].Event, event.HookStop)
+	}
+
+	// Session end right after: nothing new to ingest.
+	if err := HandleSessionEnd(input); err != nil {
+		t.Fatalf("HandleSessionEnd() error: %v", err)
+	}
+	if got := len(usageEvents(readTodayEvents(t, env.EventsDir))); got != 1 {
+		t.Errorf("usage events after session end = %d, want 1", got)
+	}
+}
+
+func TestHandlePostToolThrottlesUsage(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	transcript := filepath.Join(t.TempDir(), "sess.jsonl")
+	if err := os.WriteFile(transcript, []byte(usageTranscript), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	input := &Input{SessionID: "sess-post", CWD: projectPath, TranscriptPath: transcript, ToolName: "Read"}
+
+	// First post-tool call ingests (never ingested before).
+	if err := HandlePostTool(input); err != nil {
+		t.Fatalf("HandlePostTool() error: %v", err)
+	}
+	if got := len(usageEvents(readTodayEvents(t, env.EventsDir))); got != 1 {
+		t.Fatalf("usage events = %d, want 1", got)
+	}
+
+	// A small append right after is left for a later ingest.
+	more := `{"type":"assistant","message":{"id":"msg_2","model":"claude-sonnet-4-5","content":[],"usage":{"input_tokens":1,"output_tokens":1}}}` + "\n"
+	f, err := os.OpenFile(transcript, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(more)
+	_ = f.Close()
+
+	if err := HandlePostTool(input); err != nil {
+		t.Fatalf("HandlePostTool() error: %v", err)
+	}
+	if got := len(usageEvents(readTodayEvents(t, env.EventsDir))); got != 1 {
+		t.Errorf("usage events after throttled post-tool = %d, want 1", got)
+	}
+
+	// Stop always ingests.
+	if _, err := HandleStop(input); err != nil {
+		t.Fatalf("HandleStop() error: %v", err)
+	}
+	if got := len(usageEvents(readTodayEvents(t, env.EventsDir))); got != 2 {
+		t.Errorf("usage events after stop = %d, want 2", got)
+	}
+}
+
+func TestRecordUsageConcurrentIngestsCountOnce(t *testing.T) {
+	projectPath := t.TempDir()
+	env := setupTestEnv(t, projectPath)
+
+	transcript := filepath.Join(t.TempDir(), "sess.jsonl")
+	if err := os.WriteFile(transcript, []byte(usageTranscript), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	input := &Input{SessionID: "sess-race", CWD: projectPath, TranscriptPath: transcript}
+
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	el := event.NewEventLog(env.EventsDir)
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recordUsage(cfg, el, input, "test-project", "", true)
+		}()
+	}
+	wg.Wait()
+
+	if got := len(usageEvents(readTodayEvents(t, env.EventsDir))); got != 1 {
+		t.Errorf("usage events = %d, want 1", got)
+	}
+}
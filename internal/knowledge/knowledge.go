@@ -0,0 +1,255 @@
+// Package knowledge stores durable per-project learnings in the vault
+// (projects/<name>/knowledge.md) and selects the ones most relevant to a
+// session or ticket.
+package knowledge
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DateFormat is the provenance date format.
+const DateFormat = "2006-01-02"
+
+// Entry is one learning with its provenance.
+type Entry struct {
+	Fact   string
+	Ticket string
+	RunID  string
+	Date   time.Time
+}
+
+// Provenance returns "st_xxx, run abc, 2026-02-25", omitting unknown parts.
+func (e Entry) Provenance() string {
+	var parts []string
+	if e.Ticket != "" {
+		parts = append(parts, e.Ticket)
+	}
+	if e.RunID != "" {
+		parts = append(parts, "run "+e.RunID)
+	}
+	if !e.Date.IsZero() {
+		parts = append(parts, e.Date.Format(DateFormat))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// line renders the entry as a knowledge.md bullet.
+func (e Entry) line() string {
+	if p := e.Provenance(); p != "" {
+		return fmt.Sprintf("- %s — _%s_", e.Fact, p)
+	}
+	return "- " + e.Fact
+}
+
+// Base is a project's knowledge.md. Lines that are not bullets (headings,
+// prose added by hand) are kept as they are when the file is saved.
+type Base struct {
+	path    string
+	lines   []string
+	entries []int // indexes into lines of the entry bullets
+}
+
+// Path returns the knowledge file path for a project.
+func Path(vaultPath, projectName string) string {
+	return filepath.Join(vaultPath, "projects", projectName, "knowledge.md")
+}
+
+// Load reads a project's knowledge base. A missing file is an empty base.
+func Load(vaultPath, projectName string) (*Base, error) {
+	b := &Base{path: Path(vaultPath, projectName)}
+	data, err := os.ReadFile(b.path)
+	if os.IsNotExist(err) {
+		b.lines = []string{
+			"# " + projectName + " knowledge",
+			"",
+			"Durable project learnings recorded with `st learn`. One fact per bullet; edit or delete freely.",
+			"",
+		}
+		return b, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read knowledge.md: %w", err)
+	}
+
+	b.lines = strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+	for i, line := range b.lines {
+		if _, ok := parseLine(line); ok {
+			b.entries = append(b.entries, i)
+		}
+	}
+	return b, nil
+}
+
+// Entries returns the learnings in file order.
+func (b *Base) Entries() []Entry {
+	entries := make([]Entry, len(b.entries))
+	for i, idx := range b.entries {
+		entries[i], _ = parseLine(b.lines[idx])
+	}
+	return entries
+}
+
+// Add appends a learning. Whitespace in the fact is collapsed to one line.
+func (b *Base) Add(e Entry) error {
+	e.Fact = strings.Join(strings.Fields(e.Fact), " ")
+	if e.Fact == "" {
+		return fmt.Errorf("learning is empty")
+	}
+	for _, existing := range b.Entries() {
+		if strings.EqualFold(existing.Fact, e.Fact) {
+			return fmt.Errorf("already recorded: %s", existing.Fact)
+		}
+	}
+	b.lines = append(b.lines, e.line())
+	b.entries = append(b.entries, len(b.lines)-1)
+	return nil
+}
+
+// Remove deletes the n-th learning (1-based, as numbered by `st learn --list`).
+func (b *Base) Remove(n int) (Entry, error) {
+	if n < 1 || n > len(b.entries) {
+		return Entry{}, fmt.Errorf("no learning #%d (have %d)", n, len(b.entries))
+	}
+	idx := b.entries[n-1]
+	removed, _ := parseLine(b.lines[idx])
+	b.lines = append(b.lines[:idx], b.lines[idx+1:]...)
+	b.entries = append(b.entries[:n-1], b.entries[n:]...)
+	for i := n - 1; i < len(b.entries); i++ {
+		b.entries[i]--
+	}
+	return removed, nil
+}
+
+// Save writes the knowledge base, creating the project directory if needed.
+func (b *Base) Save() error {
+	if err := os.MkdirAll(filepath.Dir(b.path), 0o755); err != nil {
+		return fmt.Errorf("create project dir: %w", err)
+	}
+	return os.WriteFile(b.path, []byte(strings.Join(b.lines, "\n")+"\n"), 0o644)
+}
+
+var (
+	bulletRe     = regexp.MustCompile(`^\s*[-*] (.+)$`)
+	provenanceRe = regexp.MustCompile(`^(.*?) — _(.*)_$`)
+	runRe        = regexp.MustCompile(`^run (\S+)$`)
+	ticketRe     = regexp.MustCompile(`^st_[a-zA-Z0-9]{6}$`)
+)
+
+// parseLine parses a bullet into an entry. Bullets written by hand, without
+// provenance, are entries too.
+func parseLine(line string) (Entry, bool) {
+	m := bulletRe.FindStringSubmatch(line)
+	if m == nil {
+		return Entry{}, false
+	}
+	text := strings.TrimSpace(m[1])
+	if text == "" {
+		return Entry{}, false
+	}
+	pm := provenanceRe.FindStringSubmatch(text)
+	if pm == nil {
+		return Entry{Fact: text}, true
+	}
+	e := Entry{Fact: pm[1]}
+	for _, part := range strings.Split(pm[2], ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case ticketRe.MatchString(part):
+			e.Ticket = part
+		case runRe.MatchString(part):
+			e.RunID = runRe.FindStringSubmatch(part)[1]
+		default:
+			if d, err := time.Parse(DateFormat, part); err == nil {
+				e.Date = d
+			}
+		}
+	}
+	return e, true
+}
+
+// Relevant returns the learnings that fit within budget bytes (as rendered
+// by Format), most relevant to query first: entries sharing more words with
+// the query rank higher, newer entries break ties.
+func Relevant(entries []Entry, query string, budget int) []Entry {
+	queryWords := words(query)
+	type scored struct {
+		entry Entry
+		score int
+		order int
+	}
+	ranked := make([]scored, len(entries))
+	for i, e := range entries {
+		s := 0
+		for w := range words(e.Fact) {
+			if queryWords[w] {
+				s++
+			}
+		}
+		ranked[i] = scored{entry: e, score: s, order: i}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].score != ranked[j].score {
+			return ranked[i].score > ranked[j].score
+		}
+		return ranked[i].order > ranked[j].order
+	})
+
+	var picked []Entry
+	used := 0
+	for _, r := range ranked {
+		size := len(r.entry.line()) + 1
+		if used+size > budget {
+			continue
+		}
+		picked = append(picked, r.entry)
+		used += size
+	}
+	return picked
+}
+
+// Format renders entries as bullets with their provenance.
+func Format(entries []Entry) string {
+	var b strings.Builder
+	for _, e := range entries {
+		b.WriteString(e.line())
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// stopWords are ignored when matching learnings to a query.
+var stopWords = map[string]bool{
+	"the": true, "and": true, "for": true, "with": true, "this": true, "that": true,
+	"from": true, "into": true, "are": true, "was": true, "not": true, "use": true,
+	"when": true, "then": true, "before": true, "after": true, "all": true, "add": true,
+}
+
+// words returns the lowercase words of s that are at least 3 characters.
+func words(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, w := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}) {
+		if len(w) >= 3 && !stopWords[w] {
+			set[w] = true
+		}
+	}
+	return set
+}
+
+// Context loads a project's learnings and renders the ones most relevant to
+// query within budget bytes, or "" when there are none.
+func Context(vaultPath, projectName, query string, budget int) string {
+	b, err := Load(vaultPath, projectName)
+	if err != nil {
+		return ""
+	}
+	return Format(Relevant(b.Entries(), query, budget))
+}
@@ -0,0 +1,112 @@
+package knowledge
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAddListRemoveRoundTrip(t *testing.T) {
+	vault := t.TempDir()
+	date := time.Date(2026, 2, 25, 0, 0, 0, 0, time.UTC)
+
+	b, err := Load(vault, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(b.Entries()) != 0 {
+		t.Fatalf("new base has %d entries", len(b.Entries()))
+	}
+	for _, e := range []Entry{
+		{Fact: "Run `make generate`\nbefore   go build", Ticket: "st_abc123", RunID: "run-1", Date: date},
+		{Fact: "Middleware lives in internal/web/middleware", Date: date},
+		{Fact: "Integration tests need docker"},
+	} {
+		if err := b.Add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Add(Entry{Fact: "integration tests need Docker"}); err == nil {
+		t.Error("duplicate learning was added")
+	}
+	if err := b.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(Path(vault, "api"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := string(data)
+	if !strings.HasPrefix(content, "# api knowledge\n") {
+		t.Errorf("missing header:\n%s", content)
+	}
+	if !strings.Contains(content, "- Run `make generate` before go build — _st_abc123, run run-1, 2026-02-25_\n") {
+		t.Errorf("unexpected entry format:\n%s", content)
+	}
+
+	// Hand-written lines survive a rewrite; hand-written bullets are entries.
+	if err := os.WriteFile(Path(vault, "api"), []byte(content+"\nSome prose.\n* Hand written fact\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	b, err = Load(vault, "api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := b.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(entries), entries)
+	}
+	if e := entries[0]; e.Fact != "Run `make generate` before go build" || e.Ticket != "st_abc123" || e.RunID != "run-1" || !e.Date.Equal(date) {
+		t.Errorf("entry 1 = %+v", e)
+	}
+	if e := entries[3]; e.Fact != "Hand written fact" || e.Provenance() != "" {
+		t.Errorf("entry 4 = %+v", e)
+	}
+
+	removed, err := b.Remove(2)
+	if err != nil || removed.Fact != "Middleware lives in internal/web/middleware" {
+		t.Fatalf("Remove(2) = %+v, %v", removed, err)
+	}
+	if _, err := b.Remove(9); err == nil {
+		t.Error("Remove(9) succeeded")
+	}
+	removed, err = b.Remove(3)
+	if err != nil || removed.Fact != "Hand written fact" {
+		t.Fatalf("Remove(3) = %+v, %v", removed, err)
+	}
+	if err := b.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, _ = os.ReadFile(Path(vault, "api"))
+	if !strings.Contains(string(data), "Some prose.") || strings.Contains(string(data), "Middleware") {
+		t.Errorf("unexpected content after remove:\n%s", data)
+	}
+}
+
+func TestRelevant(t *testing.T) {
+	entries := []Entry{
+		{Fact: "Templ files must be regenerated with templ generate"},
+		{Fact: "Rate limiter config lives in internal/web/middleware/ratelimit.go"},
+		{Fact: "Use the fake clock in scheduler tests"},
+	}
+
+	got := Relevant(entries, "Add rate limiting to the web API middleware", 1000)
+	if len(got) != 3 || got[0].Fact != entries[1].Fact {
+		t.Fatalf("Relevant() = %+v", got)
+	}
+	// Without matches, newest first.
+	if got[1].Fact != entries[2].Fact {
+		t.Errorf("tie order = %+v", got)
+	}
+
+	got = Relevant(entries, "middleware", len(entries[1].line())+1)
+	if len(got) != 1 || got[0].Fact != entries[1].Fact {
+		t.Errorf("Relevant() within budget = %+v", got)
+	}
+	if Relevant(entries, "", 10) != nil {
+		t.Error("Relevant() exceeded a tiny budget")
+	}
+}
@@ -13,6 +13,18 @@ import (
 type ProjectMeta struct {
 	Path string `yaml:"path,omitempty"`
 	Repo string `yaml:"repo,omitempty"`
+
+	// SecretsIgnore lists file patterns (e.g. "testdata/**", "*_test.go")
+	// excluded from secret scanning.
+	SecretsIgnore []string `yaml:"secrets_ignore,omitempty"`
+
+	// RequireTests makes `st status review` require a passing test run
+	// (captured from the agent's Bash calls) since the worktree's last commit.
+	RequireTests bool `yaml:"require_tests,omitempty"`
+
+	// Verify lists shell commands (build, lint, test) that `st status review`
+	// runs in the ticket worktree; any failure refuses the transition.
+	Verify []string `yaml:"verify,omitempty"`
 }
 
 // LoadMeta reads project metadata from <vault>/projects/<name>/project.md.
@@ -75,7 +87,8 @@ func ListProjects(vaultPath string) ([]string, error) {
 
 	var names []string
 	for _, e := range entries {
-		if e.IsDir() {
+		// Hidden directories (e.g. the search index) are not projects.
+		if e.IsDir() && !strings.HasPrefix(e.Name(), ".") {
 			names = append(names, e.Name())
 		}
 	}
@@ -156,7 +156,7 @@ func TestLoadMetaNotFound(t *testing.T) {
 func TestListProjects(t *testing.T) {
 	vaultDir := t.TempDir()
 	projectsDir := filepath.Join(vaultDir, "projects")
-	for _, name := range []string{"alpha", "beta", "gamma"} {
+	for _, name := range []string{"alpha", "beta", "gamma", ".index"} {
 		if err := os.MkdirAll(filepath.Join(projectsDir, name), 0o755); err != nil {
 			t.Fatalf("mkdir: %v", err)
 		}
@@ -167,7 +167,7 @@ func TestListProjects(t *testing.T) {
 		t.Fatalf("ListProjects: %v", err)
 	}
 	if len(names) != 3 {
-		t.Errorf("ListProjects returned %d names, want 3", len(names))
+		t.Errorf("ListProjects returned %v, want 3 names without hidden dirs", names)
 	}
 }
 
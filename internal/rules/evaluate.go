@@ -33,15 +33,12 @@ func extractFields(toolInput map[string]any) (command, filePath, url string) {
 // Returns nil when no rules directory exists or no rules are configured
 // (distinct from an "ask" result).
 func Evaluate(dir, event, toolName string, toolInput map[string]any) *EvalResult {
-	rulesets, bash, err := LoadRulesets(dir)
-	if err != nil {
-		slog.Warn("failed to load rulesets", "error", err)
-		return nil
-	}
-	if len(rulesets) == 0 && bash == nil {
-		return nil
-	}
+	return EvaluateLayers(dir, "", "", event, toolName, toolInput)
+}
 
+// EvaluateRulesets evaluates the request against already-loaded rulesets.
+// Unlike Evaluate it never returns nil: no matching rule yields "ask".
+func EvaluateRulesets(rulesets []*Ruleset, bash *BashPipeline, event, toolName string, toolInput map[string]any) *EvalResult {
 	return evaluate(rulesets, bash, event, toolName, toolInput)
 }
 
@@ -50,6 +47,11 @@ func Evaluate(dir, event, toolName string, toolInput map[string]any) *EvalResult
 // each sub-command independently. First deny -> immediate deny. First allow ->
 // proceed (run bash pipeline if Bash). No match -> ask (passthrough).
 func evaluate(rulesets []*Ruleset, bash *BashPipeline, event, toolName string, toolInput map[string]any) *EvalResult {
+	return evaluateTraced(rulesets, bash, event, toolName, toolInput, nil)
+}
+
+// evaluateTraced is evaluate with an optional trace recorder (nil disables tracing).
+func evaluateTraced(rulesets []*Ruleset, bash *BashPipeline, event, toolName string, toolInput map[string]any, tr *Trace) *EvalResult {
 	command, filePath, url := extractFields(toolInput)
 
 	// Extract notification_type from toolInput if present (for notification events)
@@ -64,13 +66,18 @@ func evaluate(rulesets []*Ruleset, bash *BashPipeline, event, toolName string, t
 	if toolName == "Bash" && command != "" {
 		parts := splitChainedCommands(command)
 		if len(parts) > 1 {
-			return evaluateCompound(rulesets, bash, event, parts, filePath, url, notificationType, command)
+			if tr != nil {
+				tr.SubCommands = parts
+			}
+			return evaluateCompound(rulesets, bash, event, parts, filePath, url, notificationType, command, tr)
 		}
 	}
 
-	result := matchCommand(rulesets, event, toolName, command, filePath, url, notificationType)
+	result := matchCommandTraced(rulesets, event, toolName, command, filePath, url, notificationType, tr)
 	if result.Decision == ActionAllow && toolName == "Bash" && bash != nil {
-		if deny, reason := bash.Check(command, rulesets); deny {
+		deny, reason := bash.Check(command, rulesets)
+		tr.setPipeline(command, deny, reason)
+		if deny {
 			return &EvalResult{
 				Decision: ActionDeny,
 				Reason:   reason,
@@ -86,14 +93,14 @@ func evaluate(rulesets []*Ruleset, bash *BashPipeline, event, toolName string, t
 // Each sub-command is matched independently against rules. If any sub-command
 // is denied, the whole command is denied. If all are allowed, the full command
 // is run through the bash pipeline for structural analysis.
-func evaluateCompound(rulesets []*Ruleset, bash *BashPipeline, event string, parts []string, filePath, url, notificationType, fullCommand string) *EvalResult {
+func evaluateCompound(rulesets []*Ruleset, bash *BashPipeline, event string, parts []string, filePath, url, notificationType, fullCommand string, tr *Trace) *EvalResult {
 	var lastAllow *EvalResult
 	for _, part := range parts {
 		part = strings.TrimSpace(part)
 		if part == "" {
 			continue
 		}
-		result := matchCommand(rulesets, event, "Bash", part, filePath, url, notificationType)
+		result := matchCommandTraced(rulesets, event, "Bash", part, filePath, url, notificationType, tr)
 		switch result.Decision {
 		case ActionDeny:
 			return result
@@ -111,7 +118,9 @@ func evaluateCompound(rulesets []*Ruleset, bash *BashPipeline, event string, par
 	}
 	// Run pipeline on the full command for structural analysis.
 	if bash != nil {
-		if deny, reason := bash.Check(fullCommand, rulesets); deny {
+		deny, reason := bash.Check(fullCommand, rulesets)
+		tr.setPipeline(fullCommand, deny, reason)
+		if deny {
 			return &EvalResult{
 				Decision: ActionDeny,
 				Reason:   reason,
@@ -125,24 +134,54 @@ func evaluateCompound(rulesets []*Ruleset, bash *BashPipeline, event string, par
 
 // matchCommand finds the first matching rule for a single command/tool invocation.
 func matchCommand(rulesets []*Ruleset, event, toolName, command, filePath, url, notificationType string) *EvalResult {
+	return matchCommandTraced(rulesets, event, toolName, command, filePath, url, notificationType, nil)
+}
+
+// matchCommandTraced is matchCommand with an optional trace recorder.
+func matchCommandTraced(rulesets []*Ruleset, event, toolName, command, filePath, url, notificationType string, tr *Trace) *EvalResult {
 	for _, rs := range rulesets {
 		if rs.Event != "" && normalizeEvent(rs.Event) != normalizeEvent(event) {
+			tr.addStep(TraceStep{
+				Command:  command,
+				Origin:   rs.Origin,
+				Ruleset:  rs.Name,
+				Priority: rs.Priority,
+				Outcome:  OutcomeEventSkipped,
+				Detail:   fmt.Sprintf("ruleset event %q does not apply to %q", rs.Event, event),
+			})
 			continue
 		}
 		for _, rule := range rs.Rules {
+			step := TraceStep{
+				Command:  command,
+				Origin:   rs.Origin,
+				Ruleset:  rs.Name,
+				Priority: rs.Priority,
+				Rule:     rule.Name,
+				Action:   rule.Action,
+			}
 			matched, err := matchRule(&rule, toolName, command, filePath, url, notificationType)
 			if err != nil {
 				slog.Warn("match error", "ruleset", rs.Name, "rule", rule.Name, "match", rule.Match, "error", err)
+				step.Outcome = OutcomeError
+				step.Detail = err.Error()
+				tr.addStep(step)
 				continue
 			}
 			if !matched {
+				step.Outcome = OutcomeNoMatch
+				tr.addStep(step)
 				continue
 			}
+			step.Outcome = OutcomeMatched
+			step.Detail = rule.Message
+			tr.addStep(step)
 			return &EvalResult{
 				Decision: rule.Action,
 				Reason:   rule.Message,
 				Ruleset:  rs.Name,
 				Rule:     rule.Name,
+				Origin:   rs.Origin,
 			}
 		}
 	}
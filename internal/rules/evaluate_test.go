@@ -568,6 +568,8 @@ func TestEvaluateStCommands(t *testing.T) {
 		"st work --run-id abc123",
 		"st hold st_CTaTM7 \"waiting on API\" --run-id abc123",
 		"st unhold st_CTaTM7 --run-id abc123",
+		"st learn \"run make generate before go build\" --run-id abc123",
+		"st learn --list --run-id abc123",
 	}
 
 	blocked := []string{
@@ -581,6 +583,8 @@ func TestEvaluateStCommands(t *testing.T) {
 		"st close st_CTaTM7 --run-id abc123",
 		"st web --run-id abc123",
 		"st init --run-id abc123",
+		"st learn --remove 3 --run-id abc123",
+		"st learn --project api --remove=3",
 	}
 
 	for _, cmd := range allowed {
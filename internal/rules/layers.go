@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+)
+
+// OriginGlobal marks rulesets loaded from the vault-wide rules directory.
+// Project overlay rulesets carry the project name as their origin.
+const OriginGlobal = "global"
+
+// LoadLayers loads the global rulesets from globalDir and overlays the
+// project rulesets from projectDir. An empty projectDir loads only the
+// global layer.
+//
+// Overlay semantics:
+//   - a project ruleset with the same name as a global ruleset replaces it;
+//   - a project rule with the same name as a global rule overrides it;
+//   - names listed under a project ruleset's `disable:` remove global rules
+//     ("rule-name" or "ruleset-name/rule-name");
+//   - a project bash-pipeline file replaces the global one.
+//
+// Project rulesets win priority ties against global rulesets.
+func LoadLayers(globalDir, projectDir, projectName string) ([]*Ruleset, *BashPipeline, error) {
+	global, globalBash, err := LoadRulesets(globalDir)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rs := range global {
+		rs.Origin = OriginGlobal
+	}
+	if projectDir == "" {
+		return global, globalBash, nil
+	}
+
+	overlay, overlayBash, err := LoadRulesets(projectDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("project %s: %w", projectName, err)
+	}
+	for _, rs := range overlay {
+		rs.Origin = projectName
+	}
+
+	bash := globalBash
+	if overlayBash != nil {
+		bash = overlayBash
+	}
+	return mergeLayers(global, overlay), bash, nil
+}
+
+// EvaluateLayers is Evaluate over the merged global and project layers. A
+// project overlay that fails to load falls back to the global rules with a
+// warning, so one broken file cannot switch off the global deny rules.
+func EvaluateLayers(globalDir, projectDir, projectName, event, toolName string, toolInput map[string]any) *EvalResult {
+	rulesets, bash, err := LoadLayers(globalDir, projectDir, projectName)
+	if err != nil && projectDir != "" {
+		slog.Warn("failed to load project rulesets, using global rules", "project", projectName, "error", err)
+		rulesets, bash, err = LoadLayers(globalDir, "", "")
+	}
+	if err != nil {
+		slog.Warn("failed to load rulesets", "error", err)
+		return nil
+	}
+	if len(rulesets) == 0 && bash == nil {
+		return nil
+	}
+
+	return evaluate(rulesets, bash, event, toolName, toolInput)
+}
+
+// LayerResolver returns the rulesets in effect for a project.
+type LayerResolver func(project string) ([]*Ruleset, *BashPipeline)
+
+// ProjectLayers returns a LayerResolver that loads each project's overlay
+// (from projectDir(name); "" means no overlay) once and caches it. Projects
+// whose overlay fails to load fall back to the global rules with a warning.
+func ProjectLayers(globalDir string, projectDir func(project string) string) (LayerResolver, error) {
+	global, globalBash, err := LoadLayers(globalDir, "", "")
+	if err != nil {
+		return nil, err
+	}
+
+	type layer struct {
+		rulesets []*Ruleset
+		bash     *BashPipeline
+	}
+	cache := make(map[string]layer)
+	return func(project string) ([]*Ruleset, *BashPipeline) {
+		if l, ok := cache[project]; ok {
+			return l.rulesets, l.bash
+		}
+		l := layer{rulesets: global, bash: globalBash}
+		if dir := projectDir(project); project != "" && dir != "" {
+			if rs, bash, err := LoadLayers(globalDir, dir, project); err == nil {
+				l = layer{rulesets: rs, bash: bash}
+			} else {
+				slog.Warn("failed to load project rulesets, using global rules", "project", project, "error", err)
+			}
+		}
+		cache[project] = l
+		return l.rulesets, l.bash
+	}, nil
+}
+
+// mergeLayers applies the overlay rulesets on top of the global ones and
+// returns the combined list sorted by priority descending.
+func mergeLayers(global, overlay []*Ruleset) []*Ruleset {
+	replaced := make(map[string]bool)
+	overridden := make(map[string]bool)
+	disabled := make(map[string]bool)
+	for _, rs := range overlay {
+		if rs.Name != "" {
+			replaced[rs.Name] = true
+		}
+		for _, r := range rs.Rules {
+			overridden[r.Name] = true
+		}
+		for _, name := range rs.Disable {
+			disabled[name] = true
+		}
+	}
+
+	merged := make([]*Ruleset, 0, len(global)+len(overlay))
+	merged = append(merged, overlay...)
+	for _, rs := range global {
+		if replaced[rs.Name] {
+			continue
+		}
+		kept := *rs
+		kept.Rules = nil
+		for _, r := range rs.Rules {
+			if overridden[r.Name] || disabled[r.Name] || disabled[rs.Name+"/"+r.Name] {
+				kept.Disabled = append(kept.Disabled, r.Name)
+				continue
+			}
+			kept.Rules = append(kept.Rules, r)
+		}
+		merged = append(merged, &kept)
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		return merged[i].Priority > merged[j].Priority
+	})
+	return merged
+}
+
+// GroupByOrigin groups rulesets by origin, global first, then projects by name.
+func GroupByOrigin(rulesets []*Ruleset) [][]*Ruleset {
+	byOrigin := make(map[string][]*Ruleset)
+	var origins []string
+	for _, rs := range rulesets {
+		if _, ok := byOrigin[rs.Origin]; !ok {
+			origins = append(origins, rs.Origin)
+		}
+		byOrigin[rs.Origin] = append(byOrigin[rs.Origin], rs)
+	}
+	sort.Slice(origins, func(i, j int) bool {
+		if (origins[i] == OriginGlobal) != (origins[j] == OriginGlobal) {
+			return origins[i] == OriginGlobal
+		}
+		return strings.Compare(origins[i], origins[j]) < 0
+	})
+
+	groups := make([][]*Ruleset, 0, len(origins))
+	for _, o := range origins {
+		groups = append(groups, byOrigin[o])
+	}
+	return groups
+}
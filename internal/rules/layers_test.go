@@ -0,0 +1,202 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func layerDirs(t *testing.T) (string, string) {
+	t.Helper()
+	root := t.TempDir()
+	global := filepath.Join(root, "rules")
+	project := filepath.Join(root, "projects", "rusty", "rules")
+	for _, d := range []string{global, project} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeRuleFile(t, global, "tools.yaml", `name: tools
+priority: 50
+event: PreToolUse
+rules:
+  - name: allow-make
+    match:
+      tool: Bash
+      command: ^make\b
+    action: allow
+  - name: allow-npm-test
+    match:
+      tool: Bash
+      command: ^npm\s+test\b
+    action: allow
+  - name: allow-rg
+    match:
+      tool: Bash
+      command: ^rg\b
+    action: allow
+`)
+	writeRuleFile(t, global, "git.yaml", `name: git-safety
+priority: 100
+event: PreToolUse
+rules:
+  - name: block-git-push
+    match:
+      tool: Bash
+      command: \bgit\s+push\b
+    action: deny
+`)
+	writeRuleFile(t, project, "cargo.yaml", `name: cargo
+priority: 50
+event: PreToolUse
+disable:
+  - allow-npm-test
+  - tools/allow-rg
+rules:
+  - name: allow-cargo
+    match:
+      tool: Bash
+      command: ^cargo\s+(build|test|check)\b
+    action: allow
+  - name: allow-make
+    match:
+      tool: Bash
+      command: ^make\s+test\b
+    action: allow
+`)
+	writeRuleFile(t, project, "git.yaml", `name: git-safety
+priority: 100
+event: PreToolUse
+rules: []
+`)
+	return global, project
+}
+
+func TestLoadLayersGlobalOnly(t *testing.T) {
+	global, _ := layerDirs(t)
+
+	rulesets, _, err := LoadLayers(global, "", "")
+	if err != nil {
+		t.Fatalf("LoadLayers() error = %v", err)
+	}
+	if len(rulesets) != 2 {
+		t.Fatalf("rulesets = %d, want 2", len(rulesets))
+	}
+	for _, rs := range rulesets {
+		if rs.Origin != OriginGlobal {
+			t.Errorf("ruleset %s origin = %q, want global", rs.Name, rs.Origin)
+		}
+	}
+}
+
+func TestLoadLayersOverlay(t *testing.T) {
+	global, project := layerDirs(t)
+
+	rulesets, _, err := LoadLayers(global, project, "rusty")
+	if err != nil {
+		t.Fatalf("LoadLayers() error = %v", err)
+	}
+
+	byOrigin := map[string][]string{}
+	for _, rs := range rulesets {
+		byOrigin[rs.Origin] = append(byOrigin[rs.Origin], rs.Name)
+	}
+	if len(byOrigin[OriginGlobal]) != 1 || byOrigin[OriginGlobal][0] != "tools" {
+		t.Errorf("global rulesets = %v, want only tools (git-safety replaced)", byOrigin[OriginGlobal])
+	}
+	if len(byOrigin["rusty"]) != 2 {
+		t.Errorf("project rulesets = %v, want 2", byOrigin["rusty"])
+	}
+
+	// Project wins the priority tie against the global tools ruleset.
+	if rulesets[1].Origin != "rusty" || rulesets[2].Origin != OriginGlobal {
+		t.Errorf("order = %s/%s, %s/%s; want project before global on ties",
+			rulesets[1].Origin, rulesets[1].Name, rulesets[2].Origin, rulesets[2].Name)
+	}
+
+	tools := rulesets[2]
+	if len(tools.Rules) != 0 {
+		t.Errorf("tools rules = %+v, want all overridden or disabled", tools.Rules)
+	}
+	if len(tools.Disabled) != 3 {
+		t.Errorf("tools disabled = %v, want 3", tools.Disabled)
+	}
+}
+
+func TestEvaluateLayers(t *testing.T) {
+	global, project := layerDirs(t)
+
+	tests := []struct {
+		name       string
+		projectDir string
+		command    string
+		want       Action
+		wantOrigin string
+	}{
+		{"cargo allowed in project", project, "cargo test", ActionAllow, "rusty"},
+		{"cargo asks globally", "", "cargo test", ActionAsk, ""},
+		{"npm test disabled in project", project, "npm test", ActionAsk, ""},
+		{"npm test allowed globally", "", "npm test", ActionAllow, OriginGlobal},
+		{"make overridden by narrower project rule", project, "make deploy", ActionAsk, ""},
+		{"make test allowed by project override", project, "make test", ActionAllow, "rusty"},
+		{"git push allowed after project replaces git-safety", project, "git push", ActionAsk, ""},
+		{"git push denied globally", "", "git push", ActionDeny, OriginGlobal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EvaluateLayers(global, tt.projectDir, "rusty", "PreToolUse", "Bash", makeToolInput(tt.command, "", "", ""))
+			if result == nil {
+				t.Fatal("expected a result")
+			}
+			if result.Decision != tt.want {
+				t.Errorf("decision = %s, want %s", result.Decision, tt.want)
+			}
+			if result.Origin != tt.wantOrigin {
+				t.Errorf("origin = %q, want %q", result.Origin, tt.wantOrigin)
+			}
+		})
+	}
+}
+
+func TestEvaluateLayers_BrokenOverlayKeepsGlobalRules(t *testing.T) {
+	global, project := layerDirs(t)
+	writeRuleFile(t, project, "broken.yaml", "name: [unclosed\n")
+
+	result := EvaluateLayers(global, project, "rusty", "PreToolUse", "Bash", makeToolInput("git push", "", "", ""))
+	if result == nil {
+		t.Fatal("expected the global rules to apply")
+	}
+	if result.Decision != ActionDeny || result.Origin != OriginGlobal {
+		t.Errorf("decision = %s from %q, want a global deny", result.Decision, result.Origin)
+	}
+}
+
+func TestGroupByOrigin(t *testing.T) {
+	rulesets := []*Ruleset{
+		{Name: "b", Origin: "zeta"},
+		{Name: "a", Origin: OriginGlobal},
+		{Name: "c", Origin: "alpha"},
+		{Name: "d", Origin: OriginGlobal},
+	}
+
+	groups := GroupByOrigin(rulesets)
+
+	var origins []string
+	for _, g := range groups {
+		origins = append(origins, g[0].Origin)
+	}
+	want := []string{OriginGlobal, "alpha", "zeta"}
+	if len(origins) != len(want) {
+		t.Fatalf("origins = %v, want %v", origins, want)
+	}
+	for i := range want {
+		if origins[i] != want[i] {
+			t.Errorf("origins = %v, want %v", origins, want)
+			break
+		}
+	}
+	if len(groups[0]) != 2 {
+		t.Errorf("global group = %d rulesets, want 2", len(groups[0]))
+	}
+}
@@ -0,0 +1,472 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Lint finding severities.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// LintFinding is a single problem found in a rules directory. Project is set
+// for findings that only apply with that project's overlay in effect.
+type LintFinding struct {
+	Severity string
+	Project  string
+	File     string
+	Ruleset  string
+	Rule     string
+	Message  string
+}
+
+// LintReport holds the results of linting a rules directory.
+type LintReport struct {
+	Files    int
+	Rules    int
+	Findings []LintFinding
+}
+
+// HasErrors reports whether any finding is an error.
+func (r *LintReport) HasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *LintReport) add(severity string, rs *Ruleset, rule, format string, args ...any) {
+	f := LintFinding{Severity: severity, Rule: rule, Message: fmt.Sprintf(format, args...)}
+	if rs != nil {
+		f.File = filepath.Base(rs.Source)
+		f.Ruleset = rs.Name
+	}
+	r.Findings = append(r.Findings, f)
+}
+
+// Lint validates every rule file in dir: schema and actions, regex
+// compilation and complexity, overly broad patterns, and rules that can never
+// fire because an earlier rule (by evaluation order) always matches first.
+// Files that fail to load are reported after the findings for the rest, which
+// are still checked.
+func Lint(dir string) (*LintReport, error) {
+	report := &LintReport{}
+	rulesets, broken, err := lintLoad(report, dir)
+	if err != nil {
+		return nil, err
+	}
+	lintRulesets(report, rulesets)
+	report.Findings = append(report.Findings, broken...)
+	return report, nil
+}
+
+// LintLayers lints the global rules in globalDir, then each project overlay in
+// overlays (project name → overlay directory) layered over them, the way
+// hooks in that project see the rules. Findings already reported for the
+// global layer are not repeated per project. An overlay file that fails to
+// load is an error: hooks in that project fall back to the global rules.
+func LintLayers(globalDir string, overlays map[string]string) (*LintReport, error) {
+	report, err := Lint(globalDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlays) == 0 {
+		return report, nil
+	}
+	global, _, err := lintLoad(&LintReport{}, globalDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, rs := range global {
+		rs.Origin = OriginGlobal
+	}
+	reported := make(map[LintFinding]bool, len(report.Findings))
+	for _, f := range report.Findings {
+		reported[f] = true
+	}
+
+	projects := make([]string, 0, len(overlays))
+	for name := range overlays {
+		projects = append(projects, name)
+	}
+	sort.Strings(projects)
+	for _, name := range projects {
+		layer := &LintReport{}
+		overlay, broken, err := lintLoad(layer, overlays[name])
+		if err != nil {
+			return nil, fmt.Errorf("project %s: %w", name, err)
+		}
+		report.Files += layer.Files
+		for _, rs := range overlay {
+			rs.Origin = name
+			report.Rules += len(rs.Rules)
+		}
+
+		lintRulesets(layer, mergeLayers(global, overlay))
+		lintDisabled(layer, global, overlay)
+		for _, f := range layer.Findings {
+			if !reported[f] {
+				f.Project = name
+				report.Findings = append(report.Findings, f)
+			}
+		}
+		for _, f := range broken {
+			f.Project = name
+			f.Message += fmt.Sprintf(" (hooks in %s fall back to the global rules)", name)
+			report.Findings = append(report.Findings, f)
+		}
+	}
+	return report, nil
+}
+
+// lintDisabled warns about overlay disable: entries that name no global rule.
+func lintDisabled(report *LintReport, global, overlay []*Ruleset) {
+	known := make(map[string]bool)
+	for _, rs := range global {
+		for _, r := range rs.Rules {
+			known[r.Name] = true
+			known[rs.Name+"/"+r.Name] = true
+		}
+	}
+	for _, rs := range overlay {
+		for _, name := range rs.Disable {
+			if !known[name] {
+				report.add(SeverityWarning, rs, "", "disable entry %q matches no global rule", name)
+			}
+		}
+	}
+}
+
+// lintLoad parses each rule file in dir on its own, so one broken file does
+// not hide the others. It returns the rulesets that loaded, in evaluation
+// order, and an error finding per file that did not.
+func lintLoad(report *LintReport, dir string) ([]*Ruleset, []LintFinding, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("read rules dir: %w", err)
+	}
+
+	var rulesets []*Ruleset
+	var broken []LintFinding
+	pipeline := ""
+	for _, entry := range entries {
+		if entry.IsDir() || !isRuleFile(entry.Name()) {
+			continue
+		}
+		report.Files++
+		path := filepath.Join(dir, entry.Name())
+		rs, err := parseRulesetFile(path)
+		if err != nil {
+			broken = append(broken, LintFinding{
+				Severity: SeverityError,
+				File:     entry.Name(),
+				Message:  strings.TrimPrefix(err.Error(), path+": "),
+			})
+			continue
+		}
+		if rs == nil {
+			continue
+		}
+		if rs.Type == "bash-pipeline" {
+			if pipeline != "" {
+				broken = append(broken, LintFinding{
+					Severity: SeverityError,
+					File:     entry.Name(),
+					Message:  fmt.Sprintf("multiple bash-pipeline files are not allowed (also %s)", pipeline),
+				})
+			}
+			pipeline = entry.Name()
+			continue
+		}
+		rulesets = append(rulesets, rs)
+	}
+
+	sort.SliceStable(rulesets, func(i, j int) bool {
+		return rulesets[i].Priority > rulesets[j].Priority
+	})
+	return rulesets, broken, nil
+}
+
+// lintRulesets runs the per-ruleset, per-rule and shadowing checks over
+// rulesets in evaluation order.
+func lintRulesets(report *LintReport, rulesets []*Ruleset) {
+	seen := make(map[string]*Ruleset)
+	for _, rs := range rulesets {
+		if rs.Name == "" {
+			report.add(SeverityWarning, rs, "", "ruleset has no name")
+		} else if prev, ok := seen[rs.Name]; ok {
+			report.add(SeverityWarning, rs, "", "ruleset name %q is also used by %s", rs.Name, filepath.Base(prev.Source))
+		} else {
+			seen[rs.Name] = rs
+		}
+		for _, rule := range rs.Rules {
+			report.Rules++
+			lintRule(report, rs, rule)
+		}
+	}
+
+	lintShadowing(report, rulesets)
+}
+
+// lintRule checks a single rule for broken or overly broad patterns.
+func lintRule(report *LintReport, rs *Ruleset, rule Rule) {
+	for _, p := range rule.Match.patterns() {
+		if p.pattern == "" {
+			continue
+		}
+		if _, err := regexp.Compile(p.pattern); err != nil {
+			report.add(SeverityError, rs, rule.Name, "%s regex %q does not compile: %v", p.field, p.pattern, err)
+			continue
+		}
+		if isMatchAll(p.pattern) {
+			report.add(SeverityWarning, rs, rule.Name, "%s regex %q matches everything", p.field, p.pattern)
+		}
+	}
+
+	if rule.Match.isEmpty() {
+		report.add(SeverityWarning, rs, rule.Name, "rule has empty match config — it matches every request")
+		return
+	}
+
+	if rule.Action != ActionAllow {
+		return
+	}
+	bashOnly := len(rule.Match.Tool) > 0
+	for _, t := range rule.Match.Tool {
+		if !strings.EqualFold(t, "Bash") {
+			bashOnly = false
+		}
+	}
+	switch {
+	case rule.Match.Command == "" && bashOnly:
+		report.add(SeverityWarning, rs, rule.Name, "allow rule has no command pattern — it allows every Bash command")
+	case rule.Match.Command != "" && !isAnchored(rule.Match.Command):
+		report.add(SeverityWarning, rs, rule.Name,
+			"command allow regex %q is unanchored — it matches anywhere in the command (anchor it with ^)", rule.Match.Command)
+	}
+}
+
+// lintShadowing reports rules that can never fire because a rule evaluated
+// earlier matches every request they would match.
+func lintShadowing(report *LintReport, rulesets []*Ruleset) {
+	type entry struct {
+		rs   *Ruleset
+		rule Rule
+	}
+	var order []entry
+	for _, rs := range rulesets {
+		for _, rule := range rs.Rules {
+			order = append(order, entry{rs: rs, rule: rule})
+		}
+	}
+
+	for i, later := range order {
+		for _, earlier := range order[:i] {
+			if !ruleCovers(earlier.rs, &earlier.rule, later.rs, &later.rule) {
+				continue
+			}
+			where := earlier.rule.Name
+			if earlier.rs != later.rs {
+				where = fmt.Sprintf("%s in %s (priority %d)", earlier.rule.Name, earlier.rs.Name, earlier.rs.Priority)
+			}
+			if earlier.rule.Action != later.rule.Action {
+				report.add(SeverityError, later.rs, later.rule.Name,
+					"unreachable %s rule — shadowed by %s rule %s", later.rule.Action, earlier.rule.Action, where)
+			} else {
+				report.add(SeverityWarning, later.rs, later.rule.Name,
+					"redundant rule — %s already matches every request it matches", where)
+			}
+			break
+		}
+	}
+}
+
+// ruleCovers reports whether rule a (in ruleset ars) matches every request
+// that rule b (in ruleset brs) matches. The check is conservative: false
+// negatives are possible, false positives are not intended.
+func ruleCovers(ars *Ruleset, a *Rule, brs *Ruleset, b *Rule) bool {
+	if ars.Event != "" && (brs.Event == "" || normalizeEvent(ars.Event) != normalizeEvent(brs.Event)) {
+		return false
+	}
+	if len(a.Match.Tool) > 0 {
+		if len(b.Match.Tool) == 0 {
+			return false
+		}
+		for _, bt := range b.Match.Tool {
+			found := false
+			for _, at := range a.Match.Tool {
+				if strings.EqualFold(at, bt) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return patternCovers(a.Match.Command, b.Match.Command) &&
+		patternCovers(a.Match.FilePath, b.Match.FilePath) &&
+		patternCovers(a.Match.URL, b.Match.URL) &&
+		patternCovers(a.Match.NotificationType, b.Match.NotificationType)
+}
+
+// patternCovers reports whether regex a matches every string regex b matches.
+func patternCovers(a, b string) bool {
+	if a == "" || a == b || isMatchAll(a) {
+		return true
+	}
+	if b == "" {
+		return false
+	}
+	pa, pb := parseSimplePattern(a), parseSimplePattern(b)
+	if pa.rest != "" || len(pa.words) == 0 || len(pb.words) < len(pa.words) {
+		return false
+	}
+	if pa.anchored && !pb.anchored {
+		return false
+	}
+	if pa.leadingBoundary && !pb.anchored && !pb.leadingBoundary {
+		return false
+	}
+	last := len(pa.words) - 1
+	for i := range last {
+		if pa.words[i] != pb.words[i] {
+			return false
+		}
+	}
+	switch {
+	case pa.trailingEnd:
+		return len(pb.words) == len(pa.words) && pa.words[last] == pb.words[last] && pb.trailingEnd
+	case pa.trailingBoundary:
+		if pa.words[last] != pb.words[last] {
+			return false
+		}
+		return len(pb.words) > len(pa.words) || pb.trailingBoundary || pb.trailingEnd ||
+			strings.HasPrefix(pb.rest, `\s`) || strings.HasPrefix(pb.rest, " ")
+	default:
+		return strings.HasPrefix(pb.words[last], pa.words[last])
+	}
+}
+
+// simplePattern is a regex decomposed into whitespace-separated literal words,
+// e.g. `^go\s+test\b` → anchored, [go test], trailing boundary.
+type simplePattern struct {
+	anchored         bool
+	leadingBoundary  bool
+	words            []string
+	trailingBoundary bool
+	trailingEnd      bool
+	rest             string // unparsed remainder after the literal words
+}
+
+func parseSimplePattern(p string) simplePattern {
+	var sp simplePattern
+	switch {
+	case strings.HasPrefix(p, "^"):
+		sp.anchored = true
+		p = p[1:]
+	case strings.HasPrefix(p, `\A`):
+		sp.anchored = true
+		p = p[2:]
+	}
+	if strings.HasPrefix(p, `\b`) {
+		sp.leadingBoundary = true
+		p = p[2:]
+	}
+
+	for {
+		word, n := readLiteralWord(p)
+		if n == 0 {
+			break
+		}
+		sp.words = append(sp.words, word)
+		p = p[n:]
+
+		sep := whitespaceSeparator(p)
+		if sep == 0 {
+			break
+		}
+		if _, next := readLiteralWord(p[sep:]); next == 0 {
+			break
+		}
+		p = p[sep:]
+	}
+
+	switch p {
+	case `\b`:
+		sp.trailingBoundary = true
+	case "$", `\z`:
+		sp.trailingEnd = true
+	default:
+		sp.rest = p
+	}
+	return sp
+}
+
+// readLiteralWord reads a run of literal (non-whitespace) characters,
+// accepting escaped punctuation. Returns the unescaped word and bytes consumed.
+func readLiteralWord(p string) (string, int) {
+	var b strings.Builder
+	i := 0
+	for i < len(p) {
+		c := p[i]
+		if c == '\\' && i+1 < len(p) && strings.IndexByte(`.-/+*?()[]{}|^$\`, p[i+1]) >= 0 {
+			b.WriteByte(p[i+1])
+			i += 2
+			continue
+		}
+		if isLiteralByte(c) {
+			b.WriteByte(c)
+			i++
+			continue
+		}
+		break
+	}
+	return b.String(), i
+}
+
+func isLiteralByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' ||
+		strings.IndexByte("_-/:=,@%~", c) >= 0
+}
+
+// whitespaceSeparator returns the length of a leading whitespace token
+// (`\s+`, `\s`, ` +` or ` `), or 0 if p does not start with one.
+func whitespaceSeparator(p string) int {
+	for _, sep := range []string{`\s+`, `\s`, ` +`, " "} {
+		if strings.HasPrefix(p, sep) {
+			return len(sep)
+		}
+	}
+	return 0
+}
+
+// isAnchored reports whether a regex is anchored at the start of input.
+func isAnchored(p string) bool {
+	p = strings.TrimPrefix(p, "(?i)")
+	return strings.HasPrefix(p, "^") || strings.HasPrefix(p, `\A`)
+}
+
+// isMatchAll reports whether a regex matches every (non-empty) input.
+func isMatchAll(p string) bool {
+	p = strings.TrimPrefix(p, "(?s)")
+	p = strings.TrimPrefix(p, "^")
+	p = strings.TrimSuffix(p, "$")
+	switch p {
+	case "", ".*", ".+", ".":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,287 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRuleFile(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findingFor(report *LintReport, rule, substr string) *LintFinding {
+	for i, f := range report.Findings {
+		if f.Rule == rule && strings.Contains(f.Message, substr) {
+			return &report.Findings[i]
+		}
+	}
+	return nil
+}
+
+func TestLintDefaultsHaveNoErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := SeedDefaults(dir); err != nil {
+		t.Fatalf("SeedDefaults() error = %v", err)
+	}
+
+	report, err := Lint(dir)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.HasErrors() {
+		t.Errorf("default rules have lint errors: %+v", report.Findings)
+	}
+	if report.Rules == 0 {
+		t.Error("expected default rules to be counted")
+	}
+}
+
+func TestLintReportsEveryBrokenFile(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "a.yaml", "name: a\nrules:\n  - name: bad\n    match:\n      tool: Bash\n    action: maybe\n")
+	writeRuleFile(t, dir, "b.yaml", "name: b\nrules:\n  - name: redos\n    match:\n      command: (a+)+$\n    action: deny\n")
+	writeRuleFile(t, dir, "c.yaml", "name: c\nrules:\n  - name: fine\n    match:\n      tool: Read\n    action: allow\n")
+
+	report, err := Lint(dir)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if !report.HasErrors() {
+		t.Fatal("expected errors")
+	}
+	var files []string
+	for _, f := range report.Findings {
+		files = append(files, f.File)
+	}
+	if len(report.Findings) != 2 || files[0] != "a.yaml" || files[1] != "b.yaml" {
+		t.Errorf("findings = %+v, want one error each for a.yaml and b.yaml", report.Findings)
+	}
+	if !strings.Contains(report.Findings[1].Message, "nested quantifiers") {
+		t.Errorf("message = %q, want ReDoS detection", report.Findings[1].Message)
+	}
+}
+
+func TestLintChecksValidFilesBesideBrokenOnes(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "a.yaml", "name: a\nrules:\n  - name: redos\n    match:\n      command: (a+)+$\n    action: deny\n")
+	writeRuleFile(t, dir, "b.yaml", "name: b\nrules:\n  - name: loose\n    match:\n      tool: Bash\n      command: go\\s+test\n    action: allow\n")
+
+	report, err := Lint(dir)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if len(report.Findings) != 2 {
+		t.Fatalf("findings = %+v, want the unanchored warning and the broken file", report.Findings)
+	}
+	if f := report.Findings[0]; f.Rule != "loose" || !strings.Contains(f.Message, "unanchored") {
+		t.Errorf("first finding = %+v, want the unanchored warning for b.yaml", f)
+	}
+	if f := report.Findings[1]; f.File != "a.yaml" || f.Severity != SeverityError {
+		t.Errorf("last finding = %+v, want the a.yaml error", f)
+	}
+}
+
+func TestLintFindingOrderIsStable(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "all.yaml", `name: all
+rules:
+  - name: everything
+    match:
+      command: .*
+      file_path: .*
+      url: .*
+      notification_type: .*
+    action: deny
+`)
+
+	var want []string
+	for range 10 {
+		report, err := Lint(dir)
+		if err != nil {
+			t.Fatalf("Lint() error = %v", err)
+		}
+		var got []string
+		for _, f := range report.Findings {
+			got = append(got, f.Message)
+		}
+		if want == nil {
+			want = got
+		} else if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("findings changed order:\n%v\nvs\n%v", got, want)
+		}
+	}
+	if len(want) != 4 || !strings.HasPrefix(want[0], "command") {
+		t.Errorf("findings = %v, want one per field starting with command", want)
+	}
+}
+
+func TestLintLayersChecksOverlays(t *testing.T) {
+	global := t.TempDir()
+	writeRuleFile(t, global, "go.yaml", `name: go
+priority: 50
+event: PreToolUse
+rules:
+  - name: allow-go-test
+    match:
+      tool: Bash
+      command: ^go\s+test\b
+    action: allow
+`)
+	api := t.TempDir()
+	writeRuleFile(t, api, "local.yaml", `name: local
+priority: 10
+event: PreToolUse
+disable:
+  - go/no-such-rule
+rules:
+  - name: deny-go-test-race
+    match:
+      tool: Bash
+      command: ^go\s+test\s+-race
+    action: deny
+`)
+	broken := t.TempDir()
+	writeRuleFile(t, broken, "bad.yaml", "name: bad\nrules:\n  - name: redos\n    match:\n      command: (a+)+$\n    action: deny\n")
+
+	report, err := LintLayers(global, map[string]string{"api": api, "web": broken})
+	if err != nil {
+		t.Fatalf("LintLayers() error = %v", err)
+	}
+	if report.Files != 3 || report.Rules != 2 {
+		t.Errorf("files = %d, rules = %d, want 3 and 2", report.Files, report.Rules)
+	}
+	if f := findingFor(report, "deny-go-test-race", "unreachable"); f == nil || f.Project != "api" {
+		t.Errorf("want an unreachable finding for the api overlay, got %+v", report.Findings)
+	}
+	if f := findingFor(report, "", "go/no-such-rule"); f == nil || f.Project != "api" {
+		t.Errorf("want a finding for the unknown disable entry, got %+v", report.Findings)
+	}
+	if f := findingFor(report, "", "nested quantifiers"); f == nil || f.Project != "web" || f.Severity != SeverityError {
+		t.Errorf("want an error for the broken web overlay, got %+v", report.Findings)
+	}
+
+	clean, err := LintLayers(global, nil)
+	if err != nil {
+		t.Fatalf("LintLayers() error = %v", err)
+	}
+	if len(clean.Findings) != 0 {
+		t.Errorf("global layer alone: findings = %+v", clean.Findings)
+	}
+}
+
+func TestLintBroadPatterns(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "broad.yaml", `name: broad
+priority: 10
+rules:
+  - name: unanchored
+    match:
+      tool: Bash
+      command: go\s+test
+    action: allow
+  - name: all-bash
+    match:
+      tool: Bash
+    action: allow
+  - name: everything
+    match:
+      file_path: .*
+    action: deny
+`)
+
+	report, err := Lint(dir)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if findingFor(report, "unanchored", "unanchored") == nil {
+		t.Errorf("missing unanchored finding: %+v", report.Findings)
+	}
+	if findingFor(report, "all-bash", "every Bash command") == nil {
+		t.Errorf("missing all-bash finding: %+v", report.Findings)
+	}
+	if findingFor(report, "everything", "matches everything") == nil {
+		t.Errorf("missing match-all finding: %+v", report.Findings)
+	}
+}
+
+func TestLintShadowedRules(t *testing.T) {
+	dir := t.TempDir()
+	writeRuleFile(t, dir, "high.yaml", `name: high
+priority: 100
+event: PreToolUse
+rules:
+  - name: allow-git
+    match:
+      tool: Bash
+      command: ^git\b
+    action: allow
+`)
+	writeRuleFile(t, dir, "low.yaml", `name: low
+priority: 10
+event: PreToolUse
+rules:
+  - name: deny-git-push
+    match:
+      tool: Bash
+      command: ^git\s+push\b
+    action: deny
+  - name: allow-git-status
+    match:
+      tool: Bash
+      command: ^git\s+status
+    action: allow
+  - name: deny-github-cli
+    match:
+      tool: Bash
+      command: ^github\s+login
+    action: deny
+`)
+
+	report, err := Lint(dir)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	f := findingFor(report, "deny-git-push", "unreachable")
+	if f == nil || f.Severity != SeverityError {
+		t.Fatalf("expected unreachable error for deny-git-push, got %+v", report.Findings)
+	}
+	if !strings.Contains(f.Message, "allow-git in high") {
+		t.Errorf("message = %q, want reference to allow-git in high", f.Message)
+	}
+	if f := findingFor(report, "allow-git-status", "redundant"); f == nil || f.Severity != SeverityWarning {
+		t.Errorf("expected redundant warning for allow-git-status, got %+v", report.Findings)
+	}
+	if f := findingFor(report, "deny-github-cli", ""); f != nil {
+		t.Errorf("deny-github-cli should not be shadowed by ^git\\b: %+v", f)
+	}
+}
+
+func TestPatternCovers(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"", `^rm\b`, true},
+		{".*", `^rm\b`, true},
+		{`^git\b`, `^git\s+push\b`, true},
+		{`^git`, `^github\b`, true},
+		{`^git\b`, `^github\b`, false},
+		{`^git\b`, `^git`, false},
+		{`\bgit\s+push\b`, `^git\s+push\s+--force`, true},
+		{`^git\s+push\b`, `\bgit\s+push\b`, false},
+		{`^go\s+test\b`, `^go\s+(test|vet)\b`, false},
+		{`\.env$`, `\.env$`, true},
+		{`^make$`, `^make\s+build$`, false},
+	}
+	for _, tt := range tests {
+		if got := patternCovers(tt.a, tt.b); got != tt.want {
+			t.Errorf("patternCovers(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// checkRegexComplexity rejects patterns that could cause catastrophic backtracking.
-func checkRegexComplexity(pattern string) error {
+// CheckRegexComplexity rejects patterns that could cause catastrophic
+// backtracking. LoadRulesets applies it to every match pattern, so callers
+// writing rule files should check patterns with it first.
+func CheckRegexComplexity(pattern string) error {
 	const maxPatternLen = 1024
 	if len(pattern) > maxPatternLen {
 		return fmt.Errorf("pattern too long (%d chars, max %d)", len(pattern), maxPatternLen)
@@ -65,60 +67,17 @@ func LoadRulesets(dir string) ([]*Ruleset, *BashPipeline, error) {
 	var bashPipeline *BashPipeline
 
 	for _, entry := range entries {
-		if entry.IsDir() {
-			continue
-		}
-		ext := filepath.Ext(entry.Name())
-		if ext != ".yaml" && ext != ".yml" && ext != ".md" {
+		if entry.IsDir() || !isRuleFile(entry.Name()) {
 			continue
 		}
 
 		path := filepath.Join(dir, entry.Name())
-		data, err := os.ReadFile(path)
+		rs, err := parseRulesetFile(path)
 		if err != nil {
-			return nil, nil, fmt.Errorf("read %s: %w", path, err)
-		}
-
-		// For markdown files, extract YAML frontmatter.
-		if ext == ".md" {
-			data = extractFrontmatter(data)
-			if data == nil {
-				continue // No frontmatter found, skip.
-			}
+			return nil, nil, err
 		}
-
-		var rs Ruleset
-		if err := yaml.Unmarshal(data, &rs); err != nil {
-			return nil, nil, fmt.Errorf("parse %s: %w", path, err)
-		}
-
-		// Validate rules
-		for i, rule := range rs.Rules {
-			if rule.Name == "" {
-				return nil, nil, fmt.Errorf("%s: rule %d has no name", path, i)
-			}
-			if rule.Action != ActionAllow && rule.Action != ActionDeny && rule.Action != ActionAsk {
-				return nil, nil, fmt.Errorf("%s: rule %q has invalid action %q", path, rule.Name, rule.Action)
-			}
-
-			// Validate regex patterns (includes ReDoS protection)
-			for field, pattern := range map[string]string{
-				"command":           rule.Match.Command,
-				"file_path":         rule.Match.FilePath,
-				"url":               rule.Match.URL,
-				"notification_type": rule.Match.NotificationType,
-			} {
-				if pattern != "" {
-					if err := checkRegexComplexity(pattern); err != nil {
-						return nil, nil, fmt.Errorf("%s: rule %q has invalid %s regex %q: %w", path, rule.Name, field, pattern, err)
-					}
-				}
-			}
-
-			// Warn if a rule has completely empty MatchConfig (matches everything)
-			if len(rule.Match.Tool) == 0 && rule.Match.Command == "" && rule.Match.FilePath == "" && rule.Match.URL == "" && rule.Match.NotificationType == "" {
-				slog.Warn("rule has empty match config — it will match all requests", "file", path, "rule", rule.Name)
-			}
+		if rs == nil {
+			continue // Markdown without frontmatter.
 		}
 
 		if rs.Type == "bash-pipeline" {
@@ -134,7 +93,7 @@ func LoadRulesets(dir string) ([]*Ruleset, *BashPipeline, error) {
 			continue
 		}
 
-		rulesets = append(rulesets, &rs)
+		rulesets = append(rulesets, rs)
 	}
 
 	// Sort by priority descending
@@ -145,6 +104,61 @@ func LoadRulesets(dir string) ([]*Ruleset, *BashPipeline, error) {
 	return rulesets, bashPipeline, nil
 }
 
+// isRuleFile reports whether a file name has a rule file extension.
+func isRuleFile(name string) bool {
+	ext := filepath.Ext(name)
+	return ext == ".yaml" || ext == ".yml" || ext == ".md"
+}
+
+// parseRulesetFile reads and validates a single rule file. Returns nil with
+// no error for markdown files without frontmatter.
+func parseRulesetFile(path string) (*Ruleset, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+
+	// For markdown files, extract YAML frontmatter.
+	if filepath.Ext(path) == ".md" {
+		data = extractFrontmatter(data)
+		if data == nil {
+			return nil, nil
+		}
+	}
+
+	var rs Ruleset
+	if err := yaml.Unmarshal(data, &rs); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	rs.Source = path
+
+	// Validate rules
+	for i, rule := range rs.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("%s: rule %d has no name", path, i)
+		}
+		if rule.Action != ActionAllow && rule.Action != ActionDeny && rule.Action != ActionAsk {
+			return nil, fmt.Errorf("%s: rule %q has invalid action %q", path, rule.Name, rule.Action)
+		}
+
+		// Validate regex patterns (includes ReDoS protection)
+		for _, p := range rule.Match.patterns() {
+			if p.pattern != "" {
+				if err := CheckRegexComplexity(p.pattern); err != nil {
+					return nil, fmt.Errorf("%s: rule %q has invalid %s regex %q: %w", path, rule.Name, p.field, p.pattern, err)
+				}
+			}
+		}
+
+		// Warn if a rule has completely empty MatchConfig (matches everything)
+		if rule.Match.isEmpty() {
+			slog.Warn("rule has empty match config — it will match all requests", "file", path, "rule", rule.Name)
+		}
+	}
+
+	return &rs, nil
+}
+
 // extractFrontmatter extracts YAML frontmatter from markdown content.
 // Returns nil if no frontmatter delimiter is found.
 func extractFrontmatter(data []byte) []byte {
@@ -222,7 +222,7 @@ func TestCheckRegexComplexity(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := checkRegexComplexity(tt.pattern)
+			err := CheckRegexComplexity(tt.pattern)
 			if tt.wantErr {
 				if err == nil {
 					t.Fatalf("expected error, got nil")
@@ -0,0 +1,182 @@
+package rules
+
+import (
+	"sort"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+// ReplayChange is a group of historical requests whose decision would differ
+// under the current rules.
+type ReplayChange struct {
+	Tool     string
+	Subject  string // command or file path
+	Old      Action
+	New      Action
+	Ruleset  string // ruleset producing the new decision
+	Rule     string // rule producing the new decision
+	Reason   string
+	Count    int
+	LastSeen time.Time
+}
+
+// ReplayReport summarizes a replay of historical events against rulesets.
+type ReplayReport struct {
+	Evaluated int
+	Unchanged int
+	Changes   []ReplayChange
+}
+
+// replayCandidate is a historical request reconstructed from the event log.
+type replayCandidate struct {
+	ts      time.Time
+	project string
+	tool    string
+	subject string
+	input   map[string]any
+	old     Action
+	builtin bool // decided by a built-in hook check, not the rules
+}
+
+// Replay re-evaluates historical hook.pre-tool and hook.permission-request
+// events against the given rulesets. The historical decision for a pre-tool
+// event is taken from the hook.rule-decision event logged right after it for
+// the same run; without one, the request fell through to "ask". Permission
+// requests always reached the user, so their historical decision is "ask";
+// one that follows an unmatched pre-tool event for the same run and request
+// is the same call and is counted once. Pre-tool events decided by a
+// built-in hook check (rule-decision data "builtin": true) are skipped, as
+// the rules never applied to them. Events must be in chronological order.
+// layers resolves the rulesets in effect for an event's project (see
+// ProjectLayers).
+func Replay(layers LayerResolver, events []event.Event) *ReplayReport {
+	var candidates []*replayCandidate
+	lastPreTool := make(map[string]*replayCandidate)
+	lastAsk := make(map[string]*replayCandidate)
+
+	for _, ev := range events {
+		switch ev.Event {
+		case event.HookPreTool, event.HookPermissionReq:
+			c := candidateFromEvent(ev)
+			if c == nil {
+				continue
+			}
+			if ev.Event == event.HookPermissionReq && ev.RunID != "" {
+				if prev := lastAsk[ev.RunID]; prev != nil && prev.tool == c.tool && prev.subject == c.subject {
+					delete(lastAsk, ev.RunID)
+					continue
+				}
+			}
+			candidates = append(candidates, c)
+			if ev.Event == event.HookPreTool && ev.RunID != "" {
+				lastPreTool[ev.RunID] = c
+				lastAsk[ev.RunID] = c
+			}
+		case event.HookRuleDecision:
+			c, ok := lastPreTool[ev.RunID]
+			if !ok {
+				continue
+			}
+			delete(lastPreTool, ev.RunID)
+			tool, _ := ev.Data["tool"].(string)
+			if builtin, _ := ev.Data["builtin"].(bool); builtin && tool == c.tool {
+				c.builtin = true
+				delete(lastAsk, ev.RunID)
+				continue
+			}
+			if tool != c.tool || eventSubject(ev.Data) != c.subject {
+				continue
+			}
+			if decision, _ := ev.Data["decision"].(string); decision != "" {
+				c.old = Action(decision)
+			}
+			if c.old != ActionAsk {
+				delete(lastAsk, ev.RunID)
+			}
+		}
+	}
+
+	type groupKey struct {
+		tool, subject string
+		old, new      Action
+	}
+	groups := make(map[groupKey]*ReplayChange)
+	report := &ReplayReport{}
+
+	for _, c := range candidates {
+		if c.builtin {
+			continue
+		}
+		report.Evaluated++
+		rulesets, bash := layers(c.project)
+		result := evaluate(rulesets, bash, "PreToolUse", c.tool, c.input)
+		if result.Decision == c.old {
+			report.Unchanged++
+			continue
+		}
+		key := groupKey{tool: c.tool, subject: c.subject, old: c.old, new: result.Decision}
+		g, ok := groups[key]
+		if !ok {
+			g = &ReplayChange{
+				Tool:    c.tool,
+				Subject: c.subject,
+				Old:     c.old,
+				New:     result.Decision,
+				Ruleset: result.Ruleset,
+				Rule:    result.Rule,
+				Reason:  result.Reason,
+			}
+			groups[key] = g
+		}
+		g.Count++
+		if c.ts.After(g.LastSeen) {
+			g.LastSeen = c.ts
+		}
+	}
+
+	for _, g := range groups {
+		report.Changes = append(report.Changes, *g)
+	}
+	sort.Slice(report.Changes, func(i, j int) bool {
+		if report.Changes[i].Count != report.Changes[j].Count {
+			return report.Changes[i].Count > report.Changes[j].Count
+		}
+		return report.Changes[i].Subject < report.Changes[j].Subject
+	})
+
+	return report
+}
+
+// candidateFromEvent rebuilds the tool input recorded on a hook event.
+// Returns nil for events without a tool name.
+func candidateFromEvent(ev event.Event) *replayCandidate {
+	tool, _ := ev.Data["tool"].(string)
+	if tool == "" {
+		return nil
+	}
+	input := make(map[string]any)
+	for _, key := range []string{"command", "file_path", "url"} {
+		if v, ok := ev.Data[key].(string); ok {
+			input[key] = v
+		}
+	}
+	return &replayCandidate{
+		ts:      ev.TS,
+		project: ev.Project,
+		tool:    tool,
+		subject: eventSubject(ev.Data),
+		input:   input,
+		old:     ActionAsk,
+	}
+}
+
+// eventSubject returns the command or file path recorded in event data.
+func eventSubject(data map[string]any) string {
+	for _, key := range []string{"command", "file_path", "url", "pattern"} {
+		if v, ok := data[key].(string); ok && v != "" {
+			return v
+		}
+	}
+	return ""
+}
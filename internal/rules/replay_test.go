@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+func TestReplayReportsChangedDecisions(t *testing.T) {
+	rulesets := []*Ruleset{
+		{
+			Name:     "general",
+			Priority: 50,
+			Rules: []Rule{
+				{Name: "allow-go-test", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^go\s+test\b`}, Action: ActionAllow},
+				{Name: "deny-curl", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^curl\b`}, Action: ActionDeny},
+			},
+		},
+	}
+
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := []event.Event{
+		// Previously unmatched, now allowed.
+		{TS: base, Event: event.HookPreTool, RunID: "r1", Data: map[string]any{"tool": "Bash", "command": "go test ./..."}},
+		{TS: base.Add(time.Second), Event: event.HookPreTool, RunID: "r1", Data: map[string]any{"tool": "Bash", "command": "go test ./..."}},
+		// Previously allowed by some rule, now denied.
+		{TS: base.Add(2 * time.Second), Event: event.HookPreTool, RunID: "r2", Data: map[string]any{"tool": "Bash", "command": "curl example.com"}},
+		{TS: base.Add(2 * time.Second), Event: event.HookRuleDecision, RunID: "r2", Data: map[string]any{"tool": "Bash", "command": "curl example.com", "decision": "allow"}},
+		// Previously allowed and still allowed.
+		{TS: base.Add(3 * time.Second), Event: event.HookPreTool, RunID: "r3", Data: map[string]any{"tool": "Bash", "command": "go test ./cmd"}},
+		{TS: base.Add(3 * time.Second), Event: event.HookRuleDecision, RunID: "r3", Data: map[string]any{"tool": "Bash", "command": "go test ./cmd", "decision": "allow"}},
+		// Permission request that would now be auto-allowed.
+		{TS: base.Add(4 * time.Second), Event: event.HookPermissionReq, RunID: "r4", Data: map[string]any{"tool": "Bash", "command": "go test -run X"}},
+		// Unrelated events are ignored.
+		{TS: base.Add(5 * time.Second), Event: event.HookSessionStart, RunID: "r5"},
+	}
+
+	report := Replay(func(string) ([]*Ruleset, *BashPipeline) { return rulesets, nil }, events)
+
+	if report.Evaluated != 5 {
+		t.Errorf("evaluated = %d, want 5", report.Evaluated)
+	}
+	if report.Unchanged != 1 {
+		t.Errorf("unchanged = %d, want 1", report.Unchanged)
+	}
+	if len(report.Changes) != 3 {
+		t.Fatalf("changes = %d, want 3: %+v", len(report.Changes), report.Changes)
+	}
+
+	first := report.Changes[0]
+	if first.Subject != "go test ./..." || first.Count != 2 || first.Old != ActionAsk || first.New != ActionAllow {
+		t.Errorf("first change = %+v, want 2x go test ask→allow", first)
+	}
+	if !first.LastSeen.Equal(base.Add(time.Second)) {
+		t.Errorf("last seen = %v, want %v", first.LastSeen, base.Add(time.Second))
+	}
+
+	var curl *ReplayChange
+	for i := range report.Changes {
+		if report.Changes[i].Subject == "curl example.com" {
+			curl = &report.Changes[i]
+		}
+	}
+	if curl == nil {
+		t.Fatal("missing curl change")
+	}
+	if curl.Old != ActionAllow || curl.New != ActionDeny || curl.Rule != "deny-curl" {
+		t.Errorf("curl change = %+v, want allow→deny by deny-curl", curl)
+	}
+}
+
+func TestReplayCountsHandApprovedCallOnce(t *testing.T) {
+	rulesets := []*Ruleset{
+		{
+			Name:     "general",
+			Priority: 50,
+			Rules: []Rule{
+				{Name: "allow-go-test", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^go\s+test\b`}, Action: ActionAllow},
+			},
+		},
+	}
+
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := []event.Event{
+		// Unmatched pre-tool followed by the permission prompt for the same call.
+		{TS: base, Event: event.HookPreTool, RunID: "r1", Data: map[string]any{"tool": "Bash", "command": "go test ./..."}},
+		{TS: base, Event: event.HookRuleDecision, RunID: "r1", Data: map[string]any{"tool": "Bash", "command": "go test ./...", "decision": "ask"}},
+		{TS: base.Add(time.Second), Event: event.HookPermissionReq, RunID: "r1", Data: map[string]any{"tool": "Bash", "command": "go test ./..."}},
+		// A permission request for a different call still counts.
+		{TS: base.Add(2 * time.Second), Event: event.HookPermissionReq, RunID: "r1", Data: map[string]any{"tool": "Bash", "command": "go test ./cmd"}},
+		// Denied by a built-in hook check, not the rules: skipped.
+		{TS: base.Add(3 * time.Second), Event: event.HookPreTool, RunID: "r2", Data: map[string]any{"tool": "Write", "file_path": "/repo/main.go"}},
+		{TS: base.Add(3 * time.Second), Event: event.HookRuleDecision, RunID: "r2", Data: map[string]any{"tool": "Write", "file_path": "/repo/main.go", "decision": "deny", "rule": "missing-ticket", "builtin": true}},
+	}
+
+	report := Replay(func(string) ([]*Ruleset, *BashPipeline) { return rulesets, nil }, events)
+
+	if report.Evaluated != 2 {
+		t.Errorf("evaluated = %d, want 2", report.Evaluated)
+	}
+	if len(report.Changes) != 2 {
+		t.Fatalf("changes = %+v, want 2", report.Changes)
+	}
+	for _, c := range report.Changes {
+		if c.Count != 1 || c.Old != ActionAsk || c.New != ActionAllow {
+			t.Errorf("change = %+v, want one ask→allow", c)
+		}
+	}
+}
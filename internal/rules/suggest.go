@@ -0,0 +1,342 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"gopkg.in/yaml.v3"
+)
+
+// Suggestion risk levels. Risk lowers a suggestion's rank and is shown
+// alongside it so broad or dangerous patterns are never accepted blindly.
+const (
+	RiskLow    = "low"
+	RiskMedium = "medium"
+	RiskHigh   = "high"
+)
+
+// riskWeight scales a suggestion's frequency into its ranking score.
+var riskWeight = map[string]float64{RiskLow: 1, RiskMedium: 0.5, RiskHigh: 0.1}
+
+// highRiskCommands are programs whose allow rules can modify the system,
+// reach the network, or execute arbitrary code.
+var highRiskCommands = map[string]bool{
+	"rm": true, "mv": true, "dd": true, "chmod": true, "chown": true, "sudo": true,
+	"su": true, "kill": true, "pkill": true, "killall": true, "curl": true,
+	"wget": true, "ssh": true, "scp": true, "rsync": true, "docker": true,
+	"kubectl": true, "terraform": true, "bash": true, "sh": true, "zsh": true,
+	"eval": true, "exec": true, "xargs": true, "env": true, "python": true,
+	"python3": true, "node": true, "perl": true, "ruby": true,
+}
+
+// highRiskSubcommands are "program subcommand" pairs that publish, push or
+// delete remote state.
+var highRiskSubcommands = map[string]bool{
+	"git push": true, "git reset": true, "git clean": true, "npm publish": true,
+	"cargo publish": true, "gh release": true, "gh repo": true,
+}
+
+// Suggestion is a generalized allow rule mined from commands the user had to
+// approve by hand.
+type Suggestion struct {
+	Tool     string
+	Name     string
+	Pattern  string // command regex
+	Risk     string // one of the Risk* constants
+	Count    int    // approvals the pattern would have covered
+	Examples []string
+	LastSeen time.Time
+	Score    float64
+}
+
+// Rule returns the suggestion as an allow rule.
+func (s Suggestion) Rule() Rule {
+	return Rule{
+		Name:    s.Name,
+		Match:   MatchConfig{Tool: StringOrList{s.Tool}, Command: s.Pattern},
+		Action:  ActionAllow,
+		Message: fmt.Sprintf("suggested from %d manual approvals", s.Count),
+	}
+}
+
+// YAML returns the rule entry to append under a ruleset's `rules:` key.
+func (s Suggestion) YAML() string {
+	type match struct {
+		Tool    string `yaml:"tool"`
+		Command string `yaml:"command"`
+	}
+	type rule struct {
+		Name    string `yaml:"name"`
+		Match   match  `yaml:"match"`
+		Action  string `yaml:"action"`
+		Message string `yaml:"message"`
+	}
+	r := s.Rule()
+	out, err := yaml.Marshal([]rule{{
+		Name:    r.Name,
+		Match:   match{Tool: s.Tool, Command: r.Match.Command},
+		Action:  string(r.Action),
+		Message: r.Message,
+	}})
+	if err != nil {
+		return ""
+	}
+	// Indent to sit under `rules:`.
+	lines := strings.Split(strings.TrimRight(string(out), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = "  " + l
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// SuggestOptions tunes the suggestion engine.
+type SuggestOptions struct {
+	// MinCount is the minimum number of approvals a pattern must cover.
+	MinCount int
+	// MaxExamples caps the example commands kept per suggestion.
+	MaxExamples int
+}
+
+// askedCommand is a Bash command that reached the user for approval.
+type askedCommand struct {
+	ts      time.Time
+	command string
+}
+
+// Suggest mines hook.permission-request events and "ask" hook.rule-decision
+// events for Bash commands that needed manual approval, clusters them into
+// generalized command patterns and returns allow-rule suggestions ranked by
+// frequency and risk. Commands already allowed or denied by the rules in
+// effect for their project are ignored, as is the
+// permission request that immediately follows an "ask" decision for the same
+// command and run.
+func Suggest(layers LayerResolver, events []event.Event, opts SuggestOptions) []Suggestion {
+	if opts.MinCount < 1 {
+		opts.MinCount = 2
+	}
+	if opts.MaxExamples < 1 {
+		opts.MaxExamples = 3
+	}
+
+	lastAsk := make(map[string]string)
+	var asked []askedCommand
+	for _, ev := range events {
+		tool, _ := ev.Data["tool"].(string)
+		command, _ := ev.Data["command"].(string)
+		if tool != "Bash" || strings.TrimSpace(command) == "" {
+			continue
+		}
+		switch ev.Event {
+		case event.HookRuleDecision:
+			if decision, _ := ev.Data["decision"].(string); decision != string(ActionAsk) {
+				continue
+			}
+			if ev.RunID != "" {
+				lastAsk[ev.RunID] = command
+			}
+		case event.HookPermissionReq:
+			if ev.RunID != "" && lastAsk[ev.RunID] == command {
+				delete(lastAsk, ev.RunID)
+				continue
+			}
+		default:
+			continue
+		}
+
+		rulesets, bash := layers(ev.Project)
+		for _, part := range splitChainedCommands(command) {
+			part = strings.TrimSpace(part)
+			if part == "" {
+				continue
+			}
+			result := evaluate(rulesets, bash, "PreToolUse", "Bash", map[string]any{"command": part})
+			if result.Decision != ActionAsk {
+				continue
+			}
+			asked = append(asked, askedCommand{ts: ev.TS, command: part})
+		}
+	}
+
+	suggestions := clusterCommands(asked, opts)
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		if suggestions[i].Score != suggestions[j].Score {
+			return suggestions[i].Score > suggestions[j].Score
+		}
+		return suggestions[i].Pattern < suggestions[j].Pattern
+	})
+	return suggestions
+}
+
+// commandCluster groups commands sharing a program (and subcommand) and an
+// argument stem.
+type commandCluster struct {
+	head     []string
+	stem     string
+	args     map[string]bool
+	commands []askedCommand
+}
+
+// clusterCommands groups asked commands by head ("go run") and first-argument
+// stem ("./cmd/"), generalizing varying arguments to `\S+`. Programs seen
+// in three or more clusters also get a program-wide suggestion.
+func clusterCommands(asked []askedCommand, opts SuggestOptions) []Suggestion {
+	clusters := make(map[string]*commandCluster)
+	programs := make(map[string][]*commandCluster)
+	var order []string
+
+	for _, a := range asked {
+		words := strings.Fields(splitUnquoted(a.command, '|')[0])
+		if len(words) == 0 || strings.Contains(words[0], "=") {
+			continue
+		}
+		head, rest := commandHead(words)
+		var arg, stem string
+		if len(rest) > 0 {
+			arg = rest[0]
+			stem = argStem(arg)
+		}
+		key := strings.Join(head, " ") + "\x00" + stem
+		c, ok := clusters[key]
+		if !ok {
+			c = &commandCluster{head: head, stem: stem, args: make(map[string]bool)}
+			clusters[key] = c
+			programs[head[0]] = append(programs[head[0]], c)
+			order = append(order, key)
+		}
+		c.args[arg] = true
+		c.commands = append(c.commands, a)
+	}
+
+	var out []Suggestion
+	for _, key := range order {
+		c := clusters[key]
+		if len(c.commands) < opts.MinCount {
+			continue
+		}
+		name := strings.Join(c.head, " ") + " " + c.stem
+		out = append(out, newSuggestion(c.head, name, clusterPattern(c), c.commands, opts))
+	}
+
+	for program, group := range programs {
+		if len(group) < 3 {
+			continue
+		}
+		var all []askedCommand
+		for _, c := range group {
+			all = append(all, c.commands...)
+		}
+		head := []string{program}
+		s := newSuggestion(head, program, headPattern(head)+`\b`, all, opts)
+		if s.Risk == RiskLow {
+			// Any arguments are allowed.
+			s.Risk = RiskMedium
+			s.Score = float64(s.Count) * riskWeight[RiskMedium]
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// commandHead splits words into the program (plus a subcommand, when the
+// second word looks like one) and the remaining arguments.
+func commandHead(words []string) ([]string, []string) {
+	if len(words) > 1 && isSubcommand(words[1]) {
+		return words[:2], words[2:]
+	}
+	return words[:1], words[1:]
+}
+
+var reSubcommand = regexp.MustCompile(`^[a-z][a-z0-9-]*$`)
+
+func isSubcommand(w string) bool {
+	return reSubcommand.MatchString(w)
+}
+
+// argStem returns the directory part of a path-like argument ("./cmd/st" →
+// "./cmd/"), or the argument itself.
+func argStem(arg string) string {
+	if i := strings.LastIndex(arg, "/"); i >= 0 && i < len(arg)-1 {
+		return arg[:i+1]
+	}
+	return arg
+}
+
+// clusterPattern builds the command regex for a cluster.
+func clusterPattern(c *commandCluster) string {
+	p := headPattern(c.head)
+	switch {
+	case c.stem == "":
+		return p + `\s*$`
+	case len(c.args) == 1:
+		var arg string
+		for a := range c.args {
+			arg = a
+		}
+		return p + `\s+` + regexp.QuoteMeta(arg) + wordEnd(arg)
+	default:
+		return p + `\s+` + regexp.QuoteMeta(c.stem) + `\S+`
+	}
+}
+
+func headPattern(head []string) string {
+	quoted := make([]string, len(head))
+	for i, w := range head {
+		quoted[i] = regexp.QuoteMeta(w)
+	}
+	return "^" + strings.Join(quoted, `\s+`)
+}
+
+// wordEnd returns the pattern ending a literal word: \b after a word
+// character, otherwise whitespace or end of input.
+func wordEnd(w string) string {
+	last := w[len(w)-1]
+	if last == '_' || last >= 'a' && last <= 'z' || last >= 'A' && last <= 'Z' || last >= '0' && last <= '9' {
+		return `\b`
+	}
+	return `(\s|$)`
+}
+
+func newSuggestion(head []string, name, pattern string, commands []askedCommand, opts SuggestOptions) Suggestion {
+	s := Suggestion{
+		Tool:    "Bash",
+		Name:    "allow-" + sanitizeName(name),
+		Pattern: pattern,
+		Count:   len(commands),
+		Risk:    commandRisk(head),
+	}
+	s.Score = float64(s.Count) * riskWeight[s.Risk]
+
+	seen := make(map[string]bool)
+	for _, c := range commands {
+		if c.ts.After(s.LastSeen) {
+			s.LastSeen = c.ts
+		}
+		if !seen[c.command] && len(s.Examples) < opts.MaxExamples {
+			seen[c.command] = true
+			s.Examples = append(s.Examples, c.command)
+		}
+	}
+	return s
+}
+
+// commandRisk classifies allowing the given command head.
+func commandRisk(head []string) string {
+	if highRiskCommands[head[0]] {
+		return RiskHigh
+	}
+	if len(head) > 1 && highRiskSubcommands[head[0]+" "+head[1]] {
+		return RiskHigh
+	}
+	return RiskLow
+}
+
+var reNameUnsafe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// sanitizeName turns a command fragment into a rule-name slug.
+func sanitizeName(s string) string {
+	return strings.Trim(reNameUnsafe.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+	"gopkg.in/yaml.v3"
+)
+
+func askEvents(base time.Time, runID string, commands ...string) []event.Event {
+	var evs []event.Event
+	for i, cmd := range commands {
+		ts := base.Add(time.Duration(i) * time.Second)
+		data := map[string]any{"tool": "Bash", "command": cmd}
+		evs = append(evs,
+			event.Event{TS: ts, Event: event.HookRuleDecision, RunID: runID, Data: map[string]any{"tool": "Bash", "command": cmd, "decision": "ask"}},
+			event.Event{TS: ts, Event: event.HookPermissionReq, RunID: runID, Data: data},
+		)
+	}
+	return evs
+}
+
+func findSuggestion(suggestions []Suggestion, pattern string) *Suggestion {
+	for i := range suggestions {
+		if suggestions[i].Pattern == pattern {
+			return &suggestions[i]
+		}
+	}
+	return nil
+}
+
+func TestSuggestClustersCommands(t *testing.T) {
+	rulesets := []*Ruleset{{
+		Name:     "general",
+		Priority: 50,
+		Rules: []Rule{
+			{Name: "allow-go-test", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^go\s+test\b`}, Action: ActionAllow},
+		},
+	}}
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := askEvents(base, "r1",
+		"go run ./cmd/st list",
+		"go run ./cmd/st show st_abc",
+		"go run ./cmd/web",
+		"go test ./...", // already allowed
+		"rm -rf build",
+		"rm -rf dist",
+		"ls", // seen once, below MinCount
+	)
+	// A permission request without a preceding ask decision still counts.
+	events = append(events, event.Event{TS: base.Add(time.Minute), Event: event.HookPermissionReq, RunID: "r2", Data: map[string]any{"tool": "Bash", "command": "go run ./cmd/st next"}})
+
+	suggestions := Suggest(func(string) ([]*Ruleset, *BashPipeline) { return rulesets, nil }, events, SuggestOptions{})
+
+	goRun := findSuggestion(suggestions, `^go\s+run\s+\./cmd/\S+`)
+	if goRun == nil {
+		t.Fatalf("missing go run suggestion in %+v", suggestions)
+	}
+	if goRun.Count != 4 || goRun.Risk != RiskLow || goRun.Name != "allow-go-run-cmd" {
+		t.Errorf("go run suggestion = %+v, want 4 low-risk approvals named allow-go-run-cmd", goRun)
+	}
+	if len(goRun.Examples) != 3 {
+		t.Errorf("examples = %v, want 3", goRun.Examples)
+	}
+	if !goRun.LastSeen.Equal(base.Add(time.Minute)) {
+		t.Errorf("last seen = %v, want %v", goRun.LastSeen, base.Add(time.Minute))
+	}
+
+	rm := findSuggestion(suggestions, `^rm\s+-rf\b`)
+	if rm == nil || rm.Risk != RiskHigh {
+		t.Fatalf("rm suggestion = %+v, want high risk", rm)
+	}
+	if suggestions[0].Pattern != goRun.Pattern {
+		t.Errorf("top suggestion = %s, want go run ranked above risky rm", suggestions[0].Pattern)
+	}
+
+	for _, s := range suggestions {
+		if strings.HasPrefix(s.Pattern, `^go\s+test`) || strings.HasPrefix(s.Pattern, `^ls`) {
+			t.Errorf("unexpected suggestion %s", s.Pattern)
+		}
+		re := regexp.MustCompile(s.Pattern)
+		for _, ex := range s.Examples {
+			if !re.MatchString(ex) {
+				t.Errorf("pattern %s does not match its example %q", s.Pattern, ex)
+			}
+		}
+	}
+}
+
+func TestSuggestHeadWideSuggestion(t *testing.T) {
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := askEvents(base, "r1",
+		"make build", "make build",
+		"make lint", "make lint",
+		"make docs", "make docs",
+	)
+
+	suggestions := Suggest(func(string) ([]*Ruleset, *BashPipeline) { return nil, nil }, events, SuggestOptions{})
+
+	wide := findSuggestion(suggestions, `^make\b`)
+	if wide == nil {
+		t.Fatalf("missing head-wide make suggestion in %+v", suggestions)
+	}
+	if wide.Count != 6 || wide.Risk != RiskMedium {
+		t.Errorf("make suggestion = %+v, want 6 medium-risk approvals", wide)
+	}
+	if findSuggestion(suggestions, `^make\s+build\s*$`) == nil {
+		t.Errorf("missing narrow make build suggestion in %+v", suggestions)
+	}
+}
+
+func TestSuggestCompoundCommands(t *testing.T) {
+	rulesets := []*Ruleset{{
+		Name: "general",
+		Rules: []Rule{
+			{Name: "allow-cd", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^cd\b`}, Action: ActionAllow},
+		},
+	}}
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	events := askEvents(base, "r1", "cd web && pnpm build", "cd api && pnpm build")
+
+	suggestions := Suggest(func(string) ([]*Ruleset, *BashPipeline) { return rulesets, nil }, events, SuggestOptions{})
+
+	if len(suggestions) != 1 || suggestions[0].Pattern != `^pnpm\s+build\s*$` {
+		t.Fatalf("suggestions = %+v, want only pnpm build", suggestions)
+	}
+}
+
+func TestSuggestionYAML(t *testing.T) {
+	s := Suggestion{Tool: "Bash", Name: "allow-go-run-cmd", Pattern: `^go\s+run\s+\./cmd/\S+`, Count: 4}
+
+	var parsed struct {
+		Rules []Rule `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal([]byte("rules:\n"+s.YAML()), &parsed); err != nil {
+		t.Fatalf("unmarshal suggestion YAML: %v\n%s", err, s.YAML())
+	}
+	if len(parsed.Rules) != 1 {
+		t.Fatalf("rules = %d, want 1", len(parsed.Rules))
+	}
+	r := parsed.Rules[0]
+	if r.Name != s.Name || r.Match.Command != s.Pattern || r.Action != ActionAllow || len(r.Match.Tool) != 1 || r.Match.Tool[0] != "Bash" {
+		t.Errorf("parsed rule = %+v", r)
+	}
+}
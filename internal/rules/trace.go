@@ -0,0 +1,87 @@
+package rules
+
+import "fmt"
+
+// Step outcomes recorded in a Trace.
+const (
+	OutcomeMatched      = "matched"
+	OutcomeNoMatch      = "no-match"
+	OutcomeEventSkipped = "event-skipped"
+	OutcomeError        = "error"
+)
+
+// TraceStep records a single rule considered during evaluation.
+type TraceStep struct {
+	Command  string // the (sub-)command being matched, empty for non-Bash tools
+	Origin   string // ruleset origin (see OriginGlobal)
+	Ruleset  string
+	Priority int
+	Rule     string // empty when the whole ruleset was skipped
+	Action   Action
+	Outcome  string // one of the Outcome* constants
+	Detail   string
+}
+
+// PipelineVerdict records the bash pipeline's structural analysis.
+type PipelineVerdict struct {
+	Command string
+	Denied  bool
+	Reason  string
+}
+
+// Trace is a full record of how a request was evaluated, for debugging
+// rulesets with `st rules test` and the web rules page.
+type Trace struct {
+	Event    string
+	Tool     string
+	Command  string
+	FilePath string
+	URL      string
+
+	// SubCommands holds the split parts of a compound Bash command.
+	SubCommands []string
+	Steps       []TraceStep
+	Pipeline    *PipelineVerdict
+	Result      *EvalResult
+}
+
+func (t *Trace) addStep(s TraceStep) {
+	if t == nil {
+		return
+	}
+	t.Steps = append(t.Steps, s)
+}
+
+func (t *Trace) setPipeline(command string, denied bool, reason string) {
+	if t == nil {
+		return
+	}
+	t.Pipeline = &PipelineVerdict{Command: command, Denied: denied, Reason: reason}
+}
+
+// EvaluateTrace loads the global and project rule layers (see LoadLayers) and
+// evaluates the request, recording every rule considered. Unlike Evaluate,
+// load errors are returned and an empty rules directory still yields an
+// "ask" result.
+func EvaluateTrace(globalDir, projectDir, projectName, event, toolName string, toolInput map[string]any) (*Trace, error) {
+	rulesets, bash, err := LoadLayers(globalDir, projectDir, projectName)
+	if err != nil {
+		return nil, fmt.Errorf("load rulesets: %w", err)
+	}
+	return TraceRulesets(rulesets, bash, event, toolName, toolInput), nil
+}
+
+// TraceRulesets evaluates the request against already-loaded rulesets and
+// returns the evaluation trace.
+func TraceRulesets(rulesets []*Ruleset, bash *BashPipeline, event, toolName string, toolInput map[string]any) *Trace {
+	command, filePath, url := extractFields(toolInput)
+	tr := &Trace{
+		Event:    event,
+		Tool:     toolName,
+		Command:  command,
+		FilePath: filePath,
+		URL:      url,
+	}
+	tr.Result = evaluateTraced(rulesets, bash, event, toolName, toolInput, tr)
+	return tr
+}
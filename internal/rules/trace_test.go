@@ -0,0 +1,100 @@
+package rules
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestTraceRulesetsRecordsSteps(t *testing.T) {
+	rulesets := []*Ruleset{
+		{
+			Name:     "notifications",
+			Priority: 200,
+			Event:    "Notification",
+			Rules: []Rule{
+				{Name: "allow-idle", Match: MatchConfig{NotificationType: "idle"}, Action: ActionAllow},
+			},
+		},
+		{
+			Name:     "security",
+			Priority: 100,
+			Event:    "PreToolUse",
+			Rules: []Rule{
+				{Name: "deny-rm", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `\brm\b`}, Action: ActionDeny},
+			},
+		},
+		{
+			Name:     "general",
+			Priority: 50,
+			Event:    "PreToolUse",
+			Rules: []Rule{
+				{Name: "allow-ls", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^ls\b`}, Action: ActionAllow, Message: "ls is safe"},
+			},
+		},
+	}
+
+	tr := TraceRulesets(rulesets, nil, "PreToolUse", "Bash", makeToolInput("ls -la", "", "", ""))
+
+	if tr.Result.Decision != ActionAllow {
+		t.Fatalf("decision = %s, want allow", tr.Result.Decision)
+	}
+	if len(tr.Steps) != 3 {
+		t.Fatalf("steps = %d, want 3: %+v", len(tr.Steps), tr.Steps)
+	}
+	want := []struct{ ruleset, outcome string }{
+		{"notifications", OutcomeEventSkipped},
+		{"security", OutcomeNoMatch},
+		{"general", OutcomeMatched},
+	}
+	for i, w := range want {
+		if tr.Steps[i].Ruleset != w.ruleset || tr.Steps[i].Outcome != w.outcome {
+			t.Errorf("step %d = %s/%s, want %s/%s", i, tr.Steps[i].Ruleset, tr.Steps[i].Outcome, w.ruleset, w.outcome)
+		}
+	}
+	if tr.Pipeline != nil {
+		t.Errorf("pipeline verdict recorded without a bash pipeline: %+v", tr.Pipeline)
+	}
+}
+
+func TestTraceRulesetsCompoundAndPipeline(t *testing.T) {
+	rulesets := []*Ruleset{
+		{
+			Name:     "general",
+			Priority: 50,
+			Rules: []Rule{
+				{Name: "allow-echo", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^echo\b`}, Action: ActionAllow},
+				{Name: "allow-ls", Match: MatchConfig{Tool: StringOrList{"Bash"}, Command: `^ls\b`}, Action: ActionAllow},
+			},
+		},
+	}
+	bp := NewBashPipeline(&BashPipelineConfig{SafeSinks: []string{"head"}})
+
+	tr := TraceRulesets(rulesets, bp, "PreToolUse", "Bash", makeToolInput("ls && echo hi | bash", "", "", ""))
+
+	if len(tr.SubCommands) != 2 {
+		t.Fatalf("sub-commands = %v, want 2", tr.SubCommands)
+	}
+	if tr.Pipeline == nil || !tr.Pipeline.Denied {
+		t.Fatalf("pipeline verdict = %+v, want denied", tr.Pipeline)
+	}
+	if tr.Result.Decision != ActionDeny || tr.Result.Ruleset != "bash-pipeline" {
+		t.Errorf("result = %+v, want bash-pipeline deny", tr.Result)
+	}
+}
+
+func TestEvaluateTraceEmptyDirAsks(t *testing.T) {
+	tr, err := EvaluateTrace(filepath.Join(t.TempDir(), "missing"), "", "", "PreToolUse", "Bash", makeToolInput("ls", "", "", ""))
+	if err != nil {
+		t.Fatalf("EvaluateTrace() error = %v", err)
+	}
+	if tr.Result.Decision != ActionAsk {
+		t.Errorf("decision = %s, want ask", tr.Result.Decision)
+	}
+}
+
+func TestEvaluateTraceLoadError(t *testing.T) {
+	dir := filepath.Join(testdataDir(), "rules_invalid_action")
+	if _, err := EvaluateTrace(dir, "", "", "PreToolUse", "Bash", nil); err == nil {
+		t.Fatal("expected error for invalid rules dir")
+	}
+}
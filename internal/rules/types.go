@@ -22,6 +22,28 @@ type MatchConfig struct {
 	NotificationType string       `yaml:"notification_type"`
 }
 
+// matchPattern is a regex match field and its YAML name.
+type matchPattern struct {
+	field   string
+	pattern string
+}
+
+// patterns returns the regex match fields in declaration order, so checks
+// over them report in a stable order.
+func (m MatchConfig) patterns() []matchPattern {
+	return []matchPattern{
+		{"command", m.Command},
+		{"file_path", m.FilePath},
+		{"url", m.URL},
+		{"notification_type", m.NotificationType},
+	}
+}
+
+// isEmpty reports whether the match config has no criteria (matches everything).
+func (m MatchConfig) isEmpty() bool {
+	return len(m.Tool) == 0 && m.Command == "" && m.FilePath == "" && m.URL == "" && m.NotificationType == ""
+}
+
 // StringOrList allows a YAML field to be either a single string or a list of strings.
 type StringOrList []string
 
@@ -55,8 +77,21 @@ type Ruleset struct {
 	Type        string `yaml:"type"` // empty for normal rulesets, "bash-pipeline" for bash config
 	Rules       []Rule `yaml:"rules"`
 
+	// Disable lists global rules turned off by a project overlay ruleset,
+	// as "rule-name" or "ruleset-name/rule-name".
+	Disable []string `yaml:"disable,omitempty"`
+
 	// BashPipeline config (only when Type == "bash-pipeline")
 	Config *BashPipelineConfig `yaml:"config,omitempty"`
+
+	// Source is the file the ruleset was loaded from.
+	Source string `yaml:"-"`
+
+	// Origin is OriginGlobal or the project name for project overlays.
+	Origin string `yaml:"-"`
+
+	// Disabled lists rules removed from this ruleset by a project overlay.
+	Disabled []string `yaml:"-"`
 }
 
 // BashPipelineConfig holds configuration for the bash structural analysis.
@@ -71,4 +106,5 @@ type EvalResult struct {
 	Reason   string
 	Ruleset  string
 	Rule     string
+	Origin   string // origin of the matching ruleset (see OriginGlobal)
 }
@@ -0,0 +1,259 @@
+// Package secrets detects credentials and high-entropy strings in content
+// written by agents and in ticket branch diffs.
+package secrets
+
+import (
+	"fmt"
+	"math"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Finding is a suspected secret at a file location.
+type Finding struct {
+	File string
+	Line int // 1-based; 0 when unknown
+	Kind string
+	// Match is the offending value, redacted to its first few characters.
+	Match string
+}
+
+// String formats the finding as "file:line: kind (match)".
+func (f Finding) String() string {
+	loc := f.File
+	if f.Line > 0 {
+		loc = fmt.Sprintf("%s:%d", f.File, f.Line)
+	}
+	return fmt.Sprintf("%s: %s (%s)", loc, f.Kind, f.Match)
+}
+
+// detector matches one credential format. When group is non-zero, that
+// submatch is the secret value and must also pass the entropy check.
+type detector struct {
+	kind  string
+	re    *regexp.Regexp
+	group int
+}
+
+var detectors = []detector{
+	{kind: "private key", re: regexp.MustCompile(`-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----`)},
+	{kind: "AWS access key", re: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{kind: "GitHub token", re: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{60,})\b`)},
+	{kind: "Anthropic API key", re: regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{32,}`)},
+	{kind: "OpenAI API key", re: regexp.MustCompile(`\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,}|\bsk-proj-[A-Za-z0-9_-]{40,}`)},
+	{kind: "Stripe secret key", re: regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{20,}\b`)},
+	{kind: "Slack token", re: regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{kind: "Google API key", re: regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{kind: "JSON web token", re: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.eyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`)},
+	{kind: "credential in URL", re: regexp.MustCompile(`[a-z][a-z0-9+.-]*://[^/\s:@"']+:([^/\s:@"']{6,})@`), group: 1},
+	{
+		kind:  "hardcoded credential",
+		re:    regexp.MustCompile(`(?i)(?:api[_-]?key|secret|token|passw(?:or)?d|private[_-]?key|access[_-]?key)["']?\s*(?::=|[:=])\s*["']([^"'\s]{12,})["']`),
+		group: 1,
+	},
+}
+
+// reQuoted finds quoted string literals checked for high entropy.
+var reQuoted = regexp.MustCompile(`["'` + "`" + `]([A-Za-z0-9+/=_.-]{24,})["'` + "`" + `]`)
+
+// minEntropy is the Shannon entropy (bits per character) above which a long
+// random-looking literal is reported. Random base64 of this length scores
+// about 4.3; identifiers and words score well below 4.
+const minEntropy = 4.0
+
+// ScanContent scans file content and returns the suspected secrets found,
+// with line numbers offset by startLine-1 (pass 1 for whole files).
+func ScanContent(file, content string, startLine int) []Finding {
+	var findings []Finding
+	for i, line := range strings.Split(content, "\n") {
+		for _, f := range scanLine(line) {
+			f.File = file
+			f.Line = startLine + i
+			findings = append(findings, f)
+		}
+	}
+	return findings
+}
+
+// scanLine returns the findings on a single line (without location).
+func scanLine(line string) []Finding {
+	if strings.Contains(line, "st:ignore-secret") {
+		return nil
+	}
+
+	var findings []Finding
+	seen := make(map[string]bool)
+	for _, d := range detectors {
+		for _, m := range d.re.FindAllStringSubmatch(line, -1) {
+			value := m[0]
+			if d.group > 0 {
+				value = m[d.group]
+				if isPlaceholder(value) || entropy(value) < 3 {
+					continue
+				}
+			}
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+			findings = append(findings, Finding{Kind: d.kind, Match: redact(value)})
+		}
+	}
+	for _, m := range reQuoted.FindAllStringSubmatch(line, -1) {
+		value := m[1]
+		if seen[value] || !looksRandom(value) {
+			continue
+		}
+		seen[value] = true
+		findings = append(findings, Finding{Kind: "high-entropy string", Match: redact(value)})
+	}
+	return findings
+}
+
+// ScanDiff scans the added lines of a unified diff (`git diff` output) and
+// reports findings against the new file's line numbers. Files matching an
+// ignore pattern are skipped.
+func ScanDiff(diff string, ignore []string) []Finding {
+	var (
+		findings []Finding
+		file     string
+		skip     bool
+		line     int
+	)
+	for _, l := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+			skip = file == "/dev/null" || Ignored(file, ignore)
+		case strings.HasPrefix(l, "@@ "):
+			line = hunkStart(l)
+		case strings.HasPrefix(l, "+"):
+			if !skip {
+				for _, f := range scanLine(l[1:]) {
+					f.File = file
+					f.Line = line
+					findings = append(findings, f)
+				}
+			}
+			line++
+		case strings.HasPrefix(l, "-"), strings.HasPrefix(l, `\`):
+			// Removed lines and "\ No newline" markers do not advance the
+			// new file's line counter.
+		default:
+			line++
+		}
+	}
+	return findings
+}
+
+var reHunk = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)`)
+
+// hunkStart returns the new-file start line of a hunk header.
+func hunkStart(header string) int {
+	m := reHunk.FindStringSubmatch(header)
+	if m == nil {
+		return 0
+	}
+	var n int
+	_, _ = fmt.Sscanf(m[1], "%d", &n)
+	return n
+}
+
+// Ignored reports whether file (slash-separated, relative to the project
+// root) matches one of the ignore patterns. Patterns without a slash match
+// the base name ("*_test.go"); patterns ending in "/**" match a directory
+// and everything below it ("testdata/**"); other patterns match the whole
+// path ("internal/*/fixtures.go").
+func Ignored(file string, patterns []string) bool {
+	file = strings.TrimPrefix(file, "./")
+	for _, p := range patterns {
+		p = strings.TrimPrefix(strings.TrimSpace(p), "./")
+		switch {
+		case p == "":
+			continue
+		case strings.HasSuffix(p, "/**"):
+			dir := strings.TrimSuffix(p, "/**")
+			if file == dir || strings.HasPrefix(file, dir+"/") || strings.Contains(file, "/"+dir+"/") {
+				return true
+			}
+		case !strings.Contains(p, "/"):
+			if ok, _ := path.Match(p, path.Base(file)); ok {
+				return true
+			}
+		default:
+			if ok, _ := path.Match(p, file); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// looksRandom reports whether a literal looks like a generated secret rather
+// than an identifier, path or sentence.
+func looksRandom(s string) bool {
+	if strings.Count(s, "/") > 2 || strings.Count(s, ".") > 2 || isPlaceholder(s) {
+		return false
+	}
+	// Content hashes (sha256-..., as in lock files) and alphabets.
+	for _, marker := range []string{"sha1-", "sha256-", "sha384-", "sha512-", "abcdef", "ABCDEF", "0123456"} {
+		if strings.Contains(s, marker) {
+			return false
+		}
+	}
+	var lower, upper, digit bool
+	for _, c := range s {
+		switch {
+		case c >= 'a' && c <= 'z':
+			lower = true
+		case c >= 'A' && c <= 'Z':
+			upper = true
+		case c >= '0' && c <= '9':
+			digit = true
+		}
+	}
+	// Hex-only strings (commit hashes, checksums) are deliberately not reported.
+	if !digit || !lower || !upper {
+		return false
+	}
+	return entropy(s) >= minEntropy
+}
+
+// isPlaceholder reports obviously fake values such as "xxxxxxxx",
+// "<your-token>" or "${API_KEY}".
+func isPlaceholder(s string) bool {
+	lower := strings.ToLower(s)
+	for _, marker := range []string{"xxxx", "****", "example", "placeholder", "changeme", "your", "dummy", "redacted", "${", "{{", "<"} {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// entropy returns the Shannon entropy of s in bits per character.
+func entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	for _, c := range s {
+		counts[c]++
+	}
+	n := float64(len([]rune(s)))
+	var h float64
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// redact keeps the first four characters of a secret.
+func redact(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:4] + strings.Repeat("*", 8)
+}
@@ -0,0 +1,133 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+)
+
+// Fake credentials are assembled at runtime so this file does not itself
+// trip secret scanners.
+var (
+	fakeAWSKey      = "AKIA" + "Q3EGRKJ5EXAMPLE7"
+	fakeGitHubToken = "ghp_" + strings.Repeat("a1B2c3D4e5", 4)
+	fakeAnthropic   = "sk-ant-" + "api03-" + strings.Repeat("Zx9Yw8Vu7T", 4)
+	fakeRandom      = "q8Zr3LmW0pXv7" + "NbT2kYc5HsJd9"
+	fakePrivateKey  = "-----BEGIN RSA " + "PRIVATE KEY-----"
+)
+
+func TestScanContentDetectsCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		kind    string
+		wantHit bool
+	}{
+		{"aws key", `aws_key := "` + fakeAWSKey + `"`, "AWS access key", true},
+		{"github token", "export GH=" + fakeGitHubToken, "GitHub token", true},
+		{"anthropic key", `ANTHROPIC_API_KEY=` + fakeAnthropic, "Anthropic API key", true},
+		{"private key", fakePrivateKey, "private key", true},
+		{"password assignment", `password = "c0rrect-h0rse-battery"`, "hardcoded credential", true},
+		{"go short assignment", `apiKey := "Tq7mPz2Lw9Xc4Rv8"`, "hardcoded credential", true},
+		{"credential in url", `dsn := "postgres://admin:Zk8qPw2mRx@db:5432/app"`, "credential in URL", true},
+		{"random literal", `const seed = "` + fakeRandom + `"`, "high-entropy string", true},
+		{"placeholder", `api_key: "<your-api-key-here>"`, "", false},
+		{"env reference", `token = "${GITHUB_TOKEN_VALUE}"`, "", false},
+		{"commit hash", `ref := "9804db6c1f0e2a3b4c5d6e7f8a9b0c1d2e3f4a5b"`, "", false},
+		{"identifier", `name := "TestScanContentDetectsCredentials"`, "", false},
+		{"inline ignore", `aws_key := "` + fakeAWSKey + `" // st:ignore-secret`, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := ScanContent("main.go", "package main\n"+tt.line+"\n", 1)
+			if !tt.wantHit {
+				if len(findings) != 0 {
+					t.Errorf("findings = %v, want none", findings)
+				}
+				return
+			}
+			if len(findings) == 0 {
+				t.Fatalf("no findings for %q", tt.line)
+			}
+			f := findings[0]
+			if f.Kind != tt.kind || f.File != "main.go" || f.Line != 2 {
+				t.Errorf("finding = %+v, want %s at main.go:2", f, tt.kind)
+			}
+			if strings.Contains(tt.line, f.Match) && !strings.Contains(f.Match, "*") {
+				t.Errorf("match %q is not redacted", f.Match)
+			}
+		})
+	}
+}
+
+func TestScanContentStartLine(t *testing.T) {
+	findings := ScanContent("a.env", "X=1\nGH="+fakeGitHubToken, 10)
+	if len(findings) != 1 || findings[0].Line != 11 {
+		t.Fatalf("findings = %v, want one at line 11", findings)
+	}
+	if got := findings[0].String(); !strings.HasPrefix(got, "a.env:11: GitHub token (ghp_") {
+		t.Errorf("String() = %q", got)
+	}
+}
+
+func TestScanDiff(t *testing.T) {
+	diff := strings.Join([]string{
+		"diff --git a/config.go b/config.go",
+		"index 1111111..2222222 100644",
+		"--- a/config.go",
+		"+++ b/config.go",
+		"@@ -10,3 +10,4 @@ func load() {",
+		" 	a := 1",
+		"-	b := 2",
+		"+	b := 3",
+		"+	key := \"" + fakeAWSKey + "\"",
+		" 	return",
+		"diff --git a/testdata/fixture.txt b/testdata/fixture.txt",
+		"new file mode 100644",
+		"--- /dev/null",
+		"+++ b/testdata/fixture.txt",
+		"@@ -0,0 +1 @@",
+		"+" + fakeGitHubToken,
+		"diff --git a/old.go b/old.go",
+		"--- a/old.go",
+		"+++ /dev/null",
+		"@@ -1 +0,0 @@",
+		"-" + fakeGitHubToken,
+	}, "\n")
+
+	findings := ScanDiff(diff, nil)
+	if len(findings) != 2 {
+		t.Fatalf("findings = %v, want 2", findings)
+	}
+	if findings[0].File != "config.go" || findings[0].Line != 12 || findings[0].Kind != "AWS access key" {
+		t.Errorf("first finding = %+v, want AWS key at config.go:12", findings[0])
+	}
+	if findings[1].File != "testdata/fixture.txt" || findings[1].Line != 1 {
+		t.Errorf("second finding = %+v, want testdata/fixture.txt:1", findings[1])
+	}
+
+	findings = ScanDiff(diff, []string{"testdata/**"})
+	if len(findings) != 1 || findings[0].File != "config.go" {
+		t.Errorf("findings with ignore = %v, want only config.go", findings)
+	}
+}
+
+func TestIgnored(t *testing.T) {
+	tests := []struct {
+		file     string
+		patterns []string
+		want     bool
+	}{
+		{"internal/secrets/secrets_test.go", []string{"*_test.go"}, true},
+		{"internal/secrets/secrets.go", []string{"*_test.go"}, false},
+		{"testdata/keys.pem", []string{"testdata/**"}, true},
+		{"internal/rules/testdata/x.yaml", []string{"testdata/**"}, true},
+		{"internal/rules/fixtures.go", []string{"internal/*/fixtures.go"}, true},
+		{"./fixtures/a.json", []string{"fixtures/**"}, true},
+		{"src/main.go", []string{"", "docs/**"}, false},
+	}
+	for _, tt := range tests {
+		if got := Ignored(tt.file, tt.patterns); got != tt.want {
+			t.Errorf("Ignored(%q, %v) = %v, want %v", tt.file, tt.patterns, got, tt.want)
+		}
+	}
+}
@@ -26,12 +26,45 @@ type ClaudeBackend struct{}
 func (b *ClaudeBackend) Name() string { return "claude" }
 
 func (b *ClaudeBackend) Start(ctx context.Context, workdir, prompt, logPath string) (*exec.Cmd, func(), error) {
-	claudePath, err := exec.LookPath("claude")
+	return startAgent(ctx, b.Name(), []string{"-p", prompt}, workdir, logPath)
+}
+
+// CodexBackend runs codex exec in non-interactive mode. --full-auto lets it
+// edit and run commands inside its workspace sandbox.
+type CodexBackend struct{}
+
+func (b *CodexBackend) Name() string { return "codex" }
+
+func (b *CodexBackend) Start(ctx context.Context, workdir, prompt, logPath string) (*exec.Cmd, func(), error) {
+	return startAgent(ctx, b.Name(), []string{"exec", "--full-auto", prompt}, workdir, logPath)
+}
+
+// GeminiBackend runs gemini -p in non-interactive mode. Headless Gemini
+// cannot ask for approval, so tools are auto-approved, but only inside
+// Gemini's sandbox, matching Codex --full-auto. The smoovtask BeforeTool
+// hook still denies anything the rules forbid.
+type GeminiBackend struct{}
+
+func (b *GeminiBackend) Name() string { return "gemini" }
+
+func (b *GeminiBackend) Start(ctx context.Context, workdir, prompt, logPath string) (*exec.Cmd, func(), error) {
+	return startAgent(ctx, b.Name(), geminiArgs(prompt), workdir, logPath)
+}
+
+// geminiArgs returns the gemini arguments for a headless worker. Auto-approval
+// is never passed without --sandbox.
+func geminiArgs(prompt string) []string {
+	return []string{"--sandbox", "--approval-mode", "yolo", "-p", prompt}
+}
+
+// startAgent starts the named CLI with args as a worker in workdir.
+func startAgent(ctx context.Context, name string, args []string, workdir, logPath string) (*exec.Cmd, func(), error) {
+	cliPath, err := exec.LookPath(name)
 	if err != nil {
-		return nil, nil, fmt.Errorf("claude CLI not found in PATH: %w", err)
+		return nil, nil, fmt.Errorf("%s CLI not found in PATH: %w", name, err)
 	}
 
-	cmd := exec.CommandContext(ctx, claudePath, "-p", prompt)
+	cmd := exec.CommandContext(ctx, cliPath, args...)
 	cmd.Dir = workdir
 	cmd.Env = append(os.Environ(), "ST_ROLE=worker")
 
@@ -50,7 +83,7 @@ func (b *ClaudeBackend) Start(ctx context.Context, workdir, prompt, logPath stri
 
 	if err := cmd.Start(); err != nil {
 		cleanup()
-		return nil, nil, fmt.Errorf("start claude process: %w", err)
+		return nil, nil, fmt.Errorf("start %s process: %w", name, err)
 	}
 
 	return cmd, cleanup, nil
@@ -61,7 +94,11 @@ func GetBackend(name string) (Backend, error) {
 	switch name {
 	case "claude", "":
 		return &ClaudeBackend{}, nil
+	case "codex":
+		return &CodexBackend{}, nil
+	case "gemini":
+		return &GeminiBackend{}, nil
 	default:
-		return nil, fmt.Errorf("unknown backend %q (available: claude)", name)
+		return nil, fmt.Errorf("unknown backend %q (available: claude, codex, gemini)", name)
 	}
 }
@@ -1,6 +1,8 @@
 package spawn
 
 import (
+	"context"
+	"strings"
 	"testing"
 )
 
@@ -11,6 +13,8 @@ func TestGetBackend(t *testing.T) {
 	}{
 		{"claude", false},
 		{"", false},
+		{"codex", false},
+		{"gemini", false},
 		{"unknown", true},
 	}
 
@@ -40,3 +44,20 @@ func TestClaudeBackendName(t *testing.T) {
 		t.Errorf("Name() = %q, want %q", b.Name(), "claude")
 	}
 }
+
+func TestBackendStartWithoutCLI(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+	for _, b := range []Backend{&CodexBackend{}, &GeminiBackend{}} {
+		_, _, err := b.Start(context.Background(), t.TempDir(), "prompt", "")
+		if err == nil || !strings.Contains(err.Error(), b.Name()+" CLI not found") {
+			t.Errorf("%s Start() error = %v, want CLI not found", b.Name(), err)
+		}
+	}
+}
+
+func TestGeminiArgsSandboxed(t *testing.T) {
+	args := strings.Join(geminiArgs("prompt"), " ")
+	if !strings.Contains(args, "--sandbox") {
+		t.Errorf("gemini args = %q, want auto-approval only with --sandbox", args)
+	}
+}
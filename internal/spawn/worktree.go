@@ -7,7 +7,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // WorktreePath returns the worktree path for a ticket: <repo>/.worktrees/<ticket-id>
@@ -121,6 +123,21 @@ func CurrentCommit(dir string) (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// LastCommitTime returns the committer time of HEAD in the provided directory.
+func LastCommitTime(dir string) (time.Time, error) {
+	cmd := exec.Command("git", "log", "-1", "--format=%ct")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("git log -1: %w", err)
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse commit time: %w", err)
+	}
+	return time.Unix(secs, 0).UTC(), nil
+}
+
 func branchExists(repoRoot, branch string) (bool, error) {
 	cmd := exec.Command("git", "show-ref", "--verify", "--quiet", "refs/heads/"+branch)
 	cmd.Dir = repoRoot
@@ -159,6 +176,36 @@ func WorktreeIsClean(worktreePath string) (bool, error) {
 	return strings.TrimSpace(string(out)) == "", nil
 }
 
+// BranchDiff returns the changes on the ticket branch since it diverged from
+// the main worktree's HEAD (`git diff HEAD...st/<ticket-id>`).
+func BranchDiff(repoRoot, ticketID string) (string, error) {
+	cmd := exec.Command("git", "diff", "--no-color", "--no-ext-diff", "HEAD..."+BranchName(ticketID))
+	cmd.Dir = repoRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git diff %s: %w", BranchName(ticketID), err)
+	}
+	return string(out), nil
+}
+
+// BranchCommits returns the commits on the ticket branch that are not on the
+// main worktree's HEAD, oldest first, as "<short-hash> <subject>" lines.
+func BranchCommits(repoRoot, ticketID string) ([]string, error) {
+	cmd := exec.Command("git", "log", "--reverse", "--format=%h %s", "HEAD.."+BranchName(ticketID))
+	cmd.Dir = repoRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git log %s: %w", BranchName(ticketID), err)
+	}
+	var commits []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			commits = append(commits, line)
+		}
+	}
+	return commits, nil
+}
+
 // WorktreeRepoRoot returns the root of the main worktree (not a linked worktree).
 // If we're already in a worktree, this traverses up to find the main repo.
 func WorktreeRepoRoot(dir string) (string, error) {
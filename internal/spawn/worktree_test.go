@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -75,6 +76,33 @@ func TestEnsureWorktree_UsesCurrentCommitBase(t *testing.T) {
 	}
 }
 
+func TestBranchCommits(t *testing.T) {
+	repo := initGitRepoWithCommit(t)
+	wt, _, _, err := EnsureWorktree(repo, "st_commits", "HEAD")
+	if err != nil {
+		t.Fatalf("EnsureWorktree() error: %v", err)
+	}
+
+	commits, err := BranchCommits(repo, "st_commits")
+	if err != nil {
+		t.Fatalf("BranchCommits() error: %v", err)
+	}
+	if len(commits) != 0 {
+		t.Fatalf("commits on a fresh branch = %v, want none", commits)
+	}
+
+	for _, msg := range []string{"Add limiter", "Cover error path"} {
+		runGit(t, wt, "commit", "--allow-empty", "-m", msg)
+	}
+	commits, err = BranchCommits(repo, "st_commits")
+	if err != nil {
+		t.Fatalf("BranchCommits() error: %v", err)
+	}
+	if len(commits) != 2 || !strings.HasSuffix(commits[0], " Add limiter") || !strings.HasSuffix(commits[1], " Cover error path") {
+		t.Errorf("commits = %q", commits)
+	}
+}
+
 func initGitRepoWithCommit(t *testing.T) string {
 	t.Helper()
 
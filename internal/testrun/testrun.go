@@ -0,0 +1,363 @@
+// Package testrun detects test runner invocations in agent shell commands
+// and extracts pass/fail counts and failing test names from their output.
+package testrun
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+// Test runners recognised by Detect.
+const (
+	RunnerGo     = "go"
+	RunnerNpm    = "npm"
+	RunnerCargo  = "cargo"
+	RunnerPytest = "pytest"
+	RunnerMake   = "make"
+)
+
+// Run outcomes.
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+// maxFailures caps the failing test names kept per run.
+const maxFailures = 20
+
+// Result is one test run's outcome.
+type Result struct {
+	TS       time.Time
+	Runner   string
+	Command  string
+	Status   string
+	Passed   int
+	Failed   int
+	Skipped  int
+	Failures []string
+}
+
+// Counted reports whether any test counts were parsed.
+func (r Result) Counted() bool {
+	return r.Passed+r.Failed+r.Skipped > 0
+}
+
+// Summary formats the run as e.g. "FAIL (go test: 40 passed, 2 failed)".
+func (r Result) Summary() string {
+	var counts []string
+	if r.Passed > 0 {
+		counts = append(counts, fmt.Sprintf("%d passed", r.Passed))
+	}
+	if r.Failed > 0 {
+		counts = append(counts, fmt.Sprintf("%d failed", r.Failed))
+	}
+	if r.Skipped > 0 {
+		counts = append(counts, fmt.Sprintf("%d skipped", r.Skipped))
+	}
+	label := r.Runner + " test"
+	if r.Runner == RunnerPytest {
+		label = RunnerPytest
+	}
+	if len(counts) == 0 {
+		return fmt.Sprintf("%s (%s)", strings.ToUpper(r.Status), label)
+	}
+	return fmt.Sprintf("%s (%s: %s)", strings.ToUpper(r.Status), label, strings.Join(counts, ", "))
+}
+
+// Data returns the test.run event payload.
+func (r Result) Data(exitCode *int) map[string]any {
+	data := map[string]any{
+		"runner":  r.Runner,
+		"command": r.Command,
+		"status":  r.Status,
+		"passed":  r.Passed,
+		"failed":  r.Failed,
+		"skipped": r.Skipped,
+	}
+	if len(r.Failures) > 0 {
+		data["failures"] = r.Failures
+	}
+	if exitCode != nil {
+		data["exit_code"] = *exitCode
+	}
+	return data
+}
+
+// FromEvent decodes a test.run event. It returns false for other events.
+func FromEvent(ev event.Event) (Result, bool) {
+	if ev.Event != event.TestRun {
+		return Result{}, false
+	}
+	r := Result{TS: ev.TS}
+	r.Runner, _ = ev.Data["runner"].(string)
+	r.Command, _ = ev.Data["command"].(string)
+	r.Status, _ = ev.Data["status"].(string)
+	r.Passed = intValue(ev.Data["passed"])
+	r.Failed = intValue(ev.Data["failed"])
+	r.Skipped = intValue(ev.Data["skipped"])
+	if failures, ok := ev.Data["failures"].([]any); ok {
+		for _, f := range failures {
+			if s, ok := f.(string); ok {
+				r.Failures = append(r.Failures, s)
+			}
+		}
+	}
+	return r, true
+}
+
+func intValue(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case int:
+		return n
+	default:
+		return 0
+	}
+}
+
+// LatestByTicket returns the most recent test run per ticket. Events are
+// expected in chronological order, as QueryEvents returns them.
+func LatestByTicket(events []event.Event) map[string]Result {
+	latest := make(map[string]Result)
+	for _, ev := range events {
+		if ev.Ticket == "" {
+			continue
+		}
+		if r, ok := FromEvent(ev); ok {
+			latest[ev.Ticket] = r
+		}
+	}
+	return latest
+}
+
+// reEnvAssign matches a leading VAR=value shell assignment.
+var reEnvAssign = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*=`)
+
+// Detect returns the test runner a shell command invokes, or "" when it runs
+// no tests. Each command in a pipeline or && / ; chain is checked.
+func Detect(command string) string {
+	split := strings.NewReplacer("&&", "\n", "||", "\n", ";", "\n", "|", "\n")
+	for _, segment := range strings.Split(split.Replace(command), "\n") {
+		if runner := detectSimple(strings.Fields(segment)); runner != "" {
+			return runner
+		}
+	}
+	return ""
+}
+
+func detectSimple(args []string) string {
+	// Skip env assignments and wrappers like `time` or `env`.
+	for len(args) > 0 && (reEnvAssign.MatchString(args[0]) || args[0] == "time" || args[0] == "env" || args[0] == "(") {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	arg := func(i int) string {
+		if i < len(args) {
+			return args[i]
+		}
+		return ""
+	}
+
+	switch args[0] {
+	case "go":
+		if arg(1) == "test" {
+			return RunnerGo
+		}
+	case "cargo":
+		if arg(1) == "test" {
+			return RunnerCargo
+		}
+	case "pytest", "py.test":
+		return RunnerPytest
+	case "python", "python3":
+		if arg(1) == "-m" && arg(2) == "pytest" {
+			return RunnerPytest
+		}
+	case "uv", "poetry":
+		if arg(1) == "run" && arg(2) == "pytest" {
+			return RunnerPytest
+		}
+	case "npm", "pnpm", "yarn", "bun":
+		if arg(1) == "test" || arg(1) == "t" || (arg(1) == "run" && arg(2) == "test") {
+			return RunnerNpm
+		}
+	case "make":
+		for _, a := range args[1:] {
+			if a == "test" {
+				return RunnerMake
+			}
+		}
+	}
+	return ""
+}
+
+var (
+	reGoTest    = regexp.MustCompile(`^--- (PASS|FAIL|SKIP): (\S+)`)
+	reGoPackage = regexp.MustCompile(`^(ok|FAIL)\s+(\S+)\s`)
+
+	reCargoResult = regexp.MustCompile(`test result: \w+\. (\d+) passed; (\d+) failed; (\d+) ignored`)
+	reCargoFailed = regexp.MustCompile(`^test (\S+) \.\.\. FAILED`)
+
+	rePytestSummary = regexp.MustCompile(`^=+ (.*\d+ \w+.*) in [\d.]+s`)
+	rePytestFailed  = regexp.MustCompile(`^(?:FAILED|ERROR) (\S+)`)
+
+	reCount      = regexp.MustCompile(`(\d+) (\w+)`)
+	reJestTests  = regexp.MustCompile(`^\s*Tests:?\s+(.*\d+ (?:passed|failed).*)$`)
+	reJestFailed = regexp.MustCompile(`^\s*● (.+)$`)
+	reMocha      = regexp.MustCompile(`^\s*(\d+) (passing|failing|pending)\b`)
+)
+
+// Parse extracts counts and failing test names from a runner's output. For
+// make, the output is tried against each known runner's format.
+func Parse(runner, output string) Result {
+	lines := strings.Split(output, "\n")
+	var r Result
+	switch runner {
+	case RunnerGo:
+		r = parseGo(lines)
+	case RunnerCargo:
+		r = parseCargo(lines)
+	case RunnerPytest:
+		r = parsePytest(lines)
+	case RunnerNpm:
+		r = parseJS(lines)
+	case RunnerMake:
+		for _, parse := range []func([]string) Result{parseGo, parseCargo, parsePytest, parseJS} {
+			if r = parse(lines); r.Counted() {
+				break
+			}
+		}
+	}
+	r.Runner = runner
+	if len(r.Failures) > maxFailures {
+		r.Failures = r.Failures[:maxFailures]
+	}
+	return r
+}
+
+// parseGo counts top-level tests from -v output, falling back to package
+// results (ok/FAIL lines) when tests were not listed.
+func parseGo(lines []string) Result {
+	var tests, pkgs Result
+	for _, line := range lines {
+		if m := reGoTest.FindStringSubmatch(line); m != nil {
+			switch m[1] {
+			case "PASS":
+				tests.Passed++
+			case "FAIL":
+				tests.Failed++
+				tests.Failures = append(tests.Failures, m[2])
+			case "SKIP":
+				tests.Skipped++
+			}
+		} else if m := reGoPackage.FindStringSubmatch(line); m != nil {
+			if m[1] == "ok" {
+				pkgs.Passed++
+			} else {
+				pkgs.Failed++
+				pkgs.Failures = append(pkgs.Failures, m[2])
+			}
+		}
+	}
+	if tests.Counted() {
+		return tests
+	}
+	return pkgs
+}
+
+func parseCargo(lines []string) Result {
+	var r Result
+	for _, line := range lines {
+		if m := reCargoResult.FindStringSubmatch(line); m != nil {
+			r.Passed += atoi(m[1])
+			r.Failed += atoi(m[2])
+			r.Skipped += atoi(m[3])
+		} else if m := reCargoFailed.FindStringSubmatch(line); m != nil {
+			r.Failures = append(r.Failures, m[1])
+		}
+	}
+	return r
+}
+
+func parsePytest(lines []string) Result {
+	var r Result
+	for _, line := range lines {
+		if m := rePytestSummary.FindStringSubmatch(line); m != nil {
+			r.Passed, r.Failed, r.Skipped = 0, 0, 0
+			for _, c := range reCount.FindAllStringSubmatch(m[1], -1) {
+				switch c[2] {
+				case "passed":
+					r.Passed += atoi(c[1])
+				case "failed", "error", "errors":
+					r.Failed += atoi(c[1])
+				case "skipped":
+					r.Skipped += atoi(c[1])
+				}
+			}
+		} else if m := rePytestFailed.FindStringSubmatch(line); m != nil {
+			r.Failures = append(r.Failures, m[1])
+		}
+	}
+	return r
+}
+
+// parseJS understands Jest and Vitest "Tests:" summaries and Mocha's
+// passing/failing lines.
+func parseJS(lines []string) Result {
+	var r Result
+	seen := make(map[string]bool)
+	for _, line := range lines {
+		if m := reJestTests.FindStringSubmatch(line); m != nil {
+			for _, c := range reCount.FindAllStringSubmatch(m[1], -1) {
+				switch c[2] {
+				case "passed":
+					r.Passed += atoi(c[1])
+				case "failed":
+					r.Failed += atoi(c[1])
+				case "skipped", "todo":
+					r.Skipped += atoi(c[1])
+				}
+			}
+		} else if m := reMocha.FindStringSubmatch(line); m != nil {
+			switch m[2] {
+			case "passing":
+				r.Passed += atoi(m[1])
+			case "failing":
+				r.Failed += atoi(m[1])
+			case "pending":
+				r.Skipped += atoi(m[1])
+			}
+		} else if m := reJestFailed.FindStringSubmatch(line); m != nil && !seen[m[1]] {
+			seen[m[1]] = true
+			r.Failures = append(r.Failures, strings.TrimSpace(m[1]))
+		}
+	}
+	return r
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+// Judge sets Status from the exit code when known, otherwise from the
+// counts. It reports false when the outcome cannot be told.
+func (r *Result) Judge(exitCode *int) bool {
+	switch {
+	case exitCode != nil && *exitCode != 0, r.Failed > 0:
+		r.Status = StatusFail
+	case exitCode != nil, r.Passed > 0:
+		r.Status = StatusPass
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+package testrun
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+func TestDetect(t *testing.T) {
+	tests := []struct {
+		command string
+		want    string
+	}{
+		{"go test ./...", RunnerGo},
+		{"cd internal && go test -run TestFoo ./hook", RunnerGo},
+		{"CGO_ENABLED=0 go test -race ./... 2>&1 | tail -20", RunnerGo},
+		{"go build ./...", ""},
+		{"npm test", RunnerNpm},
+		{"pnpm run test -- --watch=false", RunnerNpm},
+		{"yarn test", RunnerNpm},
+		{"npm run build", ""},
+		{"cargo test --all", RunnerCargo},
+		{"cargo build", ""},
+		{"pytest -x tests/", RunnerPytest},
+		{"python3 -m pytest", RunnerPytest},
+		{"uv run pytest -q", RunnerPytest},
+		{"make test", RunnerMake},
+		{"make -C backend lint test", RunnerMake},
+		{"make build", ""},
+		{"echo go test", ""},
+		{"git commit -m 'go test fix'", ""},
+	}
+	for _, tt := range tests {
+		if got := Detect(tt.command); got != tt.want {
+			t.Errorf("Detect(%q) = %q, want %q", tt.command, got, tt.want)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		runner string
+		output string
+		want   Result
+	}{
+		{
+			name:   "go verbose",
+			runner: RunnerGo,
+			output: "=== RUN   TestA\n--- PASS: TestA (0.00s)\n=== RUN   TestB\n    --- FAIL: TestB/sub (0.00s)\n--- FAIL: TestB (0.01s)\n--- SKIP: TestC (0.00s)\nFAIL\nFAIL\tgithub.com/x/y\t0.02s\n",
+			want:   Result{Runner: RunnerGo, Passed: 1, Failed: 1, Skipped: 1, Failures: []string{"TestB"}},
+		},
+		{
+			name:   "go packages",
+			runner: RunnerGo,
+			output: "ok  \tgithub.com/x/a\t0.10s\nok  \tgithub.com/x/b\t(cached)\nFAIL\tgithub.com/x/c [build failed]\n",
+			want:   Result{Runner: RunnerGo, Passed: 2, Failed: 1, Failures: []string{"github.com/x/c"}},
+		},
+		{
+			name:   "cargo",
+			runner: RunnerCargo,
+			output: "running 3 tests\ntest parse::ok ... ok\ntest parse::bad ... FAILED\ntest result: FAILED. 2 passed; 1 failed; 0 ignored; 0 measured; 0 filtered out\n\nrunning 1 test\ntest result: ok. 0 passed; 0 failed; 1 ignored; 0 measured\n",
+			want:   Result{Runner: RunnerCargo, Passed: 2, Failed: 1, Skipped: 1, Failures: []string{"parse::bad"}},
+		},
+		{
+			name:   "pytest",
+			runner: RunnerPytest,
+			output: "FAILED tests/test_api.py::test_login - AssertionError\nERROR tests/test_db.py::test_conn\n==== 1 failed, 10 passed, 2 skipped, 1 error in 1.23s ====\n",
+			want:   Result{Runner: RunnerPytest, Passed: 10, Failed: 2, Skipped: 2, Failures: []string{"tests/test_api.py::test_login", "tests/test_db.py::test_conn"}},
+		},
+		{
+			name:   "jest",
+			runner: RunnerNpm,
+			output: "  ● Auth › rejects bad password\n\nTests:       1 failed, 1 skipped, 5 passed, 7 total\n",
+			want:   Result{Runner: RunnerNpm, Passed: 5, Failed: 1, Skipped: 1, Failures: []string{"Auth › rejects bad password"}},
+		},
+		{
+			name:   "vitest",
+			runner: RunnerNpm,
+			output: " Test Files  1 passed (1)\n      Tests  12 passed (12)\n",
+			want:   Result{Runner: RunnerNpm, Passed: 12},
+		},
+		{
+			name:   "mocha",
+			runner: RunnerNpm,
+			output: "  8 passing (20ms)\n  1 pending\n  2 failing\n",
+			want:   Result{Runner: RunnerNpm, Passed: 8, Failed: 2, Skipped: 1},
+		},
+		{
+			name:   "make running go test",
+			runner: RunnerMake,
+			output: "go test ./...\nok  \tgithub.com/x/a\t0.10s\n",
+			want:   Result{Runner: RunnerMake, Passed: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse(tt.runner, tt.output)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJudge(t *testing.T) {
+	zero, one := 0, 1
+	tests := []struct {
+		name     string
+		result   Result
+		exitCode *int
+		want     string
+		ok       bool
+	}{
+		{"exit zero", Result{}, &zero, StatusPass, true},
+		{"exit nonzero", Result{Passed: 3}, &one, StatusFail, true},
+		{"failures despite exit zero", Result{Failed: 1}, &zero, StatusFail, true},
+		{"counts only", Result{Passed: 3}, nil, StatusPass, true},
+		{"nothing known", Result{}, nil, "", false},
+	}
+	for _, tt := range tests {
+		r := tt.result
+		ok := r.Judge(tt.exitCode)
+		if ok != tt.ok || r.Status != tt.want {
+			t.Errorf("%s: Judge() = %v, status %q; want %v, %q", tt.name, ok, r.Status, tt.ok, tt.want)
+		}
+	}
+}
+
+func TestLatestByTicketRoundTrip(t *testing.T) {
+	zero := 0
+	first := Result{Runner: RunnerGo, Command: "go test ./...", Status: StatusFail, Passed: 2, Failed: 1, Failures: []string{"TestB"}}
+	second := Result{Runner: RunnerGo, Command: "go test ./...", Status: StatusPass, Passed: 3}
+	ts := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	events := []event.Event{
+		{TS: ts, Event: event.TestRun, Ticket: "st_a", Data: roundTrip(t, first.Data(nil))},
+		{TS: ts.Add(time.Minute), Event: event.TestRun, Ticket: "st_b", Data: roundTrip(t, first.Data(&zero))},
+		{TS: ts.Add(2 * time.Minute), Event: event.TestRun, Ticket: "st_a", Data: roundTrip(t, second.Data(&zero))},
+		{TS: ts.Add(3 * time.Minute), Event: event.HookPostTool, Ticket: "st_a"},
+	}
+
+	latest := LatestByTicket(events)
+	second.TS = ts.Add(2 * time.Minute)
+	if !reflect.DeepEqual(latest["st_a"], second) {
+		t.Errorf("latest[st_a] = %+v, want %+v", latest["st_a"], second)
+	}
+	if got := latest["st_b"]; got.Status != StatusFail || !reflect.DeepEqual(got.Failures, []string{"TestB"}) {
+		t.Errorf("latest[st_b] = %+v", got)
+	}
+	if got := latest["st_b"].Summary(); got != "FAIL (go test: 2 passed, 1 failed)" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
+
+// roundTrip converts event data as it would be after a JSONL round trip.
+func roundTrip(t *testing.T, data map[string]any) map[string]any {
+	t.Helper()
+	ev := event.Event{Data: data}
+	raw, err := json.Marshal(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out event.Event
+	if err := json.Unmarshal(raw, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out.Data
+}
@@ -13,11 +13,21 @@ type GraphNode struct {
 	Position int
 }
 
+// Edge kinds.
+const (
+	EdgeDependsOn = "depends-on"
+	EdgeParent    = "parent"
+	EdgeBlocks    = "blocks"
+)
+
 // GraphEdge represents a dependency edge from one ticket to another.
-// Direction: FromID depends on ToID (arrow points FromID → ToID).
+// Direction: FromID depends on ToID (arrow points FromID → ToID). A parent
+// depends on its children, with Kind EdgeParent, and a ticket depends on
+// those that block it, with Kind EdgeBlocks.
 type GraphEdge struct {
 	FromID string
 	ToID   string
+	Kind   string
 }
 
 // DependencyGraph is a layered DAG of ticket dependencies.
@@ -35,10 +45,11 @@ func (g DependencyGraph) EdgesJSON() string {
 	type edgeJSON struct {
 		From string `json:"from"`
 		To   string `json:"to"`
+		Kind string `json:"kind,omitempty"`
 	}
 	out := make([]edgeJSON, len(g.Edges))
 	for i, e := range g.Edges {
-		out[i] = edgeJSON{From: e.FromID, To: e.ToID}
+		out[i] = edgeJSON{From: e.FromID, To: e.ToID, Kind: e.Kind}
 	}
 	b, _ := json.Marshal(out)
 	return string(b)
@@ -46,7 +57,9 @@ func (g DependencyGraph) EdgesJSON() string {
 
 // BuildDependencyGraph computes a layered dependency graph from the given
 // tickets. It excludes DONE and CANCELLED tickets, and only includes tickets
-// that participate in at least one dependency relationship.
+// that participate in at least one dependency, blocks or parent/child
+// relationship.
+// A parent is laid out after its children, as if it depended on them.
 func BuildDependencyGraph(tickets []*Ticket) DependencyGraph {
 	// Filter out completed tickets.
 	nodes := make(map[string]*Ticket)
@@ -64,15 +77,31 @@ func BuildDependencyGraph(tickets []*Ticket) DependencyGraph {
 	reverse := make(map[string][]string)
 	participates := make(map[string]bool)
 
+	kinds := make(map[GraphEdge]string)
+	link := func(from, to, kind string) {
+		if nodes[from] == nil || nodes[to] == nil {
+			return
+		}
+		key := GraphEdge{FromID: from, ToID: to}
+		if _, dup := kinds[key]; dup {
+			return
+		}
+		kinds[key] = kind
+		forward[from] = append(forward[from], to)
+		reverse[to] = append(reverse[to], from)
+		participates[from] = true
+		participates[to] = true
+	}
+
 	for _, tk := range nodes {
 		for _, depID := range tk.DependsOn {
-			if _, ok := nodes[depID]; !ok {
-				continue
-			}
-			forward[tk.ID] = append(forward[tk.ID], depID)
-			reverse[depID] = append(reverse[depID], tk.ID)
-			participates[tk.ID] = true
-			participates[depID] = true
+			link(tk.ID, depID, EdgeDependsOn)
+		}
+		if tk.Parent != "" {
+			link(tk.Parent, tk.ID, EdgeParent)
+		}
+		for _, blockedID := range tk.Relations[RelationBlocks] {
+			link(blockedID, tk.ID, EdgeBlocks)
 		}
 	}
 
@@ -200,7 +229,7 @@ func BuildDependencyGraph(tickets []*Ticket) DependencyGraph {
 	var edges []GraphEdge
 	for id, deps := range forward {
 		for _, depID := range deps {
-			edges = append(edges, GraphEdge{FromID: id, ToID: depID})
+			edges = append(edges, GraphEdge{FromID: id, ToID: depID, Kind: kinds[GraphEdge{FromID: id, ToID: depID}]})
 		}
 	}
 	sort.Slice(edges, func(i, j int) bool {
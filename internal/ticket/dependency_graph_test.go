@@ -210,3 +210,62 @@ func nodeLayerMap(g DependencyGraph) map[string]int {
 	}
 	return m
 }
+
+func TestBuildDependencyGraphParentEdges(t *testing.T) {
+	tickets := []*Ticket{
+		{ID: "st_epic", Status: StatusOpen},
+		{ID: "st_a", Status: StatusOpen, Parent: "st_epic"},
+		{ID: "st_b", Status: StatusOpen, Parent: "st_epic", DependsOn: []string{"st_a"}},
+		{ID: "st_c", Status: StatusOpen, Parent: "st_gone"},
+	}
+
+	g := BuildDependencyGraph(tickets)
+	if len(g.Edges) != 3 {
+		t.Fatalf("expected 3 edges, got %d: %+v", len(g.Edges), g.Edges)
+	}
+	kinds := map[string]string{}
+	for _, e := range g.Edges {
+		kinds[e.FromID+"→"+e.ToID] = e.Kind
+	}
+	if kinds["st_epic→st_a"] != EdgeParent || kinds["st_epic→st_b"] != EdgeParent {
+		t.Errorf("expected parent edges from st_epic, got %v", kinds)
+	}
+	if kinds["st_b→st_a"] != EdgeDependsOn {
+		t.Errorf("expected depends-on edge st_b→st_a, got %v", kinds)
+	}
+
+	layerOf := nodeLayerMap(g)
+	if layerOf["st_a"] != 0 || layerOf["st_b"] != 1 || layerOf["st_epic"] != 2 {
+		t.Errorf("unexpected layers: %v", layerOf)
+	}
+	if _, ok := layerOf["st_c"]; ok {
+		t.Error("a ticket whose parent is missing should not participate")
+	}
+
+	var edges []struct{ Kind string }
+	if err := json.Unmarshal([]byte(g.EdgesJSON()), &edges); err != nil {
+		t.Fatal(err)
+	}
+	if edges[0].Kind == "" {
+		t.Error("EdgesJSON should include the edge kind")
+	}
+}
+
+func TestBuildDependencyGraphBlocksRelation(t *testing.T) {
+	blocker := &Ticket{ID: "st_a", Status: StatusOpen}
+	blocker.AddRelation(RelationBlocks, "st_b")
+	blocker.AddRelation(RelationRelatesTo, "st_c")
+	tickets := []*Ticket{
+		blocker,
+		{ID: "st_b", Status: StatusOpen},
+		{ID: "st_c", Status: StatusOpen},
+	}
+
+	g := BuildDependencyGraph(tickets)
+	if len(g.Edges) != 1 {
+		t.Fatalf("expected only the blocks relation as an edge, got %+v", g.Edges)
+	}
+	if e := g.Edges[0]; e.FromID != "st_b" || e.ToID != "st_a" || e.Kind != EdgeBlocks {
+		t.Errorf("edge = %+v, want st_b → st_a (blocks)", e)
+	}
+}
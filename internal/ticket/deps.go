@@ -1,6 +1,11 @@
 package ticket
 
-import "time"
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
 
 // CheckDependencies checks if all dependencies of a ticket are resolved (DONE or CANCELLED).
 // Returns the list of unresolved dependency IDs.
@@ -52,31 +57,219 @@ func AutoUnblock(store *Store, ticketID string) ([]*Ticket, error) {
 	var unblocked []*Ticket
 
 	for _, tk := range dependents {
-		if tk.Status != StatusBlocked || tk.PriorStatus == nil {
+		if !unblockIfResolved(store, tk, now) {
 			continue
 		}
-
-		unresolved, err := CheckDependencies(store, tk)
-		if err != nil {
+		if err := store.Save(tk); err != nil {
 			continue
 		}
-		if len(unresolved) > 0 {
-			continue
+		unblocked = append(unblocked, tk)
+	}
+
+	return unblocked, nil
+}
+
+// unblockIfResolved snaps a ticket BLOCKED on dependencies back to its prior
+// status once they are all resolved. Human holds are left for `st unhold`.
+// The ticket is modified but not saved.
+func unblockIfResolved(store *Store, tk *Ticket, now time.Time) bool {
+	if tk.Status != StatusBlocked || tk.PriorStatus == nil || heldByHuman(tk) {
+		return false
+	}
+	unresolved, err := CheckDependencies(store, tk)
+	if err != nil || len(unresolved) > 0 {
+		return false
+	}
+
+	// All dependencies resolved — snap back to prior status.
+	tk.Status = *tk.PriorStatus
+	tk.PriorStatus = nil
+	tk.Updated = now
+	AppendSection(tk, "Auto-Unblocked", "st", "", "", nil, now)
+	return true
+}
+
+// heldByHuman reports whether the ticket's most recent block was a human
+// hold rather than a dependency block.
+func heldByHuman(tk *Ticket) bool {
+	sections := Sections(tk.Body)
+	for i := len(sections) - 1; i >= 0; i-- {
+		if strings.HasPrefix(sections[i].Heading, "Blocked") {
+			return sections[i].Heading == "Blocked (Hold)"
 		}
+	}
+	return false
+}
 
-		// All dependencies resolved — snap back to prior status.
-		tk.Status = *tk.PriorStatus
-		tk.PriorStatus = nil
-		tk.Updated = now
+// CycleError reports a dependency that would close a cycle.
+type CycleError struct {
+	// Path runs from the ticket through the new dependency back to the ticket.
+	Path []string
+}
 
-		AppendSection(tk, "Auto-Unblocked", "st", "", "", nil, now)
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("dependency cycle: %s", strings.Join(e.Path, " → "))
+}
 
-		if err := store.Save(tk); err != nil {
-			continue
+// resolveRef returns the ID of the one ticket among all that ref names,
+// exactly or as an ID prefix, or "" if it names none or several.
+func resolveRef(all []*Ticket, ref string) string {
+	match := ""
+	for _, tk := range all {
+		switch {
+		case tk.ID == ref:
+			return tk.ID
+		case strings.HasPrefix(tk.ID, ref):
+			if match != "" {
+				return ""
+			}
+			match = tk.ID
 		}
+	}
+	return match
+}
 
-		unblocked = append(unblocked, tk)
+// DependencyPath returns a chain of depends-on links leading from one ticket
+// to another, inclusive, or nil if there is none.
+func DependencyPath(tickets []*Ticket, from, to string) []string {
+	byID := make(map[string]*Ticket, len(tickets))
+	for _, tk := range tickets {
+		byID[tk.ID] = tk
 	}
 
-	return unblocked, nil
+	visited := map[string]bool{}
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if id == to {
+			return []string{id}
+		}
+		if visited[id] {
+			return nil
+		}
+		visited[id] = true
+		tk := byID[id]
+		if tk == nil {
+			return nil
+		}
+		for _, dep := range tk.DependsOn {
+			if byID[dep] == nil {
+				// Older tickets may store an ID prefix.
+				if id := resolveRef(tickets, dep); id != "" {
+					dep = id
+				}
+			}
+			if path := walk(dep); path != nil {
+				return append([]string{id}, path...)
+			}
+		}
+		return nil
+	}
+	return walk(from)
+}
+
+// DepsChange describes an update made by SetDependencies.
+type DepsChange struct {
+	Added     []string
+	Removed   []string
+	From      Status // status before the update
+	Blocked   bool
+	Unblocked bool
+}
+
+// Changed reports whether the dependency list changed.
+func (c DepsChange) Changed() bool {
+	return len(c.Added) > 0 || len(c.Removed) > 0
+}
+
+// SetDependencies replaces tk's dependencies with deps. Each new dependency
+// must exist and must not close a cycle. BLOCKED state is then re-evaluated:
+// a ticket with unresolved dependencies is blocked (keeping its prior status),
+// and one whose dependency block no longer applies snaps back. Changes are
+// recorded as sections; tk is modified but not saved.
+func SetDependencies(store *Store, tk *Ticket, deps []string, actor, runID string, now time.Time) (DepsChange, error) {
+	change := DepsChange{From: tk.Status}
+
+	// Store full IDs: DependencyPath and CheckDependencies match exact IDs,
+	// so a prefix would slip past cycle detection. References that no
+	// longer resolve are kept only if the ticket already had them.
+	var next []string
+	for _, dep := range deps {
+		if d, err := store.Get(dep); err == nil {
+			dep = d.ID
+		} else if !slices.Contains(tk.DependsOn, dep) {
+			return change, fmt.Errorf("dependency %s: %w", dep, err)
+		}
+		if dep == tk.ID {
+			return change, &CycleError{Path: []string{tk.ID, tk.ID}}
+		}
+		if !slices.Contains(next, dep) {
+			next = append(next, dep)
+		}
+	}
+	// Compare against the current list resolved the same way, so a stored
+	// prefix being written out in full is not reported as a change.
+	current := make([]string, len(tk.DependsOn))
+	for i, dep := range tk.DependsOn {
+		current[i] = dep
+		if d, err := store.Get(dep); err == nil {
+			current[i] = d.ID
+		}
+	}
+	for _, dep := range next {
+		if !slices.Contains(current, dep) {
+			change.Added = append(change.Added, dep)
+		}
+	}
+	for _, dep := range current {
+		if !slices.Contains(next, dep) {
+			change.Removed = append(change.Removed, dep)
+		}
+	}
+	if !change.Changed() {
+		return change, nil
+	}
+
+	if len(change.Added) > 0 {
+		all, err := store.ListMeta(ListFilter{})
+		if err != nil {
+			return change, err
+		}
+		for _, dep := range change.Added {
+			if path := DependencyPath(all, dep, tk.ID); path != nil {
+				return change, &CycleError{Path: append([]string{tk.ID}, path...)}
+			}
+		}
+	}
+
+	if next == nil {
+		next = []string{}
+	}
+	tk.DependsOn = next
+	var lines []string
+	if len(change.Added) > 0 {
+		lines = append(lines, "Added: "+strings.Join(change.Added, ", "))
+	}
+	if len(change.Removed) > 0 {
+		lines = append(lines, "Removed: "+strings.Join(change.Removed, ", "))
+	}
+	AppendSection(tk, "Dependencies Changed", actor, runID, strings.Join(lines, "\n"), nil, now)
+
+	unresolved, err := CheckDependencies(store, tk)
+	if err != nil {
+		return change, err
+	}
+	switch {
+	case len(unresolved) > 0:
+		if tk.Status == StatusBlocked || tk.Status == StatusDone || tk.Status == StatusCancelled {
+			break
+		}
+		prior := tk.Status
+		tk.PriorStatus = &prior
+		tk.Status = StatusBlocked
+		AppendSection(tk, "Blocked (Dependencies)", "st", "", "Unresolved dependencies: "+strings.Join(unresolved, ", "), nil, now)
+		change.Blocked = true
+	default:
+		change.Unblocked = unblockIfResolved(store, tk, now)
+	}
+	return change, nil
 }
@@ -1,6 +1,8 @@
 package ticket
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 )
@@ -268,3 +270,186 @@ func TestAutoUnblock_CrossProject(t *testing.T) {
 		t.Errorf("expected st_bbbbbb, got %s", unblocked[0].ID)
 	}
 }
+
+func TestAutoUnblock_LeavesHoldAlone(t *testing.T) {
+	store := testStore(t)
+
+	dep := testTicket("st_aaaaaa", "proj", StatusDone, nil)
+	if err := store.Create(dep); err != nil {
+		t.Fatal(err)
+	}
+	prior := StatusOpen
+	held := testTicket("st_bbbbbb", "proj", StatusBlocked, []string{"st_aaaaaa"})
+	held.PriorStatus = &prior
+	AppendSection(held, "Blocked (Hold)", "human", "", "waiting on legal", nil, time.Now().UTC())
+	if err := store.Create(held); err != nil {
+		t.Fatal(err)
+	}
+
+	unblocked, err := AutoUnblock(store, "st_aaaaaa")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unblocked) != 0 {
+		t.Errorf("a human hold should not be auto-released, got %d unblocked", len(unblocked))
+	}
+}
+
+func TestDependencyPath(t *testing.T) {
+	tickets := []*Ticket{
+		testTicket("st_aaaaaa", "proj", StatusOpen, []string{"st_bbbbbb"}),
+		testTicket("st_bbbbbb", "proj", StatusOpen, []string{"st_cccccc"}),
+		testTicket("st_cccccc", "proj", StatusOpen, nil),
+	}
+
+	path := DependencyPath(tickets, "st_aaaaaa", "st_cccccc")
+	if got := strings.Join(path, " "); got != "st_aaaaaa st_bbbbbb st_cccccc" {
+		t.Errorf("path = %q", got)
+	}
+	if path := DependencyPath(tickets, "st_cccccc", "st_aaaaaa"); path != nil {
+		t.Errorf("expected no path, got %v", path)
+	}
+}
+
+func TestSetDependencies_RejectsCycle(t *testing.T) {
+	store := testStore(t)
+
+	a := testTicket("st_aaaaaa", "proj", StatusOpen, []string{"st_bbbbbb"})
+	b := testTicket("st_bbbbbb", "proj", StatusOpen, []string{"st_cccccc"})
+	c := testTicket("st_cccccc", "proj", StatusOpen, nil)
+	for _, tk := range []*Ticket{a, b, c} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err := SetDependencies(store, c, []string{"st_aaaaaa"}, "agent", "", time.Now().UTC())
+	var cycle *CycleError
+	if !errors.As(err, &cycle) {
+		t.Fatalf("expected a CycleError, got %v", err)
+	}
+	if want := "dependency cycle: st_cccccc → st_aaaaaa → st_bbbbbb → st_cccccc"; err.Error() != want {
+		t.Errorf("error = %q, want %q", err.Error(), want)
+	}
+	if len(c.DependsOn) != 0 {
+		t.Errorf("dependencies should be unchanged, got %v", c.DependsOn)
+	}
+
+	if _, err := SetDependencies(store, c, []string{"st_cccccc"}, "agent", "", time.Now().UTC()); !errors.As(err, &cycle) {
+		t.Errorf("a self-dependency should be a cycle, got %v", err)
+	}
+}
+
+func TestSetDependencies_ResolvesPrefixes(t *testing.T) {
+	store := testStore(t)
+
+	a := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	b := testTicket("st_bbbbbb", "proj", StatusOpen, nil)
+	for _, tk := range []*Ticket{a, b} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	change, err := SetDependencies(store, a, []string{"st_bbb"}, "human", "", time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.DependsOn) != 1 || a.DependsOn[0] != "st_bbbbbb" {
+		t.Errorf("DependsOn = %v, want the full ID", a.DependsOn)
+	}
+	if len(change.Added) != 1 || change.Added[0] != "st_bbbbbb" {
+		t.Errorf("Added = %v", change.Added)
+	}
+	if err := store.Save(a); err != nil {
+		t.Fatal(err)
+	}
+
+	// The back edge given as a prefix must still be seen as a cycle.
+	var cycle *CycleError
+	if _, err := SetDependencies(store, b, []string{"st_aaa"}, "human", "", time.Now().UTC()); !errors.As(err, &cycle) {
+		t.Fatalf("expected a CycleError, got %v", err)
+	}
+
+	// So must a cycle through a prefix stored before references were resolved.
+	a.DependsOn = []string{"st_bbb"}
+	if err := store.Save(a); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := SetDependencies(store, b, []string{"st_aaaaaa"}, "human", "", time.Now().UTC()); !errors.As(err, &cycle) {
+		t.Errorf("expected a CycleError through a stored prefix, got %v", err)
+	}
+	if change, err := SetDependencies(store, a, []string{"st_bbbbbb"}, "human", "", time.Now().UTC()); err != nil || change.Changed() {
+		t.Errorf("writing out a stored prefix in full: change = %+v, err = %v", change, err)
+	}
+}
+
+func TestSetDependencies_RejectsMissing(t *testing.T) {
+	store := testStore(t)
+
+	tk := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	if err := store.Create(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := SetDependencies(store, tk, []string{"st_zzzzzz"}, "agent", "", time.Now().UTC()); err == nil || !strings.Contains(err.Error(), "st_zzzzzz") {
+		t.Errorf("expected a missing dependency error, got %v", err)
+	}
+}
+
+func TestSetDependencies_BlocksAndUnblocks(t *testing.T) {
+	store := testStore(t)
+
+	dep := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	tk := testTicket("st_bbbbbb", "proj", StatusInProgress, nil)
+	for _, x := range []*Ticket{dep, tk} {
+		if err := store.Create(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	change, err := SetDependencies(store, tk, []string{"st_aaaaaa"}, "agent", "", time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !change.Blocked || tk.Status != StatusBlocked || tk.PriorStatus == nil || *tk.PriorStatus != StatusInProgress {
+		t.Fatalf("expected BLOCKED with prior IN-PROGRESS, got %s (change %+v)", tk.Status, change)
+	}
+	if len(change.Added) != 1 || change.From != StatusInProgress {
+		t.Errorf("change = %+v", change)
+	}
+	if !strings.Contains(tk.Body, "## Dependencies Changed") || !strings.Contains(tk.Body, "## Blocked (Dependencies)") {
+		t.Errorf("expected change and block sections, got:\n%s", tk.Body)
+	}
+
+	change, err = SetDependencies(store, tk, nil, "agent", "", time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !change.Unblocked || tk.Status != StatusInProgress || tk.PriorStatus != nil {
+		t.Errorf("expected snap back to IN-PROGRESS, got %s (change %+v)", tk.Status, change)
+	}
+	if len(change.Removed) != 1 || len(tk.DependsOn) != 0 {
+		t.Errorf("change = %+v, deps = %v", change, tk.DependsOn)
+	}
+}
+
+func TestSetDependencies_NoChange(t *testing.T) {
+	store := testStore(t)
+
+	dep := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	tk := testTicket("st_bbbbbb", "proj", StatusOpen, []string{"st_aaaaaa"})
+	for _, x := range []*Ticket{dep, tk} {
+		if err := store.Create(x); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	change, err := SetDependencies(store, tk, []string{"st_aaaaaa"}, "agent", "", time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if change.Changed() || tk.Body != "" {
+		t.Errorf("expected no change, got %+v", change)
+	}
+}
@@ -0,0 +1,200 @@
+package ticket
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrNoChanges is returned by ApplyEdit when the edit leaves the ticket as
+// it was.
+var ErrNoChanges = errors.New("nothing to change")
+
+// Edit is a set of changes to a ticket's title, priority, tags and
+// description. Nil and empty fields are left alone.
+type Edit struct {
+	Title             *string
+	Priority          *Priority
+	AddTags           []string
+	RemoveTags        []string
+	AppendDescription string
+}
+
+// ParseTagEdit splits a tag edit like "+api,-ui,docs" into tags to add and
+// tags to remove. Unprefixed tags are added.
+func ParseTagEdit(s string) (add, remove []string, err error) {
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		list := &add
+		switch part[0] {
+		case '+':
+			part = part[1:]
+		case '-':
+			part, list = part[1:], &remove
+		}
+		if err := validateTag(part); err != nil {
+			return nil, nil, err
+		}
+		*list = append(*list, part)
+	}
+	if len(add) == 0 && len(remove) == 0 {
+		return nil, nil, fmt.Errorf("no tags in %q", s)
+	}
+	for _, tag := range add {
+		if containsTag(remove, tag) {
+			return nil, nil, fmt.Errorf("tag %q is both added and removed", tag)
+		}
+	}
+	return add, remove, nil
+}
+
+func validateTag(tag string) error {
+	switch {
+	case tag == "":
+		return fmt.Errorf("empty tag")
+	case strings.ContainsAny(tag, " \t\n,:\"#"):
+		return fmt.Errorf("invalid tag %q — tags cannot contain spaces, commas, colons, quotes or #", tag)
+	}
+	return nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	return slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) })
+}
+
+// EditChange describes what ApplyEdit changed. Unchanged fields are zero.
+type EditChange struct {
+	TitleFrom, TitleTo       string
+	PriorityFrom, PriorityTo Priority
+	TagsAdded, TagsRemoved   []string
+	Description              string // appended text
+}
+
+// Changed reports whether the edit changed anything.
+func (c EditChange) Changed() bool {
+	return c.TitleTo != "" || c.PriorityTo != "" || len(c.TagsAdded) > 0 || len(c.TagsRemoved) > 0 || c.Description != ""
+}
+
+// Fields names the changed fields: title, priority, tags and description.
+func (c EditChange) Fields() []string {
+	var fields []string
+	if c.TitleTo != "" {
+		fields = append(fields, "title")
+	}
+	if c.PriorityTo != "" {
+		fields = append(fields, "priority")
+	}
+	if len(c.TagsAdded) > 0 || len(c.TagsRemoved) > 0 {
+		fields = append(fields, "tags")
+	}
+	if c.Description != "" {
+		fields = append(fields, "description")
+	}
+	return fields
+}
+
+// RaisesPriority reports whether the edit makes the ticket more urgent.
+func (c EditChange) RaisesPriority() bool {
+	return c.PriorityTo != "" && c.PriorityTo < c.PriorityFrom
+}
+
+// Diff renders the changes one per line, before → after, with any appended
+// description below.
+func (c EditChange) Diff() string {
+	var lines []string
+	if c.TitleTo != "" {
+		lines = append(lines, fmt.Sprintf("Title: %s → %s", c.TitleFrom, c.TitleTo))
+	}
+	if c.PriorityTo != "" {
+		lines = append(lines, fmt.Sprintf("Priority: %s → %s", c.PriorityFrom, c.PriorityTo))
+	}
+	if len(c.TagsAdded) > 0 || len(c.TagsRemoved) > 0 {
+		var tags []string
+		for _, t := range c.TagsAdded {
+			tags = append(tags, "+"+t)
+		}
+		for _, t := range c.TagsRemoved {
+			tags = append(tags, "-"+t)
+		}
+		lines = append(lines, "Tags: "+strings.Join(tags, ", "))
+	}
+	if c.Description != "" {
+		lines = append(lines, "Description: appended", "", c.Description)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// ApplyEdit validates e and applies it to t, recording the changes in an
+// Edited section. Values equal to the current ones, tags already present
+// and absent tags to remove are skipped; ErrNoChanges is returned if
+// nothing is left. The ticket is modified but not saved.
+func ApplyEdit(t *Ticket, e Edit, actor, runID string, now time.Time) (EditChange, error) {
+	var change EditChange
+
+	if e.Title != nil {
+		title := strings.TrimSpace(*e.Title)
+		switch {
+		case title == "":
+			return change, fmt.Errorf("title cannot be empty")
+		case strings.ContainsAny(title, "\r\n"):
+			return change, fmt.Errorf("title must be a single line")
+		case title != t.Title:
+			change.TitleFrom, change.TitleTo = t.Title, title
+		}
+	}
+
+	if e.Priority != nil {
+		p := Priority(strings.ToUpper(string(*e.Priority)))
+		if !ValidPriorities[p] {
+			return change, fmt.Errorf("invalid priority %q (use P0-P5)", *e.Priority)
+		}
+		if p != t.Priority {
+			change.PriorityFrom, change.PriorityTo = t.Priority, p
+		}
+	}
+
+	for _, tag := range e.AddTags {
+		if err := validateTag(tag); err != nil {
+			return change, err
+		}
+		if !containsTag(t.Tags, tag) && !containsTag(change.TagsAdded, tag) {
+			change.TagsAdded = append(change.TagsAdded, tag)
+		}
+	}
+	for _, tag := range e.RemoveTags {
+		if containsTag(t.Tags, tag) && !containsTag(change.TagsRemoved, tag) {
+			change.TagsRemoved = append(change.TagsRemoved, tag)
+		}
+	}
+
+	if e.AppendDescription != "" {
+		change.Description = strings.TrimSpace(e.AppendDescription)
+		if change.Description == "" {
+			return change, fmt.Errorf("description cannot be empty")
+		}
+	}
+
+	if !change.Changed() {
+		return change, ErrNoChanges
+	}
+
+	if change.TitleTo != "" {
+		t.Title = change.TitleTo
+	}
+	if change.PriorityTo != "" {
+		t.Priority = change.PriorityTo
+	}
+	if len(change.TagsAdded) > 0 || len(change.TagsRemoved) > 0 {
+		tags := slices.DeleteFunc(slices.Clone(t.Tags), func(tag string) bool {
+			return containsTag(change.TagsRemoved, tag)
+		})
+		t.Tags = append(tags, change.TagsAdded...)
+	}
+	AppendSection(t, "Edited", actor, runID, change.Diff(), nil, now)
+	return change, nil
+}
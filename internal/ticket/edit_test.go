@@ -0,0 +1,95 @@
+package ticket
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseTagEdit(t *testing.T) {
+	add, remove, err := ParseTagEdit("+api, -ui,docs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(add, []string{"api", "docs"}) || !slices.Equal(remove, []string{"ui"}) {
+		t.Errorf("ParseTagEdit = %v, %v", add, remove)
+	}
+
+	for _, bad := range []string{"", ",", "+", "+two words", "+a,-a", "tag:x"} {
+		if _, _, err := ParseTagEdit(bad); err == nil {
+			t.Errorf("ParseTagEdit(%q) should fail", bad)
+		}
+	}
+}
+
+func TestApplyEdit(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tk := &Ticket{ID: "st_aaaaaa", Title: "Old title", Priority: PriorityP3, Tags: []string{"api", "ui"}}
+
+	title := "  New title "
+	p := Priority("p1")
+	change, err := ApplyEdit(tk, Edit{
+		Title:             &title,
+		Priority:          &p,
+		AddTags:           []string{"docs", "API"},
+		RemoveTags:        []string{"ui", "missing"},
+		AppendDescription: "More detail.",
+	}, "agent", "run-1", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if tk.Title != "New title" || tk.Priority != PriorityP1 || !slices.Equal(tk.Tags, []string{"api", "docs"}) {
+		t.Errorf("ticket = %+v", tk)
+	}
+	if !tk.Updated.Equal(now) {
+		t.Errorf("Updated = %v, want %v", tk.Updated, now)
+	}
+	if !slices.Equal(change.Fields(), []string{"title", "priority", "tags", "description"}) || !change.RaisesPriority() {
+		t.Errorf("change = %+v", change)
+	}
+
+	sections := Sections(tk.Body)
+	if len(sections) != 1 || sections[0].Heading != "Edited" {
+		t.Fatalf("sections = %+v", sections)
+	}
+	for _, want := range []string{"Title: Old title → New title", "Priority: P3 → P1", "Tags: +docs, -ui", "Description: appended", "More detail."} {
+		if !strings.Contains(sections[0].Content, want) {
+			t.Errorf("Edited section missing %q:\n%s", want, sections[0].Content)
+		}
+	}
+}
+
+func TestApplyEdit_Validation(t *testing.T) {
+	now := time.Now().UTC()
+	empty, multi, same := " ", "two\nlines", "Title"
+	bad := Priority("P9")
+	tests := []struct {
+		name string
+		edit Edit
+		want error
+	}{
+		{"empty title", Edit{Title: &empty}, nil},
+		{"multi-line title", Edit{Title: &multi}, nil},
+		{"bad priority", Edit{Priority: &bad}, nil},
+		{"bad tag", Edit{AddTags: []string{"a b"}}, nil},
+		{"blank description", Edit{AppendDescription: "  "}, nil},
+		{"no changes", Edit{Title: &same, RemoveTags: []string{"absent"}}, ErrNoChanges},
+	}
+	for _, tt := range tests {
+		tk := &Ticket{ID: "st_aaaaaa", Title: "Title", Priority: PriorityP3}
+		_, err := ApplyEdit(tk, tt.edit, "human", "", now)
+		if err == nil {
+			t.Errorf("%s: expected error", tt.name)
+			continue
+		}
+		if tt.want != nil && !errors.Is(err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, err, tt.want)
+		}
+		if tk.Body != "" || tk.Title != "Title" {
+			t.Errorf("%s: ticket modified on error: %+v", tt.name, tk)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package ticket
+
+import (
+	"fmt"
+	"time"
+)
+
+// Children returns the tickets whose parent is parentID, in input order.
+func Children(tickets []*Ticket, parentID string) []*Ticket {
+	var children []*Ticket
+	for _, tk := range tickets {
+		if tk.Parent == parentID {
+			children = append(children, tk)
+		}
+	}
+	return children
+}
+
+// Progress summarizes the statuses of a parent's children.
+type Progress struct {
+	Total     int
+	Done      int
+	Cancelled int
+}
+
+// ChildProgress counts the finished children.
+func ChildProgress(children []*Ticket) Progress {
+	p := Progress{Total: len(children)}
+	for _, tk := range children {
+		switch tk.Status {
+		case StatusDone:
+			p.Done++
+		case StatusCancelled:
+			p.Cancelled++
+		}
+	}
+	return p
+}
+
+// Finished reports whether every child is DONE or CANCELLED and at least one
+// is DONE.
+func (p Progress) Finished() bool {
+	return p.Done > 0 && p.Done+p.Cancelled == p.Total
+}
+
+// String formats the progress as e.g. "2/3 done (1 cancelled)"; cancelled
+// children are left out of the count.
+func (p Progress) String() string {
+	s := fmt.Sprintf("%d/%d done", p.Done, p.Total-p.Cancelled)
+	if p.Cancelled > 0 {
+		s += fmt.Sprintf(" (%d cancelled)", p.Cancelled)
+	}
+	return s
+}
+
+// ValidateParent checks that parentID exists and that making it the parent
+// of childID would not create a cycle.
+func ValidateParent(store *Store, childID, parentID string) error {
+	seen := map[string]bool{}
+	for id := parentID; id != ""; {
+		if id == childID {
+			return fmt.Errorf("cannot make %s the parent of %s — it would create a cycle", parentID, childID)
+		}
+		if seen[id] {
+			return nil
+		}
+		seen[id] = true
+		tk, err := store.Get(id)
+		if err != nil {
+			if id == parentID {
+				return fmt.Errorf("parent %s: %w", parentID, err)
+			}
+			return nil
+		}
+		id = tk.Parent
+	}
+	return nil
+}
+
+// TreeNode is a ticket with its children.
+type TreeNode struct {
+	Ticket   *Ticket
+	Children []*TreeNode
+}
+
+// BuildTree arranges tickets under their parents. Tickets whose parent is not
+// among them are roots, as are tickets whose parent link would close a cycle.
+// Input order is kept at every level.
+func BuildTree(tickets []*Ticket) []*TreeNode {
+	nodes := make(map[string]*TreeNode, len(tickets))
+	for _, tk := range tickets {
+		nodes[tk.ID] = &TreeNode{Ticket: tk}
+	}
+
+	// Attach links that don't close a cycle, following only links already
+	// attached.
+	attached := make(map[string]string, len(tickets))
+	for _, tk := range tickets {
+		if nodes[tk.Parent] == nil {
+			continue
+		}
+		cycle := false
+		for cur := tk.Parent; cur != ""; cur = attached[cur] {
+			if cur == tk.ID {
+				cycle = true
+				break
+			}
+		}
+		if !cycle {
+			attached[tk.ID] = tk.Parent
+		}
+	}
+
+	var roots []*TreeNode
+	for _, tk := range tickets {
+		node := nodes[tk.ID]
+		if parentID, ok := attached[tk.ID]; ok {
+			nodes[parentID].Children = append(nodes[parentID].Children, node)
+		} else {
+			roots = append(roots, node)
+		}
+	}
+	return roots
+}
+
+// ParentChange records a parent moved by AutoCompleteParents.
+type ParentChange struct {
+	Ticket *Ticket
+	From   Status
+}
+
+// AutoCompleteParents moves tk's parent to target once all the parent's
+// children are finished. When target is DONE the rollup continues up the
+// hierarchy. Parents that are BLOCKED, already at or past target, or
+// finished are left alone.
+func AutoCompleteParents(store *Store, tk *Ticket, target Status) ([]ParentChange, error) {
+	var changes []ParentChange
+	seen := map[string]bool{}
+	for tk.Parent != "" && !seen[tk.Parent] {
+		seen[tk.Parent] = true
+		parent, err := store.Get(tk.Parent)
+		if err != nil {
+			return changes, err
+		}
+		switch parent.Status {
+		case target, StatusDone, StatusCancelled, StatusBlocked:
+			return changes, nil
+		case StatusHumanReview:
+			if target == StatusReview {
+				return changes, nil
+			}
+		}
+
+		all, err := store.ListMeta(ListFilter{})
+		if err != nil {
+			return changes, err
+		}
+		progress := ChildProgress(Children(all, parent.ID))
+		if !progress.Finished() {
+			return changes, nil
+		}
+
+		now := time.Now().UTC()
+		from := parent.Status
+		parent.Status = target
+		parent.PriorStatus = nil
+		if target == StatusReview {
+			parent.Assignee = ""
+		}
+		AppendSection(parent, "Children Finished", "st", "", "All child tickets finished: "+progress.String()+".", map[string]string{
+			"from": string(from),
+		}, now)
+		if err := store.Save(parent); err != nil {
+			return changes, err
+		}
+		changes = append(changes, ParentChange{Ticket: parent, From: from})
+
+		if target != StatusDone {
+			return changes, nil
+		}
+		tk = parent
+	}
+	return changes, nil
+}
@@ -0,0 +1,169 @@
+package ticket
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestChildProgress(t *testing.T) {
+	children := []*Ticket{
+		{ID: "st_a", Status: StatusDone},
+		{ID: "st_b", Status: StatusCancelled},
+		{ID: "st_c", Status: StatusInProgress},
+	}
+	p := ChildProgress(children)
+	if p.Finished() {
+		t.Error("progress with an unfinished child should not be finished")
+	}
+	if got := p.String(); got != "1/2 done (1 cancelled)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	children[2].Status = StatusDone
+	if !ChildProgress(children).Finished() {
+		t.Error("expected finished once every child is DONE or CANCELLED")
+	}
+	if ChildProgress([]*Ticket{{Status: StatusCancelled}}).Finished() {
+		t.Error("all-cancelled children should not finish the parent")
+	}
+}
+
+func TestValidateParent(t *testing.T) {
+	store := testStore(t)
+	epic := testTicket("st_epic01", "proj", StatusOpen, nil)
+	child := testTicket("st_chld01", "proj", StatusOpen, nil)
+	child.Parent = epic.ID
+	for _, tk := range []*Ticket{epic, child} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := ValidateParent(store, "st_new001", epic.ID); err != nil {
+		t.Errorf("valid parent: %v", err)
+	}
+	if err := ValidateParent(store, "st_new001", "st_nope01"); err == nil {
+		t.Error("expected an error for a missing parent")
+	}
+	if err := ValidateParent(store, epic.ID, child.ID); err == nil || !strings.Contains(err.Error(), "cycle") {
+		t.Errorf("expected a cycle error, got %v", err)
+	}
+	if err := ValidateParent(store, epic.ID, epic.ID); err == nil {
+		t.Error("a ticket cannot be its own parent")
+	}
+}
+
+func TestBuildTree(t *testing.T) {
+	tickets := []*Ticket{
+		{ID: "st_epic"},
+		{ID: "st_a", Parent: "st_epic"},
+		{ID: "st_a1", Parent: "st_a"},
+		{ID: "st_b", Parent: "st_epic"},
+		{ID: "st_orphan", Parent: "st_gone"},
+		{ID: "st_x", Parent: "st_y"},
+		{ID: "st_y", Parent: "st_x"},
+	}
+
+	roots := BuildTree(tickets)
+	var ids []string
+	for _, r := range roots {
+		ids = append(ids, r.Ticket.ID)
+	}
+	if got := strings.Join(ids, ","); got != "st_epic,st_orphan,st_y" {
+		t.Fatalf("roots = %s", got)
+	}
+	epic := roots[0]
+	if len(epic.Children) != 2 || epic.Children[0].Ticket.ID != "st_a" || epic.Children[1].Ticket.ID != "st_b" {
+		t.Fatalf("unexpected epic children: %+v", epic.Children)
+	}
+	if len(epic.Children[0].Children) != 1 || epic.Children[0].Children[0].Ticket.ID != "st_a1" {
+		t.Error("expected st_a1 nested under st_a")
+	}
+	if len(roots[2].Children) != 1 || roots[2].Children[0].Ticket.ID != "st_x" {
+		t.Error("a parent cycle should be broken at the link that closes it")
+	}
+}
+
+func TestAutoCompleteParents(t *testing.T) {
+	store := testStore(t)
+	top := testTicket("st_top001", "proj", StatusInProgress, nil)
+	epic := testTicket("st_epic01", "proj", StatusInProgress, nil)
+	epic.Parent = top.ID
+	epic.Assignee = "agent-1"
+	a := testTicket("st_chld01", "proj", StatusDone, nil)
+	a.Parent = epic.ID
+	b := testTicket("st_chld02", "proj", StatusInProgress, nil)
+	b.Parent = epic.ID
+	for _, tk := range []*Ticket{top, epic, a, b} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, err := AutoCompleteParents(store, a, StatusReview)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes while a child is open, got %d", len(changes))
+	}
+
+	b.Status = StatusCancelled
+	if err := store.Save(b); err != nil {
+		t.Fatal(err)
+	}
+	changes, err = AutoCompleteParents(store, b, StatusReview)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Ticket.ID != epic.ID || changes[0].From != StatusInProgress {
+		t.Fatalf("unexpected changes: %+v", changes)
+	}
+
+	got, err := store.Get(epic.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != StatusReview || got.Assignee != "" {
+		t.Errorf("epic status = %s, assignee = %q", got.Status, got.Assignee)
+	}
+	if !strings.Contains(got.Body, "## Children Finished") || !strings.Contains(got.Body, "1/1 done (1 cancelled)") {
+		t.Errorf("expected a Children Finished section, got:\n%s", got.Body)
+	}
+	if top, _ := store.Get(top.ID); top.Status != StatusInProgress {
+		t.Error("review rollup should not continue past the epic")
+	}
+}
+
+func TestAutoCompleteParentsDoneCascades(t *testing.T) {
+	store := testStore(t)
+	top := testTicket("st_top001", "proj", StatusOpen, nil)
+	epic := testTicket("st_epic01", "proj", StatusOpen, nil)
+	epic.Parent = top.ID
+	a := testTicket("st_chld01", "proj", StatusDone, nil)
+	a.Parent = epic.ID
+	blocked := testTicket("st_blkd01", "proj", StatusBlocked, nil)
+	c := testTicket("st_chld03", "proj", StatusDone, nil)
+	c.Parent = blocked.ID
+	for _, tk := range []*Ticket{top, epic, a, blocked, c} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	changes, err := AutoCompleteParents(store, a, StatusDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[0].Ticket.ID != epic.ID || changes[1].Ticket.ID != top.ID {
+		t.Fatalf("expected epic then top to complete, got %+v", changes)
+	}
+
+	changes, err = AutoCompleteParents(store, c, StatusDone)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 0 {
+		t.Error("a BLOCKED parent should be left alone")
+	}
+}
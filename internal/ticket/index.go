@@ -0,0 +1,307 @@
+package ticket
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/boozedog/smoovtask/internal/finder"
+	"golang.org/x/sys/unix"
+)
+
+// The full-text index lives under the projects dir in a hidden directory,
+// so project listings and ticket discovery skip it.
+const indexDir = ".index"
+
+// pendingDir holds one small index per ticket saved since the last search.
+// Saves write only their own ticket's file, so a command that saves many
+// tickets does not rewrite the whole index each time; the next search folds
+// them into search.json.
+const pendingDir = "docs"
+
+// searchIndex is an inverted index over ticket titles and body sections.
+// Each ticket is split into sections (see searchSections); postings record
+// which section of which ticket a term occurs in, and how often.
+type searchIndex struct {
+	Docs     map[string]indexDoc  `json:"docs"`
+	Postings map[string][]posting `json:"postings"`
+}
+
+type indexDoc struct {
+	Path     string   `json:"path"`
+	ModTime  int64    `json:"mtime"`
+	Sections []string `json:"sections"`
+	Terms    []string `json:"terms"`
+}
+
+type posting struct {
+	Ticket  string `json:"t"`
+	Section int    `json:"s"`
+	Count   int    `json:"n"`
+}
+
+// searchSection is a searchable part of a ticket: its title, the text before
+// the first heading, or a body section.
+type searchSection struct {
+	Heading string
+	Text    string
+}
+
+var bodyHeadingRe = regexp.MustCompile(`^## (.+?)(?: — \S+)?$`)
+
+// searchSections splits a ticket into its searchable sections. Section 0 is
+// always the title. Section field lines (actor, run) are left out.
+func searchSections(t *Ticket) []searchSection {
+	sections := []searchSection{{Heading: "Title", Text: t.Title}}
+	cur := searchSection{Heading: "Body"}
+	var lines []string
+	flush := func() {
+		cur.Text = strings.TrimSpace(strings.Join(lines, "\n"))
+		if cur.Text != "" {
+			sections = append(sections, cur)
+		}
+		lines = nil
+	}
+	for _, line := range strings.Split(t.Body, "\n") {
+		if m := bodyHeadingRe.FindStringSubmatch(line); m != nil {
+			flush()
+			cur = searchSection{Heading: m[1]}
+			continue
+		}
+		if sectionFieldRe.MatchString(line) {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	flush()
+	return sections
+}
+
+// tokenize lowercases s and splits it into words of two or more letters or
+// digits.
+func tokenize(s string) []string {
+	fields := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return slices.DeleteFunc(fields, func(f string) bool { return len([]rune(f)) < 2 })
+}
+
+func (s *Store) indexPath() string {
+	return filepath.Join(s.projectsDir, indexDir, "search.json")
+}
+
+// withIndex runs fn on the index under an exclusive lock, writing it back if
+// fn reports a change.
+func (s *Store) withIndex(fn func(idx *searchIndex) (bool, error)) error {
+	dir := filepath.Join(s.projectsDir, indexDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create index dir: %w", err)
+	}
+	lock, err := os.OpenFile(filepath.Join(dir, "lock"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("open index lock: %w", err)
+	}
+	defer lock.Close()
+	if err := unix.Flock(int(lock.Fd()), unix.LOCK_EX); err != nil {
+		return fmt.Errorf("lock index: %w", err)
+	}
+	defer unix.Flock(int(lock.Fd()), unix.LOCK_UN)
+
+	idx := &searchIndex{}
+	if data, err := os.ReadFile(s.indexPath()); err == nil {
+		// A corrupt index is rebuilt from scratch by the refresh.
+		_ = json.Unmarshal(data, idx)
+	}
+	if idx.Docs == nil {
+		idx.Docs = map[string]indexDoc{}
+	}
+	if idx.Postings == nil {
+		idx.Postings = map[string][]posting{}
+	}
+
+	changed, err := fn(idx)
+	if err != nil || !changed {
+		return err
+	}
+
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("marshal index: %w", err)
+	}
+	tmp := s.indexPath() + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("write index: %w", err)
+	}
+	return os.Rename(tmp, s.indexPath())
+}
+
+// indexTicket records the index entry for a ticket just written to path in
+// its own pending file, replacing any earlier one for the same ticket.
+func (s *Store) indexTicket(t *Ticket, path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	single := &searchIndex{Docs: map[string]indexDoc{}, Postings: map[string][]posting{}}
+	single.put(t, path, info.ModTime().UnixNano())
+	data, err := json.Marshal(single)
+	if err != nil {
+		return fmt.Errorf("marshal index entry: %w", err)
+	}
+
+	dir := filepath.Join(s.projectsDir, indexDir, pendingDir)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create index dir: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, t.ID+"-*.tmp")
+	if err != nil {
+		return fmt.Errorf("write index entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write index entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("write index entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), filepath.Join(dir, t.ID+".json"))
+}
+
+// mergePending folds the pending per-ticket entries into idx and removes
+// them. An entry older than what idx already holds is dropped; one lost to a
+// concurrent save is recovered by refreshIndex from the file's mtime.
+func (s *Store) mergePending(idx *searchIndex) bool {
+	dir := filepath.Join(s.projectsDir, indexDir, pendingDir)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	changed := false
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		if filepath.Ext(entry.Name()) != ".json" {
+			continue
+		}
+		var single searchIndex
+		data, err := os.ReadFile(path)
+		if err == nil && json.Unmarshal(data, &single) == nil {
+			for id, doc := range single.Docs {
+				if cur, ok := idx.Docs[id]; ok && cur.ModTime > doc.ModTime {
+					continue
+				}
+				idx.remove(id)
+				idx.Docs[id] = doc
+				for term, postings := range single.Postings {
+					idx.Postings[term] = append(idx.Postings[term], postings...)
+				}
+				changed = true
+			}
+		}
+		_ = os.Remove(path)
+	}
+	return changed
+}
+
+// refreshIndex brings the index up to date with the ticket files on disk:
+// it merges the entries pending from saves, re-indexes tickets written
+// without going through the store (hand edits, other tools) and drops
+// deleted ones.
+func (s *Store) refreshIndex(idx *searchIndex) (bool, error) {
+	changed := s.mergePending(idx)
+	files, err := finder.FindFiles(s.projectsDir)
+	if err != nil {
+		return false, fmt.Errorf("find ticket files: %w", err)
+	}
+
+	seen := make(map[string]bool, len(files))
+	for _, path := range files {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		id := idFromFilename(filepath.Base(path))
+		seen[id] = true
+		if doc, ok := idx.Docs[id]; ok && doc.Path == path && doc.ModTime == info.ModTime().UnixNano() {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		t, err := Parse(data)
+		if err != nil {
+			continue
+		}
+		idx.put(t, path, info.ModTime().UnixNano())
+		changed = true
+	}
+	for id := range idx.Docs {
+		if !seen[id] {
+			idx.remove(id)
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// idFromFilename returns the ticket ID from a <created>-<id>.md filename.
+func idFromFilename(name string) string {
+	name = strings.TrimSuffix(name, ".md")
+	if i := strings.LastIndex(name, "-"+IDPrefix); i >= 0 {
+		return name[i+1:]
+	}
+	return name
+}
+
+func (idx *searchIndex) put(t *Ticket, path string, modTime int64) {
+	idx.remove(t.ID)
+
+	sections := searchSections(t)
+	doc := indexDoc{Path: path, ModTime: modTime}
+	counts := map[string]map[int]int{}
+	for i, sec := range sections {
+		doc.Sections = append(doc.Sections, sec.Heading)
+		text := sec.Text
+		if i == 0 {
+			// Make the ID findable alongside the title.
+			text = t.ID + " " + text
+		}
+		for _, term := range tokenize(text) {
+			if counts[term] == nil {
+				counts[term] = map[int]int{}
+			}
+			counts[term][i]++
+		}
+	}
+	for term, bySection := range counts {
+		doc.Terms = append(doc.Terms, term)
+		for i, n := range bySection {
+			idx.Postings[term] = append(idx.Postings[term], posting{Ticket: t.ID, Section: i, Count: n})
+		}
+	}
+	slices.Sort(doc.Terms)
+	idx.Docs[t.ID] = doc
+}
+
+func (idx *searchIndex) remove(id string) {
+	doc, ok := idx.Docs[id]
+	if !ok {
+		return
+	}
+	for _, term := range doc.Terms {
+		postings := slices.DeleteFunc(idx.Postings[term], func(p posting) bool { return p.Ticket == id })
+		if len(postings) == 0 {
+			delete(idx.Postings, term)
+		} else {
+			idx.Postings[term] = postings
+		}
+	}
+	delete(idx.Docs, id)
+}
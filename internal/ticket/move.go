@@ -0,0 +1,230 @@
+package ticket
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MoveResult describes what Move changed.
+type MoveResult struct {
+	From, To string // projects
+
+	// Rewritten maps old dependency references to the full IDs that
+	// replaced them, per ticket ID.
+	Rewritten map[string]map[string]string
+	// Dependents are the other tickets whose references were rewritten.
+	// They are modified but not saved.
+	Dependents []*Ticket
+	// Unresolved are the moved ticket's dependencies that match no single
+	// ticket. They are left as they are.
+	Unresolved []string
+}
+
+// Move reassigns tk to project; the next Save relocates its file into that
+// project's tickets directory. Ticket IDs are global, so references stay
+// valid across projects, but any dependency reference to or from tk that is
+// only an ID prefix is rewritten to the full ID so it keeps naming the same
+// ticket. An IN-PROGRESS or REWORK ticket is refused unless force is set:
+// hooks in the old repo would no longer find it and would block the
+// assigned agent's writes. tk is modified but not saved.
+func Move(store *Store, tk *Ticket, project string, force bool, actor, runID string, now time.Time) (MoveResult, error) {
+	result := MoveResult{From: tk.Project, To: project, Rewritten: map[string]map[string]string{}}
+	if project == "" {
+		return result, fmt.Errorf("project is required")
+	}
+	if project == tk.Project {
+		return result, fmt.Errorf("%s is already in project %s", tk.ID, project)
+	}
+	if (tk.Status == StatusInProgress || tk.Status == StatusRework) && !force {
+		return result, fmt.Errorf("%s is %s; hand it off first or pass --force", tk.ID, tk.Status)
+	}
+
+	all, err := store.List(ListFilter{})
+	if err != nil {
+		return result, err
+	}
+
+	// rewrite replaces t's prefix references to tickets match accepts with
+	// their full IDs.
+	rewrite := func(t *Ticket, match func(id string) bool) map[string]string {
+		var changed map[string]string
+		for i, ref := range t.DependsOn {
+			id := resolveRef(all, ref)
+			if id == "" && t.ID == tk.ID {
+				result.Unresolved = append(result.Unresolved, ref)
+			}
+			if id == "" || id == ref || !match(id) {
+				continue
+			}
+			if changed == nil {
+				changed = map[string]string{}
+			}
+			changed[ref] = id
+			t.DependsOn[i] = id
+		}
+		if changed != nil {
+			result.Rewritten[t.ID] = changed
+		}
+		return changed
+	}
+
+	own := rewrite(tk, func(string) bool { return true })
+	for _, other := range all {
+		if other.ID == tk.ID {
+			continue
+		}
+		changed := rewrite(other, func(id string) bool { return id == tk.ID })
+		if changed == nil {
+			continue
+		}
+		AppendSection(other, "Dependencies Changed", actor, runID, rewriteLines(changed, fmt.Sprintf("(%s moved to %s)", tk.ID, project)), nil, now)
+		result.Dependents = append(result.Dependents, other)
+	}
+
+	tk.Project = project
+	AppendSection(tk, "Moved", actor, runID, rewriteLines(own, ""), map[string]string{"from": result.From, "to": project}, now)
+	return result, nil
+}
+
+// rewriteLines lists rewritten references as "Rewrote: old → new" lines.
+func rewriteLines(changed map[string]string, suffix string) string {
+	refs := make([]string, 0, len(changed))
+	for ref := range changed {
+		refs = append(refs, ref)
+	}
+	slices.Sort(refs)
+	lines := make([]string, len(refs))
+	for i, ref := range refs {
+		lines[i] = strings.TrimSpace(fmt.Sprintf("Rewrote: %s → %s %s", ref, changed[ref], suffix))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// MergeResult describes what MergeDuplicate changed.
+type MergeResult struct {
+	From  Status // the duplicate's status before it was cancelled
+	Notes int    // notes copied into the canonical ticket
+
+	// Dependents are the tickets that depended on the duplicate and now
+	// depend on the canonical ticket instead, with their dependency changes.
+	// The canonical ticket itself is included if it depended on the
+	// duplicate. They are modified but not saved.
+	Dependents []*Ticket
+	Changes    map[string]DepsChange
+
+	// Children were the duplicate's children and are now the canonical
+	// ticket's. They are modified but not saved.
+	Children []*Ticket
+	// Kept lists references to the duplicate that were left in place:
+	// children that would close a parent cycle under the canonical ticket,
+	// and typed links from other tickets.
+	Kept []string
+}
+
+// MergeDuplicate folds dup into canonical: tickets depending on dup are
+// repointed at canonical (re-checking cycles and blocking as
+// SetDependencies does), dup's children become canonical's, dup's
+// description and notes are copied into a Merged Duplicate section on
+// canonical, and dup is cancelled with a duplicates link. The tickets are
+// modified but not saved; on error they may be partly modified and must not
+// be saved.
+func MergeDuplicate(store *Store, dup, canonical *Ticket, actor, runID string, now time.Time) (MergeResult, error) {
+	result := MergeResult{From: dup.Status, Changes: map[string]DepsChange{}}
+	switch {
+	case dup.ID == canonical.ID:
+		return result, fmt.Errorf("cannot merge %s into itself", dup.ID)
+	case dup.Status == StatusCancelled:
+		return result, fmt.Errorf("%s is already CANCELLED", dup.ID)
+	case canonical.Status == StatusCancelled:
+		return result, fmt.Errorf("canonical ticket %s is CANCELLED", canonical.ID)
+	}
+
+	all, err := store.List(ListFilter{})
+	if err != nil {
+		return result, err
+	}
+
+	for _, other := range all {
+		if other.ID == dup.ID {
+			continue
+		}
+		if other.ID == canonical.ID {
+			other = canonical
+		}
+		var deps []string
+		found := false
+		for _, ref := range other.DependsOn {
+			if resolveRef(all, ref) != dup.ID {
+				deps = append(deps, ref)
+				continue
+			}
+			found = true
+			if other.ID != canonical.ID {
+				deps = append(deps, canonical.ID)
+			}
+		}
+		if !found {
+			continue
+		}
+		change, err := SetDependencies(store, other, deps, actor, runID, now)
+		if err != nil {
+			return result, fmt.Errorf("repoint %s: %w", other.ID, err)
+		}
+		other.Updated = now
+		result.Dependents = append(result.Dependents, other)
+		result.Changes[other.ID] = change
+	}
+
+	for _, other := range all {
+		if other.Parent != dup.ID {
+			continue
+		}
+		if other.ID == canonical.ID {
+			other = canonical
+		}
+		if other.ID == canonical.ID || ValidateParent(store, other.ID, canonical.ID) != nil {
+			result.Kept = append(result.Kept, fmt.Sprintf("%s has parent %s", other.ID, dup.ID))
+			continue
+		}
+		other.Parent = canonical.ID
+		AppendSection(other, "Parent Changed", actor, runID, fmt.Sprintf("Parent: %s → %s (%s merged into %s)", dup.ID, canonical.ID, dup.ID, canonical.ID), map[string]string{"from": dup.ID, "to": canonical.ID}, now)
+		other.Updated = now
+		result.Children = append(result.Children, other)
+	}
+	for _, l := range Links(all, dup) {
+		if l.Inverse && l.Ticket != canonical.ID {
+			result.Kept = append(result.Kept, fmt.Sprintf("%s %s %s", l.Ticket, l.Type, dup.ID))
+		}
+	}
+
+	var parts []string
+	sections := Sections(dup.Body)
+	if len(sections) > 0 && sections[0].Heading == "Created" && sections[0].Content != "" {
+		parts = append(parts, "#### Description\n\n"+sections[0].Content)
+	}
+	for _, s := range sections {
+		if s.Heading != "Note" || s.Content == "" {
+			continue
+		}
+		by := s.Actor
+		if s.Session != "" {
+			by += ", session " + s.Session
+		}
+		parts = append(parts, fmt.Sprintf("#### Note — %s (%s)\n\n%s", s.TS.UTC().Format(time.RFC3339), by, s.Content))
+		result.Notes++
+	}
+	content := fmt.Sprintf("Merged from %s: %s", dup.ID, dup.Title)
+	if len(parts) > 0 {
+		content += "\n\n" + strings.Join(parts, "\n\n")
+	}
+	AppendSection(canonical, "Merged Duplicate", actor, runID, content, map[string]string{"duplicate": dup.ID}, now)
+
+	dup.Status = StatusCancelled
+	dup.PriorStatus = nil
+	dup.Assignee = ""
+	dup.AddRelation(RelationDuplicates, canonical.ID)
+	AppendSection(dup, "Cancelled", actor, runID, "Duplicate of "+canonical.ID, map[string]string{"duplicate-of": canonical.ID}, now)
+	return result, nil
+}
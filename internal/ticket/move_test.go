@@ -0,0 +1,130 @@
+package ticket
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMove_CanonicalizesReferences(t *testing.T) {
+	store := testStore(t)
+	for _, tk := range []*Ticket{
+		testTicket("st_aaaaaa", "proj-a", StatusDone, nil),
+		testTicket("st_cccccc", "proj-a", StatusOpen, []string{"st_aaa", "st_zzz"}),
+		testTicket("st_dddddd", "proj-b", StatusOpen, []string{"st_ccc"}),
+		testTicket("st_eeeeee", "proj-b", StatusOpen, []string{"st_aaa"}),
+	} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tk, err := store.Get("st_cccccc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	result, err := Move(store, tk, "proj-b", false, "human", "", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Project != "proj-b" || result.From != "proj-a" {
+		t.Errorf("project = %q, result = %+v", tk.Project, result)
+	}
+	if !slices.Equal(tk.DependsOn, []string{"st_aaaaaa", "st_zzz"}) {
+		t.Errorf("own DependsOn = %v", tk.DependsOn)
+	}
+	if !slices.Equal(result.Unresolved, []string{"st_zzz"}) {
+		t.Errorf("Unresolved = %v", result.Unresolved)
+	}
+	// Only references to the moved ticket are rewritten in other tickets.
+	if len(result.Dependents) != 1 || result.Dependents[0].ID != "st_dddddd" {
+		t.Fatalf("Dependents = %v", result.Dependents)
+	}
+	if !slices.Equal(result.Dependents[0].DependsOn, []string{"st_cccccc"}) {
+		t.Errorf("dependent DependsOn = %v", result.Dependents[0].DependsOn)
+	}
+	if !strings.Contains(result.Dependents[0].Body, "Rewrote: st_ccc → st_cccccc (st_cccccc moved to proj-b)") {
+		t.Errorf("dependent body:\n%s", result.Dependents[0].Body)
+	}
+
+	if _, err := Move(store, tk, "proj-b", false, "human", "", now); err == nil {
+		t.Error("expected error moving into the current project")
+	}
+}
+
+func TestMergeDuplicate_CanonicalDependedOnDuplicate(t *testing.T) {
+	store := testStore(t)
+	dup := testTicket("st_aaaaaa", "proj-a", StatusOpen, nil)
+	canonical := testTicket("st_bbbbbb", "proj-b", StatusBlocked, []string{"st_aaaaaa"})
+	prior := StatusOpen
+	canonical.PriorStatus = &prior
+	for _, tk := range []*Ticket{dup, canonical} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := MergeDuplicate(store, dup, canonical, "human", "", time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(canonical.DependsOn) != 0 {
+		t.Errorf("canonical DependsOn = %v, want none", canonical.DependsOn)
+	}
+	if canonical.Status != StatusOpen {
+		t.Errorf("canonical status = %s, want OPEN", canonical.Status)
+	}
+	if len(result.Dependents) != 1 || result.Dependents[0] != canonical {
+		t.Errorf("Dependents = %v, want the canonical ticket itself", result.Dependents)
+	}
+	if dup.Status != StatusCancelled || result.From != StatusOpen {
+		t.Errorf("dup status = %s, result.From = %s", dup.Status, result.From)
+	}
+}
+
+func TestMove_RefusesActiveTicketWithoutForce(t *testing.T) {
+	store := testStore(t)
+	tk := testTicket("st_aaaaaa", "proj-a", StatusInProgress, nil)
+	if err := store.Create(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now().UTC()
+	if _, err := Move(store, tk, "proj-b", false, "human", "", now); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("err = %v, want a refusal mentioning --force", err)
+	}
+	if tk.Project != "proj-a" {
+		t.Errorf("project = %q, want unchanged", tk.Project)
+	}
+	if _, err := Move(store, tk, "proj-b", true, "human", "", now); err != nil || tk.Project != "proj-b" {
+		t.Errorf("forced move: project = %q, err = %v", tk.Project, err)
+	}
+}
+
+func TestMergeDuplicate_ReparentsChildren(t *testing.T) {
+	store := testStore(t)
+	dup := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	canonical := testTicket("st_bbbbbb", "proj", StatusOpen, nil)
+	child := testTicket("st_cccccc", "proj", StatusOpen, nil)
+	child.Parent = dup.ID
+	linked := testTicket("st_dddddd", "proj", StatusOpen, nil)
+	linked.AddRelation(RelationBlocks, dup.ID)
+	for _, tk := range []*Ticket{dup, canonical, child, linked} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	result, err := MergeDuplicate(store, dup, canonical, "human", "", time.Now().UTC())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Children) != 1 || result.Children[0].ID != child.ID || result.Children[0].Parent != canonical.ID {
+		t.Fatalf("Children = %+v, want st_cccccc under st_bbbbbb", result.Children)
+	}
+	if !slices.Equal(result.Kept, []string{"st_dddddd blocks st_aaaaaa"}) {
+		t.Errorf("Kept = %v", result.Kept)
+	}
+}
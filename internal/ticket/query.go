@@ -0,0 +1,360 @@
+package ticket
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Query is a parsed ticket query: a filter plus the order to list matches in.
+//
+// The syntax is a space-separated list of terms:
+//
+//	status:open,review      status is one of the listed values
+//	priority:<=P2           priority comparison (<, <=, >, >=) or a list (P0,P1)
+//	tag:api                 has the tag; repeat for several
+//	assignee:none           unassigned, or assigned to the given run ID
+//	project:api             in the project
+//	parent:st_abc123        child of the ticket
+//	updated:<7d             updated within 7d; >7d for older. Units h, d, w;
+//	created:>=2026-01-02    or an absolute date
+//	sort:priority,-updated  order by keys; a leading - reverses
+//	"rate limit"            text in the ID, title or body
+//
+// Bare words are text terms. A term starting with @ names a saved query,
+// expanded by ExpandSaved before parsing.
+type Query struct {
+	Filter ListFilter
+	Sort   []SortKey
+}
+
+// SortKey orders query results by a ticket field.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+// Sort fields.
+var sortFields = []string{"priority", "status", "updated", "created", "title", "id", "project"}
+
+// ParseQuery parses a query string. Relative times are resolved against now.
+func ParseQuery(s string, now time.Time) (Query, error) {
+	var q Query
+	terms, err := splitQuery(s)
+	if err != nil {
+		return q, err
+	}
+
+	for _, term := range terms {
+		if term.quoted {
+			q.Filter.Text = append(q.Filter.Text, term.text)
+			continue
+		}
+		key, value, ok := strings.Cut(term.text, ":")
+		if !ok {
+			q.Filter.Text = append(q.Filter.Text, term.text)
+			continue
+		}
+		if value == "" {
+			return q, fmt.Errorf("%s: missing value", key)
+		}
+
+		switch strings.ToLower(key) {
+		case "status":
+			for _, v := range strings.Split(value, ",") {
+				status := Status(strings.ReplaceAll(strings.ToUpper(v), "_", "-"))
+				if !ValidStatuses[status] {
+					return q, fmt.Errorf("status: unknown status %q", v)
+				}
+				q.Filter.Statuses = append(q.Filter.Statuses, status)
+			}
+		case "priority":
+			priorities, err := parsePriorities(value)
+			if err != nil {
+				return q, fmt.Errorf("priority: %w", err)
+			}
+			q.Filter.Priorities = append(q.Filter.Priorities, priorities...)
+		case "tag":
+			q.Filter.Tags = append(q.Filter.Tags, strings.Split(value, ",")...)
+		case "assignee":
+			if strings.EqualFold(value, "none") {
+				q.Filter.Unassigned = true
+			} else {
+				q.Filter.Assignee = value
+			}
+		case "project":
+			q.Filter.Project = value
+		case "parent":
+			q.Filter.Parent = value
+		case "updated":
+			if err := parseTimeBound(value, now, &q.Filter.UpdatedAfter, &q.Filter.UpdatedBefore); err != nil {
+				return q, fmt.Errorf("updated: %w", err)
+			}
+		case "created":
+			if err := parseTimeBound(value, now, &q.Filter.CreatedAfter, &q.Filter.CreatedBefore); err != nil {
+				return q, fmt.Errorf("created: %w", err)
+			}
+		case "sort":
+			for _, v := range strings.Split(value, ",") {
+				key := SortKey{Field: strings.ToLower(strings.TrimPrefix(v, "-")), Desc: strings.HasPrefix(v, "-")}
+				if !slices.Contains(sortFields, key.Field) {
+					return q, fmt.Errorf("sort: unknown field %q (use %s)", key.Field, strings.Join(sortFields, ", "))
+				}
+				q.Sort = append(q.Sort, key)
+			}
+		default:
+			return q, fmt.Errorf("unknown query key %q", key)
+		}
+	}
+	return q, nil
+}
+
+type queryTerm struct {
+	text   string
+	quoted bool
+}
+
+// splitQuery splits a query on whitespace, keeping double-quoted phrases
+// together. A quote inside a term (tag:"x y") extends the term.
+func splitQuery(s string) ([]queryTerm, error) {
+	var terms []queryTerm
+	var cur strings.Builder
+	inQuote, quoted, started := false, false, false
+	flush := func() {
+		if started {
+			terms = append(terms, queryTerm{text: cur.String(), quoted: quoted})
+		}
+		cur.Reset()
+		inQuote, quoted, started = false, false, false
+	}
+	for _, r := range s {
+		switch {
+		case r == '"':
+			if !started {
+				quoted = true
+			}
+			started = true
+			inQuote = !inQuote
+		case unicode.IsSpace(r) && !inQuote:
+			flush()
+		default:
+			started = true
+			cur.WriteRune(r)
+		}
+	}
+	if inQuote {
+		return nil, fmt.Errorf("unterminated quote in query")
+	}
+	flush()
+	return terms, nil
+}
+
+// parsePriorities expands a priority comparison or list into the matching
+// priorities.
+func parsePriorities(value string) ([]Priority, error) {
+	op := ""
+	for _, prefix := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(value, prefix) {
+			op, value = prefix, strings.TrimPrefix(value, prefix)
+			break
+		}
+	}
+
+	var listed []Priority
+	for _, v := range strings.Split(value, ",") {
+		p := Priority(strings.ToUpper(v))
+		if !ValidPriorities[p] {
+			return nil, fmt.Errorf("unknown priority %q", v)
+		}
+		listed = append(listed, p)
+	}
+	if op == "" || op == "=" {
+		return listed, nil
+	}
+	if len(listed) != 1 {
+		return nil, fmt.Errorf("%s takes a single priority", op)
+	}
+
+	// P0 is the highest priority, so <=P2 means P0, P1 and P2.
+	var out []Priority
+	for p := range ValidPriorities {
+		switch {
+		case op == "<" && p < listed[0],
+			op == "<=" && p <= listed[0],
+			op == ">" && p > listed[0],
+			op == ">=" && p >= listed[0]:
+			out = append(out, p)
+		}
+	}
+	slices.Sort(out)
+	return out, nil
+}
+
+// parseTimeBound parses "<7d" (within the last 7 days), ">7d" (longer ago)
+// or a comparison with a date, setting after or before. A date stands for the
+// whole UTC day: <=2026-01-02 includes that day and >2026-01-02 starts at the
+// next one.
+func parseTimeBound(value string, now time.Time, after, before *time.Time) error {
+	op := value[:1]
+	if op != "<" && op != ">" {
+		return fmt.Errorf("expected <age, >age or a comparison with a date, got %q", value)
+	}
+	value = value[1:]
+	orEqual := strings.HasPrefix(value, "=")
+	value = strings.TrimPrefix(value, "=")
+
+	if day, err := time.Parse("2006-01-02", value); err == nil {
+		next := day.AddDate(0, 0, 1)
+		// The filter bounds are exclusive, so >= and > sit just before the
+		// first instant they admit.
+		switch {
+		case op == "<" && orEqual:
+			*before = next
+		case op == "<":
+			*before = day
+		case orEqual:
+			*after = day.Add(-time.Nanosecond)
+		default:
+			*after = next.Add(-time.Nanosecond)
+		}
+		return nil
+	}
+
+	age, err := parseAge(value)
+	if err != nil {
+		return err
+	}
+	// A smaller age is more recent: <7d is after now-7d.
+	if op == "<" {
+		*after = now.Add(-age)
+	} else {
+		*before = now.Add(-age)
+	}
+	return nil
+}
+
+func parseAge(value string) (time.Duration, error) {
+	if len(value) < 2 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d, 2w)", value)
+	}
+	n, err := strconv.Atoi(value[:len(value)-1])
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d, 2w)", value)
+	}
+	switch value[len(value)-1] {
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	default:
+		return 0, fmt.Errorf("invalid age %q (use e.g. 12h, 7d, 2w)", value)
+	}
+}
+
+// ExpandSaved replaces each @name term in s with the saved query of that
+// name. Saved queries may refer to other saved queries. An @ inside a quoted
+// phrase is text, not a reference.
+func ExpandSaved(s string, saved map[string]string) (string, error) {
+	return expandSaved(s, saved, nil)
+}
+
+func expandSaved(s string, saved map[string]string, seen []string) (string, error) {
+	fields := rawTerms(s)
+	for i, f := range fields {
+		name, ok := strings.CutPrefix(f, "@")
+		if !ok {
+			continue
+		}
+		if slices.Contains(seen, name) {
+			return "", fmt.Errorf("saved query @%s refers to itself", name)
+		}
+		q, ok := saved[name]
+		if !ok {
+			return "", fmt.Errorf("no saved query named %q", name)
+		}
+		expanded, err := expandSaved(q, saved, append(seen, name))
+		if err != nil {
+			return "", err
+		}
+		fields[i] = expanded
+	}
+	return strings.Join(fields, " "), nil
+}
+
+// rawTerms splits s like splitQuery but keeps each term as written, quotes
+// included, so the result can be joined and parsed again.
+func rawTerms(s string) []string {
+	var terms []string
+	var cur strings.Builder
+	inQuote := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuote = !inQuote
+			cur.WriteRune(r)
+		case unicode.IsSpace(r) && !inQuote:
+			if cur.Len() > 0 {
+				terms = append(terms, cur.String())
+				cur.Reset()
+			}
+		default:
+			cur.WriteRune(r)
+		}
+	}
+	if cur.Len() > 0 {
+		terms = append(terms, cur.String())
+	}
+	return terms
+}
+
+// SortTickets orders tickets by the query's sort keys, keeping the existing
+// order for ties. It does nothing when the query has no sort keys.
+func (q Query) SortTickets(tickets []*Ticket) {
+	if len(q.Sort) == 0 {
+		return
+	}
+	sort.SliceStable(tickets, func(i, j int) bool {
+		for _, key := range q.Sort {
+			c := compareField(tickets[i], tickets[j], key.Field)
+			if c == 0 {
+				continue
+			}
+			if key.Desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+}
+
+func compareField(a, b *Ticket, field string) int {
+	switch field {
+	case "priority":
+		return strings.Compare(string(a.Priority), string(b.Priority))
+	case "status":
+		return slices.Index(statusOrder, a.Status) - slices.Index(statusOrder, b.Status)
+	case "updated":
+		return a.Updated.Compare(b.Updated)
+	case "created":
+		return a.Created.Compare(b.Created)
+	case "title":
+		return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+	case "project":
+		return strings.Compare(a.Project, b.Project)
+	default:
+		return strings.Compare(a.ID, b.ID)
+	}
+}
+
+// statusOrder is the workflow order used when sorting by status.
+var statusOrder = []Status{
+	StatusBacklog, StatusOpen, StatusInProgress, StatusReview, StatusHumanReview,
+	StatusRework, StatusBlocked, StatusDone, StatusCancelled,
+}
@@ -0,0 +1,201 @@
+package ticket
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseQuery(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	q, err := ParseQuery(`status:open,in_progress priority:<=P2 tag:api tag:auth assignee:none updated:<7d "rate limit" retry sort:priority,-updated`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := q.Filter
+	if !slices.Equal(f.Statuses, []Status{StatusOpen, StatusInProgress}) {
+		t.Errorf("statuses = %v", f.Statuses)
+	}
+	if !slices.Equal(f.Priorities, []Priority{PriorityP0, PriorityP1, PriorityP2}) {
+		t.Errorf("priorities = %v", f.Priorities)
+	}
+	if !slices.Equal(f.Tags, []string{"api", "auth"}) || !f.Unassigned {
+		t.Errorf("tags = %v, unassigned = %v", f.Tags, f.Unassigned)
+	}
+	if !f.UpdatedAfter.Equal(now.Add(-7 * 24 * time.Hour)) {
+		t.Errorf("updated after = %v", f.UpdatedAfter)
+	}
+	if !slices.Equal(f.Text, []string{"rate limit", "retry"}) {
+		t.Errorf("text = %q", f.Text)
+	}
+	if want := []SortKey{{Field: "priority"}, {Field: "updated", Desc: true}}; !slices.Equal(q.Sort, want) {
+		t.Errorf("sort = %v", q.Sort)
+	}
+}
+
+func TestParseQuery_Bounds(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	q, err := ParseQuery("priority:>P3 updated:>2w created:<2026-01-02", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(q.Filter.Priorities, []Priority{PriorityP4, PriorityP5}) {
+		t.Errorf("priorities = %v", q.Filter.Priorities)
+	}
+	if !q.Filter.UpdatedBefore.Equal(now.Add(-14 * 24 * time.Hour)) {
+		t.Errorf("updated before = %v", q.Filter.UpdatedBefore)
+	}
+	if !q.Filter.CreatedBefore.Equal(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("created before = %v", q.Filter.CreatedBefore)
+	}
+}
+
+func TestParseQuery_DateBounds(t *testing.T) {
+	day := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+	tk := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	for _, tc := range []struct {
+		created time.Time
+		query   string
+		want    bool
+	}{
+		{day.Add(15 * time.Hour), "created:<=2026-01-02", true},
+		{day.Add(15 * time.Hour), "created:<2026-01-02", false},
+		{day.AddDate(0, 0, 1), "created:<=2026-01-02", false},
+		{day.Add(15 * time.Hour), "created:>2026-01-02", false},
+		{day.AddDate(0, 0, 1), "created:>2026-01-02", true},
+		{day, "created:>=2026-01-02", true},
+		{day.Add(-time.Second), "created:>=2026-01-02", false},
+	} {
+		q, err := ParseQuery(tc.query, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		tk.Created = tc.created
+		if got := q.Filter.Match(tk); got != tc.want {
+			t.Errorf("%s matching created %v = %v, want %v", tc.query, tc.created, got, tc.want)
+		}
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	for _, s := range []string{
+		"status:nope",
+		"priority:<=P9",
+		"priority:<P1,P2",
+		"updated:7d",
+		"updated:<7y",
+		"sort:colour",
+		"colour:red",
+		"tag:",
+		`"unterminated`,
+	} {
+		if _, err := ParseQuery(s, time.Now()); err == nil {
+			t.Errorf("ParseQuery(%q): expected an error", s)
+		}
+	}
+}
+
+func TestListFilterMatch(t *testing.T) {
+	now := time.Now().UTC()
+	tk := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	tk.Title = "Add rate limit to API"
+	tk.Priority = PriorityP1
+	tk.Tags = []string{"api", "Backend"}
+	tk.Body = "## Description\n\nUse a token bucket."
+
+	q, err := ParseQuery(`status:open priority:<=P2 tag:api tag:backend assignee:none updated:<1d "rate limit" bucket`, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !q.Filter.Match(tk) {
+		t.Error("expected a match")
+	}
+
+	for _, s := range []string{"status:review", "priority:P0", "tag:web", "assignee:run-1", "updated:>1d", "ratelimit", "parent:st_bbbbbb"} {
+		q, err := ParseQuery(s, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if q.Filter.Match(tk) {
+			t.Errorf("%q should not match", s)
+		}
+	}
+}
+
+func TestStoreListWithQueryFilter(t *testing.T) {
+	store := testStore(t)
+	a := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	a.Tags = []string{"api"}
+	b := testTicket("st_bbbbbb", "proj", StatusOpen, nil)
+	for _, tk := range []*Ticket{a, b} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	q, err := ParseQuery("tag:api", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := store.ListMeta(q.Filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].ID != "st_aaaaaa" {
+		t.Errorf("expected only st_aaaaaa, got %d tickets", len(got))
+	}
+}
+
+func TestQuerySortTickets(t *testing.T) {
+	base := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	a := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	a.Priority, a.Updated = PriorityP2, base
+	b := testTicket("st_bbbbbb", "proj", StatusOpen, nil)
+	b.Priority, b.Updated = PriorityP1, base
+	c := testTicket("st_cccccc", "proj", StatusOpen, nil)
+	c.Priority, c.Updated = PriorityP2, base.Add(time.Hour)
+
+	q, err := ParseQuery("sort:priority,-updated", time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tickets := []*Ticket{a, b, c}
+	q.SortTickets(tickets)
+	var ids []string
+	for _, tk := range tickets {
+		ids = append(ids, tk.ID)
+	}
+	if got := strings.Join(ids, " "); got != "st_bbbbbb st_cccccc st_aaaaaa" {
+		t.Errorf("order = %s", got)
+	}
+}
+
+func TestExpandSaved(t *testing.T) {
+	saved := map[string]string{
+		"mine":   "assignee:run-1",
+		"urgent": "priority:<=P1 @mine",
+		"loop":   "@loop",
+	}
+	got, err := ExpandSaved("@urgent tag:api", saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "priority:<=P1 assignee:run-1 tag:api" {
+		t.Errorf("expanded = %q", got)
+	}
+	if _, err := ExpandSaved("@loop", saved); err == nil {
+		t.Error("expected an error for a self-referencing query")
+	}
+	got, err = ExpandSaved(`"ping @bob" @mine`, saved)
+	if err != nil {
+		t.Fatalf("quoted @ should be text: %v", err)
+	}
+	if got != `"ping @bob" assignee:run-1` {
+		t.Errorf("expanded = %q", got)
+	}
+	if _, err := ExpandSaved("@missing", saved); err == nil {
+		t.Error("expected an error for an unknown saved query")
+	}
+}
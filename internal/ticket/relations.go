@@ -0,0 +1,146 @@
+package ticket
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// RelationType is the kind of a typed link from one ticket to another.
+type RelationType string
+
+const (
+	RelationBlocks     RelationType = "blocks"
+	RelationRelatesTo  RelationType = "relates-to"
+	RelationDuplicates RelationType = "duplicates"
+	RelationFollowUpOf RelationType = "follow-up-of"
+)
+
+// relationInverses names each relation as seen from the linked ticket.
+var relationInverses = map[RelationType]string{
+	RelationBlocks:     "blocked-by",
+	RelationRelatesTo:  "relates-to",
+	RelationDuplicates: "duplicated-by",
+	RelationFollowUpOf: "followed-up-by",
+}
+
+// RelationNames lists the accepted relation names, forward then inverse.
+func RelationNames() []string {
+	names := []string{
+		string(RelationBlocks), string(RelationRelatesTo),
+		string(RelationDuplicates), string(RelationFollowUpOf),
+	}
+	for _, n := range names {
+		if inv := relationInverses[RelationType(n)]; inv != n {
+			names = append(names, inv)
+		}
+	}
+	return names
+}
+
+// ParseRelation resolves a relation name. Inverse names such as "blocked-by"
+// return the forward type with inverse set, meaning the relation is stored on
+// the other ticket.
+func ParseRelation(name string) (RelationType, bool, error) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if _, ok := relationInverses[RelationType(name)]; ok {
+		return RelationType(name), false, nil
+	}
+	for typ, inv := range relationInverses {
+		if inv == name {
+			return typ, true, nil
+		}
+	}
+	return "", false, fmt.Errorf("unknown relation %q (use %s)", name, strings.Join(RelationNames(), ", "))
+}
+
+// AddRelation records a relation from t to id. It reports false if the
+// relation already exists.
+func (t *Ticket) AddRelation(typ RelationType, id string) bool {
+	if slices.Contains(t.Relations[typ], id) {
+		return false
+	}
+	if t.Relations == nil {
+		t.Relations = map[RelationType][]string{}
+	}
+	t.Relations[typ] = append(t.Relations[typ], id)
+	return true
+}
+
+// RemoveRelation deletes a relation from t to id. It reports false if there
+// was none.
+func (t *Ticket) RemoveRelation(typ RelationType, id string) bool {
+	ids := t.Relations[typ]
+	i := slices.Index(ids, id)
+	if i < 0 {
+		return false
+	}
+	ids = slices.Delete(ids, i, i+1)
+	if len(ids) == 0 {
+		delete(t.Relations, typ)
+	} else {
+		t.Relations[typ] = ids
+	}
+	if len(t.Relations) == 0 {
+		t.Relations = nil
+	}
+	return true
+}
+
+// Link is a relation as seen from one ticket. Inverse links are stored on
+// the other ticket.
+type Link struct {
+	Type    RelationType
+	Ticket  string
+	Inverse bool
+}
+
+// Label names the link from the viewing ticket's side, e.g. "blocked-by".
+func (l Link) Label() string {
+	if l.Inverse {
+		return relationInverses[l.Type]
+	}
+	return string(l.Type)
+}
+
+// Links resolves tk's relations in both directions: those stored on tk and
+// those other tickets in all store pointing at it. A symmetric relation
+// recorded on both sides is listed once. Links are ordered by label, then
+// ticket ID.
+func Links(all []*Ticket, tk *Ticket) []Link {
+	seen := map[string]bool{}
+	var links []Link
+	add := func(l Link) {
+		key := l.Label() + "\x00" + l.Ticket
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		links = append(links, l)
+	}
+
+	for typ, ids := range tk.Relations {
+		for _, id := range ids {
+			add(Link{Type: typ, Ticket: id})
+		}
+	}
+	for _, other := range all {
+		if other.ID == tk.ID {
+			continue
+		}
+		for typ, ids := range other.Relations {
+			if slices.Contains(ids, tk.ID) {
+				add(Link{Type: typ, Ticket: other.ID, Inverse: true})
+			}
+		}
+	}
+
+	sort.Slice(links, func(i, j int) bool {
+		if links[i].Label() != links[j].Label() {
+			return links[i].Label() < links[j].Label()
+		}
+		return links[i].Ticket < links[j].Ticket
+	})
+	return links
+}
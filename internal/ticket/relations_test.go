@@ -0,0 +1,91 @@
+package ticket
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRelation(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     RelationType
+		inverse bool
+	}{
+		{"blocks", RelationBlocks, false},
+		{"Blocked-By", RelationBlocks, true},
+		{"relates-to", RelationRelatesTo, false},
+		{"duplicated-by", RelationDuplicates, true},
+		{"followed-up-by", RelationFollowUpOf, true},
+	}
+	for _, tt := range tests {
+		typ, inverse, err := ParseRelation(tt.name)
+		if err != nil || typ != tt.typ || inverse != tt.inverse {
+			t.Errorf("ParseRelation(%q) = %s, %v, %v", tt.name, typ, inverse, err)
+		}
+	}
+	if _, _, err := ParseRelation("causes"); err == nil || !strings.Contains(err.Error(), "blocked-by") {
+		t.Errorf("expected an error listing the relation names, got %v", err)
+	}
+}
+
+func TestAddRemoveRelation(t *testing.T) {
+	tk := &Ticket{ID: "st_a"}
+	if !tk.AddRelation(RelationBlocks, "st_b") || tk.AddRelation(RelationBlocks, "st_b") {
+		t.Fatal("AddRelation should add once")
+	}
+	if tk.RemoveRelation(RelationRelatesTo, "st_b") {
+		t.Error("removing an absent relation should report false")
+	}
+	if !tk.RemoveRelation(RelationBlocks, "st_b") || tk.Relations != nil {
+		t.Errorf("expected relations to be emptied, got %v", tk.Relations)
+	}
+}
+
+func TestRelationsRoundTrip(t *testing.T) {
+	tk := testTicket("st_rel001", "proj", StatusOpen, nil)
+	tk.AddRelation(RelationFollowUpOf, "st_src001")
+	data, err := Marshal(tk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "relations:\n    follow-up-of:\n        - st_src001\n") {
+		t.Errorf("unexpected frontmatter:\n%s", data)
+	}
+	got, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := got.Relations[RelationFollowUpOf]; len(ids) != 1 || ids[0] != "st_src001" {
+		t.Errorf("relations = %v", got.Relations)
+	}
+
+	plain, _ := Marshal(testTicket("st_rel002", "proj", StatusOpen, nil))
+	if strings.Contains(string(plain), "relations") {
+		t.Error("tickets without relations should omit the key")
+	}
+}
+
+func TestLinks(t *testing.T) {
+	a := &Ticket{ID: "st_a"}
+	a.AddRelation(RelationBlocks, "st_b")
+	a.AddRelation(RelationRelatesTo, "st_c")
+	b := &Ticket{ID: "st_b"}
+	c := &Ticket{ID: "st_c"}
+	c.AddRelation(RelationRelatesTo, "st_a")
+	d := &Ticket{ID: "st_d"}
+	d.AddRelation(RelationFollowUpOf, "st_a")
+	all := []*Ticket{a, b, c, d}
+
+	var got []string
+	for _, l := range Links(all, a) {
+		got = append(got, l.Label()+" "+l.Ticket)
+	}
+	want := "blocks st_b,followed-up-by st_d,relates-to st_c"
+	if strings.Join(got, ",") != want {
+		t.Errorf("Links(a) = %v, want %s", got, want)
+	}
+
+	if links := Links(all, b); len(links) != 1 || links[0].Label() != "blocked-by" || links[0].Ticket != "st_a" {
+		t.Errorf("Links(b) = %+v", links)
+	}
+}
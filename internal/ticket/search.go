@@ -0,0 +1,291 @@
+package ticket
+
+import (
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// SearchResult is a ticket matching a full-text search, with the section
+// that matched best and a snippet of it.
+type SearchResult struct {
+	Ticket  *Ticket
+	Score   float64
+	Section string
+	Snippet string
+	// Terms are the searched terms, for highlighting the snippet.
+	Terms []string
+}
+
+// titleBoost weights matches in the title over matches in the body.
+const titleBoost = 3.0
+
+// snippetRadius is roughly how many characters of context a snippet keeps
+// on either side of the first match.
+const snippetRadius = 80
+
+// Search finds the tickets containing every text term of q (quoted phrases
+// must appear as written, and a final bare word also matches as a prefix)
+// that also pass the rest of its filter, ranked by
+// term frequency and rarity with title matches weighted up. Without text
+// terms it lists the filtered tickets, most recently updated first. The
+// index is refreshed from disk first. limit <= 0 returns all matches.
+func (s *Store) Search(q Query, limit int) ([]SearchResult, error) {
+	var terms, phrases []string
+	for _, text := range q.Filter.Text {
+		tokens := tokenize(text)
+		terms = append(terms, tokens...)
+		if len(tokens) > 1 {
+			phrases = append(phrases, strings.Join(strings.Fields(strings.ToLower(text)), " "))
+		}
+	}
+	filter := q.Filter
+	filter.Text = nil
+
+	if len(terms) == 0 {
+		tickets, err := s.ListMeta(filter)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(tickets, func(i, j int) bool { return tickets[i].Updated.After(tickets[j].Updated) })
+		q.SortTickets(tickets)
+		results := make([]SearchResult, 0, len(tickets))
+		for _, t := range tickets {
+			results = append(results, SearchResult{Ticket: t})
+		}
+		return truncateResults(results, limit), nil
+	}
+
+	var idx *searchIndex
+	err := s.withIndex(func(i *searchIndex) (bool, error) {
+		idx = i
+		return s.refreshIndex(i)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A final bare word also matches as a prefix, for search-as-you-type.
+	prefixLast := len(tokenize(q.Filter.Text[len(q.Filter.Text)-1])) == 1
+	expansions := make([][]string, len(terms))
+	for i, term := range terms {
+		expansions[i] = []string{term}
+		if i == len(terms)-1 && prefixLast {
+			for indexed := range idx.Postings {
+				if indexed != term && strings.HasPrefix(indexed, term) {
+					expansions[i] = append(expansions[i], indexed)
+				}
+			}
+		}
+	}
+
+	// Score each section of each ticket holding every term.
+	type hit struct {
+		sections map[int]float64
+		terms    map[int]bool
+	}
+	hits := map[string]*hit{}
+	n := float64(len(idx.Docs))
+	var matched []string
+	for i, variants := range expansions {
+		for _, term := range variants {
+			postings := idx.Postings[term]
+			if len(postings) > 0 {
+				matched = append(matched, term)
+			}
+			docs := map[string]bool{}
+			for _, p := range postings {
+				docs[p.Ticket] = true
+			}
+			idf := math.Log(1 + n/float64(max(len(docs), 1)))
+			for _, p := range postings {
+				h := hits[p.Ticket]
+				if h == nil {
+					h = &hit{sections: map[int]float64{}, terms: map[int]bool{}}
+					hits[p.Ticket] = h
+				}
+				// Saturating term frequency: repeats count, with diminishing returns.
+				w := idf * float64(p.Count) * 2.2 / (float64(p.Count) + 1.2)
+				if p.Section == 0 {
+					w *= titleBoost
+				}
+				h.sections[p.Section] += w
+				h.terms[i] = true
+			}
+		}
+	}
+
+	var results []SearchResult
+	for id, h := range hits {
+		if len(h.terms) < len(terms) {
+			continue
+		}
+		doc := idx.Docs[id]
+		data, err := os.ReadFile(doc.Path)
+		if err != nil {
+			continue
+		}
+		t, err := Parse(data)
+		if err != nil || !filter.Match(t) {
+			continue
+		}
+		sections := searchSections(t)
+		if !containsPhrases(t.ID, sections, phrases) {
+			continue
+		}
+
+		best, score := 0, 0.0
+		for i, w := range h.sections {
+			score += w
+			if w > h.sections[best] || (w == h.sections[best] && i < best) {
+				best = i
+			}
+		}
+		r := SearchResult{Ticket: t, Score: score, Terms: matched}
+		if best < len(sections) {
+			r.Section = sections[best].Heading
+			r.Snippet = snippet(sections[best].Text, matched)
+		}
+		results = append(results, r)
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Ticket.Updated.After(results[j].Ticket.Updated)
+	})
+	if len(q.Sort) > 0 {
+		tickets := make([]*Ticket, len(results))
+		byID := make(map[string]SearchResult, len(results))
+		for i, r := range results {
+			tickets[i] = r.Ticket
+			byID[r.Ticket.ID] = r
+		}
+		q.SortTickets(tickets)
+		for i, t := range tickets {
+			results[i] = byID[t.ID]
+		}
+	}
+	return truncateResults(results, limit), nil
+}
+
+func truncateResults(results []SearchResult, limit int) []SearchResult {
+	if limit > 0 && len(results) > limit {
+		return results[:limit]
+	}
+	return results
+}
+
+// containsPhrases reports whether every phrase appears in the ticket ID or
+// some section, ignoring case and runs of whitespace.
+func containsPhrases(id string, sections []searchSection, phrases []string) bool {
+	for _, phrase := range phrases {
+		found := strings.Contains(strings.ToLower(id), phrase)
+		for _, sec := range sections {
+			if strings.Contains(strings.Join(strings.Fields(strings.ToLower(sec.Text)), " "), phrase) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// snippet returns a single-line excerpt of text around the first occurrence
+// of any of terms, cut at word boundaries and marked with … where trimmed.
+func snippet(text string, terms []string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	runes := []rune(text)
+	lower := []rune(strings.ToLower(text))
+
+	at := -1
+	for _, term := range terms {
+		if i := indexWord(lower, []rune(term)); i >= 0 && (at < 0 || i < at) {
+			at = i
+		}
+	}
+	if at < 0 {
+		at = 0
+	}
+
+	start := max(at-snippetRadius, 0)
+	end := min(at+2*snippetRadius, len(runes))
+	for start > 0 && start < at && runes[start-1] != ' ' {
+		start++
+	}
+	for end < len(runes) && end > at && runes[end-1] != ' ' {
+		end--
+	}
+	out := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		out = "…" + out
+	}
+	if end < len(runes) {
+		out += "…"
+	}
+	return out
+}
+
+// indexWord returns the rune offset of the first occurrence of word in s
+// that starts at a word boundary, or -1.
+func indexWord(s, word []rune) int {
+	for i := 0; i+len(word) <= len(s); i++ {
+		if i > 0 && (unicode.IsLetter(s[i-1]) || unicode.IsDigit(s[i-1])) {
+			continue
+		}
+		if string(s[i:i+len(word)]) == string(word) {
+			return i
+		}
+	}
+	return -1
+}
+
+// SnippetPart is a run of snippet text, either matching a search term or not.
+type SnippetPart struct {
+	Text  string
+	Match bool
+}
+
+// SnippetParts splits the snippet into runs, marking whole words that match
+// a searched term, for highlighting.
+func (r SearchResult) SnippetParts() []SnippetPart {
+	match := map[string]bool{}
+	for _, t := range r.Terms {
+		match[t] = true
+	}
+
+	var parts []SnippetPart
+	add := func(text string, m bool) {
+		if text == "" {
+			return
+		}
+		if n := len(parts); n > 0 && parts[n-1].Match == m {
+			parts[n-1].Text += text
+			return
+		}
+		parts = append(parts, SnippetPart{Text: text, Match: m})
+	}
+
+	var word strings.Builder
+	flush := func() {
+		w := word.String()
+		add(w, match[strings.ToLower(w)])
+		word.Reset()
+	}
+	for _, r := range r.Snippet {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			word.WriteRune(r)
+			continue
+		}
+		flush()
+		add(string(r), false)
+	}
+	flush()
+	return parts
+}
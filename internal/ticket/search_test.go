@@ -0,0 +1,251 @@
+package ticket
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func searchStore(t *testing.T) *Store {
+	t.Helper()
+	store := testStore(t)
+	now := time.Now().UTC()
+
+	a := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	a.Title = "Rate limit the public API"
+	AppendSection(a, "Description", "human", "", "Requests are unbounded today.", nil, now)
+	AppendSection(a, "Note", "agent", "run-1", "Went with a token bucket per API key; refill every second.", nil, now)
+
+	b := testTicket("st_bbbbbb", "proj", StatusInProgress, nil)
+	b.Title = "Token refresh for the CLI"
+	AppendSection(b, "Description", "human", "", "Refresh the auth token before it expires.", nil, now)
+
+	c := testTicket("st_cccccc", "other", StatusOpen, nil)
+	c.Title = "Bucket lifecycle rules"
+	AppendSection(c, "Description", "human", "", "Expire old objects in the storage bucket.", nil, now)
+
+	for _, tk := range []*Ticket{a, b, c} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return store
+}
+
+func search(t *testing.T, store *Store, query string) []SearchResult {
+	t.Helper()
+	q, err := ParseQuery(query, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	results, err := store.Search(q, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return results
+}
+
+func resultIDs(results []SearchResult) string {
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Ticket.ID)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestSearch_SectionAttributionAndSnippet(t *testing.T) {
+	store := searchStore(t)
+
+	results := search(t, store, "token bucket")
+	if got := resultIDs(results); got != "st_aaaaaa" {
+		t.Fatalf("results = %q, want only st_aaaaaa", got)
+	}
+	r := results[0]
+	if r.Section != "Note" {
+		t.Errorf("section = %q, want Note", r.Section)
+	}
+	if !strings.Contains(r.Snippet, "token bucket per API key") {
+		t.Errorf("snippet = %q", r.Snippet)
+	}
+
+	var matched []string
+	for _, p := range r.SnippetParts() {
+		if p.Match {
+			matched = append(matched, p.Text)
+		}
+	}
+	if strings.Join(matched, ",") != "token,bucket" {
+		t.Errorf("highlighted = %v", matched)
+	}
+}
+
+func TestSearch_RanksTitleMatchesFirst(t *testing.T) {
+	store := searchStore(t)
+
+	results := search(t, store, "token")
+	if got := resultIDs(results); got != "st_bbbbbb st_aaaaaa" {
+		t.Errorf("results = %q, want the title match first", got)
+	}
+	if results[0].Section != "Title" {
+		t.Errorf("section = %q, want Title", results[0].Section)
+	}
+}
+
+func TestSearch_PhraseAndFilter(t *testing.T) {
+	store := searchStore(t)
+
+	if got := resultIDs(search(t, store, `"bucket per"`)); got != "st_aaaaaa" {
+		t.Errorf("phrase results = %q", got)
+	}
+	if got := resultIDs(search(t, store, `"per bucket"`)); got != "" {
+		t.Errorf("phrase out of order should not match, got %q", got)
+	}
+	if got := resultIDs(search(t, store, "bucket project:other")); got != "st_cccccc" {
+		t.Errorf("filtered results = %q", got)
+	}
+}
+
+func TestSearch_IncrementalAndRefresh(t *testing.T) {
+	store := searchStore(t)
+
+	// Updated on Save.
+	tk, err := store.Get("st_bbbbbb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	AppendSection(tk, "Note", "agent", "run-2", "Found a leaky goroutine.", nil, time.Now().UTC())
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+	if got := resultIDs(search(t, store, "goroutine")); got != "st_bbbbbb" {
+		t.Errorf("results after save = %q", got)
+	}
+
+	// Hand edits and deletions are picked up when searching.
+	path := loadIndex(t, store).Docs["st_cccccc"].Path
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, append(data, []byte("\nEdited by hand: flamingo\n")...), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	later := time.Now().Add(time.Second)
+	if err := os.Chtimes(path, later, later); err != nil {
+		t.Fatal(err)
+	}
+	if got := resultIDs(search(t, store, "flamingo")); got != "st_cccccc" {
+		t.Errorf("results after hand edit = %q", got)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if got := resultIDs(search(t, store, "bucket")); got != "st_aaaaaa" {
+		t.Errorf("results after delete = %q", got)
+	}
+	if _, ok := loadIndex(t, store).Docs["st_cccccc"]; ok {
+		t.Error("deleted ticket should be dropped from the index")
+	}
+}
+
+func TestSearch_SavesLeaveIndexFileAlone(t *testing.T) {
+	store := searchStore(t)
+	search(t, store, "bucket")
+	indexPath := filepath.Join(store.projectsDir, indexDir, "search.json")
+	before, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, id := range []string{"st_aaaaaa", "st_bbbbbb", "st_cccccc"} {
+		tk, err := store.Get(id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		AppendSection(tk, "Note", "human", "", "Checked during the pelican audit.", nil, time.Now().UTC())
+		if err := store.Save(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	after, err := os.ReadFile(indexPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(after) != string(before) {
+		t.Error("saves rewrote search.json")
+	}
+	pending, _ := filepath.Glob(filepath.Join(store.projectsDir, indexDir, pendingDir, "*.json"))
+	if len(pending) != 3 {
+		t.Errorf("pending entries = %v, want one per saved ticket", pending)
+	}
+
+	if got := resultIDs(search(t, store, "pelican")); len(strings.Fields(got)) != 3 {
+		t.Errorf("results = %q, want all three saved tickets", got)
+	}
+	if pending, _ := filepath.Glob(filepath.Join(store.projectsDir, indexDir, pendingDir, "*")); len(pending) != 0 {
+		t.Errorf("pending entries left after search: %v", pending)
+	}
+	if docs := loadIndex(t, store).Docs; len(docs) != 3 {
+		t.Errorf("index docs = %d, want 3", len(docs))
+	}
+}
+
+func TestSearch_PrefixAndID(t *testing.T) {
+	store := searchStore(t)
+
+	if got := resultIDs(search(t, store, "token buck")); got != "st_aaaaaa" {
+		t.Errorf("prefix results = %q", got)
+	}
+	if got := resultIDs(search(t, store, `"token buck"`)); got != "" {
+		t.Errorf("a phrase should not match by prefix, got %q", got)
+	}
+	if got := resultIDs(search(t, store, "st_cccccc")); got != "st_cccccc" {
+		t.Errorf("ID results = %q", got)
+	}
+}
+
+func TestSearch_NoTermsListsByUpdated(t *testing.T) {
+	store := searchStore(t)
+
+	results := search(t, store, "project:proj")
+	if len(results) != 2 {
+		t.Errorf("expected 2 results, got %q", resultIDs(results))
+	}
+}
+
+func TestSearchSections(t *testing.T) {
+	tk := testTicket("st_aaaaaa", "proj", StatusOpen, nil)
+	tk.Title = "Title here"
+	tk.Body = "Lead text.\n\n## Note — 2026-03-01T10:00:00Z\n**actor:** agent (session: run-1)\n\nA note.\n"
+
+	sections := searchSections(tk)
+	if len(sections) != 3 {
+		t.Fatalf("sections = %+v", sections)
+	}
+	if sections[1].Heading != "Body" || sections[1].Text != "Lead text." {
+		t.Errorf("body section = %+v", sections[1])
+	}
+	if sections[2].Heading != "Note" || sections[2].Text != "A note." {
+		t.Errorf("note section = %+v", sections[2])
+	}
+}
+
+// loadIndex reads the store's on-disk index.
+func loadIndex(t *testing.T, s *Store) *searchIndex {
+	t.Helper()
+	var idx *searchIndex
+	if err := s.withIndex(func(i *searchIndex) (bool, error) {
+		idx = i
+		return false, nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(s.projectsDir, indexDir, "search.json")); err != nil {
+		t.Fatal(err)
+	}
+	return idx
+}
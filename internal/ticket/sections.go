@@ -0,0 +1,104 @@
+package ticket
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Section is one entry of a ticket's append-only body, as written by
+// AppendSection.
+type Section struct {
+	Heading string
+	TS      time.Time
+	Actor   string
+	Session string
+	Fields  map[string]string
+	Content string
+}
+
+var (
+	sectionHeadingRe = regexp.MustCompile(`^## (.+) — (\S+)$`)
+	sectionFieldRe   = regexp.MustCompile(`^\*\*([^*]+):\*\* (.*)$`)
+	sectionActorRe   = regexp.MustCompile(`^(.*) \(session: (.*)\)$`)
+)
+
+// Sections parses a ticket body into its sections, in order. Text before the
+// first section heading is ignored.
+func Sections(body string) []Section {
+	var sections []Section
+	var cur *Section
+	var content []string
+	inFields := false
+
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		cur.Content = strings.TrimSpace(strings.Join(content, "\n"))
+		sections = append(sections, *cur)
+	}
+
+	for _, line := range strings.Split(body, "\n") {
+		if m := sectionHeadingRe.FindStringSubmatch(line); m != nil {
+			ts, err := time.Parse(time.RFC3339, m[2])
+			if err == nil {
+				flush()
+				cur = &Section{Heading: m[1], TS: ts, Fields: map[string]string{}}
+				content = nil
+				inFields = true
+				continue
+			}
+		}
+		if cur == nil {
+			continue
+		}
+		if inFields {
+			if m := sectionFieldRe.FindStringSubmatch(line); m != nil {
+				if m[1] == "actor" {
+					cur.Actor = m[2]
+					if am := sectionActorRe.FindStringSubmatch(m[2]); am != nil {
+						cur.Actor, cur.Session = am[1], am[2]
+					}
+				} else {
+					cur.Fields[m[1]] = m[2]
+				}
+				continue
+			}
+			inFields = false
+		}
+		content = append(content, line)
+	}
+	flush()
+	return sections
+}
+
+// LastSection returns the latest section with the given heading.
+func LastSection(sections []Section, heading string) (Section, bool) {
+	for i := len(sections) - 1; i >= 0; i-- {
+		if sections[i].Heading == heading {
+			return sections[i], true
+		}
+	}
+	return Section{}, false
+}
+
+// ReworkFindings returns the reviewer's findings for the latest REWORK: the
+// Rework section's own content, or else the note written just before it.
+func ReworkFindings(sections []Section) (Section, bool) {
+	for i := len(sections) - 1; i >= 0; i-- {
+		if sections[i].Heading != "Rework" {
+			continue
+		}
+		if sections[i].Content != "" {
+			return sections[i], true
+		}
+		for j := i - 1; j >= 0; j-- {
+			if sections[j].Heading == "Note" && sections[j].Content != "" {
+				return sections[j], true
+			}
+		}
+		return Section{}, false
+	}
+	return Section{}, false
+}
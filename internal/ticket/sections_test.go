@@ -0,0 +1,65 @@
+package ticket
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSectionsRoundTrip(t *testing.T) {
+	ts := time.Date(2026, 2, 25, 10, 0, 0, 0, time.UTC)
+	tk := &Ticket{}
+	AppendSection(tk, "Created", "human", "", "Add rate limiting.\n\n## Acceptance Criteria\n- 429 on burst", nil, ts)
+	AppendSection(tk, "Note", "agent", "run-1", "Implemented the limiter.", nil, ts.Add(time.Minute))
+	AppendSection(tk, "Rework", "reviewer", "run-2", "", map[string]string{"reviewed-by": "run-2"}, ts.Add(2*time.Minute))
+
+	sections := Sections(tk.Body)
+	if len(sections) != 3 {
+		t.Fatalf("got %d sections, want 3: %+v", len(sections), sections)
+	}
+
+	created := sections[0]
+	if created.Heading != "Created" || !created.TS.Equal(ts) || created.Actor != "human" || created.Session != "" {
+		t.Errorf("created = %+v", created)
+	}
+	if created.Content != "Add rate limiting.\n\n## Acceptance Criteria\n- 429 on burst" {
+		t.Errorf("created content = %q", created.Content)
+	}
+
+	note := sections[1]
+	if note.Actor != "agent" || note.Session != "run-1" || note.Content != "Implemented the limiter." {
+		t.Errorf("note = %+v", note)
+	}
+
+	rework, ok := LastSection(sections, "Rework")
+	if !ok || rework.Fields["reviewed-by"] != "run-2" || rework.Content != "" {
+		t.Errorf("rework = %+v, ok = %v", rework, ok)
+	}
+	if _, ok := LastSection(sections, "Done"); ok {
+		t.Error("LastSection found a missing heading")
+	}
+}
+
+func TestSectionsIgnoresPreamble(t *testing.T) {
+	body := "stray text\n\n## Not a section\n\n## Note — 2026-02-25T10:00:00Z\n**actor:** agent\n\nhello\n"
+	sections := Sections(body)
+	if len(sections) != 1 || sections[0].Content != "hello" {
+		t.Errorf("sections = %+v", sections)
+	}
+}
+
+func TestReworkFindings(t *testing.T) {
+	ts := time.Date(2026, 2, 25, 10, 0, 0, 0, time.UTC)
+	tk := &Ticket{}
+	AppendSection(tk, "Note", "agent", "run-1", "Implemented.", nil, ts)
+	if _, ok := ReworkFindings(Sections(tk.Body)); ok {
+		t.Error("findings without a Rework section")
+	}
+
+	AppendSection(tk, "Note", "reviewer", "run-2", "Missing tests for the error path.", nil, ts.Add(time.Minute))
+	AppendSection(tk, "Rework", "reviewer", "run-2", "", nil, ts.Add(2*time.Minute))
+	AppendSection(tk, "Note", "agent", "run-3", "Added tests.", nil, ts.Add(3*time.Minute))
+	f, ok := ReworkFindings(Sections(tk.Body))
+	if !ok || f.Content != "Missing tests for the error path." {
+		t.Errorf("findings = %+v, ok = %v", f, ok)
+	}
+}
@@ -1,15 +1,23 @@
 package ticket
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/boozedog/smoovtask/internal/finder"
 )
 
+// ErrNotFound is returned when no ticket matches an ID or prefix.
+var ErrNotFound = errors.New("not found")
+
+// ErrAmbiguousID is returned when an ID prefix matches more than one ticket.
+var ErrAmbiguousID = errors.New("ambiguous ticket prefix")
+
 // Store provides file-based ticket storage.
 type Store struct {
 	projectsDir string
@@ -25,6 +33,57 @@ type ListFilter struct {
 	Project  string
 	Status   Status
 	Excludes []Status
+
+	// Statuses and Priorities match any of the listed values.
+	Statuses   []Status
+	Priorities []Priority
+	// Tags must all be present.
+	Tags       []string
+	Assignee   string
+	Unassigned bool
+	Parent     string
+
+	UpdatedAfter  time.Time
+	UpdatedBefore time.Time
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+
+	// Text terms must each appear, case-insensitively, in the ID, title or
+	// body. ListMeta does not read bodies, so only IDs and titles match there.
+	Text []string
+}
+
+// Match reports whether t passes the filter.
+func (f ListFilter) Match(t *Ticket) bool {
+	switch {
+	case f.Project != "" && t.Project != f.Project,
+		f.Status != "" && t.Status != f.Status,
+		excluded(t.Status, f.Excludes),
+		len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status),
+		len(f.Priorities) > 0 && !slices.Contains(f.Priorities, t.Priority),
+		f.Assignee != "" && t.Assignee != f.Assignee,
+		f.Unassigned && t.Assignee != "",
+		f.Parent != "" && t.Parent != f.Parent,
+		!f.UpdatedAfter.IsZero() && !t.Updated.After(f.UpdatedAfter),
+		!f.UpdatedBefore.IsZero() && !t.Updated.Before(f.UpdatedBefore),
+		!f.CreatedAfter.IsZero() && !t.Created.After(f.CreatedAfter),
+		!f.CreatedBefore.IsZero() && !t.Created.Before(f.CreatedBefore):
+		return false
+	}
+	for _, tag := range f.Tags {
+		if !slices.ContainsFunc(t.Tags, func(x string) bool { return strings.EqualFold(x, tag) }) {
+			return false
+		}
+	}
+	if len(f.Text) > 0 {
+		haystack := strings.ToLower(t.ID + "\n" + t.Title + "\n" + t.Body)
+		for _, text := range f.Text {
+			if !strings.Contains(haystack, strings.ToLower(text)) {
+				return false
+			}
+		}
+	}
+	return true
 }
 
 // ticketDir returns the directory for a ticket based on project and creation time.
@@ -73,6 +132,9 @@ func (s *Store) Create(t *Ticket) error {
 		return fmt.Errorf("write ticket: %w", err)
 	}
 
+	// The search index is best-effort: Search refreshes it from disk anyway.
+	_ = s.indexTicket(t, path)
+
 	return nil
 }
 
@@ -117,6 +179,9 @@ func (s *Store) Save(t *Ticket) error {
 		return fmt.Errorf("write ticket: %w", err)
 	}
 
+	// The search index is best-effort: Search refreshes it from disk anyway.
+	_ = s.indexTicket(t, path)
+
 	return nil
 }
 
@@ -153,13 +218,7 @@ func (s *Store) listWithParser(filter ListFilter, parser func([]byte) (*Ticket,
 			continue
 		}
 
-		if filter.Project != "" && t.Project != filter.Project {
-			continue
-		}
-		if filter.Status != "" && t.Status != filter.Status {
-			continue
-		}
-		if excluded(t.Status, filter.Excludes) {
+		if !filter.Match(t) {
 			continue
 		}
 
@@ -198,10 +257,10 @@ func (s *Store) findFile(id string) (string, error) {
 
 	switch len(matches) {
 	case 0:
-		return "", fmt.Errorf("ticket %s not found", id)
+		return "", fmt.Errorf("ticket %s %w", id, ErrNotFound)
 	case 1:
 		return matches[0], nil
 	default:
-		return "", fmt.Errorf("ambiguous ticket prefix %q: %d matches", id, len(matches))
+		return "", fmt.Errorf("%w %q: %d matches", ErrAmbiguousID, id, len(matches))
 	}
 }
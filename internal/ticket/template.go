@@ -0,0 +1,255 @@
+package ticket
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed templates/*.md
+var defaultTemplates embed.FS
+
+// ErrTemplateNotFound is returned by LoadTemplate when no directory has the
+// named template.
+var ErrTemplateNotFound = errors.New("template not found")
+
+// Template is a starting point for new tickets: frontmatter defaults and a
+// description skeleton of headed sections, some of which must be filled in
+// before the ticket can be OPEN.
+//
+//	---
+//	priority: P2
+//	tags: [bug]
+//	required: [Steps to reproduce, Expected behavior]
+//	---
+//	### Steps to reproduce
+//
+//	<!-- hints in comments don't count as content -->
+type Template struct {
+	Name     string
+	Priority Priority // empty to keep the default
+	Tags     []string
+	Required []string
+	Body     string
+}
+
+type templateFrontmatter struct {
+	Priority Priority `yaml:"priority"`
+	Tags     []string `yaml:"tags"`
+	Required []string `yaml:"required"`
+}
+
+// ParseTemplate parses a template file. The frontmatter is optional.
+func ParseTemplate(name string, data []byte) (*Template, error) {
+	t := &Template{Name: name, Body: string(data)}
+	if strings.HasPrefix(t.Body, "---") {
+		frontmatter, body, err := splitFrontmatter(t.Body)
+		if err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+		var fm templateFrontmatter
+		if err := yaml.Unmarshal([]byte(frontmatter), &fm); err != nil {
+			return nil, fmt.Errorf("template %s: parse frontmatter: %w", name, err)
+		}
+		t.Priority, t.Tags, t.Required, t.Body = fm.Priority, fm.Tags, fm.Required, body
+	}
+	t.Body = strings.TrimSpace(t.Body)
+
+	if t.Priority != "" && !ValidPriorities[t.Priority] {
+		return nil, fmt.Errorf("template %s: invalid priority %q (use P0-P5)", name, t.Priority)
+	}
+	for _, tag := range t.Tags {
+		if err := validateTag(tag); err != nil {
+			return nil, fmt.Errorf("template %s: %w", name, err)
+		}
+	}
+	for _, req := range t.Required {
+		if strings.TrimSpace(req) == "" || strings.Contains(req, ",") {
+			return nil, fmt.Errorf("template %s: invalid required section %q", name, req)
+		}
+	}
+	return t, nil
+}
+
+// LoadTemplate reads <name>.md from the first of dirs that has it, so a
+// project's templates directory listed first overrides the vault's.
+func LoadTemplate(name string, dirs ...string) (*Template, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	for _, dir := range dirs {
+		data, err := os.ReadFile(filepath.Join(dir, name+".md"))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read template %s: %w", name, err)
+		}
+		return ParseTemplate(name, data)
+	}
+	return nil, fmt.Errorf("template %q %w", name, ErrTemplateNotFound)
+}
+
+// ListTemplates returns the names of the templates in dirs, sorted.
+func ListTemplates(dirs ...string) ([]string, error) {
+	seen := map[string]bool{}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read templates dir: %w", err)
+		}
+		for _, e := range entries {
+			if !e.IsDir() && filepath.Ext(e.Name()) == ".md" && !strings.HasPrefix(e.Name(), ".") {
+				seen[strings.TrimSuffix(e.Name(), ".md")] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// SeedTemplates writes the built-in bug and feature templates into dir,
+// leaving any existing file alone.
+func SeedTemplates(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create templates dir: %w", err)
+	}
+	entries, err := defaultTemplates.ReadDir("templates")
+	if err != nil {
+		return fmt.Errorf("read embedded templates: %w", err)
+	}
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		data, err := defaultTemplates.ReadFile("templates/" + e.Name())
+		if err != nil {
+			return fmt.Errorf("read embedded %s: %w", e.Name(), err)
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// Fill renders the template's skeleton with description above the first
+// section and each entry of sections (keyed by heading, case-insensitive)
+// under its heading. Sections the skeleton lacks are appended in heading
+// order.
+func (t *Template) Fill(description string, sections map[string]string) string {
+	remaining := make(map[string]string, len(sections))
+	for heading, text := range sections {
+		remaining[strings.ToLower(strings.TrimSpace(heading))] = strings.TrimSpace(text)
+	}
+
+	var out []string
+	if d := strings.TrimSpace(description); d != "" {
+		out = append(out, d, "")
+	}
+	for _, line := range strings.Split(t.Body, "\n") {
+		out = append(out, line)
+		if m := subsectionRe.FindStringSubmatch(line); m != nil {
+			key := strings.ToLower(strings.TrimSpace(m[1]))
+			if text, ok := remaining[key]; ok {
+				out = append(out, "", text)
+				delete(remaining, key)
+			}
+		}
+	}
+	var extra []string
+	for heading := range sections {
+		if _, ok := remaining[strings.ToLower(strings.TrimSpace(heading))]; ok {
+			extra = append(extra, heading)
+		}
+	}
+	sort.Strings(extra)
+	for _, heading := range extra {
+		out = append(out, "", "### "+strings.TrimSpace(heading), "", remaining[strings.ToLower(strings.TrimSpace(heading))])
+	}
+	return strings.TrimSpace(strings.Join(out, "\n"))
+}
+
+var (
+	subsectionRe  = regexp.MustCompile(`^#{2,3} (.+)$`)
+	htmlCommentRe = regexp.MustCompile(`(?s)<!--.*?-->`)
+)
+
+// filledSubsections returns the lowercased headings of the "##" and "###"
+// subsections in text that have content other than HTML comments.
+func filledSubsections(text string) map[string]bool {
+	filled := map[string]bool{}
+	heading := ""
+	var content []string
+	flush := func() {
+		if heading != "" && strings.TrimSpace(htmlCommentRe.ReplaceAllString(strings.Join(content, "\n"), "")) != "" {
+			filled[heading] = true
+		}
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if m := subsectionRe.FindStringSubmatch(line); m != nil {
+			flush()
+			heading, content = strings.ToLower(strings.TrimSpace(m[1])), nil
+			continue
+		}
+		content = append(content, line)
+	}
+	flush()
+	return filled
+}
+
+// Template field names on a ticket's Created section.
+const (
+	TemplateField = "template"
+	RequiredField = "required"
+)
+
+// TemplateFields records the template a ticket was created from on its
+// Created section, so its required sections can be checked later.
+func TemplateFields(t *Template) map[string]string {
+	fields := map[string]string{TemplateField: t.Name}
+	if len(t.Required) > 0 {
+		fields[RequiredField] = strings.Join(t.Required, ", ")
+	}
+	return fields
+}
+
+// MissingSections returns the required sections of the ticket's template
+// that are not yet filled in anywhere in its body, in template order. A
+// section filled in a later Edited or Note section counts.
+func MissingSections(t *Ticket) []string {
+	sections := Sections(t.Body)
+	if len(sections) == 0 || sections[0].Heading != "Created" || sections[0].Fields[RequiredField] == "" {
+		return nil
+	}
+	filled := map[string]bool{}
+	for _, s := range sections {
+		for heading := range filledSubsections(s.Content) {
+			filled[heading] = true
+		}
+	}
+	var missing []string
+	for _, req := range strings.Split(sections[0].Fields[RequiredField], ",") {
+		req = strings.TrimSpace(req)
+		if req != "" && !filled[strings.ToLower(req)] && !slices.Contains(missing, req) {
+			missing = append(missing, req)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,127 @@
+package ticket
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const bugTemplate = `---
+priority: P2
+tags: [bug]
+required: [Steps to reproduce, Expected behavior]
+---
+### Steps to reproduce
+
+<!-- numbered steps -->
+
+### Expected behavior
+
+### Environment
+`
+
+func TestParseTemplate(t *testing.T) {
+	tmpl, err := ParseTemplate("bug", []byte(bugTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Priority != PriorityP2 || len(tmpl.Tags) != 1 || tmpl.Tags[0] != "bug" || len(tmpl.Required) != 2 {
+		t.Errorf("template = %+v", tmpl)
+	}
+	if !strings.HasPrefix(tmpl.Body, "### Steps to reproduce") {
+		t.Errorf("body = %q", tmpl.Body)
+	}
+
+	plain, err := ParseTemplate("plain", []byte("### Notes\n"))
+	if err != nil || plain.Body != "### Notes" || plain.Priority != "" {
+		t.Errorf("plain = %+v, err = %v", plain, err)
+	}
+
+	for _, bad := range []string{
+		"---\npriority: P9\n---\n",
+		"---\ntags: [has space]\n---\n",
+		"---\nrequired: [\"a, b\"]\n---\n",
+	} {
+		if _, err := ParseTemplate("bad", []byte(bad)); err == nil {
+			t.Errorf("ParseTemplate(%q) succeeded", bad)
+		}
+	}
+}
+
+func TestLoadTemplate_ProjectOverride(t *testing.T) {
+	vault, project := t.TempDir(), t.TempDir()
+	if err := SeedTemplates(vault); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(project, "bug.md"), []byte("---\npriority: P0\n---\n### Repro\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl, err := LoadTemplate("bug", project, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Priority != PriorityP0 {
+		t.Errorf("project override not used: %+v", tmpl)
+	}
+	if tmpl, err = LoadTemplate("feature", project, vault); err != nil || len(tmpl.Required) == 0 {
+		t.Errorf("feature = %+v, err = %v", tmpl, err)
+	}
+	if _, err := LoadTemplate("chore", project, vault); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("err = %v, want ErrTemplateNotFound", err)
+	}
+	if _, err := LoadTemplate("../bug", vault); err == nil {
+		t.Error("expected path names to be rejected")
+	}
+
+	names, err := ListTemplates(project, vault)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(names, ",") != "bug,feature" {
+		t.Errorf("names = %v", names)
+	}
+}
+
+func TestTemplateFill(t *testing.T) {
+	tmpl, err := ParseTemplate("bug", []byte(bugTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := tmpl.Fill("Login breaks.", map[string]string{
+		"steps to reproduce": "1. Open /login",
+		"Workaround":         "Use Firefox",
+	})
+	want := "Login breaks.\n\n### Steps to reproduce\n\n1. Open /login\n\n<!-- numbered steps -->\n\n### Expected behavior\n\n### Environment\n\n### Workaround\n\nUse Firefox"
+	if got != want {
+		t.Errorf("Fill() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestMissingSections(t *testing.T) {
+	tmpl, err := ParseTemplate("bug", []byte(bugTemplate))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	tk := &Ticket{}
+	AppendSection(tk, "Created", "human", "", tmpl.Fill("", map[string]string{"Steps to reproduce": "1. Open /login"}), TemplateFields(tmpl), now)
+
+	if got := MissingSections(tk); len(got) != 1 || got[0] != "Expected behavior" {
+		t.Fatalf("MissingSections() = %v", got)
+	}
+
+	AppendSection(tk, "Edited", "human", "", "### Expected Behavior\n\nThe dashboard loads.", nil, now.Add(time.Minute))
+	if got := MissingSections(tk); len(got) != 0 {
+		t.Errorf("MissingSections() after edit = %v", got)
+	}
+
+	plain := &Ticket{}
+	AppendSection(plain, "Created", "human", "", "No template.", nil, now)
+	if got := MissingSections(plain); got != nil {
+		t.Errorf("MissingSections() without template = %v", got)
+	}
+}
@@ -58,17 +58,19 @@ var ValidPriorities = map[Priority]bool{
 
 // Ticket represents a smoovtask ticket with frontmatter and body.
 type Ticket struct {
-	ID          string    `yaml:"id"`
-	Title       string    `yaml:"title"`
-	Project     string    `yaml:"project"`
-	Status      Status    `yaml:"status"`
-	PriorStatus *Status   `yaml:"prior-status"`
-	Assignee    string    `yaml:"assignee"`
-	Priority    Priority  `yaml:"priority"`
-	DependsOn   []string  `yaml:"depends-on"`
-	Created     time.Time `yaml:"created"`
-	Updated     time.Time `yaml:"updated"`
-	Tags        []string  `yaml:"tags"`
+	ID          string                    `yaml:"id"`
+	Title       string                    `yaml:"title"`
+	Project     string                    `yaml:"project"`
+	Status      Status                    `yaml:"status"`
+	PriorStatus *Status                   `yaml:"prior-status"`
+	Assignee    string                    `yaml:"assignee"`
+	Priority    Priority                  `yaml:"priority"`
+	DependsOn   []string                  `yaml:"depends-on"`
+	Parent      string                    `yaml:"parent"`
+	Relations   map[RelationType][]string `yaml:"relations"`
+	Created     time.Time                 `yaml:"created"`
+	Updated     time.Time                 `yaml:"updated"`
+	Tags        []string                  `yaml:"tags"`
 
 	// Body is the markdown body below the frontmatter.
 	Body string `yaml:"-"`
@@ -11,17 +11,19 @@ import (
 // frontmatterData is the YAML-serializable frontmatter structure.
 // We use a separate struct to control field ordering and null handling.
 type frontmatterData struct {
-	ID          string   `yaml:"id"`
-	Title       string   `yaml:"title"`
-	Project     string   `yaml:"project"`
-	Status      Status   `yaml:"status"`
-	PriorStatus *Status  `yaml:"prior-status"`
-	Assignee    string   `yaml:"assignee"`
-	Priority    Priority `yaml:"priority"`
-	DependsOn   []string `yaml:"depends-on"`
-	Created     string   `yaml:"created"`
-	Updated     string   `yaml:"updated"`
-	Tags        []string `yaml:"tags"`
+	ID          string                    `yaml:"id"`
+	Title       string                    `yaml:"title"`
+	Project     string                    `yaml:"project"`
+	Status      Status                    `yaml:"status"`
+	PriorStatus *Status                   `yaml:"prior-status"`
+	Assignee    string                    `yaml:"assignee"`
+	Priority    Priority                  `yaml:"priority"`
+	DependsOn   []string                  `yaml:"depends-on"`
+	Parent      string                    `yaml:"parent,omitempty"`
+	Relations   map[RelationType][]string `yaml:"relations,omitempty"`
+	Created     string                    `yaml:"created"`
+	Updated     string                    `yaml:"updated"`
+	Tags        []string                  `yaml:"tags"`
 }
 
 // Render serializes a Ticket to markdown bytes (frontmatter + body).
@@ -41,6 +43,8 @@ func Marshal(t *Ticket) ([]byte, error) {
 		Assignee:    t.Assignee,
 		Priority:    t.Priority,
 		DependsOn:   t.DependsOn,
+		Parent:      t.Parent,
+		Relations:   t.Relations,
 		Created:     t.Created.UTC().Format(time.RFC3339),
 		Updated:     t.Updated.UTC().Format(time.RFC3339),
 		Tags:        t.Tags,
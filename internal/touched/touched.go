@@ -0,0 +1,132 @@
+// Package touched aggregates the files each ticket has edited, from the
+// file_path data of hook.pre-tool events, and finds overlaps between tickets.
+package touched
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+// editTools are the tools whose file_path counts as a modification.
+var editTools = map[string]bool{
+	"Edit":         true,
+	"MultiEdit":    true,
+	"Write":        true,
+	"NotebookEdit": true,
+}
+
+// IsEdit reports whether tool modifies the file it names.
+func IsEdit(tool string) bool {
+	return editTools[tool]
+}
+
+// worktreeDir is the directory ticket worktrees live under.
+const worktreeDir = ".worktrees"
+
+// Relative returns path relative to the ticket worktree it lies in, or else
+// to root, so the same file matches across tickets. Paths outside both are
+// returned cleaned.
+func Relative(path, root string) string {
+	path = filepath.Clean(path)
+	parts := strings.Split(filepath.ToSlash(path), "/")
+	for i := len(parts) - 3; i >= 0; i-- {
+		if parts[i] == worktreeDir {
+			return filepath.FromSlash(strings.Join(parts[i+2:], "/"))
+		}
+	}
+	if root != "" {
+		if rel, err := filepath.Rel(root, path); err == nil && rel != "." && !strings.HasPrefix(rel, "..") {
+			return rel
+		}
+	}
+	return path
+}
+
+// ByTicket returns each ticket's edited files from events, relative to root,
+// sorted and de-duplicated.
+func ByTicket(events []event.Event, root string) map[string][]string {
+	seen := map[string]map[string]bool{}
+	for _, ev := range events {
+		if ev.Event != event.HookPreTool || ev.Ticket == "" {
+			continue
+		}
+		tool, _ := ev.Data["tool"].(string)
+		path, _ := ev.Data["file_path"].(string)
+		if !IsEdit(tool) || path == "" {
+			continue
+		}
+		if seen[ev.Ticket] == nil {
+			seen[ev.Ticket] = map[string]bool{}
+		}
+		seen[ev.Ticket][Relative(path, root)] = true
+	}
+
+	sets := make(map[string][]string, len(seen))
+	for id, files := range seen {
+		for f := range files {
+			sets[id] = append(sets[id], f)
+		}
+		sort.Strings(sets[id])
+	}
+	return sets
+}
+
+// Load reads the project's events since the given time and returns each
+// ticket's edited files.
+func Load(eventsDir, project, root string, since time.Time) (map[string][]string, error) {
+	events, err := event.QueryEvents(eventsDir, event.Query{Project: project, After: since})
+	if err != nil {
+		return nil, err
+	}
+	return ByTicket(events, root), nil
+}
+
+// Overlap is a set of files edited by two tickets.
+type Overlap struct {
+	Ticket string
+	Other  string
+	Files  []string
+}
+
+// With returns the overlaps between ticketID and each of others, ordered by
+// the other ticket's ID.
+func With(sets map[string][]string, ticketID string, others []string) []Overlap {
+	mine := map[string]bool{}
+	for _, f := range sets[ticketID] {
+		mine[f] = true
+	}
+
+	var overlaps []Overlap
+	for _, other := range others {
+		if other == ticketID {
+			continue
+		}
+		var shared []string
+		for _, f := range sets[other] {
+			if mine[f] {
+				shared = append(shared, f)
+			}
+		}
+		if len(shared) > 0 {
+			overlaps = append(overlaps, Overlap{Ticket: ticketID, Other: other, Files: shared})
+		}
+	}
+	sort.Slice(overlaps, func(i, j int) bool { return overlaps[i].Other < overlaps[j].Other })
+	return overlaps
+}
+
+// Among returns every ticket's overlaps with the other given tickets, keyed
+// by ticket ID. Each overlap appears under both tickets.
+func Among(sets map[string][]string, ids []string) map[string][]Overlap {
+	result := map[string][]Overlap{}
+	for _, id := range ids {
+		if overlaps := With(sets, id, ids); len(overlaps) > 0 {
+			result[id] = overlaps
+		}
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+package touched
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+func TestRelative(t *testing.T) {
+	tests := []struct {
+		path, root, want string
+	}{
+		{"/repo/.worktrees/st_abc123/internal/a.go", "/repo", "internal/a.go"},
+		{"/repo/internal/a.go", "/repo", "internal/a.go"},
+		{"/elsewhere/a.go", "/repo", "/elsewhere/a.go"},
+		{"/repo/./b.go", "", "/repo/b.go"},
+	}
+	for _, tt := range tests {
+		if got := Relative(tt.path, tt.root); got != tt.want {
+			t.Errorf("Relative(%q, %q) = %q, want %q", tt.path, tt.root, got, tt.want)
+		}
+	}
+}
+
+func TestByTicketAndOverlaps(t *testing.T) {
+	edit := func(ticketID, tool, path string) event.Event {
+		return event.Event{Event: event.HookPreTool, Ticket: ticketID, Data: map[string]any{"tool": tool, "file_path": path}}
+	}
+	events := []event.Event{
+		edit("st_a", "Edit", "/repo/.worktrees/st_a/router.go"),
+		edit("st_a", "Write", "/repo/.worktrees/st_a/limit.go"),
+		edit("st_a", "Edit", "/repo/.worktrees/st_a/router.go"),
+		edit("st_b", "Edit", "/repo/.worktrees/st_b/router.go"),
+		edit("st_b", "Read", "/repo/.worktrees/st_b/limit.go"),
+		edit("st_c", "MultiEdit", "/repo/limit.go"),
+		edit("", "Edit", "/repo/unowned.go"),
+	}
+
+	sets := ByTicket(events, "/repo")
+	want := map[string][]string{
+		"st_a": {"limit.go", "router.go"},
+		"st_b": {"router.go"},
+		"st_c": {"limit.go"},
+	}
+	if !reflect.DeepEqual(sets, want) {
+		t.Fatalf("ByTicket() = %v, want %v", sets, want)
+	}
+
+	got := With(sets, "st_a", []string{"st_a", "st_b", "st_c"})
+	wantA := []Overlap{
+		{Ticket: "st_a", Other: "st_b", Files: []string{"router.go"}},
+		{Ticket: "st_a", Other: "st_c", Files: []string{"limit.go"}},
+	}
+	if !reflect.DeepEqual(got, wantA) {
+		t.Errorf("With() = %+v, want %+v", got, wantA)
+	}
+
+	among := Among(sets, []string{"st_b", "st_c"})
+	if len(among) != 0 {
+		t.Errorf("Among(st_b, st_c) = %+v, want none", among)
+	}
+	among = Among(sets, []string{"st_a", "st_b"})
+	if len(among["st_a"]) != 1 || len(among["st_b"]) != 1 || among["st_b"][0].Other != "st_a" {
+		t.Errorf("Among(st_a, st_b) = %+v", among)
+	}
+}
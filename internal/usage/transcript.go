@@ -0,0 +1,199 @@
+package usage
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"golang.org/x/sys/unix"
+)
+
+// State tracks how far a session's transcript has been ingested, so repeated
+// hook invocations only count new lines.
+type State struct {
+	Offset        int64     `json:"offset"`
+	LastMessageID string    `json:"last_message_id,omitempty"`
+	PendingTurns  int       `json:"pending_turns,omitempty"`
+	LastIngest    time.Time `json:"last_ingest"`
+}
+
+// LoadState reads the ingest state for sessionID from dir. A missing file
+// yields a zero state.
+func LoadState(dir, sessionID string) (*State, error) {
+	data, err := os.ReadFile(statePath(dir, sessionID))
+	if os.IsNotExist(err) {
+		return &State{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read usage state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parse usage state: %w", err)
+	}
+	return &s, nil
+}
+
+// SaveState writes the ingest state for sessionID to dir.
+func SaveState(dir, sessionID string, s *State) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create usage state dir: %w", err)
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("marshal usage state: %w", err)
+	}
+	if err := os.WriteFile(statePath(dir, sessionID), data, 0o644); err != nil {
+		return fmt.Errorf("write usage state: %w", err)
+	}
+	return nil
+}
+
+// LockState takes an exclusive lock on sessionID's ingest state, so that
+// overlapping hooks do not ingest the same transcript lines twice. Hold it
+// from LoadState through SaveState and call the returned function to
+// release it.
+func LockState(dir, sessionID string) (func(), error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create usage state dir: %w", err)
+	}
+	lock, err := os.OpenFile(statePath(dir, sessionID)+".lock", os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("open usage state lock: %w", err)
+	}
+	if err := unix.Flock(int(lock.Fd()), unix.LOCK_EX); err != nil {
+		lock.Close()
+		return nil, fmt.Errorf("lock usage state: %w", err)
+	}
+	return func() {
+		_ = unix.Flock(int(lock.Fd()), unix.LOCK_UN)
+		lock.Close()
+	}, nil
+}
+
+func statePath(dir, sessionID string) string {
+	return filepath.Join(dir, filepath.Base(sessionID)+".json")
+}
+
+// transcriptLine is the subset of a Claude Code transcript entry needed for
+// usage accounting.
+type transcriptLine struct {
+	Type    string `json:"type"`
+	IsMeta  bool   `json:"isMeta"`
+	Message struct {
+		ID      string          `json:"id"`
+		Model   string          `json:"model"`
+		Content json.RawMessage `json:"content"`
+		Usage   *struct {
+			InputTokens              int64 `json:"input_tokens"`
+			OutputTokens             int64 `json:"output_tokens"`
+			CacheCreationInputTokens int64 `json:"cache_creation_input_tokens"`
+			CacheReadInputTokens     int64 `json:"cache_read_input_tokens"`
+		} `json:"usage"`
+	} `json:"message"`
+}
+
+// ReadTranscript ingests the complete lines appended to the transcript at
+// path since s.Offset and returns the usage they add, by model. s is updated
+// in place; a trailing partial line is left for the next call. If the
+// transcript shrank (it was rewritten), ingestion restarts from the top.
+func ReadTranscript(path string, s *State) (Totals, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open transcript: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat transcript: %w", err)
+	}
+	if info.Size() < s.Offset {
+		*s = State{}
+	}
+	if _, err := f.Seek(s.Offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("seek transcript: %w", err)
+	}
+
+	totals := make(Totals)
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if err == io.EOF {
+			break // partial line (or nothing): wait for the rest
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read transcript: %w", err)
+		}
+		s.Offset += int64(len(line))
+		ingestLine(bytes.TrimSpace(line), s, totals)
+	}
+	return totals, nil
+}
+
+// ingestLine adds one transcript entry to totals.
+func ingestLine(line []byte, s *State, totals Totals) {
+	if len(line) == 0 {
+		return
+	}
+	var tl transcriptLine
+	if err := json.Unmarshal(line, &tl); err != nil {
+		return
+	}
+
+	switch tl.Type {
+	case "user":
+		if !tl.IsMeta && isPrompt(tl.Message.Content) {
+			s.PendingTurns++
+		}
+	case "assistant":
+		u := tl.Message.Usage
+		if u == nil || tl.Message.Model == "" || tl.Message.Model == "<synthetic>" {
+			return
+		}
+		// Claude Code writes one line per content block, each repeating the
+		// message's usage; count each message once.
+		if tl.Message.ID != "" && tl.Message.ID == s.LastMessageID {
+			return
+		}
+		s.LastMessageID = tl.Message.ID
+		totals.Add(tl.Message.Model, Usage{
+			InputTokens:         u.InputTokens,
+			OutputTokens:        u.OutputTokens,
+			CacheReadTokens:     u.CacheReadInputTokens,
+			CacheCreationTokens: u.CacheCreationInputTokens,
+			Requests:            1,
+			Turns:               s.PendingTurns,
+		})
+		s.PendingTurns = 0
+	}
+}
+
+// isPrompt reports whether user message content is a prompt typed by the
+// user rather than a tool result.
+func isPrompt(content json.RawMessage) bool {
+	if len(content) == 0 {
+		return false
+	}
+	var text string
+	if err := json.Unmarshal(content, &text); err == nil {
+		return text != ""
+	}
+	var blocks []struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(content, &blocks); err != nil {
+		return false
+	}
+	for _, b := range blocks {
+		if b.Type == "tool_result" {
+			return false
+		}
+	}
+	return len(blocks) > 0
+}
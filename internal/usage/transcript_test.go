@@ -0,0 +1,131 @@
+package usage
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleTranscript = `{"type":"user","message":{"role":"user","content":"fix the bug"}}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-5","content":[{"type":"text","text":"Looking."}],"usage":{"input_tokens":10,"output_tokens":5,"cache_creation_input_tokens":100,"cache_read_input_tokens":1000}}}
+{"type":"assistant","message":{"id":"msg_1","model":"claude-sonnet-4-5","content":[{"type":"tool_use","name":"Read"}],"usage":{"input_tokens":10,"output_tokens":5,"cache_creation_input_tokens":100,"cache_read_input_tokens":1000}}}
+{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":"file contents"}]}}
+{"type":"assistant","message":{"id":"msg_2","model":"claude-sonnet-4-5","content":[{"type":"text","text":"Done."}],"usage":{"input_tokens":20,"output_tokens":50,"cache_creation_input_tokens":0,"cache_read_input_tokens":1100}}}
+{"type":"user","isMeta":true,"message":{"role":"user","content":"<command-name>/clear</command-name>"}}
+{"type":"summary","summary":"Bug fix"}
+{"type":"user","message":{"role":"user","content":[{"type":"text","text":"now add a test"}]}}
+{"type":"assistant","message":{"id":"msg_3","model":"claude-haiku-4-5","content":[{"type":"text","text":"Sure."}],"usage":{"input_tokens":7,"output_tokens":3}}}
+`
+
+func writeTranscript(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "transcript.jsonl")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestReadTranscript(t *testing.T) {
+	path := writeTranscript(t, sampleTranscript)
+
+	var s State
+	totals, err := ReadTranscript(path, &s)
+	if err != nil {
+		t.Fatalf("ReadTranscript: %v", err)
+	}
+
+	sonnet := totals["claude-sonnet-4-5"]
+	want := Usage{InputTokens: 30, OutputTokens: 55, CacheReadTokens: 2100, CacheCreationTokens: 100, Requests: 2, Turns: 1}
+	if sonnet != want {
+		t.Errorf("sonnet usage = %+v, want %+v", sonnet, want)
+	}
+	haiku := totals["claude-haiku-4-5"]
+	if haiku.Requests != 1 || haiku.Turns != 1 || haiku.OutputTokens != 3 {
+		t.Errorf("haiku usage = %+v, want 1 request, 1 turn, 3 output tokens", haiku)
+	}
+	if s.Offset != int64(len(sampleTranscript)) {
+		t.Errorf("offset = %d, want %d", s.Offset, len(sampleTranscript))
+	}
+
+	// Nothing new: no usage.
+	totals, err = ReadTranscript(path, &s)
+	if err != nil {
+		t.Fatalf("second ReadTranscript: %v", err)
+	}
+	if len(totals) != 0 {
+		t.Errorf("second read totals = %v, want empty", totals)
+	}
+}
+
+func TestReadTranscriptIncremental(t *testing.T) {
+	lines := strings.SplitAfter(sampleTranscript, "\n")
+	// First two complete lines plus half of the third.
+	third := lines[2]
+	partial := lines[0] + lines[1] + third[:len(third)/2]
+	path := writeTranscript(t, partial)
+
+	var s State
+	totals, err := ReadTranscript(path, &s)
+	if err != nil {
+		t.Fatalf("ReadTranscript: %v", err)
+	}
+	if got := totals["claude-sonnet-4-5"].Requests; got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+	if s.Offset != int64(len(lines[0]+lines[1])) {
+		t.Errorf("offset = %d, want end of second line", s.Offset)
+	}
+
+	// The transcript grows; the duplicate block of msg_1 must not be recounted.
+	if err := os.WriteFile(path, []byte(sampleTranscript), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	totals, err = ReadTranscript(path, &s)
+	if err != nil {
+		t.Fatalf("ReadTranscript: %v", err)
+	}
+	if got := totals["claude-sonnet-4-5"].Requests; got != 1 {
+		t.Errorf("requests after growth = %d, want 1 (msg_2 only)", got)
+	}
+}
+
+func TestReadTranscriptRewritten(t *testing.T) {
+	path := writeTranscript(t, sampleTranscript)
+	s := State{Offset: int64(len(sampleTranscript)) + 500, LastMessageID: "msg_3"}
+
+	totals, err := ReadTranscript(path, &s)
+	if err != nil {
+		t.Fatalf("ReadTranscript: %v", err)
+	}
+	if got := totals.Sum().Requests; got != 3 {
+		t.Errorf("requests = %d, want 3 after restarting from the top", got)
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "usage")
+
+	s, err := LoadState(dir, "sess-1")
+	if err != nil {
+		t.Fatalf("LoadState missing: %v", err)
+	}
+	if s.Offset != 0 {
+		t.Errorf("missing state offset = %d, want 0", s.Offset)
+	}
+
+	s.Offset = 42
+	s.LastMessageID = "msg_9"
+	s.PendingTurns = 2
+	if err := SaveState(dir, "sess-1", s); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	got, err := LoadState(dir, "sess-1")
+	if err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+	if got.Offset != 42 || got.LastMessageID != "msg_9" || got.PendingTurns != 2 {
+		t.Errorf("state = %+v, want offset 42, msg_9, 2 pending turns", got)
+	}
+}
@@ -0,0 +1,236 @@
+// Package usage extracts token usage from agent transcripts and aggregates it
+// into per-ticket and per-project totals with cost estimates.
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+// Usage is a token count for one model.
+type Usage struct {
+	InputTokens         int64
+	OutputTokens        int64
+	CacheReadTokens     int64
+	CacheCreationTokens int64
+	Requests            int // API responses
+	Turns               int // user prompts answered
+}
+
+// Add accumulates o into u.
+func (u *Usage) Add(o Usage) {
+	u.InputTokens += o.InputTokens
+	u.OutputTokens += o.OutputTokens
+	u.CacheReadTokens += o.CacheReadTokens
+	u.CacheCreationTokens += o.CacheCreationTokens
+	u.Requests += o.Requests
+	u.Turns += o.Turns
+}
+
+// Tokens returns the total token count across all categories.
+func (u Usage) Tokens() int64 {
+	return u.InputTokens + u.OutputTokens + u.CacheReadTokens + u.CacheCreationTokens
+}
+
+// IsZero reports whether u records nothing.
+func (u Usage) IsZero() bool {
+	return u == Usage{}
+}
+
+// Data returns the usage.recorded event payload for model.
+func (u Usage) Data(model string) map[string]any {
+	return map[string]any{
+		"model":                 model,
+		"input_tokens":          u.InputTokens,
+		"output_tokens":         u.OutputTokens,
+		"cache_read_tokens":     u.CacheReadTokens,
+		"cache_creation_tokens": u.CacheCreationTokens,
+		"requests":              u.Requests,
+		"turns":                 u.Turns,
+	}
+}
+
+// FromEvent decodes a usage.recorded event. ok is false for other events.
+func FromEvent(ev event.Event) (model string, u Usage, ok bool) {
+	if ev.Event != event.UsageRecorded {
+		return "", Usage{}, false
+	}
+	model, _ = ev.Data["model"].(string)
+	u = Usage{
+		InputTokens:         dataInt(ev.Data, "input_tokens"),
+		OutputTokens:        dataInt(ev.Data, "output_tokens"),
+		CacheReadTokens:     dataInt(ev.Data, "cache_read_tokens"),
+		CacheCreationTokens: dataInt(ev.Data, "cache_creation_tokens"),
+		Requests:            int(dataInt(ev.Data, "requests")),
+		Turns:               int(dataInt(ev.Data, "turns")),
+	}
+	return model, u, true
+}
+
+// dataInt reads a number from event data, which decodes as float64 from JSON.
+func dataInt(data map[string]any, key string) int64 {
+	switch v := data[key].(type) {
+	case float64:
+		return int64(v)
+	case int64:
+		return v
+	case int:
+		return int64(v)
+	}
+	return 0
+}
+
+// Totals is usage broken down by model.
+type Totals map[string]Usage
+
+// Add accumulates u under model.
+func (t Totals) Add(model string, u Usage) {
+	cur := t[model]
+	cur.Add(u)
+	t[model] = cur
+}
+
+// Sum returns the usage across all models.
+func (t Totals) Sum() Usage {
+	var sum Usage
+	for _, u := range t {
+		sum.Add(u)
+	}
+	return sum
+}
+
+// Models returns the model names sorted by descending token count.
+func (t Totals) Models() []string {
+	models := make([]string, 0, len(t))
+	for m := range t {
+		models = append(models, m)
+	}
+	sort.Slice(models, func(i, j int) bool {
+		ti, tj := t[models[i]].Tokens(), t[models[j]].Tokens()
+		if ti != tj {
+			return ti > tj
+		}
+		return models[i] < models[j]
+	})
+	return models
+}
+
+// Cost estimates the cost of t in USD. partial is true when some tokens were
+// used by a model without a configured price.
+func (t Totals) Cost(p Pricing) (cost float64, partial bool) {
+	for model, u := range t {
+		c, ok := p.Cost(model, u)
+		if !ok && u.Tokens() > 0 {
+			partial = true
+		}
+		cost += c
+	}
+	return cost, partial
+}
+
+// Summarize groups usage.recorded events by key (e.g. ticket or project).
+// Events for which key returns "" are skipped.
+func Summarize(events []event.Event, key func(event.Event) string) map[string]Totals {
+	out := make(map[string]Totals)
+	for _, ev := range events {
+		model, u, ok := FromEvent(ev)
+		if !ok {
+			continue
+		}
+		k := key(ev)
+		if k == "" {
+			continue
+		}
+		if out[k] == nil {
+			out[k] = make(Totals)
+		}
+		out[k].Add(model, u)
+	}
+	return out
+}
+
+// ByTicket groups usage events by ticket ID.
+func ByTicket(ev event.Event) string { return ev.Ticket }
+
+// ByProject groups usage events by project.
+func ByProject(ev event.Event) string { return ev.Project }
+
+// ByRun groups usage events by run (session) ID.
+func ByRun(ev event.Event) string { return ev.RunID }
+
+// Pricing maps model-name prefixes to prices.
+type Pricing map[string]config.ModelPrice
+
+// DefaultPrices are list prices in USD per million tokens, keyed by model
+// name prefix. Config entries under [usage.prices] override or extend them.
+var DefaultPrices = Pricing{
+	"claude-opus-4":     {Input: 15, Output: 75, CacheRead: 1.5, CacheWrite: 18.75},
+	"claude-opus-4-5":   {Input: 5, Output: 25, CacheRead: 0.5, CacheWrite: 6.25},
+	"claude-sonnet-4":   {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+	"claude-3-7-sonnet": {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75},
+	"claude-haiku-4":    {Input: 1, Output: 5, CacheRead: 0.1, CacheWrite: 1.25},
+	"claude-3-5-haiku":  {Input: 0.8, Output: 4, CacheRead: 0.08, CacheWrite: 1},
+}
+
+// NewPricing returns the default prices with overrides applied.
+func NewPricing(overrides map[string]config.ModelPrice) Pricing {
+	p := make(Pricing, len(DefaultPrices)+len(overrides))
+	for k, v := range DefaultPrices {
+		p[k] = v
+	}
+	for k, v := range overrides {
+		p[k] = v
+	}
+	return p
+}
+
+// Lookup returns the price for model, matching the longest configured prefix.
+func (p Pricing) Lookup(model string) (config.ModelPrice, bool) {
+	var best string
+	found := false
+	for prefix := range p {
+		if strings.HasPrefix(model, prefix) && (!found || len(prefix) > len(best)) {
+			best, found = prefix, true
+		}
+	}
+	return p[best], found
+}
+
+// Cost estimates the cost of u for model in USD. ok is false when the model
+// has no price.
+func (p Pricing) Cost(model string, u Usage) (float64, bool) {
+	price, ok := p.Lookup(model)
+	if !ok {
+		return 0, false
+	}
+	cost := float64(u.InputTokens)*price.Input +
+		float64(u.OutputTokens)*price.Output +
+		float64(u.CacheReadTokens)*price.CacheRead +
+		float64(u.CacheCreationTokens)*price.CacheWrite
+	return cost / 1e6, true
+}
+
+// FormatTokens renders a token count compactly ("950", "12.3k", "4.1M").
+func FormatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1e6)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1e3)
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// FormatCost renders an estimated cost, marking partial estimates with "+".
+func FormatCost(cost float64, partial bool) string {
+	s := fmt.Sprintf("$%.2f", cost)
+	if partial {
+		s += "+"
+	}
+	return s
+}
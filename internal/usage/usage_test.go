@@ -0,0 +1,107 @@
+package usage
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/boozedog/smoovtask/internal/config"
+	"github.com/boozedog/smoovtask/internal/event"
+)
+
+func TestPricingLookupLongestPrefix(t *testing.T) {
+	p := NewPricing(nil)
+
+	tests := []struct {
+		model string
+		want  float64 // input price
+		ok    bool
+	}{
+		{"claude-opus-4-1-20250805", 15, true},
+		{"claude-opus-4-5-20251101", 5, true},
+		{"claude-sonnet-4-5-20250929", 3, true},
+		{"gpt-5", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := p.Lookup(tt.model)
+		if ok != tt.ok || got.Input != tt.want {
+			t.Errorf("Lookup(%q) = %v, %v; want input %v, %v", tt.model, got.Input, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestPricingOverrides(t *testing.T) {
+	p := NewPricing(map[string]config.ModelPrice{
+		"claude-sonnet-4": {Input: 1, Output: 2},
+		"gpt-5":           {Input: 1.25, Output: 10},
+	})
+
+	if got, _ := p.Lookup("claude-sonnet-4-5"); got.Input != 1 {
+		t.Errorf("overridden sonnet input = %v, want 1", got.Input)
+	}
+	if _, ok := p.Lookup("gpt-5-codex"); !ok {
+		t.Error("gpt-5-codex should match the configured gpt-5 prefix")
+	}
+	if DefaultPrices["claude-sonnet-4"].Input != 3 {
+		t.Error("NewPricing must not modify DefaultPrices")
+	}
+}
+
+func TestCost(t *testing.T) {
+	p := Pricing{"m": {Input: 3, Output: 15, CacheRead: 0.3, CacheWrite: 3.75}}
+	u := Usage{InputTokens: 1_000_000, OutputTokens: 100_000, CacheReadTokens: 2_000_000, CacheCreationTokens: 400_000}
+
+	got, ok := p.Cost("m-1", u)
+	if !ok {
+		t.Fatal("Cost: no price for m-1")
+	}
+	want := 3 + 1.5 + 0.6 + 1.5
+	if math.Abs(got-want) > 1e-9 {
+		t.Errorf("Cost = %v, want %v", got, want)
+	}
+
+	totals := Totals{"m-1": u, "unknown": {OutputTokens: 10}}
+	cost, partial := totals.Cost(p)
+	if math.Abs(cost-want) > 1e-9 || !partial {
+		t.Errorf("Totals.Cost = %v, %v; want %v, partial", cost, partial, want)
+	}
+	if s := FormatCost(cost, partial); s != "$6.60+" {
+		t.Errorf("FormatCost = %q, want $6.60+", s)
+	}
+}
+
+func TestSummarizeEvents(t *testing.T) {
+	u := Usage{InputTokens: 10, OutputTokens: 20, Requests: 1, Turns: 1}
+	var events []event.Event
+	for _, tk := range []string{"st_a", "st_a", "st_b", ""} {
+		ev := event.Event{Event: event.UsageRecorded, Ticket: tk, Project: "p", Data: u.Data("m")}
+		// Round-trip through JSON as events are read back from the log.
+		data, _ := json.Marshal(ev)
+		var decoded event.Event
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatal(err)
+		}
+		events = append(events, decoded)
+	}
+	events = append(events, event.Event{Event: event.HookStop, Ticket: "st_a"})
+
+	byTicket := Summarize(events, ByTicket)
+	if len(byTicket) != 2 {
+		t.Fatalf("tickets = %d, want 2 (untracked usage skipped)", len(byTicket))
+	}
+	if got := byTicket["st_a"].Sum(); got.Tokens() != 60 || got.Turns != 2 {
+		t.Errorf("st_a = %+v, want 60 tokens over 2 turns", got)
+	}
+	if got := Summarize(events, ByProject)["p"].Sum().Requests; got != 4 {
+		t.Errorf("project requests = %d, want 4", got)
+	}
+}
+
+func TestFormatTokens(t *testing.T) {
+	tests := map[int64]string{950: "950", 12_345: "12.3k", 4_100_000: "4.1M"}
+	for n, want := range tests {
+		if got := FormatTokens(n); got != want {
+			t.Errorf("FormatTokens(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Package verify runs a project's verification commands (build, lint, test)
+// in a ticket worktree before the ticket is submitted for review.
+package verify
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// DefaultTimeout bounds each verification command.
+const DefaultTimeout = 10 * time.Minute
+
+// Excerpt limits for the output attached to the ticket.
+const (
+	excerptLines = 40
+	excerptBytes = 4000
+)
+
+// Step is the outcome of one verification command.
+type Step struct {
+	Command  string
+	ExitCode int
+	Duration time.Duration
+	Output   string
+	Err      error // set when the command could not run or timed out
+}
+
+// Passed reports whether the command exited successfully.
+func (s Step) Passed() bool {
+	return s.Err == nil && s.ExitCode == 0
+}
+
+// Result is the outcome of a verification run. Commands run in order and
+// stop at the first failure, so only the last step can have failed.
+type Result struct {
+	Steps    []Step
+	Duration time.Duration
+}
+
+// Passed reports whether every command succeeded.
+func (r Result) Passed() bool {
+	for _, s := range r.Steps {
+		if !s.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+// Failed returns the failing step, if any.
+func (r Result) Failed() (Step, bool) {
+	for _, s := range r.Steps {
+		if !s.Passed() {
+			return s, true
+		}
+	}
+	return Step{}, false
+}
+
+// Run executes commands with `sh -c` in dir, in order, stopping at the first
+// failure. A timeout of zero uses DefaultTimeout.
+func Run(dir string, commands []string, timeout time.Duration) Result {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	start := time.Now()
+	var r Result
+	for _, command := range commands {
+		command = strings.TrimSpace(command)
+		if command == "" {
+			continue
+		}
+		s := runStep(dir, command, timeout)
+		r.Steps = append(r.Steps, s)
+		if !s.Passed() {
+			break
+		}
+	}
+	r.Duration = time.Since(start)
+	return r
+}
+
+func runStep(dir, command string, timeout time.Duration) Step {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var out bytes.Buffer
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = dir
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	// Don't wait forever on output pipes held open by orphaned children.
+	cmd.WaitDelay = 5 * time.Second
+
+	start := time.Now()
+	err := cmd.Run()
+	s := Step{Command: command, Duration: time.Since(start), Output: out.String()}
+
+	var exitErr *exec.ExitError
+	switch {
+	case ctx.Err() == context.DeadlineExceeded:
+		s.ExitCode = -1
+		s.Err = fmt.Errorf("timed out after %s", timeout)
+	case errors.As(err, &exitErr):
+		s.ExitCode = exitErr.ExitCode()
+	case err != nil:
+		s.ExitCode = -1
+		s.Err = err
+	}
+	return s
+}
+
+// Summary returns a one-line description such as
+// "PASS (3 commands, 12s)" or "FAIL (go vet ./... exited 1)".
+func (r Result) Summary() string {
+	if s, ok := r.Failed(); ok {
+		if s.Err != nil {
+			return fmt.Sprintf("FAIL (%s: %v)", s.Command, s.Err)
+		}
+		return fmt.Sprintf("FAIL (%s exited %d)", s.Command, s.ExitCode)
+	}
+	n := len(r.Steps)
+	noun := "commands"
+	if n == 1 {
+		noun = "command"
+	}
+	return fmt.Sprintf("PASS (%d %s, %s)", n, noun, r.Duration.Round(time.Second))
+}
+
+// Report renders the result as a ticket section body: one line per command
+// and, on failure, a truncated excerpt of the failing command's output.
+func (r Result) Report() string {
+	var b strings.Builder
+	for _, s := range r.Steps {
+		mark := "PASS"
+		if !s.Passed() {
+			mark = "FAIL"
+		}
+		fmt.Fprintf(&b, "- %s `%s` (%s)\n", mark, s.Command, s.Duration.Round(time.Millisecond))
+	}
+	if s, ok := r.Failed(); ok {
+		output := s.Output
+		if s.Err != nil {
+			output = strings.TrimRight(output, "\n") + "\n" + s.Err.Error()
+		}
+		if excerpt := Excerpt(output); excerpt != "" {
+			b.WriteString("\n```\n")
+			b.WriteString(excerpt)
+			b.WriteString("\n```\n")
+		}
+	}
+	return b.String()
+}
+
+// Excerpt keeps the tail of output, where build and test failures are
+// usually reported, within the line and byte limits. Code fences are
+// neutralized so the excerpt cannot break out of its block.
+func Excerpt(output string) string {
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return ""
+	}
+	lines := strings.Split(output, "\n")
+	truncated := false
+	if len(lines) > excerptLines {
+		lines = lines[len(lines)-excerptLines:]
+		truncated = true
+	}
+	excerpt := strings.Join(lines, "\n")
+	if len(excerpt) > excerptBytes {
+		excerpt = excerpt[len(excerpt)-excerptBytes:]
+		if i := strings.IndexByte(excerpt, '\n'); i >= 0 {
+			excerpt = excerpt[i+1:]
+		}
+		truncated = true
+	}
+	excerpt = strings.ReplaceAll(excerpt, "```", "'''")
+	if truncated {
+		excerpt = "... (truncated)\n" + excerpt
+	}
+	return excerpt
+}
+
+// Data returns the verify.passed / verify.failed event data.
+func (r Result) Data() map[string]any {
+	commands := make([]string, len(r.Steps))
+	for i, s := range r.Steps {
+		commands[i] = s.Command
+	}
+	data := map[string]any{
+		"commands":    commands,
+		"duration_ms": r.Duration.Milliseconds(),
+		"summary":     r.Summary(),
+	}
+	if s, ok := r.Failed(); ok {
+		data["failed_command"] = s.Command
+		data["exit_code"] = s.ExitCode
+		data["excerpt"] = Excerpt(s.Output)
+	}
+	return data
+}
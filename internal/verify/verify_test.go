@@ -0,0 +1,86 @@
+package verify
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRunStopsAtFirstFailure(t *testing.T) {
+	dir := t.TempDir()
+	r := Run(dir, []string{"echo building", "  ", "echo broken >&2; exit 3", "touch never-ran"}, 0)
+
+	if r.Passed() {
+		t.Fatal("Passed() = true, want false")
+	}
+	if len(r.Steps) != 2 {
+		t.Fatalf("steps = %d, want 2 (blank skipped, stop after failure)", len(r.Steps))
+	}
+	s, ok := r.Failed()
+	if !ok || s.ExitCode != 3 || !strings.Contains(s.Output, "broken") {
+		t.Fatalf("failed step = %+v, ok = %v", s, ok)
+	}
+	if got := r.Summary(); got != "FAIL (echo broken >&2; exit 3 exited 3)" {
+		t.Errorf("Summary() = %q", got)
+	}
+	report := r.Report()
+	for _, want := range []string{"- PASS `echo building`", "- FAIL `echo broken >&2; exit 3`", "```\nbroken\n```"} {
+		if !strings.Contains(report, want) {
+			t.Errorf("Report() missing %q:\n%s", want, report)
+		}
+	}
+	data := r.Data()
+	if data["failed_command"] != "echo broken >&2; exit 3" || data["exit_code"] != 3 {
+		t.Errorf("Data() = %v", data)
+	}
+}
+
+func TestRunRunsInDir(t *testing.T) {
+	dir := t.TempDir()
+	r := Run(dir, []string{"pwd"}, 0)
+	if !r.Passed() {
+		t.Fatalf("Passed() = false: %+v", r.Steps)
+	}
+	if !strings.Contains(r.Steps[0].Output, dir) {
+		t.Errorf("output = %q, want %q", r.Steps[0].Output, dir)
+	}
+	if !strings.HasPrefix(r.Summary(), "PASS (1 command,") {
+		t.Errorf("Summary() = %q", r.Summary())
+	}
+	if _, ok := r.Data()["failed_command"]; ok {
+		t.Error("Data() has failed_command for a passing run")
+	}
+}
+
+func TestRunTimeout(t *testing.T) {
+	r := Run(t.TempDir(), []string{"exec sleep 5"}, 50*time.Millisecond)
+	s, ok := r.Failed()
+	if !ok || s.Err == nil || !strings.Contains(s.Err.Error(), "timed out") {
+		t.Fatalf("failed step = %+v, ok = %v", s, ok)
+	}
+}
+
+func TestExcerpt(t *testing.T) {
+	if got := Excerpt("  \n"); got != "" {
+		t.Errorf("Excerpt(blank) = %q", got)
+	}
+
+	var lines []string
+	for i := 1; i <= 100; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	got := Excerpt(strings.Join(lines, "\n"))
+	if !strings.HasPrefix(got, "... (truncated)\nline 61\n") || !strings.HasSuffix(got, "line 100") {
+		t.Errorf("Excerpt(100 lines) = %q", got)
+	}
+
+	long := strings.Repeat(strings.Repeat("x", 99)+"\n", 30)
+	if got := Excerpt(long); len(got) > excerptBytes+len("... (truncated)\n") {
+		t.Errorf("Excerpt length = %d, want <= %d", len(got), excerptBytes)
+	}
+
+	if got := Excerpt("```\nfenced"); strings.Contains(got, "```") {
+		t.Errorf("Excerpt did not neutralize fence: %q", got)
+	}
+}
@@ -5,7 +5,11 @@ import (
 	"sort"
 	"time"
 
+	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/testrun"
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/touched"
 	"github.com/boozedog/smoovtask/internal/web/templates"
 )
 
@@ -69,12 +73,59 @@ func (h *Handler) buildBoardData(r *http.Request) (templates.BoardData, error) {
 		return templates.BoardData{}, err
 	}
 
-	groups := groupByStatus(tickets)
-
 	// Done column: only show tickets completed in the past 24 hours.
 	cutoff := time.Now().Add(-24 * time.Hour)
+	lanes, others := epicLanes(tickets, cutoff)
+	columns := boardColumns(others, cutoff)
+
+	runIDSet := make(map[string]struct{})
+	for _, tk := range tickets {
+		if tk.Assignee == "" {
+			continue
+		}
+		runIDSet[tk.Assignee] = struct{}{}
+	}
+
+	runIDs := make([]string, 0, len(runIDSet))
+	for runID := range runIDSet {
+		runIDs = append(runIDs, runID)
+	}
+
+	now := time.Now().UTC()
+	runSources := h.resolveRunSources(runIDs)
+	runLastHooks := h.resolveRunLastHookTimes(runIDs)
+	runLastHookUnixMs := make(map[string]int64, len(runIDs))
+	stalledRunIDs := make(map[string]bool, len(runIDs))
+	for _, runID := range runIDs {
+		lastHookTS, ok := runLastHooks[runID]
+		if ok {
+			runLastHookUnixMs[runID] = lastHookTS.UnixMilli()
+		}
+		if !ok || now.Sub(lastHookTS) > stalledThreshold {
+			stalledRunIDs[runID] = true
+		}
+	}
+
+	return templates.BoardData{
+		Columns:           columns,
+		Epics:             lanes,
+		RunSources:        runSources,
+		RunLastHookUnixMs: runLastHookUnixMs,
+		StalledRunIDs:     stalledRunIDs,
+		TestRuns:          h.lastTestRuns(filterProject),
+		Overlaps:          h.fileOverlaps(tickets),
+		CurrentProject:    filterProject,
+		Projects:          h.allProjects(),
+	}, nil
+}
+
+// boardColumns groups tickets into the board's status columns, dropping
+// tickets finished before cutoff.
+func boardColumns(tickets []*ticket.Ticket, cutoff time.Time) []templates.BoardColumn {
+	groups := groupByStatus(tickets)
+
 	if done, ok := groups[ticket.StatusDone]; ok {
-		recent := done[:0]
+		recent := make([]*ticket.Ticket, 0, len(done))
 		for _, tk := range done {
 			if tk.Updated.After(cutoff) {
 				recent = append(recent, tk)
@@ -146,40 +197,94 @@ func (h *Handler) buildBoardData(r *http.Request) (templates.BoardData, error) {
 		})
 	}
 
-	runIDSet := make(map[string]struct{})
+	return columns
+}
+
+// epicLanes splits tickets into a swimlane per epic — a ticket with children
+// among tickets — and the tickets outside any lane. An epic finished before
+// cutoff gets no lane. A child goes in its parent's lane, so an epic with a
+// parent appears both as a card in the parent's lane and as a lane of its own.
+func epicLanes(tickets []*ticket.Ticket, cutoff time.Time) ([]templates.EpicLane, []*ticket.Ticket) {
+	var lanes []templates.EpicLane
+	isEpic := make(map[string]bool)
 	for _, tk := range tickets {
-		if tk.Assignee == "" {
+		children := ticket.Children(tickets, tk.ID)
+		if len(children) == 0 {
 			continue
 		}
-		runIDSet[tk.Assignee] = struct{}{}
+		if (tk.Status == ticket.StatusDone || tk.Status == ticket.StatusCancelled) && !tk.Updated.After(cutoff) {
+			continue
+		}
+		isEpic[tk.ID] = true
+		lanes = append(lanes, templates.EpicLane{
+			Epic:     tk,
+			Progress: ticket.ChildProgress(children),
+			Columns:  boardColumns(children, cutoff),
+		})
 	}
 
-	runIDs := make([]string, 0, len(runIDSet))
-	for runID := range runIDSet {
-		runIDs = append(runIDs, runID)
+	var others []*ticket.Ticket
+	for _, tk := range tickets {
+		if !isEpic[tk.ID] && !isEpic[tk.Parent] {
+			others = append(others, tk)
+		}
 	}
 
-	now := time.Now().UTC()
-	runSources := h.resolveRunSources(runIDs)
-	runLastHooks := h.resolveRunLastHookTimes(runIDs)
-	runLastHookUnixMs := make(map[string]int64, len(runIDs))
-	stalledRunIDs := make(map[string]bool, len(runIDs))
-	for _, runID := range runIDs {
-		lastHookTS, ok := runLastHooks[runID]
-		if ok {
-			runLastHookUnixMs[runID] = lastHookTS.UnixMilli()
+	sort.SliceStable(lanes, func(i, j int) bool {
+		if lanes[i].Epic.Priority != lanes[j].Epic.Priority {
+			return lanes[i].Epic.Priority < lanes[j].Epic.Priority
 		}
-		if !ok || now.Sub(lastHookTS) > stalledThreshold {
-			stalledRunIDs[runID] = true
+		return lanes[i].Epic.Created.Before(lanes[j].Epic.Created)
+	})
+	return lanes, others
+}
+
+// lastTestRuns returns the latest test run per ticket from the past 30 days.
+func (h *Handler) lastTestRuns(project string) map[string]testrun.Result {
+	events, err := event.QueryEvents(h.eventsDir, event.Query{
+		Project: project,
+		After:   time.Now().UTC().Add(-30 * 24 * time.Hour),
+	})
+	if err != nil {
+		return nil
+	}
+	return testrun.LatestByTicket(events)
+}
+
+// fileOverlaps returns, per active (IN-PROGRESS or REWORK) ticket, the files
+// it has edited that another active ticket in the same project has too.
+func (h *Handler) fileOverlaps(tickets []*ticket.Ticket) map[string][]touched.Overlap {
+	active := map[string][]string{}
+	var since time.Time
+	for _, tk := range tickets {
+		if tk.Status != ticket.StatusInProgress && tk.Status != ticket.StatusRework {
+			continue
+		}
+		active[tk.Project] = append(active[tk.Project], tk.ID)
+		if since.IsZero() || tk.Created.Before(since) {
+			since = tk.Created
 		}
 	}
 
-	return templates.BoardData{
-		Columns:           columns,
-		RunSources:        runSources,
-		RunLastHookUnixMs: runLastHookUnixMs,
-		StalledRunIDs:     stalledRunIDs,
-		CurrentProject:    filterProject,
-		Projects:          h.allProjects(),
-	}, nil
+	vaultPath, _ := h.cfg.VaultPath()
+	overlaps := map[string][]touched.Overlap{}
+	for proj, ids := range active {
+		if len(ids) < 2 {
+			continue
+		}
+		root := ""
+		if vaultPath != "" {
+			if meta, err := project.LoadMeta(vaultPath, proj); err == nil {
+				root = meta.Path
+			}
+		}
+		sets, err := touched.Load(h.eventsDir, proj, root, since)
+		if err != nil {
+			continue
+		}
+		for id, o := range touched.Among(sets, ids) {
+			overlaps[id] = o
+		}
+	}
+	return overlaps
 }
@@ -50,10 +50,27 @@ func (h *Handler) buildCriticalPathData(r *http.Request) (templates.CriticalPath
 	}
 	runSources := h.resolveRunSources(runIDs)
 
+	// Non-blocking relations are listed on the nodes rather than laid out.
+	links := make(map[string][]ticket.Link)
+	for _, layer := range graph.Layers {
+		for _, node := range layer {
+			tk := byID[node.ID]
+			if tk == nil {
+				continue
+			}
+			for _, l := range ticket.Links(all, tk) {
+				if l.Type != ticket.RelationBlocks {
+					links[node.ID] = append(links[node.ID], l)
+				}
+			}
+		}
+	}
+
 	return templates.CriticalPathData{
 		Project:        filterProject,
 		Graph:          graph,
 		ByID:           byID,
+		Links:          links,
 		RunSources:     runSources,
 		CurrentProject: filterProject,
 		Projects:       h.allProjects(),
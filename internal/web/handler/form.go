@@ -3,38 +3,98 @@ package handler
 import (
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
 	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/boozedog/smoovtask/internal/web/templates"
+	"github.com/boozedog/smoovtask/internal/workflow"
 )
 
 func (h *Handler) NewTicket(w http.ResponseWriter, r *http.Request) {
-	data := templates.TicketFormData{
-		Mode: "new",
-		Values: templates.TicketFormValues{
-			Project:  h.SelectedProject(),
-			Status:   string(ticket.StatusOpen),
-			Priority: string(ticket.DefaultPriority),
-		},
-		Projects: h.allProjects(),
-	}
-	_ = templates.TicketFormPage(data).Render(r.Context(), w)
+	_ = templates.TicketFormPage(h.newFormData(r)).Render(r.Context(), w)
 }
 
 func (h *Handler) PartialNewTicket(w http.ResponseWriter, r *http.Request) {
+	_ = templates.TicketFormModalPartial(h.newFormData(r)).Render(r.Context(), w)
+}
+
+// newFormData builds the new-ticket form. Picking a template or project
+// re-requests the form with the values entered so far, and the template's
+// priority, tags and description skeleton are filled in.
+func (h *Handler) newFormData(r *http.Request) templates.TicketFormData {
+	values := templates.TicketFormValues{
+		Project:  h.SelectedProject(),
+		Status:   string(ticket.StatusOpen),
+		Priority: string(ticket.DefaultPriority),
+	}
+	if r.URL.Query().Has("project") {
+		values = h.formValuesFromRequest(r)
+	}
 	data := templates.TicketFormData{
-		Mode: "new",
-		Values: templates.TicketFormValues{
-			Project:  h.SelectedProject(),
-			Status:   string(ticket.StatusOpen),
-			Priority: string(ticket.DefaultPriority),
-		},
-		Projects: h.allProjects(),
+		Mode:      "new",
+		Values:    values,
+		Projects:  h.allProjects(),
+		Templates: h.templateNames(values.Project),
 	}
-	_ = templates.TicketFormModalPartial(data).Render(r.Context(), w)
+	if !slices.Contains(data.Templates, values.Template) {
+		// No template picked, or the new project doesn't have it.
+		data.Values.Template = ""
+		return data
+	}
+
+	tmpl, err := h.loadTemplate(values.Project, values.Template)
+	if err != nil {
+		data.Error = err.Error()
+		return data
+	}
+	if tmpl.Priority != "" {
+		data.Values.Priority = string(tmpl.Priority)
+	}
+	tags := splitCSV(values.Tags)
+	for _, tag := range tmpl.Tags {
+		if !slices.ContainsFunc(tags, func(t string) bool { return strings.EqualFold(t, tag) }) {
+			tags = append(tags, tag)
+		}
+	}
+	data.Values.Tags = strings.Join(tags, ",")
+	if h.isSkeleton(values.Project, values.Description) {
+		data.Values.Description = tmpl.Fill("", nil)
+	}
+	return data
+}
+
+// isSkeleton reports whether a description is empty or still an untouched
+// template skeleton, so switching templates may replace it.
+func (h *Handler) isSkeleton(project, description string) bool {
+	if strings.TrimSpace(description) == "" {
+		return true
+	}
+	for _, name := range h.templateNames(project) {
+		if tmpl, err := h.loadTemplate(project, name); err == nil && tmpl.Fill("", nil) == strings.TrimSpace(description) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) templateNames(project string) []string {
+	dirs, err := h.cfg.TemplateDirs(project)
+	if err != nil {
+		return nil
+	}
+	names, _ := ticket.ListTemplates(dirs...)
+	return names
+}
+
+func (h *Handler) loadTemplate(project, name string) (*ticket.Template, error) {
+	dirs, err := h.cfg.TemplateDirs(project)
+	if err != nil {
+		return nil, err
+	}
+	return ticket.LoadTemplate(name, dirs...)
 }
 
 func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
@@ -78,10 +138,35 @@ func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 	}
 
 	body := values.Description
+	var fields map[string]string
+	if values.Template != "" {
+		tmpl, err := h.loadTemplate(values.Project, values.Template)
+		if err != nil {
+			if isHTMX {
+				h.renderFormModalErrorWithValues(w, r, "new", "", values, err.Error())
+			} else {
+				h.renderFormErrorWithValues(w, r, "new", "", values, err.Error())
+			}
+			return
+		}
+		if body == "" {
+			body = tmpl.Fill("", nil)
+		}
+		fields = ticket.TemplateFields(tmpl)
+	}
 	if body == "" {
 		body = values.Title
 	}
-	ticket.AppendSection(tk, "Created", "web", "", body, nil, now)
+	ticket.AppendSection(tk, "Created", "web", "", body, fields, now)
+
+	if err := requireSections(tk); err != nil {
+		if isHTMX {
+			h.renderFormModalErrorWithValues(w, r, "new", "", values, err.Error()+" — fill them in or create the ticket in BACKLOG")
+		} else {
+			h.renderFormErrorWithValues(w, r, "new", "", values, err.Error()+" — fill them in or create the ticket in BACKLOG")
+		}
+		return
+	}
 
 	if err := h.store.Create(tk); err != nil {
 		if isHTMX {
@@ -197,14 +282,34 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 	tk.Project = values.Project
 	tk.Status = ticket.Status(values.Status)
 	tk.Priority = ticket.Priority(values.Priority)
-	tk.DependsOn = splitCSV(values.DependsOn)
 	tk.Tags = splitCSV(values.Tags)
 	tk.Updated = now
 
+	deps, err := ticket.SetDependencies(h.store, tk, splitCSV(values.DependsOn), "web", "", now)
+	if err != nil {
+		if isHTMX {
+			h.renderFormModalErrorWithValues(w, r, "edit", tk.ID, values, err.Error())
+		} else {
+			h.renderFormErrorWithValues(w, r, "edit", tk.ID, values, err.Error())
+		}
+		return
+	}
+
 	if values.Description != "" {
 		ticket.AppendSection(tk, "Edited", "web", "", values.Description, nil, now)
 	}
 
+	if oldStatus != tk.Status {
+		if err := requireSections(tk); err != nil {
+			if isHTMX {
+				h.renderFormModalErrorWithValues(w, r, "edit", tk.ID, values, err.Error())
+			} else {
+				h.renderFormErrorWithValues(w, r, "edit", tk.ID, values, err.Error())
+			}
+			return
+		}
+	}
+
 	if err := h.store.Save(tk); err != nil {
 		if isHTMX {
 			h.renderFormModalErrorWithValues(w, r, "edit", tk.ID, values, "failed to save ticket")
@@ -225,16 +330,32 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 			"message": "ticket edited in web view",
 		},
 	})
+	if deps.Changed() {
+		_ = el.Append(event.Event{
+			TS:      now,
+			Event:   event.TicketDepsChanged,
+			Ticket:  tk.ID,
+			Project: tk.Project,
+			Actor:   "web",
+			Data:    depsChangedData(tk, deps),
+		})
+	}
 	if oldStatus != tk.Status {
+		data := map[string]any{
+			"from": string(oldStatus),
+		}
+		if deps.Blocked {
+			data["reason"] = "depends-on"
+		} else if deps.Unblocked {
+			data["reason"] = "auto-unblock"
+		}
 		_ = el.Append(event.Event{
 			TS:      now,
 			Event:   "status." + strings.ToLower(string(tk.Status)),
 			Ticket:  tk.ID,
 			Project: tk.Project,
 			Actor:   "web",
-			Data: map[string]any{
-				"from": string(oldStatus),
-			},
+			Data:    data,
 		})
 	}
 
@@ -255,9 +376,22 @@ func (h *Handler) formValuesFromRequest(r *http.Request) templates.TicketFormVal
 		DependsOn:   strings.TrimSpace(r.FormValue("depends_on")),
 		Tags:        strings.TrimSpace(r.FormValue("tags")),
 		Description: strings.TrimSpace(r.FormValue("description")),
+		Template:    strings.TrimSpace(r.FormValue("template")),
 	}
 }
 
+// requireSections refuses a ticket entering OPEN while required sections of
+// its template are empty.
+func requireSections(tk *ticket.Ticket) error {
+	if !workflow.RequiresSections(tk.Status) {
+		return nil
+	}
+	if missing := ticket.MissingSections(tk); len(missing) > 0 {
+		return fmt.Errorf("required sections are empty: %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 func validateFormValues(v templates.TicketFormValues) error {
 	if v.Title == "" {
 		return fmt.Errorf("title is required")
@@ -274,6 +408,15 @@ func validateFormValues(v templates.TicketFormValues) error {
 	return nil
 }
 
+// depsChangedData is the payload of a ticket.deps-changed event.
+func depsChangedData(tk *ticket.Ticket, c ticket.DepsChange) map[string]any {
+	return map[string]any{
+		"added":      c.Added,
+		"removed":    c.Removed,
+		"depends_on": tk.DependsOn,
+	}
+}
+
 func splitCSV(s string) []string {
 	if strings.TrimSpace(s) == "" {
 		return []string{}
@@ -304,6 +447,9 @@ func (h *Handler) renderFormErrorWithValues(w http.ResponseWriter, r *http.Reque
 		Error:    msg,
 		Projects: h.allProjects(),
 	}
+	if mode == "new" {
+		data.Templates = h.templateNames(values.Project)
+	}
 	w.WriteHeader(http.StatusBadRequest)
 	_ = templates.TicketFormPage(data).Render(r.Context(), w)
 }
@@ -323,5 +469,8 @@ func (h *Handler) renderFormModalErrorWithValues(w http.ResponseWriter, r *http.
 		Error:    msg,
 		Projects: h.allProjects(),
 	}
+	if mode == "new" {
+		data.Templates = h.templateNames(values.Project)
+	}
 	_ = templates.TicketFormModalPartial(data).Render(r.Context(), w)
 }
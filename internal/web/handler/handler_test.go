@@ -13,7 +13,10 @@ import (
 
 	"github.com/boozedog/smoovtask/internal/config"
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/rules"
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/usage"
 	"github.com/boozedog/smoovtask/internal/web/handler"
 	"github.com/boozedog/smoovtask/internal/web/sse"
 )
@@ -101,6 +104,76 @@ func TestBoard(t *testing.T) {
 	}
 }
 
+func TestBoardShowsFileOverlap(t *testing.T) {
+	h, projectsDir, eventsDir := testSetup(t)
+
+	store := ticket.NewStore(projectsDir)
+	if err := store.Create(&ticket.Ticket{
+		ID:       "st_ovl789",
+		Title:    "Overlapping ticket",
+		Project:  "testproj",
+		Status:   ticket.StatusRework,
+		Priority: ticket.PriorityP2,
+		Created:  time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC),
+		Updated:  time.Date(2026, 2, 26, 12, 0, 0, 0, time.UTC),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	evLog := event.NewEventLog(eventsDir)
+	for _, id := range []string{"st_def456", "st_ovl789"} {
+		if err := evLog.Append(event.Event{
+			TS:      time.Now().UTC(),
+			Event:   event.HookPreTool,
+			Ticket:  id,
+			Project: "testproj",
+			Data:    map[string]any{"tool": "Edit", "file_path": "/repo/.worktrees/" + id + "/router.go"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	h.Board(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := w.Body.String()
+	if strings.Count(body, "⚠ overlaps 1") != 2 {
+		t.Errorf("expected an overlap badge on both active tickets")
+	}
+	if !strings.Contains(body, "st_ovl789: router.go") || !strings.Contains(body, "st_def456: router.go") {
+		t.Errorf("expected overlap titles to list the other ticket and file")
+	}
+}
+
+func TestBoardShowsEpicSwimlanes(t *testing.T) {
+	h, projectsDir, _ := testSetup(t)
+
+	store := ticket.NewStore(projectsDir)
+	tk, err := store.Get("st_def456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk.Parent = "st_abc123"
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	h.Board(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	body := w.Body.String()
+	if !strings.Contains(body, `data-epic-id="st_abc123"`) {
+		t.Fatal("expected a swimlane for the epic")
+	}
+	if !strings.Contains(body, "0/1 done") || !strings.Contains(body, "Other tickets") {
+		t.Errorf("expected epic progress and an Other tickets lane")
+	}
+	// The epic heads its lane rather than appearing as a card.
+	if strings.Contains(body, `hx-get="/partials/ticket/st_abc123" hx-target="#ticket-modal-body" class="card`) {
+		t.Errorf("epic should not be rendered as a card")
+	}
+}
+
 func TestBoardShowsStalledIndicatorForAssignedAgentWithoutRecentHook(t *testing.T) {
 	h, _, _ := testSetup(t)
 
@@ -291,6 +364,29 @@ func TestTicket(t *testing.T) {
 	}
 }
 
+func TestTicketShowsLinks(t *testing.T) {
+	h, projectsDir, _ := testSetup(t)
+	store := ticket.NewStore(projectsDir)
+	tk, err := store.Get("st_def456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tk.AddRelation(ticket.RelationBlocks, "st_abc123")
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/ticket/st_abc123", nil)
+	req.SetPathValue("id", "st_abc123")
+	w := httptest.NewRecorder()
+	h.Ticket(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, "blocked-by") || !strings.Contains(body, `href="/ticket/st_def456"`) {
+		t.Error("expected the inverse blocked-by link on the ticket page")
+	}
+}
+
 func TestTicketNotFound(t *testing.T) {
 	h, _, _ := testSetup(t)
 
@@ -689,6 +785,215 @@ func TestCreateTicket(t *testing.T) {
 	}
 }
 
+func TestNewTicketTemplate(t *testing.T) {
+	vault := t.TempDir()
+	projectsDir := filepath.Join(vault, "projects")
+	if err := ticket.SeedTemplates(filepath.Join(vault, "templates")); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Settings: config.SettingsConfig{VaultPath: vault}}
+	h := handler.New(cfg, projectsDir, t.TempDir(), sse.NewBroker())
+
+	// Picking a template fills in its defaults and skeleton.
+	req := httptest.NewRequest(http.MethodGet, "/partials/form/new?project=web&title=Crash&status=OPEN&priority=P3&tags=ui&template=bug", nil)
+	w := httptest.NewRecorder()
+	h.PartialNewTicket(w, req)
+	body := w.Body.String()
+	for _, want := range []string{`<option value="bug" selected`, `<option value="P2" selected`, `value="ui,bug"`, "### Steps to reproduce"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("form missing %q:\n%s", want, body)
+		}
+	}
+
+	create := func(status string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set("title", "Crash")
+		form.Set("project", "web")
+		form.Set("status", status)
+		form.Set("priority", "P2")
+		form.Set("template", "bug")
+		req := httptest.NewRequest(http.MethodPost, "/new", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.CreateTicket(w, req)
+		return w
+	}
+
+	// An unfilled skeleton cannot be created OPEN, but can go to BACKLOG.
+	if w := create("OPEN"); !strings.Contains(w.Body.String(), "required sections are empty") {
+		t.Errorf("expected missing sections error, got %d:\n%s", w.Code, w.Body.String())
+	}
+	if w := create("BACKLOG"); w.Code != http.StatusSeeOther {
+		t.Fatalf("expected 303, got %d: %s", w.Code, w.Body.String())
+	}
+	tickets, err := ticket.NewStore(projectsDir).List(ticket.ListFilter{})
+	if err != nil || len(tickets) != 1 {
+		t.Fatalf("tickets = %v, err = %v", tickets, err)
+	}
+	if missing := ticket.MissingSections(tickets[0]); len(missing) != 3 {
+		t.Errorf("missing = %v", missing)
+	}
+}
+
+func TestUpdateTicketDependencies(t *testing.T) {
+	h, projectsDir, eventsDir := testSetup(t)
+
+	update := func(id, dependsOn string) *httptest.ResponseRecorder {
+		form := url.Values{}
+		form.Set("title", "Edited")
+		form.Set("project", "testproj")
+		form.Set("status", "OPEN")
+		form.Set("priority", "P3")
+		form.Set("depends_on", dependsOn)
+		req := httptest.NewRequest(http.MethodPost, "/ticket/"+id+"/edit", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("id", id)
+		w := httptest.NewRecorder()
+		h.UpdateTicket(w, req)
+		return w
+	}
+
+	if w := update("st_abc123", "st_def456"); w.Code != http.StatusSeeOther {
+		t.Fatalf("expected 303, got %d: %s", w.Code, w.Body.String())
+	}
+	tk, err := ticket.NewStore(projectsDir).Get("st_abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.Status != ticket.StatusBlocked || len(tk.DependsOn) != 1 {
+		t.Errorf("expected BLOCKED on st_def456, got %s %v", tk.Status, tk.DependsOn)
+	}
+	events, err := event.QueryEvents(eventsDir, event.Query{TicketID: "st_abc123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var changed bool
+	for _, e := range events {
+		changed = changed || e.Event == event.TicketDepsChanged
+	}
+	if !changed {
+		t.Error("expected a ticket.deps-changed event")
+	}
+
+	// Closing the cycle is rejected with the offending path.
+	w := update("st_def456", "st_abc123")
+	if !strings.Contains(w.Body.String(), "st_def456 → st_abc123 → st_def456") {
+		t.Errorf("expected a cycle error, got %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestListQuery(t *testing.T) {
+	h, _, _ := testSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/list?q="+url.QueryEscape("priority:<=P2 assignee:session-123"), nil)
+	w := httptest.NewRecorder()
+	h.List(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `data-ticket-id="st_def456"`) || strings.Contains(body, `data-ticket-id="st_abc123"`) {
+		t.Errorf("expected only st_def456 in the list:\n%s", body)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/partials/list?q=colour:red", nil)
+	req.Header.Set("HX-Request", "true")
+	w = httptest.NewRecorder()
+	h.PartialList(w, req)
+	if !strings.Contains(w.Body.String(), "unknown query key") {
+		t.Errorf("expected a query error, got:\n%s", w.Body.String())
+	}
+	if got := w.Header().Get("HX-Push-Url"); got != "/list?q=colour%3Ared" {
+		t.Errorf("HX-Push-Url = %q", got)
+	}
+}
+
+func TestListSavedQuery(t *testing.T) {
+	projectsDir := t.TempDir()
+	eventsDir := t.TempDir()
+	store := ticket.NewStore(projectsDir)
+	now := time.Now().UTC()
+	for _, tk := range []*ticket.Ticket{
+		{ID: "st_aaa111", Title: "API ticket", Project: "p", Status: ticket.StatusOpen, Priority: ticket.PriorityP3, Tags: []string{"api"}, Created: now, Updated: now},
+		{ID: "st_bbb222", Title: "Other ticket", Project: "p", Status: ticket.StatusOpen, Priority: ticket.PriorityP3, Created: now, Updated: now},
+	} {
+		if err := store.Create(tk); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg := &config.Config{Queries: map[string]string{"api": "tag:api"}}
+	h := handler.New(cfg, projectsDir, eventsDir, sse.NewBroker())
+
+	req := httptest.NewRequest(http.MethodGet, "/list?q=%40api", nil)
+	w := httptest.NewRecorder()
+	h.List(w, req)
+
+	body := w.Body.String()
+	if !strings.Contains(body, `data-ticket-id="st_aaa111"`) || strings.Contains(body, `data-ticket-id="st_bbb222"`) {
+		t.Errorf("expected only st_aaa111 in the list:\n%s", body)
+	}
+	if !strings.Contains(body, "st-saved-query") {
+		t.Error("expected saved query links")
+	}
+}
+
+func TestSearchTicketsQuery(t *testing.T) {
+	h, _, _ := testSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search-tickets?q="+url.QueryEscape("status:open"), nil)
+	w := httptest.NewRecorder()
+	h.SearchTickets(w, req)
+
+	var result []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0]["id"] != "st_abc123" {
+		t.Errorf("expected only st_abc123, got %v", result)
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/api/search-tickets?q="+url.QueryEscape("priority:P9"), nil)
+	w = httptest.NewRecorder()
+	h.SearchTickets(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a bad query, got %d", w.Code)
+	}
+}
+
+func TestSearchFullText(t *testing.T) {
+	h, projectsDir, _ := testSetup(t)
+	store := ticket.NewStore(projectsDir)
+	tk, err := store.Get("st_def456")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ticket.AppendSection(tk, "Note", "agent", "session-123", "Settled on a token bucket per key.", nil, time.Now().UTC())
+	if err := store.Save(tk); err != nil {
+		t.Fatal(err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/search-tickets?q="+url.QueryEscape("token bucket"), nil)
+	w := httptest.NewRecorder()
+	h.SearchTickets(w, req)
+
+	var result []map[string]any
+	if err := json.Unmarshal(w.Body.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result) != 1 || result[0]["id"] != "st_def456" || result[0]["section"] != "Note" {
+		t.Fatalf("expected st_def456 via its note, got %v", result)
+	}
+	if !strings.Contains(result[0]["snippet"].(string), "token bucket") {
+		t.Errorf("snippet = %v", result[0]["snippet"])
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/search?q="+url.QueryEscape("bucket"), nil)
+	w = httptest.NewRecorder()
+	h.Search(w, req)
+	body := w.Body.String()
+	if !strings.Contains(body, `data-ticket-id="st_def456"`) || !strings.Contains(body, "<mark>bucket</mark>") {
+		t.Errorf("expected a highlighted result:\n%s", body)
+	}
+}
+
 func TestCriticalPathPage(t *testing.T) {
 	h, projectsDir, _ := testSetup(t)
 	store := ticket.NewStore(projectsDir)
@@ -743,6 +1048,32 @@ func TestCriticalPathPage(t *testing.T) {
 	}
 }
 
+func TestCriticalPathShowsRelations(t *testing.T) {
+	h, projectsDir, _ := testSetup(t)
+	store := ticket.NewStore(projectsDir)
+
+	blocker, err := store.Get("st_abc123")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocker.AddRelation(ticket.RelationBlocks, "st_def456")
+	blocker.AddRelation(ticket.RelationRelatesTo, "st_def456")
+	if err := store.Save(blocker); err != nil {
+		t.Fatal(err)
+	}
+
+	w := httptest.NewRecorder()
+	h.CriticalPath(w, httptest.NewRequest(http.MethodGet, "/critical-path", nil))
+
+	body := w.Body.String()
+	if !strings.Contains(body, `&#34;kind&#34;:&#34;blocks&#34;`) && !strings.Contains(body, `"kind":"blocks"`) {
+		t.Error("expected a blocks edge in the graph data")
+	}
+	if !strings.Contains(body, "relates-to st_def456") {
+		t.Error("expected non-blocking relations listed on the node")
+	}
+}
+
 func TestCriticalPathScopeDefaultsToAllAndSupportsCurrent(t *testing.T) {
 	h, projectsDir, _ := testSetup(t)
 	store := ticket.NewStore(projectsDir)
@@ -873,6 +1204,56 @@ func TestSessions(t *testing.T) {
 	}
 }
 
+func TestSessionsShowsUsage(t *testing.T) {
+	h, _, eventsDir := testSetup(t)
+
+	evLog := event.NewEventLog(eventsDir)
+	for _, ev := range []event.Event{
+		{
+			TS:      time.Now().UTC().Add(-30 * time.Second),
+			Event:   event.HookSessionStart,
+			Ticket:  "st_def456",
+			Project: "testproj",
+			Actor:   "agent",
+			RunID:   "session-123",
+			Source:  "claude",
+		},
+		{
+			TS:      time.Now().UTC().Add(-10 * time.Second),
+			Event:   event.UsageRecorded,
+			Ticket:  "st_def456",
+			Project: "testproj",
+			Actor:   "agent",
+			RunID:   "session-123",
+			Source:  "claude",
+			Data:    usage.Usage{InputTokens: 1_000_000, OutputTokens: 200_000, Requests: 5, Turns: 3}.Data("claude-sonnet-4-5"),
+		},
+	} {
+		if err := evLog.Append(ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/sessions", nil)
+	w := httptest.NewRecorder()
+	h.Sessions(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{"Usage by project", "Usage by ticket", "1.2M", "$6.00", "In progress ticket"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected sessions page to contain %q", want)
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/partials/session/session-123", nil)
+	req.SetPathValue("runID", "session-123")
+	w = httptest.NewRecorder()
+	h.SessionDetail(w, req)
+	if body := w.Body.String(); !strings.Contains(body, "1.2M tokens, est. $6.00") {
+		t.Error("expected session detail to show usage total")
+	}
+}
+
 func TestSessionsShowsStalledIndicatorAfterTwoMinutes(t *testing.T) {
 	h, _, eventsDir := testSetup(t)
 
@@ -1149,3 +1530,255 @@ func TestWatcherHookEventBroadcastsActivityOnly(t *testing.T) {
 		}
 	}
 }
+
+func TestRulesPageHasTestBox(t *testing.T) {
+	h, _, _ := testSetup(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/rules", nil)
+	w := httptest.NewRecorder()
+	h.Rules(w, req)
+
+	if !strings.Contains(w.Body.String(), `hx-post="/rules/test"`) {
+		t.Error("expected rules page to include the rule test box")
+	}
+}
+
+func TestTestRuleRendersTrace(t *testing.T) {
+	vault := t.TempDir()
+	rulesDir := filepath.Join(vault, "rules")
+	if err := os.MkdirAll(rulesDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	ruleset := "name: go\npriority: 50\nrules:\n  - name: allow-go-test\n    match:\n      tool: Bash\n      command: ^go\\s+test\\b\n    action: allow\n"
+	if err := os.WriteFile(filepath.Join(rulesDir, "go.yaml"), []byte(ruleset), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Settings: config.SettingsConfig{VaultPath: vault}}
+	h := handler.New(cfg, t.TempDir(), t.TempDir(), sse.NewBroker())
+
+	form := url.Values{}
+	form.Set("tool", "Bash")
+	form.Set("value", "go test ./...")
+	req := httptest.NewRequest(http.MethodPost, "/rules/test", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	h.TestRule(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", w.Code)
+	}
+	body := w.Body.String()
+	for _, want := range []string{"go / allow-go-test", "matched", "allow"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected body to contain %q", want)
+		}
+	}
+}
+
+func TestTestRuleMissingValue(t *testing.T) {
+	h, _, _ := testSetup(t)
+
+	req := httptest.NewRequest(http.MethodPost, "/rules/test", strings.NewReader("tool=Bash"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	h.TestRule(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestTestRuleUnknownProject(t *testing.T) {
+	h, _, _ := testSetup(t)
+
+	form := url.Values{}
+	form.Set("tool", "Bash")
+	form.Set("value", "ls")
+	form.Set("project", "../../etc")
+	req := httptest.NewRequest(http.MethodPost, "/rules/test", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+
+	h.TestRule(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestRulesPageShowsProjectLayers(t *testing.T) {
+	vault := t.TempDir()
+	rulesDir := filepath.Join(vault, "rules")
+	overlayDir := filepath.Join(vault, "projects", "rusty", "rules")
+	for _, d := range []string{rulesDir, overlayDir} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	global := "name: tools\npriority: 50\nrules:\n  - name: allow-npm-test\n    match:\n      tool: Bash\n      command: ^npm\\s+test\\b\n    action: allow\n"
+	overlay := "name: cargo\npriority: 50\ndisable:\n  - allow-npm-test\nrules:\n  - name: allow-cargo-test\n    match:\n      tool: Bash\n      command: ^cargo\\s+test\\b\n    action: allow\n"
+	if err := os.WriteFile(filepath.Join(rulesDir, "tools.yaml"), []byte(global), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(overlayDir, "cargo.yaml"), []byte(overlay), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := project.SaveMeta(vault, "rusty", &project.ProjectMeta{Path: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{Settings: config.SettingsConfig{VaultPath: vault}}
+	h := handler.New(cfg, filepath.Join(vault, "projects"), t.TempDir(), sse.NewBroker())
+
+	req := httptest.NewRequest(http.MethodGet, "/rules?project=rusty", nil)
+	w := httptest.NewRecorder()
+	h.Rules(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{">global<", ">rusty<", "cargo", "allow-npm-test", "line-through"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected rules page to contain %q", want)
+		}
+	}
+
+	form := url.Values{}
+	form.Set("tool", "Bash")
+	form.Set("value", "cargo test")
+	form.Set("project", "rusty")
+	req = httptest.NewRequest(http.MethodPost, "/rules/test", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.TestRule(w, req)
+
+	if !strings.Contains(w.Body.String(), "cargo / allow-cargo-test") {
+		t.Errorf("expected project overlay rule to match, got:\n%s", w.Body.String())
+	}
+}
+
+func TestRulesPageShowsSuggestions(t *testing.T) {
+	vault := t.TempDir()
+	eventsDir := t.TempDir()
+	el := event.NewEventLog(eventsDir)
+	for _, cmd := range []string{"go run ./cmd/st list", "go run ./cmd/web", "go run ./cmd/st next"} {
+		if err := el.Append(event.Event{
+			TS:    time.Now().UTC().Add(-time.Hour),
+			Event: event.HookPermissionReq,
+			RunID: "run-1",
+			Data:  map[string]any{"tool": "Bash", "command": cmd},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{Settings: config.SettingsConfig{VaultPath: vault}}
+	h := handler.New(cfg, filepath.Join(vault, "projects"), eventsDir, sse.NewBroker())
+
+	req := httptest.NewRequest(http.MethodGet, "/rules", nil)
+	w := httptest.NewRecorder()
+	h.Rules(w, req)
+
+	body := w.Body.String()
+	for _, want := range []string{"Suggested rules", `^go\s+run\s+\./cmd/\S+`, "3 approvals", "/rules/suggestions/accept", "allow-go-run-cmd"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected rules page to contain %q", want)
+		}
+	}
+}
+
+func TestAcceptRuleSuggestion(t *testing.T) {
+	vault := t.TempDir()
+	cfg := &config.Config{Settings: config.SettingsConfig{VaultPath: vault}}
+	h := handler.New(cfg, filepath.Join(vault, "projects"), t.TempDir(), sse.NewBroker())
+
+	form := url.Values{}
+	form.Set("tool", "Bash")
+	form.Set("name", "allow-go-run-cmd")
+	form.Set("pattern", `^go\s+run\s+\./cmd/\S+`)
+	req := httptest.NewRequest(http.MethodPost, "/rules/suggestions/accept", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w := httptest.NewRecorder()
+	h.AcceptRuleSuggestion(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	rulesDir := filepath.Join(vault, "rules")
+	result := rules.Evaluate(rulesDir, "PreToolUse", "Bash", map[string]any{"command": "go run ./cmd/st list"})
+	if result == nil || result.Decision != rules.ActionAllow || result.Rule != "allow-go-run-cmd" {
+		t.Errorf("evaluate after accept = %+v, want allow by allow-go-run-cmd", result)
+	}
+
+	// Invalid patterns are rejected.
+	form.Set("pattern", `^go(`)
+	req = httptest.NewRequest(http.MethodPost, "/rules/suggestions/accept", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.AcceptRuleSuggestion(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("invalid pattern: expected 400, got %d", w.Code)
+	}
+
+	// So are patterns that would make LoadRulesets drop every rule.
+	form.Set("name", "allow-nested")
+	form.Set("pattern", `^(a+)+$`)
+	req = httptest.NewRequest(http.MethodPost, "/rules/suggestions/accept", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	w = httptest.NewRecorder()
+	h.AcceptRuleSuggestion(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("nested quantifiers: expected 400, got %d", w.Code)
+	}
+	result = rules.Evaluate(rulesDir, "PreToolUse", "Bash", map[string]any{"command": "go run ./cmd/st list"})
+	if result == nil || result.Decision != rules.ActionAllow {
+		t.Errorf("evaluate after rejected pattern = %+v, want the earlier rule intact", result)
+	}
+}
+
+func TestProjectKnowledgeAddRemove(t *testing.T) {
+	vault := t.TempDir()
+	projectsDir := filepath.Join(vault, "projects")
+	eventsDir := t.TempDir()
+	if err := project.SaveMeta(vault, "testproj", &project.ProjectMeta{}); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &config.Config{Settings: config.SettingsConfig{VaultPath: vault}}
+	h := handler.New(cfg, projectsDir, eventsDir, sse.NewBroker())
+
+	post := func(handle http.HandlerFunc, path string, form url.Values, name string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetPathValue("name", name)
+		w := httptest.NewRecorder()
+		handle(w, req)
+		return w
+	}
+
+	w := post(h.AddKnowledge, "/projects/testproj/knowledge", url.Values{"fact": {"Run make generate before building"}}, "testproj")
+	if w.Code != http.StatusOK {
+		t.Fatalf("add: status %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "Run make generate before building") || !strings.Contains(w.Body.String(), "Knowledge (1)") {
+		t.Errorf("projects partial missing learning:\n%s", w.Body.String())
+	}
+
+	if w := post(h.AddKnowledge, "/projects/nope/knowledge", url.Values{"fact": {"x"}}, "nope"); w.Code != http.StatusNotFound {
+		t.Errorf("unknown project: status %d, want 404", w.Code)
+	}
+
+	w = post(h.RemoveKnowledge, "/projects/testproj/knowledge/remove", url.Values{"n": {"1"}}, "testproj")
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Knowledge (0)") {
+		t.Fatalf("remove: status %d:\n%s", w.Code, w.Body.String())
+	}
+
+	events, err := event.QueryEvents(eventsDir, event.Query{Project: "testproj"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 || events[0].Event != event.KnowledgeAdded || events[1].Event != event.KnowledgeRemoved {
+		t.Errorf("events = %+v", events)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/web/templates"
+)
+
+// List renders the ticket list page filtered by the ?q= query.
+func (h *Handler) List(w http.ResponseWriter, r *http.Request) {
+	data := h.buildListData(r)
+	_ = templates.ListPage(data).Render(r.Context(), w)
+}
+
+// PartialList renders the ticket list partial for htmx swaps.
+func (h *Handler) PartialList(w http.ResponseWriter, r *http.Request) {
+	data := h.buildListData(r)
+	// Push the canonical /list URL so queries are bookmarkable, except on
+	// the partial's own SSE refresh.
+	if r.Header.Get("HX-Trigger") != "st-list-refresh" {
+		w.Header().Set("HX-Push-Url", templates.ListURL("/list", data.Query, data.CurrentProject))
+	}
+	_ = templates.ListPartial(data).Render(r.Context(), w)
+}
+
+func (h *Handler) buildListData(r *http.Request) templates.ListData {
+	filterProject := r.URL.Query().Get("project")
+	raw := strings.TrimSpace(r.URL.Query().Get("q"))
+	data := templates.ListData{
+		Query:          raw,
+		Saved:          h.cfg.Queries,
+		CurrentProject: filterProject,
+		Projects:       h.allProjects(),
+	}
+
+	query, err := h.parseTicketQuery(raw, filterProject)
+	if err != nil {
+		data.Error = err.Error()
+		return data
+	}
+	// Like `st list`, finished tickets are hidden unless a status is asked for.
+	if len(query.Filter.Statuses) == 0 {
+		query.Filter.Excludes = []ticket.Status{ticket.StatusDone, ticket.StatusCancelled}
+	}
+
+	tickets, err := h.queryTickets(query)
+	if err != nil {
+		data.Error = err.Error()
+		return data
+	}
+	sort.SliceStable(tickets, func(i, j int) bool {
+		if tickets[i].Priority != tickets[j].Priority {
+			return tickets[i].Priority < tickets[j].Priority
+		}
+		return tickets[i].Updated.After(tickets[j].Updated)
+	})
+	query.SortTickets(tickets)
+	data.Tickets = tickets
+	return data
+}
+
+// parseTicketQuery expands saved queries in raw and parses it. A project
+// selected in the UI applies unless the query names one.
+func (h *Handler) parseTicketQuery(raw, project string) (ticket.Query, error) {
+	expanded, err := ticket.ExpandSaved(raw, h.cfg.Queries)
+	if err != nil {
+		return ticket.Query{}, err
+	}
+	query, err := ticket.ParseQuery(expanded, time.Now().UTC())
+	if err != nil {
+		return ticket.Query{}, err
+	}
+	if query.Filter.Project == "" {
+		query.Filter.Project = project
+	}
+	return query, nil
+}
+
+// queryTickets lists the tickets matching the query, reading bodies only
+// when text terms need them.
+func (h *Handler) queryTickets(query ticket.Query) ([]*ticket.Ticket, error) {
+	if len(query.Filter.Text) > 0 {
+		return h.store.List(query.Filter)
+	}
+	return h.store.ListMeta(query.Filter)
+}
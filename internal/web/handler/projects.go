@@ -1,14 +1,18 @@
 package handler
 
 import (
+	"fmt"
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/knowledge"
 	"github.com/boozedog/smoovtask/internal/project"
 	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/boozedog/smoovtask/internal/web/templates"
@@ -105,6 +109,11 @@ func (h *Handler) buildProjectsData(r *http.Request) templates.ProjectsData {
 			s.Path = pm.Path
 			s.Repo = pm.Repo
 		}
+		if vaultPath != "" {
+			if kb, err := knowledge.Load(vaultPath, name); err == nil {
+				s.Knowledge = kb.Entries()
+			}
+		}
 
 		// Ticket stats.
 		for _, tk := range ticketsByProject[name] {
@@ -163,6 +172,75 @@ func (h *Handler) buildProjectsData(r *http.Request) templates.ProjectsData {
 	}
 }
 
+// AddKnowledge handles POST /projects/{name}/knowledge, recording a learning
+// entered on the projects page.
+func (h *Handler) AddKnowledge(w http.ResponseWriter, r *http.Request) {
+	h.updateKnowledge(w, r, func(kb *knowledge.Base) (knowledge.Entry, string, error) {
+		e := knowledge.Entry{Fact: r.FormValue("fact"), Date: time.Now().UTC()}
+		if err := kb.Add(e); err != nil {
+			return e, "", err
+		}
+		e.Fact = strings.Join(strings.Fields(e.Fact), " ")
+		return e, event.KnowledgeAdded, nil
+	})
+}
+
+// RemoveKnowledge handles POST /projects/{name}/knowledge/remove, deleting
+// the learning numbered n (1-based).
+func (h *Handler) RemoveKnowledge(w http.ResponseWriter, r *http.Request) {
+	h.updateKnowledge(w, r, func(kb *knowledge.Base) (knowledge.Entry, string, error) {
+		n, err := strconv.Atoi(r.FormValue("n"))
+		if err != nil {
+			return knowledge.Entry{}, "", fmt.Errorf("invalid learning number")
+		}
+		e, err := kb.Remove(n)
+		return e, event.KnowledgeRemoved, err
+	})
+}
+
+// updateKnowledge applies change to a registered project's knowledge base,
+// logs the event and re-renders the projects partial.
+func (h *Handler) updateKnowledge(w http.ResponseWriter, r *http.Request, change func(*knowledge.Base) (knowledge.Entry, string, error)) {
+	name := r.PathValue("name")
+	vaultPath, err := h.cfg.VaultPath()
+	if err != nil {
+		http.Error(w, "vault path: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+	names, _ := project.ListProjects(vaultPath)
+	if !slices.Contains(names, name) {
+		http.Error(w, "unknown project", http.StatusNotFound)
+		return
+	}
+
+	kb, err := knowledge.Load(vaultPath, name)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	entry, evType, err := change(kb)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := kb.Save(); err != nil {
+		http.Error(w, "save knowledge: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	_ = event.NewEventLog(h.eventsDir).Append(event.Event{
+		TS:      time.Now().UTC(),
+		Event:   evType,
+		Ticket:  entry.Ticket,
+		Project: name,
+		Actor:   "web",
+		Data:    map[string]any{"fact": entry.Fact},
+	})
+
+	data := h.buildProjectsData(r)
+	_ = templates.ProjectsPartial(data).Render(r.Context(), w)
+}
+
 func scanWorktrees(projectPath string) []templates.WorktreeInfo {
 	wtDir := filepath.Join(projectPath, ".worktrees")
 	entries, err := os.ReadDir(wtDir)
@@ -7,11 +7,14 @@ import (
 	"os"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/project"
+	"github.com/boozedog/smoovtask/internal/rules"
 	"github.com/boozedog/smoovtask/internal/web/templates"
 	"gopkg.in/yaml.v3"
 )
@@ -53,6 +56,105 @@ func (h *Handler) AllowRule(w http.ResponseWriter, r *http.Request) {
 	_ = templates.RulesPartial(data).Render(r.Context(), w)
 }
 
+// AcceptRuleSuggestion handles POST requests to add a suggested rule to the
+// allowlist with its generalized pattern.
+func (h *Handler) AcceptRuleSuggestion(w http.ResponseWriter, r *http.Request) {
+	tool := r.FormValue("tool")
+	name := r.FormValue("name")
+	pattern := r.FormValue("pattern")
+	if tool == "" || name == "" || pattern == "" {
+		http.Error(w, "missing tool, name or pattern", http.StatusBadRequest)
+		return
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		http.Error(w, "invalid pattern: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	// A pattern LoadRulesets would reject must never reach the rule files.
+	if err := rules.CheckRegexComplexity(pattern); err != nil {
+		http.Error(w, "invalid pattern: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rulesDir, err := h.cfg.RulesDir()
+	if err != nil {
+		http.Error(w, "rules dir: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if err := appendAllowRule(rulesDir, tool, sanitizeRuleName(name), pattern, "suggested from permission history"); err != nil {
+		http.Error(w, "add rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := h.buildRulesData(r)
+	_ = templates.RulesPartial(data).Render(r.Context(), w)
+}
+
+// TestRule handles POST requests from the rules page test box, evaluating a
+// single request against the current rules and rendering the trace.
+func (h *Handler) TestRule(w http.ResponseWriter, r *http.Request) {
+	data := templates.RuleTestData{
+		Tool:  r.FormValue("tool"),
+		Value: strings.TrimSpace(r.FormValue("value")),
+	}
+	if data.Tool == "" || data.Value == "" {
+		http.Error(w, "missing tool or value", http.StatusBadRequest)
+		return
+	}
+
+	rulesDir, err := h.cfg.RulesDir()
+	if err != nil {
+		http.Error(w, "rules dir: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	proj := r.FormValue("project")
+	var projectRulesDir string
+	if proj != "" {
+		if !h.isProject(proj) {
+			http.Error(w, "unknown project", http.StatusBadRequest)
+			return
+		}
+		if projectRulesDir, err = h.cfg.ProjectRulesDir(proj); err != nil {
+			http.Error(w, "project rules dir: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	tr, err := rules.EvaluateTrace(rulesDir, projectRulesDir, proj, "PreToolUse", data.Tool, ruleTestInput(data.Tool, data.Value))
+	if err != nil {
+		data.Error = err.Error()
+	}
+	data.Trace = tr
+	_ = templates.RuleTestResult(data).Render(r.Context(), w)
+}
+
+// isProject reports whether name is a registered project, so form values
+// never build paths outside the vault's projects directory.
+func (h *Handler) isProject(name string) bool {
+	vaultPath, err := h.cfg.VaultPath()
+	if err != nil {
+		return false
+	}
+	names, _ := project.ListProjects(vaultPath)
+	return slices.Contains(names, name)
+}
+
+// ruleTestInput maps the test box value onto the tool input field the tool uses.
+func ruleTestInput(tool, value string) map[string]any {
+	switch tool {
+	case "Bash":
+		return map[string]any{"command": value}
+	case "WebFetch":
+		return map[string]any{"url": value}
+	case "Glob", "Grep":
+		return map[string]any{"pattern": value}
+	default:
+		return map[string]any{"file_path": value}
+	}
+}
+
 func (h *Handler) buildRulesData(r *http.Request) templates.RulesData {
 	filterProject := r.URL.Query().Get("project")
 
@@ -174,13 +276,110 @@ func (h *Handler) buildRulesData(r *http.Request) templates.RulesData {
 
 	return templates.RulesData{
 		Groups:         result,
+		Suggestions:    h.buildRuleSuggestions(events),
+		Layers:         h.buildRuleLayers(filterProject),
 		CurrentProject: filterProject,
 		Projects:       h.allProjects(),
 	}
 }
 
+// buildRuleSuggestions mines the queried events for allow rules the user
+// keeps approving by hand.
+func (h *Handler) buildRuleSuggestions(events []event.Event) []templates.RuleSuggestion {
+	rulesDir, err := h.cfg.RulesDir()
+	if err != nil {
+		return nil
+	}
+	layers, err := rules.ProjectLayers(rulesDir, func(proj string) string {
+		dir, _ := h.cfg.ProjectRulesDir(proj)
+		return dir
+	})
+	if err != nil {
+		return nil
+	}
+
+	var out []templates.RuleSuggestion
+	for _, s := range rules.Suggest(layers, events, rules.SuggestOptions{}) {
+		out = append(out, templates.RuleSuggestion{
+			Tool:     s.Tool,
+			Name:     s.Name,
+			Pattern:  s.Pattern,
+			Risk:     s.Risk,
+			Count:    s.Count,
+			Examples: s.Examples,
+			YAML:     s.YAML(),
+		})
+		if len(out) == maxRuleSuggestions {
+			break
+		}
+	}
+	return out
+}
+
+// maxRuleSuggestions caps the suggestions shown on the rules page.
+const maxRuleSuggestions = 10
+
+// buildRuleLayers summarizes the rulesets in effect for project (global only
+// when project is empty), grouped by origin.
+func (h *Handler) buildRuleLayers(project string) []templates.RuleLayer {
+	rulesDir, err := h.cfg.RulesDir()
+	if err != nil {
+		return nil
+	}
+	var projectRulesDir string
+	if project != "" {
+		if !h.isProject(project) {
+			return nil
+		}
+		if projectRulesDir, err = h.cfg.ProjectRulesDir(project); err != nil {
+			return nil
+		}
+	}
+	rulesets, _, err := rules.LoadLayers(rulesDir, projectRulesDir, project)
+	if err != nil {
+		return nil
+	}
+
+	var layers []templates.RuleLayer
+	for _, group := range rules.GroupByOrigin(rulesets) {
+		layer := templates.RuleLayer{Origin: group[0].Origin}
+		for _, rs := range group {
+			layer.Rulesets = append(layer.Rulesets, templates.RulesetSummary{
+				Name:     rs.Name,
+				Priority: rs.Priority,
+				Rules:    len(rs.Rules),
+				Disabled: rs.Disabled,
+			})
+		}
+		layers = append(layers, layer)
+	}
+	return layers
+}
+
 // addAllowRule appends a new allow rule to user-allowlist.md in the rules dir.
 func addAllowRule(rulesDir, tool, command string) error {
+	// Build a safe rule name from the command.
+	name := "allow-" + tool
+	if command != "" {
+		// Take first word of command for the name.
+		parts := strings.Fields(command)
+		if len(parts) > 0 {
+			name += "-" + sanitizeRuleName(parts[0])
+		}
+	}
+
+	// Build a regex pattern from the command.
+	var pattern string
+	if command != "" {
+		pattern = buildCommandPattern(command)
+	}
+
+	return appendAllowRule(rulesDir, tool, name, pattern, "allowed from web UI")
+}
+
+// appendAllowRule appends an allow rule with the given command pattern to
+// user-allowlist.md in the rules dir, unless an identical rule exists.
+func appendAllowRule(rulesDir, tool, name, pattern, message string) error {
 	if err := os.MkdirAll(rulesDir, 0o755); err != nil {
 		return fmt.Errorf("create rules dir: %w", err)
 	}
@@ -221,33 +420,18 @@ func addAllowRule(rulesDir, tool, command string) error {
 		}
 	}
 
-	// Build a safe rule name from the command.
-	safeName := "allow-" + tool
-	if command != "" {
-		// Take first word of command for the name.
-		parts := strings.Fields(command)
-		if len(parts) > 0 {
-			safeName += "-" + sanitizeRuleName(parts[0])
-		}
-	}
-
 	// Check if a rule with this exact match already exists.
 	for _, r := range rs.Rules {
-		if r.Match.Tool == tool && r.Match.Command == command {
+		if r.Match.Tool == tool && r.Match.Command == pattern {
 			return nil // Already exists.
 		}
 	}
 
 	newRule := rule{
-		Name:    safeName,
-		Match:   matchConfig{Tool: tool},
+		Name:    name,
+		Match:   matchConfig{Tool: tool, Command: pattern},
 		Action:  "allow",
-		Message: "allowed from web UI",
-	}
-
-	// Build a regex pattern from the command.
-	if command != "" {
-		newRule.Match.Command = buildCommandPattern(command)
+		Message: message,
 	}
 
 	rs.Rules = append(rs.Rules, newRule)
@@ -3,9 +3,12 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/ticket"
+	"github.com/boozedog/smoovtask/internal/web/templates"
 )
 
 type searchTicket struct {
@@ -14,30 +17,90 @@ type searchTicket struct {
 	Project  string    `json:"project"`
 	Status   string    `json:"status"`
 	Priority string    `json:"priority"`
+	Tags     []string  `json:"tags"`
+	Assignee string    `json:"assignee,omitempty"`
 	Updated  time.Time `json:"updated"`
+	Section  string    `json:"section,omitempty"`
+	Snippet  string    `json:"snippet,omitempty"`
 }
 
-// SearchTickets returns all ticket metadata as JSON for client-side search.
+// defaultSearchLimit caps search results unless ?limit= says otherwise.
+const defaultSearchLimit = 50
+
+// SearchTickets returns full-text search results as JSON, ranked, with the
+// best-matching section and a snippet. ?q= takes search terms and the query
+// keys of the list view; without terms, the filtered tickets are returned
+// most recently updated first.
 func (h *Handler) SearchTickets(w http.ResponseWriter, r *http.Request) {
-	tickets, err := h.store.ListMeta(ticket.ListFilter{})
+	results, err := h.searchTickets(r)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	result := make([]searchTicket, len(tickets))
-	for i, tk := range tickets {
-		result[i] = searchTicket{
+	out := make([]searchTicket, len(results))
+	for i, res := range results {
+		tk := res.Ticket
+		out[i] = searchTicket{
 			ID:       tk.ID,
 			Title:    tk.Title,
 			Project:  tk.Project,
 			Status:   string(tk.Status),
 			Priority: string(tk.Priority),
+			Tags:     tk.Tags,
+			Assignee: tk.Assignee,
 			Updated:  tk.Updated,
+			Section:  res.Section,
+			Snippet:  res.Snippet,
 		}
 	}
 
 	w.Header().Set("Content-Type", "application/json")
 	w.Header().Set("Cache-Control", "no-cache")
-	_ = json.NewEncoder(w).Encode(result)
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// Search renders the full-text search page.
+func (h *Handler) Search(w http.ResponseWriter, r *http.Request) {
+	data := h.buildSearchData(r)
+	_ = templates.SearchPage(data).Render(r.Context(), w)
+}
+
+// PartialSearch renders the search results partial for htmx swaps.
+func (h *Handler) PartialSearch(w http.ResponseWriter, r *http.Request) {
+	data := h.buildSearchData(r)
+	w.Header().Set("HX-Push-Url", templates.ListURL("/search", data.Query, data.CurrentProject))
+	_ = templates.SearchContent(data).Render(r.Context(), w)
+}
+
+func (h *Handler) buildSearchData(r *http.Request) templates.SearchData {
+	data := templates.SearchData{
+		Query:          strings.TrimSpace(r.URL.Query().Get("q")),
+		CurrentProject: r.URL.Query().Get("project"),
+		Projects:       h.allProjects(),
+	}
+	if data.Query == "" {
+		return data
+	}
+	results, err := h.searchTickets(r)
+	if err != nil {
+		data.Error = err.Error()
+		return data
+	}
+	data.Results = results
+	return data
+}
+
+// searchTickets runs the request's ?q= search, scoped to ?project= unless
+// the query names a project.
+func (h *Handler) searchTickets(r *http.Request) ([]ticket.SearchResult, error) {
+	query, err := h.parseTicketQuery(r.URL.Query().Get("q"), r.URL.Query().Get("project"))
+	if err != nil {
+		return nil, err
+	}
+	limit := defaultSearchLimit
+	if n, err := strconv.Atoi(r.URL.Query().Get("limit")); err == nil {
+		limit = n
+	}
+	return h.store.Search(query, limit)
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/boozedog/smoovtask/internal/event"
+	"github.com/boozedog/smoovtask/internal/usage"
 	"github.com/boozedog/smoovtask/internal/web/templates"
 )
 
@@ -38,6 +39,7 @@ func (h *Handler) SessionDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	totalEvents := len(events)
+	runUsage := usage.Summarize(events, usage.ByRun)[runID]
 	if len(events) > maxDetailEvents {
 		events = events[len(events)-maxDetailEvents:]
 	}
@@ -101,6 +103,10 @@ func (h *Handler) SessionDetail(w http.ResponseWriter, r *http.Request) {
 		Events:       events,
 		TotalEvents:  totalEvents,
 	}
+	if len(runUsage) > 0 {
+		data.Tokens = usage.FormatTokens(runUsage.Sum().Tokens())
+		data.Cost = usage.FormatCost(runUsage.Cost(h.pricing()))
+	}
 	_ = templates.SessionDetailPartial(data).Render(r.Context(), w)
 }
 
@@ -128,8 +134,15 @@ func (h *Handler) buildSessionsData(r *http.Request) templates.SessionsData {
 		events      []event.Event
 	}
 
+	var usageEvents []event.Event
 	sessions := make(map[string]*sessionState)
 	for _, ev := range events {
+		if ev.Event == event.UsageRecorded {
+			if filterProject == "" || ev.Project == filterProject {
+				usageEvents = append(usageEvents, ev)
+			}
+			continue
+		}
 		if !strings.HasPrefix(ev.Event, "hook.") {
 			continue
 		}
@@ -169,6 +182,9 @@ func (h *Handler) buildSessionsData(r *http.Request) templates.SessionsData {
 		s.events = append(s.events, ev)
 	}
 
+	pricing := h.pricing()
+	usageByRun := usage.Summarize(usageEvents, usage.ByRun)
+
 	now := time.Now().UTC()
 	var active, ended []templates.SessionInfo
 
@@ -242,6 +258,10 @@ func (h *Handler) buildSessionsData(r *http.Request) templates.SessionsData {
 			EventCount:   s.eventCount,
 			Events:       recentEvents,
 		}
+		if t := usageByRun[s.runID]; len(t) > 0 {
+			info.Tokens = usage.FormatTokens(t.Sum().Tokens())
+			info.Cost = usage.FormatCost(t.Cost(pricing))
+		}
 
 		if isActive {
 			active = append(active, info)
@@ -275,7 +295,53 @@ func (h *Handler) buildSessionsData(r *http.Request) templates.SessionsData {
 
 	return templates.SessionsData{
 		Sessions:       result,
+		ProjectUsage:   h.usageSummaries(usage.Summarize(usageEvents, usage.ByProject), pricing, false),
+		TicketUsage:    h.usageSummaries(usage.Summarize(usageEvents, usage.ByTicket), pricing, true),
 		CurrentProject: filterProject,
 		Projects:       h.allProjects(),
 	}
 }
+
+// pricing returns the model prices for cost estimates.
+func (h *Handler) pricing() usage.Pricing {
+	if h.cfg == nil {
+		return usage.NewPricing(nil)
+	}
+	return usage.NewPricing(h.cfg.Usage.Prices)
+}
+
+// usageSummaries converts grouped usage into display rows, most expensive
+// first. When tickets is set, keys are ticket IDs and titles are resolved.
+func (h *Handler) usageSummaries(groups map[string]usage.Totals, pricing usage.Pricing, tickets bool) []templates.UsageSummary {
+	const maxRows = 10
+
+	out := make([]templates.UsageSummary, 0, len(groups))
+	costs := make(map[string]float64, len(groups))
+	for key, t := range groups {
+		sum := t.Sum()
+		cost, partial := t.Cost(pricing)
+		costs[key] = cost
+		row := templates.UsageSummary{
+			Key:    key,
+			Tokens: usage.FormatTokens(sum.Tokens()),
+			Turns:  sum.Turns,
+			Cost:   usage.FormatCost(cost, partial),
+		}
+		if tickets {
+			if tk, err := h.store.Get(key); err == nil && tk != nil {
+				row.Title = tk.Title
+			}
+		}
+		out = append(out, row)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if costs[out[i].Key] != costs[out[j].Key] {
+			return costs[out[i].Key] > costs[out[j].Key]
+		}
+		return out[i].Key < out[j].Key
+	})
+	if len(out) > maxRows {
+		out = out[:maxRows]
+	}
+	return out
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/boozedog/smoovtask/internal/ticket"
 	"github.com/boozedog/smoovtask/internal/web/templates"
 	"github.com/yuin/goldmark"
 	highlighting "github.com/yuin/goldmark-highlighting/v2"
@@ -113,9 +114,15 @@ func (h *Handler) buildTicketData(r *http.Request) (templates.TicketData, error)
 		return match
 	})
 
+	var links []ticket.Link
+	if all, err := h.store.ListMeta(ticket.ListFilter{}); err == nil {
+		links = ticket.Links(all, tk)
+	}
+
 	return templates.TicketData{
 		Ticket:         tk,
 		BodyHTML:       rendered,
+		Links:          links,
 		CurrentProject: r.URL.Query().Get("project"),
 		Projects:       h.allProjects(),
 	}, nil
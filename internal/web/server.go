@@ -77,10 +77,16 @@ func (s *Server) ListenAndServe(ctx context.Context) error {
 	mux.HandleFunc("GET /agents", func(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/sessions", http.StatusMovedPermanently)
 	})
+	mux.HandleFunc("GET /list", h.List)
+	mux.HandleFunc("GET /search", h.Search)
 	mux.HandleFunc("GET /critical-path", h.CriticalPath)
 	mux.HandleFunc("GET /projects", h.Projects)
+	mux.HandleFunc("POST /projects/{name}/knowledge", h.AddKnowledge)
+	mux.HandleFunc("POST /projects/{name}/knowledge/remove", h.RemoveKnowledge)
 	mux.HandleFunc("GET /rules", h.Rules)
 	mux.HandleFunc("POST /rules/allow", h.AllowRule)
+	mux.HandleFunc("POST /rules/test", h.TestRule)
+	mux.HandleFunc("POST /rules/suggestions/accept", h.AcceptRuleSuggestion)
 
 	// API endpoints.
 	mux.HandleFunc("GET /api/search-tickets", h.SearchTickets)
@@ -97,6 +103,8 @@ func (s *Server) ListenAndServe(ctx context.Context) error {
 	mux.HandleFunc("GET /partials/activity-content", h.PartialActivityContent)
 	mux.HandleFunc("GET /partials/sessions", h.PartialSessions)
 	mux.HandleFunc("GET /partials/session/{runID}", h.SessionDetail)
+	mux.HandleFunc("GET /partials/list", h.PartialList)
+	mux.HandleFunc("GET /partials/search", h.PartialSearch)
 	mux.HandleFunc("GET /partials/critical-path", h.PartialCriticalPath)
 	mux.HandleFunc("GET /partials/projects", h.PartialProjects)
 	mux.HandleFunc("GET /partials/rules", h.PartialRules)
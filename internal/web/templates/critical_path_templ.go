@@ -18,6 +18,7 @@ type CriticalPathData struct {
 	Project        string
 	Graph          ticket.DependencyGraph
 	ByID           map[string]*ticket.Ticket
+	Links          map[string][]ticket.Link
 	RunSources     map[string]string
 	CurrentProject string
 	Projects       []string
@@ -145,7 +146,7 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 			var templ_7745c5c3_Var5 string
 			templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(data.Graph.EdgesJSON())
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 43, Col: 84}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 44, Col: 84}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
 			if templ_7745c5c3_Err != nil {
@@ -169,7 +170,7 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 						var templ_7745c5c3_Var6 templ.SafeURL
 						templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinURLErrs(templ.SafeURL(fmt.Sprintf("/ticket/%s", node.ID)))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 51, Col: 66}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 52, Col: 66}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
 						if templ_7745c5c3_Err != nil {
@@ -182,7 +183,7 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 						var templ_7745c5c3_Var7 string
 						templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("/partials/ticket/%s", node.ID))
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 52, Col: 62}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 53, Col: 62}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 						if templ_7745c5c3_Err != nil {
@@ -195,7 +196,7 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 						var templ_7745c5c3_Var8 string
 						templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(node.ID)
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 55, Col: 32}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 56, Col: 32}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 						if templ_7745c5c3_Err != nil {
@@ -213,7 +214,7 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 							var templ_7745c5c3_Var9 string
 							templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(data.ByID[node.ID].Project)
 							if templ_7745c5c3_Err != nil {
-								return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 58, Col: 90}
+								return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 59, Col: 90}
 							}
 							_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 							if templ_7745c5c3_Err != nil {
@@ -231,7 +232,7 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 						var templ_7745c5c3_Var10 string
 						templ_7745c5c3_Var10, templ_7745c5c3_Err = templ.JoinStringErrs(data.ByID[node.ID].Title)
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 60, Col: 67}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 61, Col: 67}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var10))
 						if templ_7745c5c3_Err != nil {
@@ -256,29 +257,75 @@ func CriticalPathContent(data CriticalPathData) templ.Component {
 						var templ_7745c5c3_Var11 string
 						templ_7745c5c3_Var11, templ_7745c5c3_Err = templ.JoinStringErrs(node.ID)
 						if templ_7745c5c3_Err != nil {
-							return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/critical_path.templ`, Line: 64, Col: 26}
+							return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 65, Col: 26}
 						}
 						_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var11))
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
-						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "</span></div></a>")
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "</span></div>")
+						if templ_7745c5c3_Err != nil {
+							return templ_7745c5c3_Err
+						}
+						if len(data.Links[node.ID]) > 0 {
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "<div class=\"st-dep-node-links\">")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+							for _, l := range data.Links[node.ID] {
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "<span>")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								var templ_7745c5c3_Var12 string
+								templ_7745c5c3_Var12, templ_7745c5c3_Err = templ.JoinStringErrs(l.Label())
+								if templ_7745c5c3_Err != nil {
+									return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 70, Col: 30}
+								}
+								_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var12))
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, " ")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								var templ_7745c5c3_Var13 string
+								templ_7745c5c3_Var13, templ_7745c5c3_Err = templ.JoinStringErrs(l.Ticket)
+								if templ_7745c5c3_Err != nil {
+									return templ.Error{Err: templ_7745c5c3_Err, FileName: `critical_path.templ`, Line: 70, Col: 43}
+								}
+								_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var13))
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+								templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "</span>")
+								if templ_7745c5c3_Err != nil {
+									return templ_7745c5c3_Err
+								}
+							}
+							templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, "</div>")
+							if templ_7745c5c3_Err != nil {
+								return templ_7745c5c3_Err
+							}
+						}
+						templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "</a>")
 						if templ_7745c5c3_Err != nil {
 							return templ_7745c5c3_Err
 						}
 					}
 				}
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "</div>")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "</div>")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "</div></div><script>\n\t\t\t\t(function() {\n\t\t\t\t\tfunction stDepDrawEdges() {\n\t\t\t\t\t\tvar graph = document.getElementById('dep-graph');\n\t\t\t\t\t\tvar svg = document.getElementById('dep-edges');\n\t\t\t\t\t\tif (!graph || !svg) return;\n\n\t\t\t\t\t\tvar edgeData = graph.getAttribute('data-edges');\n\t\t\t\t\t\tif (!edgeData) return;\n\t\t\t\t\t\tvar edgeList = JSON.parse(edgeData);\n\n\t\t\t\t\t\tsvg.setAttribute('width', graph.scrollWidth);\n\t\t\t\t\t\tsvg.setAttribute('height', graph.scrollHeight);\n\t\t\t\t\t\tsvg.innerHTML = '';\n\n\t\t\t\t\t\tedgeList.forEach(function(e) {\n\t\t\t\t\t\t\tvar fromEl = graph.querySelector('[data-node-id=\"' + e.from + '\"]');\n\t\t\t\t\t\t\tvar toEl = graph.querySelector('[data-node-id=\"' + e.to + '\"]');\n\t\t\t\t\t\t\tif (!fromEl || !toEl) return;\n\n\t\t\t\t\t\t\tvar fromRect = fromEl.getBoundingClientRect();\n\t\t\t\t\t\t\tvar toRect = toEl.getBoundingClientRect();\n\t\t\t\t\t\t\tvar graphRect = graph.getBoundingClientRect();\n\n\t\t\t\t\t\t\t// Draw from dependency (to, left) to dependant (from, right).\n\t\t\t\t\t\t\tvar x1 = toRect.right - graphRect.left + graph.scrollLeft;\n\t\t\t\t\t\t\tvar y1 = toRect.top + toRect.height / 2 - graphRect.top + graph.scrollTop;\n\t\t\t\t\t\t\tvar x2 = fromRect.left - graphRect.left + graph.scrollLeft;\n\t\t\t\t\t\t\tvar y2 = fromRect.top + fromRect.height / 2 - graphRect.top + graph.scrollTop;\n\n\t\t\t\t\t\t\tvar dx = Math.abs(x2 - x1) * 0.4;\n\t\t\t\t\t\t\tvar path = document.createElementNS('http://www.w3.org/2000/svg', 'path');\n\t\t\t\t\t\t\tpath.setAttribute('d', 'M' + x1 + ',' + y1 + ' C' + (x1 + dx) + ',' + y1 + ' ' + (x2 - dx) + ',' + y2 + ' ' + x2 + ',' + y2);\n\t\t\t\t\t\t\tpath.setAttribute('class', 'st-dep-edge');\n\t\t\t\t\t\t\tpath.setAttribute('data-from', e.from);\n\t\t\t\t\t\t\tpath.setAttribute('data-to', e.to);\n\t\t\t\t\t\t\tsvg.appendChild(path);\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\n\t\t\t\t\t// Hover highlighting (uses event delegation on the graph container).\n\t\t\t\t\tvar graph = document.getElementById('dep-graph');\n\t\t\t\t\tif (graph) {\n\t\t\t\t\t\tgraph.addEventListener('mouseenter', function(evt) {\n\t\t\t\t\t\t\tvar node = evt.target.closest('[data-node-id]');\n\t\t\t\t\t\t\tif (!node) return;\n\t\t\t\t\t\t\tvar id = node.getAttribute('data-node-id');\n\t\t\t\t\t\t\tgraph.classList.add('dimmed');\n\t\t\t\t\t\t\tnode.classList.add('highlighted');\n\n\t\t\t\t\t\t\tgraph.querySelectorAll('.st-dep-edge').forEach(function(edge) {\n\t\t\t\t\t\t\t\tif (edge.getAttribute('data-from') === id || edge.getAttribute('data-to') === id) {\n\t\t\t\t\t\t\t\t\tedge.classList.add('highlighted');\n\t\t\t\t\t\t\t\t\tvar otherId = edge.getAttribute('data-from') === id ? edge.getAttribute('data-to') : edge.getAttribute('data-from');\n\t\t\t\t\t\t\t\t\tvar otherNode = graph.querySelector('[data-node-id=\"' + otherId + '\"]');\n\t\t\t\t\t\t\t\t\tif (otherNode) otherNode.classList.add('highlighted');\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t}, true);\n\n\t\t\t\t\t\tgraph.addEventListener('mouseleave', function(evt) {\n\t\t\t\t\t\t\tvar node = evt.target.closest('[data-node-id]');\n\t\t\t\t\t\t\tif (!node) return;\n\t\t\t\t\t\t\tgraph.classList.remove('dimmed');\n\t\t\t\t\t\t\tgraph.querySelectorAll('.highlighted').forEach(function(el) {\n\t\t\t\t\t\t\t\tel.classList.remove('highlighted');\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t}, true);\n\t\t\t\t\t}\n\n\t\t\t\t\t// Defer initial draw to ensure layout is complete.\n\t\t\t\t\trequestAnimationFrame(function() {\n\t\t\t\t\t\trequestAnimationFrame(stDepDrawEdges);\n\t\t\t\t\t});\n\n\t\t\t\t\tif (!window._stDepEdgesInit) {\n\t\t\t\t\t\twindow._stDepEdgesInit = true;\n\t\t\t\t\t\tdocument.addEventListener('htmx:afterSwap', function() {\n\t\t\t\t\t\t\trequestAnimationFrame(function() {\n\t\t\t\t\t\t\t\trequestAnimationFrame(stDepDrawEdges);\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t\twindow.addEventListener('resize', stDepDrawEdges);\n\t\t\t\t\t}\n\t\t\t\t})();\n\t\t\t</script>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "</div></div><script>\n\t\t\t\t(function() {\n\t\t\t\t\tfunction stDepDrawEdges() {\n\t\t\t\t\t\tvar graph = document.getElementById('dep-graph');\n\t\t\t\t\t\tvar svg = document.getElementById('dep-edges');\n\t\t\t\t\t\tif (!graph || !svg) return;\n\n\t\t\t\t\t\tvar edgeData = graph.getAttribute('data-edges');\n\t\t\t\t\t\tif (!edgeData) return;\n\t\t\t\t\t\tvar edgeList = JSON.parse(edgeData);\n\n\t\t\t\t\t\tsvg.setAttribute('width', graph.scrollWidth);\n\t\t\t\t\t\tsvg.setAttribute('height', graph.scrollHeight);\n\t\t\t\t\t\tsvg.innerHTML = '';\n\n\t\t\t\t\t\tedgeList.forEach(function(e) {\n\t\t\t\t\t\t\tvar fromEl = graph.querySelector('[data-node-id=\"' + e.from + '\"]');\n\t\t\t\t\t\t\tvar toEl = graph.querySelector('[data-node-id=\"' + e.to + '\"]');\n\t\t\t\t\t\t\tif (!fromEl || !toEl) return;\n\n\t\t\t\t\t\t\tvar fromRect = fromEl.getBoundingClientRect();\n\t\t\t\t\t\t\tvar toRect = toEl.getBoundingClientRect();\n\t\t\t\t\t\t\tvar graphRect = graph.getBoundingClientRect();\n\n\t\t\t\t\t\t\t// Draw from dependency (to, left) to dependant (from, right).\n\t\t\t\t\t\t\tvar x1 = toRect.right - graphRect.left + graph.scrollLeft;\n\t\t\t\t\t\t\tvar y1 = toRect.top + toRect.height / 2 - graphRect.top + graph.scrollTop;\n\t\t\t\t\t\t\tvar x2 = fromRect.left - graphRect.left + graph.scrollLeft;\n\t\t\t\t\t\t\tvar y2 = fromRect.top + fromRect.height / 2 - graphRect.top + graph.scrollTop;\n\n\t\t\t\t\t\t\tvar dx = Math.abs(x2 - x1) * 0.4;\n\t\t\t\t\t\t\tvar path = document.createElementNS('http://www.w3.org/2000/svg', 'path');\n\t\t\t\t\t\t\tpath.setAttribute('d', 'M' + x1 + ',' + y1 + ' C' + (x1 + dx) + ',' + y1 + ' ' + (x2 - dx) + ',' + y2 + ' ' + x2 + ',' + y2);\n\t\t\t\t\t\t\tpath.setAttribute('class', e.kind ? 'st-dep-edge st-dep-edge-' + e.kind : 'st-dep-edge');\n\t\t\t\t\t\t\tpath.setAttribute('data-from', e.from);\n\t\t\t\t\t\t\tpath.setAttribute('data-to', e.to);\n\t\t\t\t\t\t\tsvg.appendChild(path);\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\n\t\t\t\t\t// Hover highlighting (uses event delegation on the graph container).\n\t\t\t\t\tvar graph = document.getElementById('dep-graph');\n\t\t\t\t\tif (graph) {\n\t\t\t\t\t\tgraph.addEventListener('mouseenter', function(evt) {\n\t\t\t\t\t\t\tvar node = evt.target.closest('[data-node-id]');\n\t\t\t\t\t\t\tif (!node) return;\n\t\t\t\t\t\t\tvar id = node.getAttribute('data-node-id');\n\t\t\t\t\t\t\tgraph.classList.add('dimmed');\n\t\t\t\t\t\t\tnode.classList.add('highlighted');\n\n\t\t\t\t\t\t\tgraph.querySelectorAll('.st-dep-edge').forEach(function(edge) {\n\t\t\t\t\t\t\t\tif (edge.getAttribute('data-from') === id || edge.getAttribute('data-to') === id) {\n\t\t\t\t\t\t\t\t\tedge.classList.add('highlighted');\n\t\t\t\t\t\t\t\t\tvar otherId = edge.getAttribute('data-from') === id ? edge.getAttribute('data-to') : edge.getAttribute('data-from');\n\t\t\t\t\t\t\t\t\tvar otherNode = graph.querySelector('[data-node-id=\"' + otherId + '\"]');\n\t\t\t\t\t\t\t\t\tif (otherNode) otherNode.classList.add('highlighted');\n\t\t\t\t\t\t\t\t}\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t}, true);\n\n\t\t\t\t\t\tgraph.addEventListener('mouseleave', function(evt) {\n\t\t\t\t\t\t\tvar node = evt.target.closest('[data-node-id]');\n\t\t\t\t\t\t\tif (!node) return;\n\t\t\t\t\t\t\tgraph.classList.remove('dimmed');\n\t\t\t\t\t\t\tgraph.querySelectorAll('.highlighted').forEach(function(el) {\n\t\t\t\t\t\t\t\tel.classList.remove('highlighted');\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t}, true);\n\t\t\t\t\t}\n\n\t\t\t\t\t// Defer initial draw to ensure layout is complete.\n\t\t\t\t\trequestAnimationFrame(function() {\n\t\t\t\t\t\trequestAnimationFrame(stDepDrawEdges);\n\t\t\t\t\t});\n\n\t\t\t\t\tif (!window._stDepEdgesInit) {\n\t\t\t\t\t\twindow._stDepEdgesInit = true;\n\t\t\t\t\t\tdocument.addEventListener('htmx:afterSwap', function() {\n\t\t\t\t\t\t\trequestAnimationFrame(function() {\n\t\t\t\t\t\t\t\trequestAnimationFrame(stDepDrawEdges);\n\t\t\t\t\t\t\t});\n\t\t\t\t\t\t});\n\t\t\t\t\t\twindow.addEventListener('resize', stDepDrawEdges);\n\t\t\t\t\t}\n\t\t\t\t})();\n\t\t\t</script>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, "</div>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, "</div>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -36,17 +36,17 @@ func Layout(title string, currentPath string, currentProject string, projects []
 		var templ_7745c5c3_Var2 string
 		templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(title)
 		if templ_7745c5c3_Err != nil {
-			return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/layout.templ`, Line: 9, Col: 17}
+			return templ.Error{Err: templ_7745c5c3_Err, FileName: `layout.templ`, Line: 9, Col: 17}
 		}
 		_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, " — smoovtask</title><link rel=\"stylesheet\" href=\"/static/daisyui.css\"><link rel=\"stylesheet\" href=\"/static/daisyui-themes.css\"><script src=\"/static/tailwindcss-browser.js\"></script><script src=\"/static/htmx.min.js\"></script><script src=\"/static/htmx-sse.min.js\"></script><style>\n\t\t\t\t/* Bridge CSS variables: map old FrankenUI var names to DaisyUI sunset theme.\n\t\t\t\t   These allow existing hsl(var(--name)) references to keep working\n\t\t\t\t   until component migration tickets update them. */\n\t\t\t\t[data-theme=\"black\"] {\n\t\t\t\t\t--color-primary: oklch(70% 0.15 45);\n\t\t\t\t\t--color-primary-content: oklch(100% 0 0);\n\t\t\t\t\t--radius-selector: 0.25rem;\n\t\t\t\t\t--radius-field: 0.25rem;\n\t\t\t\t\t--radius-box: 0.5rem;\n\t\t\t\t\t--background: 220 20% 10%;\n\t\t\t\t\t--foreground: 220 10% 65%;\n\t\t\t\t\t--card: 220 20% 12%;\n\t\t\t\t\t--st-border: 220 15% 20%;\n\t\t\t\t\t--primary: 25 70% 55%;\n\t\t\t\t}\n\t\t\t\t@font-face { font-family: 'Inter'; src: url('/static/Inter.woff2') format('woff2'); font-weight: 400 700; font-style: normal; font-display: swap; }\n\t\t\t\t@font-face { font-family: 'Maple Mono NF'; src: url('/static/MapleMonoNL-NF-Regular.ttf') format('truetype'); font-weight: 400; font-style: normal; font-display: swap; }\n\t\t\t\t@font-face { font-family: 'Maple Mono NF'; src: url('/static/MapleMonoNL-NF-Bold.ttf') format('truetype'); font-weight: 700; font-style: normal; font-display: swap; }\n\t\t\t\thtml, body { font-family: 'Inter', sans-serif; }\n\t\t\t\tcode, pre, kbd, samp, .font-mono { font-family: 'Maple Mono NF', monospace; }\n\t\t\t\t.st-board { display: flex; gap: 1rem; overflow-x: auto; padding-bottom: 1rem; height: 100%; }\n\t\t\t\t.st-board-wrapper { height: 100%; }\n\t\t\t\t.st-column { min-width: 200px; flex: 1; overflow-y: auto; }\n\t\t\t\t.st-column-header { padding: 0.5rem 0.75rem; font-weight: 600; text-transform: none; letter-spacing: normal; border-bottom: 2px solid; margin-bottom: 0.5rem; position: sticky; top: 0; z-index: 1; background: hsl(var(--background)); }\n\t\t\t\t.st-ticket-card { display: flex; flex-direction: column; gap: 0.35rem; margin-bottom: 0.5rem; cursor: pointer; position: relative; }\n\t\t\t\t.st-card-corner-badge { position: absolute; top: 0.75rem; right: 0.75rem; z-index: 1; display: inline-flex; width: auto; align-self: flex-start; }\n\t\t\t\t.st-ticket-card:hover { border-color: hsl(var(--primary)); }\n\t\t\t\t.st-card-project { min-height: 1rem; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }\n\t\t\t\t.st-card-title { font-size: 1rem; line-height: 1.35; font-weight: 500; margin: 0; overflow: hidden; display: -webkit-box; -webkit-box-orient: vertical; -webkit-line-clamp: 2; overflow-wrap: break-word; }\n\t\t\t\t.st-ticket-meta { margin-top: 0.35rem; }\n\t\t\t\t.st-ticket-footer { margin-top: 0.1rem; }\n\t\t\t\t.st-priority-p0 { background: #dc2626; color: white; }\n\t\t\t\t.st-priority-p1 { background: #ea580c; color: white; }\n\t\t\t\t.st-priority-p2 { background: #d97706; color: white; }\n\t\t\t\t.st-priority-p3 { background: #2563eb; color: white; }\n\t\t\t\t.st-priority-p4 { background: #6b7280; color: white; }\n\t\t\t\t.st-priority-p5 { background: #374151; color: #9ca3af; }\n\t\t\t\t.st-status-badge-done { background: #22c55e; color: white; }\n\t\t\t\t.st-status-badge-cancelled { background: #6b7280; color: white; }\n\t\t\t\t.st-source-badge-image { height: 1.5rem; width: auto; image-rendering: pixelated; }\n\t\t\t\t.st-assignee-wrap { margin-left: auto; display: inline-flex; align-items: center; gap: 0.25rem; }\n\t\t\t\t.st-assignee-pill { display: inline-block; padding: 0.1rem 0.4rem; font-family: 'Maple Mono NF', monospace; font-weight: 600; background: var(--st-assignee-bg, #6b7280); }\n\t\t\t\t.st-status-backlog { border-color: #6b7280; }\n\t\t\t\t.st-status-open { border-color: #3b82f6; }\n\t\t\t\t.st-status-in-progress { border-color: #f59e0b; }\n\t\t\t\t.st-status-review { border-color: #8b5cf6; }\n\t\t\t\t.st-status-rework { border-color: #ef4444; }\n\t\t\t\t.st-status-blocked { border-color: #dc2626; }\n\t\t\t\t.st-status-done { border-color: #22c55e; }\n\t\t\t\t.st-status-cancelled { border-color: #9ca3af; }\n\t\t\t\t.st-event-row { padding: 0.5rem 0; border-bottom: 1px solid hsl(var(--st-border)); font-size: 0.85rem; }\n\t\t\t\t.st-ticket-body { line-height: 1.6; overflow-wrap: break-word; word-break: break-word; margin-top: 1.5rem; }\n\t\t\t\t.st-ticket-body h2 { font-size: 1.1rem; margin-top: 2rem; margin-bottom: 0.25rem; font-weight: 600; }\n\t\t\t\t.st-ticket-body h2:first-child { margin-top: 0; }\n\t\t\t\t.st-ticket-body h2.st-event-created { color: #60a5fa; }\n\t\t\t\t.st-ticket-body h2.st-event-in-progress { color: #f59e0b; }\n\t\t\t\t.st-ticket-body h2.st-event-note { color: #a78bfa; }\n\t\t\t\t.st-ticket-body h2.st-event-review { color: #c084fc; }\n\t\t\t\t.st-ticket-body h2.st-event-done { color: #4ade80; }\n\t\t\t\t.st-ticket-body h2.st-event-rework { color: #f87171; }\n\t\t\t\t.st-ticket-body h2.st-event-blocked { color: #ef4444; }\n\t\t\t\t.st-ticket-body h2.st-event-backlog { color: #9ca3af; }\n\t\t\t\t.st-ticket-body h2.st-event-open { color: #38bdf8; }\n\t\t\t\t.st-ticket-body h3 { font-size: 1.05rem; margin-top: 1.25rem; margin-bottom: 0.5rem; font-weight: 600; }\n\t\t\t\t.st-ticket-body p { margin-bottom: 0.75rem; }\n\t\t\t\t.st-ticket-body strong { opacity: 0.5; font-weight: 500; }\n\t\t\t\t.st-ticket-body pre { background: hsl(var(--card)); padding: 1rem; border-radius: 0.375rem; overflow-x: auto; margin-bottom: 1rem; max-width: 100%; }\n\t\t\t\t.st-ticket-body :not(pre) > code { font-size: 0.85em; background: hsl(var(--card)); padding: 0.15em 0.4em; border-radius: 0.25rem; }\n\t\t\t\t.st-ticket-body pre code { font-size: 0.85em; background: none; padding: 0; }\n\t\t\t\t.st-ticket-body ul, .st-ticket-body ol { margin-bottom: 0.75rem; padding-left: 1.5rem; }\n\t\t\t\t.st-copy-ticket-id { border: 1px solid hsl(var(--st-border)); background: transparent; font-size: 0.8rem; cursor: pointer; user-select: none; }\n\t\t\t\t.st-ticket-dep-link { font-size: 0.8rem; }\n\t\t\t\t.st-search-modal { width: 560px; max-width: 90vw; padding: 0; overflow: hidden; }\n\t\t\t\t.st-search-header { display: flex; align-items: center; gap: 0.5rem; padding: 0.75rem 1rem; border-bottom: 1px solid hsl(var(--st-border)); }\n\t\t\t\t.st-search-icon { opacity: 0.5; flex-shrink: 0; }\n\t\t\t\t.st-search-input { flex: 1; background: transparent; border: none; outline: none; font-size: 1rem; color: inherit; font-family: inherit; }\n\t\t\t\t.st-search-input::placeholder { opacity: 0.4; }\n\t\t\t\t.st-search-kbd { font-size: 0.65rem; padding: 0.15rem 0.4rem; border: 1px solid hsl(var(--st-border)); border-radius: 0.25rem; opacity: 0.5; font-family: inherit; }\n\t\t\t\t.st-search-results { height: 400px; overflow-y: auto; }\n\t\t\t\t.st-search-results:empty::after { content: ''; }\n\t\t\t\t.st-search-item { display: flex; align-items: center; gap: 0.75rem; padding: 0.6rem 1rem; cursor: pointer; text-decoration: none; color: inherit; border-bottom: 1px solid hsl(var(--st-border) / 0.3); }\n\t\t\t\t.st-search-item:last-child { border-bottom: none; }\n\t\t\t\t.st-search-item:hover, .st-search-item.st-search-active { background: hsl(var(--primary) / 0.1); }\n\t\t\t\t.st-search-item-title { font-weight: 500; font-size: 0.9rem; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; flex: 1; }\n\t\t\t\t.st-search-item-meta { font-size: 0.75rem; opacity: 0.6; font-family: 'Maple Mono NF', monospace; display: flex; gap: 0.5rem; align-items: center; flex-shrink: 0; }\n\t\t\t\t.st-search-empty { padding: 2rem 1rem; text-align: center; opacity: 0.5; font-size: 0.85rem; }\n\t\t\t\t.st-search-hint { padding: 0.5rem 1rem; text-align: center; opacity: 0.35; font-size: 0.75rem; border-top: 1px solid hsl(var(--st-border) / 0.3); }\n\t\t\t\t.st-column-collapsed .st-ticket-card:nth-child(n+7) { display: none; }\n\t\t\t\t.st-toggle-done { display: block; width: 100%; padding: 0.5rem; margin-top: 0.25rem; background: transparent; border: 1px dashed hsl(var(--st-border)); border-radius: 0.375rem; color: hsl(var(--foreground)); opacity: 0.6; cursor: pointer; }\n\t\t\t\t.st-toggle-done:hover { opacity: 1; }\n\t\t\t\t.st-board-wrapper { position: relative; }\n\t\t\t\t.st-board-wrapper::before, .st-board-wrapper::after { content: ''; position: absolute; top: 0; bottom: 0; width: 24px; pointer-events: none; z-index: 1; opacity: 0; transition: opacity 0.2s; }\n\t\t\t\t.st-board-wrapper::before { left: 0; background: linear-gradient(to right, hsl(var(--background)), transparent); }\n\t\t\t\t.st-board-wrapper::after { right: 0; background: linear-gradient(to left, hsl(var(--background)), transparent); }\n\t\t\t\t.st-board-wrapper.scroll-left::before { opacity: 1; }\n\t\t\t\t.st-board-wrapper.scroll-right::after { opacity: 1; }\n\t\t\t\t.st-modal-ticket { width: 80vw; max-width: 1400px; }\n\t\t\t\t.st-modal-header { padding: 1rem 1.25rem 0.85rem; padding-right: 3.5rem; border-bottom: 1px solid hsl(var(--st-border)); }\n\t\t\t\t.st-modal-body { padding: 1.1rem 1.25rem 1.25rem; }\n\t\t\t\t.st-ticket-header { display: flex; flex-direction: column; gap: 0.5rem; }\n\t\t\t\t.st-ticket-header-title { line-height: 1.2; }\n\t\t\t\t.st-ticket-header-meta { row-gap: 0.45rem; }\n\t\t\t\t.st-ticket-form-modal { padding-top: 0.35rem; }\n\t\t\t\t.st-ticket-form-grid { row-gap: 0.75rem; }\n\t\t\t\t.st-ticket-form-actions { padding-top: 0.85rem; border-top: 1px solid hsl(var(--st-border)); }\n\t\t\t\t.st-ticket-form-shell { padding-bottom: 1.5rem; }\n\t\t\t\t.st-ticket-form-page-header { margin-bottom: 1rem; }\n\t\t\t\t.st-ticket-form { border: 0; border-radius: 0; background: transparent; }\n\t\t\t\t.st-ticket-form-page { padding: 1.25rem; row-gap: 0.85rem; }\n\t\t\t\t.st-ticket-form-label { font-weight: 600; letter-spacing: 0.01em; }\n\t\t\t\t.st-ticket-form-field { padding-bottom: 0.55rem; }\n\t\t\t\t.st-ticket-form-input { transition: border-color 0.15s, box-shadow 0.15s, background-color 0.15s; }\n\t\t\t\t.st-ticket-form-input:focus { border-color: hsl(var(--primary)); box-shadow: 0 0 0 2px color-mix(in srgb, hsl(var(--primary)) 25%, transparent); }\n\t\t\t\t.st-ticket-form-help { margin: 0.35rem 0 0; font-size: 0.76rem; opacity: 0.72; }\n\t\t\t\t.st-dep-graph { position: relative; overflow-x: auto; padding-bottom: 1rem; }\n\t\t\t\t.st-dep-grid { display: flex; gap: 2rem; align-items: flex-start; min-width: min-content; }\n\t\t\t\t.st-dep-layer { display: flex; flex-direction: column; gap: 0.75rem; min-width: 14rem; }\n\t\t\t\t.st-dep-node { padding: 0.75rem; border-radius: 0.375rem; border: 1px solid hsl(var(--st-border)); background: hsl(var(--card)); cursor: pointer; transition: border-color 0.15s, opacity 0.15s; text-decoration: none; color: inherit; display: block; }\n\t\t\t\t.st-dep-node:hover { border-color: hsl(var(--primary)); }\n\t\t\t\t.st-dep-node-title { font-weight: 500; font-size: 0.9rem; margin-bottom: 0.25rem; overflow-wrap: break-word; }\n\t\t\t\t.st-dep-node-meta { font-size: 0.75rem; opacity: 0.7; display: flex; gap: 0.5rem; align-items: center; }\n\t\t\t\t.st-dep-edges { position: absolute; top: 0; left: 0; pointer-events: none; }\n\t\t\t\t.st-dep-edge { stroke: hsl(var(--st-border)); stroke-width: 2; fill: none; transition: stroke 0.15s, stroke-width 0.15s; }\n\t\t\t\t.st-dep-edge.highlighted { stroke: hsl(var(--primary)); stroke-width: 2.5; }\n\t\t\t\t.st-dep-graph.dimmed .st-dep-node { opacity: 0.3; }\n\t\t\t\t.st-dep-graph.dimmed .st-dep-node.highlighted { opacity: 1; }\n\t\t\t\t@keyframes st-dot-flash { 0% { background: #22c55e; box-shadow: 0 0 6px rgba(34, 197, 94, 0.6); } 100% { background: #166534; box-shadow: none; } }\n\t\t\t\t.st-session-dot { width: 6px; height: 6px; border-radius: 50%; background: #ef4444; flex-shrink: 0; transition: background-color 0.3s ease, box-shadow 0.3s ease; }\n\t\t\t\t.st-session-dot.st-session-dot-hot { background: #166534; animation: none; }\n\t\t\t\t.st-session-dot.st-session-dot-hot.st-session-dot-flash { animation: st-dot-flash 0.4s ease-out forwards; }\n\t\t\t\t.st-session-dot.st-session-dot-warm { background: #eab308; box-shadow: none; animation: none; }\n\t\t\t\t.st-session-dot.st-session-dot-cold { background: #ef4444; box-shadow: none; animation: none; }\n\t\t\t\t.st-alert-icon { display: none; align-items: center; justify-content: center; flex-shrink: 0; cursor: default; }\n\t\t\t\t.st-stalled-icon { display: none; align-items: center; justify-content: center; flex-shrink: 0; cursor: default; }\n\t\t\t\t@keyframes st-alert-pulse { 0%, 100% { opacity: 1; } 50% { opacity: 0.5; } }\n\t\t\t\t.st-alert-icon.st-alert-active { display: inline-flex; animation: st-alert-pulse 2s ease-in-out infinite; }\n\t\t\t\t.st-stalled-icon.st-alert-active { display: inline-flex; animation: st-alert-pulse 1.6s ease-in-out infinite; }\n\t\t\t\t.st-audio-toggle { background: transparent; border: 1px solid hsl(var(--st-border)); border-radius: 0.375rem; padding: 0.25rem 0.5rem; cursor: pointer; color: hsl(var(--foreground)); opacity: 0.7; transition: opacity 0.15s; display: inline-flex; align-items: center; }\n\t\t\t\t.st-audio-toggle:hover { opacity: 1; }\n\t\t\t\t@media (max-width: 640px) {\n\t\t\t\t\t.st-event-row { gap: 0.35rem; }\n\t\t\t\t\t.st-modal-ticket { width: calc(100vw - 1rem); margin: 0.5rem; }\n\t\t\t\t\t.st-modal-header { padding: 0.9rem 1rem 0.8rem; padding-right: 2.75rem; }\n\t\t\t\t\t.st-modal-body { padding: 0.9rem 1rem 1rem; }\n\t\t\t\t\t.st-ticket-header-top { gap: 0.65rem; }\n\t\t\t\t\t.st-ticket-header-title { font-size: 1.25rem; }\n\t\t\t\t\t.st-ticket-header-edit { margin-left: 0; }\n\t\t\t\t\t.st-ticket-form-page { padding: 1rem; }\n\t\t\t\t\t.st-ticket-form-actions { gap: 0.5rem; }\n\t\t\t\t}\n\t\t\t</style></head><body hx-ext=\"sse\" sse-connect=\"/events\" class=\"h-screen flex flex-col overflow-hidden bg-background text-foreground\"><nav class=\"flex items-center justify-between px-4 pt-2 shrink-0\"><div class=\"flex items-center min-w-0\"><a class=\"font-bold flex items-center gap-2 text-xl\" href=\"/\"><img src=\"/static/logo.png\" alt=\"smoovtask\" class=\"h-10 w-10 rounded-full object-cover object-center\"> <span class=\"font-mono\">smoovtask</span></a><div role=\"tablist\" class=\"tabs tabs-border ml-4 st-main-nav\"><a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, " — smoovtask</title><link rel=\"stylesheet\" href=\"/static/daisyui.css\"><link rel=\"stylesheet\" href=\"/static/daisyui-themes.css\"><script src=\"/static/tailwindcss-browser.js\"></script><script src=\"/static/htmx.min.js\"></script><script src=\"/static/htmx-sse.min.js\"></script><style>\n\t\t\t\t/* Bridge CSS variables: map old FrankenUI var names to DaisyUI sunset theme.\n\t\t\t\t   These allow existing hsl(var(--name)) references to keep working\n\t\t\t\t   until component migration tickets update them. */\n\t\t\t\t[data-theme=\"black\"] {\n\t\t\t\t\t--color-primary: oklch(70% 0.15 45);\n\t\t\t\t\t--color-primary-content: oklch(100% 0 0);\n\t\t\t\t\t--radius-selector: 0.25rem;\n\t\t\t\t\t--radius-field: 0.25rem;\n\t\t\t\t\t--radius-box: 0.5rem;\n\t\t\t\t\t--background: 220 20% 10%;\n\t\t\t\t\t--foreground: 220 10% 65%;\n\t\t\t\t\t--card: 220 20% 12%;\n\t\t\t\t\t--st-border: 220 15% 20%;\n\t\t\t\t\t--primary: 25 70% 55%;\n\t\t\t\t}\n\t\t\t\t@font-face { font-family: 'Inter'; src: url('/static/Inter.woff2') format('woff2'); font-weight: 400 700; font-style: normal; font-display: swap; }\n\t\t\t\t@font-face { font-family: 'Maple Mono NF'; src: url('/static/MapleMonoNL-NF-Regular.ttf') format('truetype'); font-weight: 400; font-style: normal; font-display: swap; }\n\t\t\t\t@font-face { font-family: 'Maple Mono NF'; src: url('/static/MapleMonoNL-NF-Bold.ttf') format('truetype'); font-weight: 700; font-style: normal; font-display: swap; }\n\t\t\t\thtml, body { font-family: 'Inter', sans-serif; }\n\t\t\t\tcode, pre, kbd, samp, .font-mono { font-family: 'Maple Mono NF', monospace; }\n\t\t\t\t.st-board { display: flex; gap: 1rem; overflow-x: auto; padding-bottom: 1rem; height: 100%; }\n\t\t\t\t.st-board-wrapper { height: 100%; }\n\t\t\t\t.st-epic-lane { margin-bottom: 1.5rem; }\n\t\t\t\t.st-epic-lane .st-board { height: auto; }\n\t\t\t\t.st-epic-lane-header { padding: 0.5rem 0.25rem; border-bottom: 1px solid hsl(var(--st-border) / 0.5); margin-bottom: 0.5rem; }\n\t\t\t\t.st-column { min-width: 200px; flex: 1; overflow-y: auto; }\n\t\t\t\t.st-column-header { padding: 0.5rem 0.75rem; font-weight: 600; text-transform: none; letter-spacing: normal; border-bottom: 2px solid; margin-bottom: 0.5rem; position: sticky; top: 0; z-index: 1; background: hsl(var(--background)); }\n\t\t\t\t.st-ticket-card { display: flex; flex-direction: column; gap: 0.35rem; margin-bottom: 0.5rem; cursor: pointer; position: relative; }\n\t\t\t\t.st-card-corner-badge { position: absolute; top: 0.75rem; right: 0.75rem; z-index: 1; display: inline-flex; width: auto; align-self: flex-start; }\n\t\t\t\t.st-ticket-card:hover { border-color: hsl(var(--primary)); }\n\t\t\t\t.st-card-project { min-height: 1rem; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }\n\t\t\t\t.st-card-title { font-size: 1rem; line-height: 1.35; font-weight: 500; margin: 0; overflow: hidden; display: -webkit-box; -webkit-box-orient: vertical; -webkit-line-clamp: 2; overflow-wrap: break-word; }\n\t\t\t\t.st-ticket-meta { margin-top: 0.35rem; }\n\t\t\t\t.st-ticket-footer { margin-top: 0.1rem; }\n\t\t\t\t.st-priority-p0 { background: #dc2626; color: white; }\n\t\t\t\t.st-priority-p1 { background: #ea580c; color: white; }\n\t\t\t\t.st-priority-p2 { background: #d97706; color: white; }\n\t\t\t\t.st-priority-p3 { background: #2563eb; color: white; }\n\t\t\t\t.st-priority-p4 { background: #6b7280; color: white; }\n\t\t\t\t.st-priority-p5 { background: #374151; color: #9ca3af; }\n\t\t\t\t.st-status-badge-done { background: #22c55e; color: white; }\n\t\t\t\t.st-status-badge-cancelled { background: #6b7280; color: white; }\n\t\t\t\t.st-source-badge-image { height: 1.5rem; width: auto; image-rendering: pixelated; }\n\t\t\t\t.st-assignee-wrap { margin-left: auto; display: inline-flex; align-items: center; gap: 0.25rem; }\n\t\t\t\t.st-assignee-pill { display: inline-block; padding: 0.1rem 0.4rem; font-family: 'Maple Mono NF', monospace; font-weight: 600; background: var(--st-assignee-bg, #6b7280); }\n\t\t\t\t.st-status-backlog { border-color: #6b7280; }\n\t\t\t\t.st-status-open { border-color: #3b82f6; }\n\t\t\t\t.st-status-in-progress { border-color: #f59e0b; }\n\t\t\t\t.st-status-review { border-color: #8b5cf6; }\n\t\t\t\t.st-status-rework { border-color: #ef4444; }\n\t\t\t\t.st-status-blocked { border-color: #dc2626; }\n\t\t\t\t.st-status-done { border-color: #22c55e; }\n\t\t\t\t.st-status-cancelled { border-color: #9ca3af; }\n\t\t\t\t.st-event-row { padding: 0.5rem 0; border-bottom: 1px solid hsl(var(--st-border)); font-size: 0.85rem; }\n\t\t\t\t.st-ticket-body { line-height: 1.6; overflow-wrap: break-word; word-break: break-word; margin-top: 1.5rem; }\n\t\t\t\t.st-ticket-body h2 { font-size: 1.1rem; margin-top: 2rem; margin-bottom: 0.25rem; font-weight: 600; }\n\t\t\t\t.st-ticket-body h2:first-child { margin-top: 0; }\n\t\t\t\t.st-ticket-body h2.st-event-created { color: #60a5fa; }\n\t\t\t\t.st-ticket-body h2.st-event-in-progress { color: #f59e0b; }\n\t\t\t\t.st-ticket-body h2.st-event-note { color: #a78bfa; }\n\t\t\t\t.st-ticket-body h2.st-event-review { color: #c084fc; }\n\t\t\t\t.st-ticket-body h2.st-event-done { color: #4ade80; }\n\t\t\t\t.st-ticket-body h2.st-event-rework { color: #f87171; }\n\t\t\t\t.st-ticket-body h2.st-event-blocked { color: #ef4444; }\n\t\t\t\t.st-ticket-body h2.st-event-backlog { color: #9ca3af; }\n\t\t\t\t.st-ticket-body h2.st-event-open { color: #38bdf8; }\n\t\t\t\t.st-ticket-body h3 { font-size: 1.05rem; margin-top: 1.25rem; margin-bottom: 0.5rem; font-weight: 600; }\n\t\t\t\t.st-ticket-body p { margin-bottom: 0.75rem; }\n\t\t\t\t.st-ticket-body strong { opacity: 0.5; font-weight: 500; }\n\t\t\t\t.st-ticket-body pre { background: hsl(var(--card)); padding: 1rem; border-radius: 0.375rem; overflow-x: auto; margin-bottom: 1rem; max-width: 100%; }\n\t\t\t\t.st-ticket-body :not(pre) > code { font-size: 0.85em; background: hsl(var(--card)); padding: 0.15em 0.4em; border-radius: 0.25rem; }\n\t\t\t\t.st-ticket-body pre code { font-size: 0.85em; background: none; padding: 0; }\n\t\t\t\t.st-ticket-body ul, .st-ticket-body ol { margin-bottom: 0.75rem; padding-left: 1.5rem; }\n\t\t\t\t.st-copy-ticket-id { border: 1px solid hsl(var(--st-border)); background: transparent; font-size: 0.8rem; cursor: pointer; user-select: none; }\n\t\t\t\t.st-ticket-dep-link { font-size: 0.8rem; }\n\t\t\t\t.st-ticket-link { display: inline-flex; gap: 0.25rem; align-items: baseline; font-size: 0.8rem; }\n\t\t\t\t.st-search-modal { width: 560px; max-width: 90vw; padding: 0; overflow: hidden; }\n\t\t\t\t.st-search-header { display: flex; align-items: center; gap: 0.5rem; padding: 0.75rem 1rem; border-bottom: 1px solid hsl(var(--st-border)); }\n\t\t\t\t.st-search-icon { opacity: 0.5; flex-shrink: 0; }\n\t\t\t\t.st-search-input { flex: 1; background: transparent; border: none; outline: none; font-size: 1rem; color: inherit; font-family: inherit; }\n\t\t\t\t.st-search-input::placeholder { opacity: 0.4; }\n\t\t\t\t.st-search-kbd { font-size: 0.65rem; padding: 0.15rem 0.4rem; border: 1px solid hsl(var(--st-border)); border-radius: 0.25rem; opacity: 0.5; font-family: inherit; }\n\t\t\t\t.st-search-results { max-height: 400px; overflow-y: auto; }\n\t\t\t\t.st-search-results:empty::after { content: ''; }\n\t\t\t\t.st-search-item { display: flex; align-items: center; gap: 0.75rem; padding: 0.6rem 1rem; cursor: pointer; text-decoration: none; color: inherit; border-bottom: 1px solid hsl(var(--st-border) / 0.3); }\n\t\t\t\t.st-search-item:last-child { border-bottom: none; }\n\t\t\t\t.st-search-item:hover, .st-search-item.st-search-active { background: hsl(var(--primary) / 0.1); }\n\t\t\t\t.st-search-item-body { flex: 1; min-width: 0; }\n\t\t\t\t.st-search-item-title { font-weight: 500; font-size: 0.9rem; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }\n\t\t\t\t.st-search-item-snippet { font-size: 0.75rem; opacity: 0.6; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }\n\t\t\t\t.st-search-section { font-weight: 500; opacity: 0.7; margin-right: 0.25rem; }\n\t\t\t\t.st-search-item-meta { font-size: 0.75rem; opacity: 0.6; font-family: 'Maple Mono NF', monospace; display: flex; gap: 0.5rem; align-items: center; flex-shrink: 0; }\n\t\t\t\t.st-search-empty { padding: 2rem 1rem; text-align: center; opacity: 0.5; font-size: 0.85rem; }\n\t\t\t\t.st-search-hint { padding: 0.5rem 1rem; text-align: center; opacity: 0.35; font-size: 0.75rem; border-top: 1px solid hsl(var(--st-border) / 0.3); }\n\t\t\t\t.st-column-collapsed .st-ticket-card:nth-child(n+7) { display: none; }\n\t\t\t\t.st-toggle-done { display: block; width: 100%; padding: 0.5rem; margin-top: 0.25rem; background: transparent; border: 1px dashed hsl(var(--st-border)); border-radius: 0.375rem; color: hsl(var(--foreground)); opacity: 0.6; cursor: pointer; }\n\t\t\t\t.st-toggle-done:hover { opacity: 1; }\n\t\t\t\t.st-board-wrapper { position: relative; }\n\t\t\t\t.st-board-wrapper::before, .st-board-wrapper::after { content: ''; position: absolute; top: 0; bottom: 0; width: 24px; pointer-events: none; z-index: 1; opacity: 0; transition: opacity 0.2s; }\n\t\t\t\t.st-board-wrapper::before { left: 0; background: linear-gradient(to right, hsl(var(--background)), transparent); }\n\t\t\t\t.st-board-wrapper::after { right: 0; background: linear-gradient(to left, hsl(var(--background)), transparent); }\n\t\t\t\t.st-board-wrapper.scroll-left::before { opacity: 1; }\n\t\t\t\t.st-board-wrapper.scroll-right::after { opacity: 1; }\n\t\t\t\t.st-modal-ticket { width: 80vw; max-width: 1400px; }\n\t\t\t\t.st-modal-header { padding: 1rem 1.25rem 0.85rem; padding-right: 3.5rem; border-bottom: 1px solid hsl(var(--st-border)); }\n\t\t\t\t.st-modal-body { padding: 1.1rem 1.25rem 1.25rem; }\n\t\t\t\t.st-ticket-header { display: flex; flex-direction: column; gap: 0.5rem; }\n\t\t\t\t.st-ticket-header-title { line-height: 1.2; }\n\t\t\t\t.st-ticket-header-meta { row-gap: 0.45rem; }\n\t\t\t\t.st-ticket-form-modal { padding-top: 0.35rem; }\n\t\t\t\t.st-ticket-form-grid { row-gap: 0.75rem; }\n\t\t\t\t.st-ticket-form-actions { padding-top: 0.85rem; border-top: 1px solid hsl(var(--st-border)); }\n\t\t\t\t.st-ticket-form-shell { padding-bottom: 1.5rem; }\n\t\t\t\t.st-ticket-form-page-header { margin-bottom: 1rem; }\n\t\t\t\t.st-ticket-form { border: 0; border-radius: 0; background: transparent; }\n\t\t\t\t.st-ticket-form-page { padding: 1.25rem; row-gap: 0.85rem; }\n\t\t\t\t.st-ticket-form-label { font-weight: 600; letter-spacing: 0.01em; }\n\t\t\t\t.st-ticket-form-field { padding-bottom: 0.55rem; }\n\t\t\t\t.st-ticket-form-input { transition: border-color 0.15s, box-shadow 0.15s, background-color 0.15s; }\n\t\t\t\t.st-ticket-form-input:focus { border-color: hsl(var(--primary)); box-shadow: 0 0 0 2px color-mix(in srgb, hsl(var(--primary)) 25%, transparent); }\n\t\t\t\t.st-ticket-form-help { margin: 0.35rem 0 0; font-size: 0.76rem; opacity: 0.72; }\n\t\t\t\t.st-dep-graph { position: relative; overflow-x: auto; padding-bottom: 1rem; }\n\t\t\t\t.st-dep-grid { display: flex; gap: 2rem; align-items: flex-start; min-width: min-content; }\n\t\t\t\t.st-dep-layer { display: flex; flex-direction: column; gap: 0.75rem; min-width: 14rem; }\n\t\t\t\t.st-dep-node { padding: 0.75rem; border-radius: 0.375rem; border: 1px solid hsl(var(--st-border)); background: hsl(var(--card)); cursor: pointer; transition: border-color 0.15s, opacity 0.15s; text-decoration: none; color: inherit; display: block; }\n\t\t\t\t.st-dep-node:hover { border-color: hsl(var(--primary)); }\n\t\t\t\t.st-dep-node-title { font-weight: 500; font-size: 0.9rem; margin-bottom: 0.25rem; overflow-wrap: break-word; }\n\t\t\t\t.st-dep-node-meta { font-size: 0.75rem; opacity: 0.7; display: flex; gap: 0.5rem; align-items: center; }\n\t\t\t\t.st-dep-edges { position: absolute; top: 0; left: 0; pointer-events: none; }\n\t\t\t\t.st-dep-edge { stroke: hsl(var(--st-border)); stroke-width: 2; fill: none; transition: stroke 0.15s, stroke-width 0.15s; }\n\t\t\t\t.st-dep-edge-parent { stroke-dasharray: 4 4; }\n\t\t\t\t.st-dep-edge-blocks { stroke: var(--color-error); stroke-opacity: 0.7; }\n\t\t\t\t.st-dep-node-links { display: flex; flex-wrap: wrap; gap: 0.25rem 0.5rem; font-size: 0.65rem; opacity: 0.6; margin-top: 0.25rem; }\n\t\t\t\t.st-dep-edge.highlighted { stroke: hsl(var(--primary)); stroke-width: 2.5; }\n\t\t\t\t.st-dep-graph.dimmed .st-dep-node { opacity: 0.3; }\n\t\t\t\t.st-dep-graph.dimmed .st-dep-node.highlighted { opacity: 1; }\n\t\t\t\t@keyframes st-dot-flash { 0% { background: #22c55e; box-shadow: 0 0 6px rgba(34, 197, 94, 0.6); } 100% { background: #166534; box-shadow: none; } }\n\t\t\t\t.st-session-dot { width: 6px; height: 6px; border-radius: 50%; background: #ef4444; flex-shrink: 0; transition: background-color 0.3s ease, box-shadow 0.3s ease; }\n\t\t\t\t.st-session-dot.st-session-dot-hot { background: #166534; animation: none; }\n\t\t\t\t.st-session-dot.st-session-dot-hot.st-session-dot-flash { animation: st-dot-flash 0.4s ease-out forwards; }\n\t\t\t\t.st-session-dot.st-session-dot-warm { background: #eab308; box-shadow: none; animation: none; }\n\t\t\t\t.st-session-dot.st-session-dot-cold { background: #ef4444; box-shadow: none; animation: none; }\n\t\t\t\t.st-alert-icon { display: none; align-items: center; justify-content: center; flex-shrink: 0; cursor: default; }\n\t\t\t\t.st-stalled-icon { display: none; align-items: center; justify-content: center; flex-shrink: 0; cursor: default; }\n\t\t\t\t@keyframes st-alert-pulse { 0%, 100% { opacity: 1; } 50% { opacity: 0.5; } }\n\t\t\t\t.st-alert-icon.st-alert-active { display: inline-flex; animation: st-alert-pulse 2s ease-in-out infinite; }\n\t\t\t\t.st-stalled-icon.st-alert-active { display: inline-flex; animation: st-alert-pulse 1.6s ease-in-out infinite; }\n\t\t\t\t.st-audio-toggle { background: transparent; border: 1px solid hsl(var(--st-border)); border-radius: 0.375rem; padding: 0.25rem 0.5rem; cursor: pointer; color: hsl(var(--foreground)); opacity: 0.7; transition: opacity 0.15s; display: inline-flex; align-items: center; }\n\t\t\t\t.st-audio-toggle:hover { opacity: 1; }\n\t\t\t\t@media (max-width: 640px) {\n\t\t\t\t\t.st-event-row { gap: 0.35rem; }\n\t\t\t\t\t.st-modal-ticket { width: calc(100vw - 1rem); margin: 0.5rem; }\n\t\t\t\t\t.st-modal-header { padding: 0.9rem 1rem 0.8rem; padding-right: 2.75rem; }\n\t\t\t\t\t.st-modal-body { padding: 0.9rem 1rem 1rem; }\n\t\t\t\t\t.st-ticket-header-top { gap: 0.65rem; }\n\t\t\t\t\t.st-ticket-header-title { font-size: 1.25rem; }\n\t\t\t\t\t.st-ticket-header-edit { margin-left: 0; }\n\t\t\t\t\t.st-ticket-form-page { padding: 1rem; }\n\t\t\t\t\t.st-ticket-form-actions { gap: 0.5rem; }\n\t\t\t\t}\n\t\t\t.st-inbox-row { transition: background-color 0.15s; }\n\t\t\t.st-inbox-row.st-inbox-read { opacity: 0.55; }\n\t\t\t.st-inbox-row.st-inbox-read .st-inbox-subject { font-weight: 400; }\n\t\t\t</style></head><body hx-ext=\"sse\" sse-connect=\"/events\" class=\"h-screen flex flex-col overflow-hidden bg-background text-foreground\"><nav class=\"flex items-center justify-between px-4 pt-2 shrink-0\"><div class=\"flex items-center min-w-0\"><a class=\"font-bold flex items-center gap-2 text-xl\" href=\"/\"><img src=\"/static/logo.png\" alt=\"smoovtask\" class=\"h-10 w-10 rounded-full object-cover object-center\"> <span class=\"font-mono\">smoovtask</span></a><div role=\"tablist\" class=\"tabs tabs-border ml-4 st-main-nav\"><a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/" {
+		if currentPath == "/inbox" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -57,11 +57,11 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, " hx-get=\"/partials/board\" hx-target=\"#content\" hx-push-url=\"/\" href=\"/\">Board</a> <a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, " hx-get=\"/partials/inbox\" hx-target=\"#content\" hx-push-url=\"/inbox\" href=\"/inbox\">Inbox</a> <a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/list" {
+		if currentPath == "/" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -72,11 +72,11 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, " hx-get=\"/partials/list\" hx-target=\"#content\" hx-push-url=\"/list\" href=\"/list\">Tickets</a> <a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, " hx-get=\"/partials/board\" hx-target=\"#content\" hx-push-url=\"/\" href=\"/\">Board</a> <a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/critical-path" {
+		if currentPath == "/list" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -87,11 +87,11 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, " hx-get=\"/partials/critical-path\" hx-target=\"#content\" hx-push-url=\"/critical-path\" href=\"/critical-path\">Critical Path</a> <a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, " hx-get=\"/partials/list\" hx-target=\"#content\" hx-push-url=\"/list\" href=\"/list\">List</a> <a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/sessions" {
+		if currentPath == "/critical-path" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -102,11 +102,11 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " hx-get=\"/partials/sessions\" hx-target=\"#content\" hx-push-url=\"/sessions\" href=\"/sessions\">Sessions</a> <a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " hx-get=\"/partials/critical-path\" hx-target=\"#content\" hx-push-url=\"/critical-path\" href=\"/critical-path\">Critical Path</a> <a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/activity" {
+		if currentPath == "/sessions" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -117,11 +117,11 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, " hx-get=\"/partials/activity\" hx-target=\"#content\" hx-push-url=\"/activity\" href=\"/activity\">Activity</a> <a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, " hx-get=\"/partials/sessions\" hx-target=\"#content\" hx-push-url=\"/sessions\" href=\"/sessions\">Sessions</a> <a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/projects" {
+		if currentPath == "/activity" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -132,11 +132,11 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, " hx-get=\"/partials/projects\" hx-target=\"#content\" hx-push-url=\"/projects\" href=\"/projects\">Projects</a> <a role=\"tab\"")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, " hx-get=\"/partials/activity\" hx-target=\"#content\" hx-push-url=\"/activity\" href=\"/activity\">Activity</a> <a role=\"tab\"")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		if currentPath == "/rules" {
+		if currentPath == "/projects" {
 			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, " class=\"tab tab-active\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
@@ -147,53 +147,68 @@ func Layout(title string, currentPath string, currentProject string, projects []
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " hx-get=\"/partials/rules\" hx-target=\"#content\" hx-push-url=\"/rules\" href=\"/rules\">Rules</a></div></div><div class=\"flex items-center gap-2\"><select id=\"st-project-select\" class=\"select select-sm w-44\" onchange=\"stSelectProject(this)\"><option value=\"\">All Projects</option> ")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, " hx-get=\"/partials/projects\" hx-target=\"#content\" hx-push-url=\"/projects\" href=\"/projects\">Projects</a> <a role=\"tab\"")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if currentPath == "/rules" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " class=\"tab tab-active\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		} else {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, " class=\"tab\"")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " hx-get=\"/partials/rules\" hx-target=\"#content\" hx-push-url=\"/rules\" href=\"/rules\">Rules</a></div></div><div class=\"flex items-center gap-2\"><select id=\"st-project-select\" class=\"select select-sm w-44\" onchange=\"stSelectProject(this)\"><option value=\"\">All Projects</option> ")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
 		for _, p := range projects {
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, "<option value=\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "<option value=\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var3 string
 			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(p)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/layout.templ`, Line: 281, Col: 17}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `layout.templ`, Line: 308, Col: 17}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if currentProject == p {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " selected")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, " selected")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, ">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, ">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var4 string
 			templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(p)
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `internal/web/templates/layout.templ`, Line: 286, Col: 11}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `layout.templ`, Line: 313, Col: 11}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 28, "</option>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 31, "</option>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 29, "</select> <button id=\"st-audio-toggle\" class=\"st-audio-toggle\" type=\"button\" title=\"Toggle hook audio alerts\" onclick=\"stToggleAudioMute()\"><svg id=\"st-audio-icon-on\" width=\"16\" height=\"16\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon><path d=\"M19.07 4.93a10 10 0 0 1 0 14.14\"></path><path d=\"M15.54 8.46a5 5 0 0 1 0 7.07\"></path></svg> <svg id=\"st-audio-icon-off\" width=\"16\" height=\"16\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" style=\"display:none\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon><line x1=\"23\" y1=\"9\" x2=\"17\" y2=\"15\"></line><line x1=\"17\" y1=\"9\" x2=\"23\" y2=\"15\"></line></svg></button> <button class=\"btn btn-ghost btn-sm gap-1 opacity-60 hover:opacity-100\" type=\"button\" onclick=\"stOpenSearch()\" title=\"Search tickets (Cmd+K)\"><svg width=\"14\" height=\"14\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><circle cx=\"11\" cy=\"11\" r=\"8\"></circle><line x1=\"21\" y1=\"21\" x2=\"16.65\" y2=\"16.65\"></line></svg> <kbd class=\"text-xs opacity-70\" style=\"font-size:0.65rem\">&#8984;K</kbd></button> <a class=\"btn btn-primary btn-sm\" href=\"/new\" hx-get=\"/partials/form/new\" hx-target=\"#ticket-modal-body\" hx-push-url=\"false\">New Ticket</a></div></nav><main class=\"w-full p-4 sm:p-6 flex-1 overflow-y-auto min-h-0\"><div id=\"content\">")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 32, "</select> <button id=\"st-audio-toggle\" class=\"st-audio-toggle\" type=\"button\" title=\"Toggle hook audio alerts\" onclick=\"stToggleAudioMute()\"><svg id=\"st-audio-icon-on\" width=\"16\" height=\"16\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon><path d=\"M19.07 4.93a10 10 0 0 1 0 14.14\"></path><path d=\"M15.54 8.46a5 5 0 0 1 0 7.07\"></path></svg> <svg id=\"st-audio-icon-off\" width=\"16\" height=\"16\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\" style=\"display:none\"><polygon points=\"11 5 6 9 2 9 2 15 6 15 11 19 11 5\"></polygon><line x1=\"23\" y1=\"9\" x2=\"17\" y2=\"15\"></line><line x1=\"17\" y1=\"9\" x2=\"23\" y2=\"15\"></line></svg></button> <button class=\"btn btn-ghost btn-sm gap-1 opacity-60 hover:opacity-100\" type=\"button\" onclick=\"stOpenSearch()\" title=\"Search tickets (Cmd+K)\"><svg width=\"14\" height=\"14\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><circle cx=\"11\" cy=\"11\" r=\"8\"></circle><line x1=\"21\" y1=\"21\" x2=\"16.65\" y2=\"16.65\"></line></svg> <kbd class=\"text-xs opacity-70\" style=\"font-size:0.65rem\">&#8984;K</kbd></button> <a class=\"btn btn-primary btn-sm\" href=\"/new\" hx-get=\"/partials/form/new\" hx-target=\"#ticket-modal-body\" hx-push-url=\"false\">New Ticket</a></div></nav><main class=\"w-full p-4 sm:p-6 flex-1 overflow-y-auto min-h-0\"><div id=\"content\">")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -201,7 +216,7 @@ func Layout(title string, currentPath string, currentProject string, projects []
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 30, "</div></main><dialog id=\"search-modal\" class=\"modal\"><div class=\"modal-box st-search-modal\"><div class=\"st-search-header\"><svg class=\"st-search-icon\" width=\"18\" height=\"18\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><circle cx=\"11\" cy=\"11\" r=\"8\"></circle><line x1=\"21\" y1=\"21\" x2=\"16.65\" y2=\"16.65\"></line></svg> <input id=\"search-input\" type=\"text\" class=\"st-search-input\" placeholder=\"Search tickets...\" autocomplete=\"off\" spellcheck=\"false\"> <kbd class=\"st-search-kbd\">esc</kbd></div><div id=\"search-results\" class=\"st-search-results\"></div></div><form method=\"dialog\" class=\"modal-backdrop\"><button>close</button></form></dialog> <dialog id=\"ticket-modal\" class=\"modal\"><div class=\"modal-box st-modal-ticket\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-3 top-3 z-10\" type=\"button\" onclick=\"document.getElementById('ticket-modal').close()\">✕</button><div class=\"st-modal-header\" id=\"ticket-modal-header\"></div><div class=\"st-modal-body overflow-auto\" id=\"ticket-modal-body\"></div></div><form method=\"dialog\" class=\"modal-backdrop\"><button>close</button></form></dialog><script>\n\t\t\t\t// Global function definitions (safe to re-declare on script re-evaluation).\n\n\t\t\t\t// --- Project selector ---\n\t\t\t\tfunction stSelectProject(select) {\n\t\t\t\t\tvar url = new URL(window.location);\n\t\t\t\t\tif (select.value) {\n\t\t\t\t\t\turl.searchParams.set('project', select.value);\n\t\t\t\t\t} else {\n\t\t\t\t\t\turl.searchParams.delete('project');\n\t\t\t\t\t}\n\t\t\t\t\twindow.location = url.toString();\n\t\t\t\t}\n\n\t\t\t\t// --- Audio alert system ---\n\t\t\t\tvar _stSoundMap = {\n\t\t\t\t\t'hook.permission-request': { cat: 'deny', files: ['deny-1.wav', 'deny-2.wav'] },\n\t\t\t\t\t'hook.session-start':      { cat: 'start', files: ['start-1.wav'] },\n\t\t\t\t\t'hook.stop':               { cat: 'complete', files: ['complete-1.wav', 'complete-2.wav'] },\n\t\t\t\t\t'hook.session-end':        { cat: 'complete', files: ['complete-1.wav', 'complete-2.wav'] },\n\t\t\t\t\t'hook.task-completed':     { cat: 'event', files: ['event-4.wav', 'event-7.wav', 'event-9.wav'] },\n\t\t\t\t\t'hook.pre-tool':           { cat: 'event', files: ['event-4.wav', 'event-7.wav', 'event-9.wav'] },\n\t\t\t\t\t'hook.post-tool':          { cat: 'event', files: ['event-4.wav', 'event-7.wav', 'event-9.wav'] }\n\t\t\t\t};\n\t\t\t\tvar _stStallThresholdMs = 2 * 60 * 1000;\n\t\t\t\tvar _stStallSpeakCooldownMs = 30 * 1000;\n\t\t\t\tvar _stLastSoundByCategory = {};\n\t\t\t\tvar _stAudioUnlocked = false;\n\t\t\t\tvar _stLastStallSpeakAt = 0;\n\n\t\t\t\tfunction stIsAudioMuted() {\n\t\t\t\t\treturn localStorage.getItem('st-audio-muted') === '1';\n\t\t\t\t}\n\t\t\t\tfunction stToggleAudioMute() {\n\t\t\t\t\tvar muted = !stIsAudioMuted();\n\t\t\t\t\tlocalStorage.setItem('st-audio-muted', muted ? '1' : '0');\n\t\t\t\t\tstSyncMuteIcon();\n\t\t\t\t\t// Unlock audio on first interaction\n\t\t\t\t\tif (!muted && !_stAudioUnlocked) _stAudioUnlocked = true;\n\t\t\t\t}\n\t\t\t\tfunction stSyncMuteIcon() {\n\t\t\t\t\tvar muted = stIsAudioMuted();\n\t\t\t\t\tvar iconOn = document.getElementById('st-audio-icon-on');\n\t\t\t\t\tvar iconOff = document.getElementById('st-audio-icon-off');\n\t\t\t\t\tif (iconOn) iconOn.style.display = muted ? 'none' : '';\n\t\t\t\t\tif (iconOff) iconOff.style.display = muted ? '' : 'none';\n\t\t\t\t}\n\t\t\t\tfunction stPlayHookSound(hookName) {\n\t\t\t\t\tif (stIsAudioMuted()) return;\n\t\t\t\t\tvar entry = _stSoundMap[hookName];\n\t\t\t\t\tif (!entry) return;\n\t\t\t\t\tvar now = Date.now();\n\t\t\t\t\tif (_stLastSoundByCategory[entry.cat] && now - _stLastSoundByCategory[entry.cat] < 400) return;\n\t\t\t\t\t_stLastSoundByCategory[entry.cat] = now;\n\t\t\t\t\tvar file = entry.files[Math.floor(Math.random() * entry.files.length)];\n\t\t\t\t\ttry {\n\t\t\t\t\t\tvar audio = new Audio('/static/sounds/' + file);\n\t\t\t\t\t\taudio.volume = 0.4;\n\t\t\t\t\t\taudio.play().catch(function() {});\n\t\t\t\t\t} catch (_) {}\n\t\t\t\t}\n\n\t\t\t\t// --- Alert icon toggle ---\n\t\t\t\tfunction stUpdateAlertIcons(runID, hookName, ticketID) {\n\t\t\t\t\tvar active = hookName === 'hook.permission-request';\n\t\t\t\t\tvar selector = '.st-alert-icon[data-run-id=\"' + runID + '\"]';\n\t\t\t\t\tif (ticketID) {\n\t\t\t\t\t\tselector += ', .st-alert-icon[data-ticket-id=\"' + ticketID + '\"]';\n\t\t\t\t\t}\n\t\t\t\t\tdocument.querySelectorAll(selector).forEach(function(el) {\n\t\t\t\t\t\tel.classList.toggle('st-alert-active', active);\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction stRunIDForTicket(ticketID) {\n\t\t\t\t\tif (!ticketID) return '';\n\t\t\t\t\tvar dot = document.querySelector('.st-session-dot[data-ticket-id=\"' + ticketID + '\"][data-run-id]');\n\t\t\t\t\tif (!dot) return '';\n\t\t\t\t\treturn dot.getAttribute('data-run-id') || '';\n\t\t\t\t}\n\n\t\t\t\tfunction seedLastHookStateFromDOM() {\n\t\t\t\t\tif (!window._stLastHookByRunID) {\n\t\t\t\t\t\twindow._stLastHookByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id][data-last-hook-ts-ms]').forEach(function(dot) {\n\t\t\t\t\t\tvar runID = dot.getAttribute('data-run-id') || '';\n\t\t\t\t\t\tif (!runID) return;\n\t\t\t\t\t\tvar ts = Number(dot.getAttribute('data-last-hook-ts-ms') || '0');\n\t\t\t\t\t\tif (!Number.isFinite(ts) || ts <= 0) return;\n\t\t\t\t\t\tif (!window._stLastHookByRunID[runID] || ts > window._stLastHookByRunID[runID]) {\n\t\t\t\t\t\t\twindow._stLastHookByRunID[runID] = ts;\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction stIsRunStalled(runID) {\n\t\t\t\t\tif (!runID) return false;\n\t\t\t\t\tvar ts = window._stLastHookByRunID && window._stLastHookByRunID[runID];\n\t\t\t\t\tif (!ts) return true;\n\t\t\t\t\treturn (Date.now() - ts) > _stStallThresholdMs;\n\t\t\t\t}\n\n\t\t\t\tfunction stSpeakStalledAgent() {\n\t\t\t\t\tif (stIsAudioMuted()) return;\n\t\t\t\t\tif (!window.speechSynthesis || typeof window.SpeechSynthesisUtterance !== 'function') return;\n\t\t\t\t\tvar now = Date.now();\n\t\t\t\t\tif (_stLastStallSpeakAt && (now - _stLastStallSpeakAt) < _stStallSpeakCooldownMs) return;\n\t\t\t\t\t_stLastStallSpeakAt = now;\n\t\t\t\t\ttry {\n\t\t\t\t\t\twindow.speechSynthesis.speak(new SpeechSynthesisUtterance('stalled agent'));\n\t\t\t\t\t} catch (_) {}\n\t\t\t\t}\n\n\t\t\t\tfunction stUpdateStalledIndicators(runID) {\n\t\t\t\t\tif (!window._stRunStalledState) {\n\t\t\t\t\t\twindow._stRunStalledState = {};\n\t\t\t\t\t}\n\t\t\t\t\tvar shouldSpeak = false;\n\t\t\t\t\tdocument.querySelectorAll('.st-stalled-icon[data-run-id]').forEach(function(el) {\n\t\t\t\t\t\tvar currentRunID = el.getAttribute('data-run-id') || '';\n\t\t\t\t\t\tif (runID && currentRunID !== runID) return;\n\t\t\t\t\t\tvar stalled = stIsRunStalled(currentRunID);\n\t\t\t\t\t\tel.classList.toggle('st-alert-active', stalled);\n\t\t\t\t\t\tvar previous = window._stRunStalledState[currentRunID];\n\t\t\t\t\t\tif (typeof previous === 'boolean' && !previous && stalled) {\n\t\t\t\t\t\t\tshouldSpeak = true;\n\t\t\t\t\t\t}\n\t\t\t\t\t\twindow._stRunStalledState[currentRunID] = stalled;\n\t\t\t\t\t});\n\t\t\t\t\tif (shouldSpeak) {\n\t\t\t\t\t\tstSpeakStalledAgent();\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction toggleDone(btn) {\n\t\t\t\t\tvar col = btn.closest('.st-column');\n\t\t\t\t\tcol.classList.toggle('st-column-collapsed');\n\t\t\t\t\tvar count = btn.getAttribute('data-count');\n\t\t\t\t\tif (col.classList.contains('st-column-collapsed')) {\n\t\t\t\t\t\tbtn.textContent = 'Show all (' + count + ')';\n\t\t\t\t\t} else {\n\t\t\t\t\t\tbtn.textContent = 'Collapse';\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\tfunction updateScrollIndicators() {\n\t\t\t\t\tvar wrapper = document.querySelector('.st-board-wrapper');\n\t\t\t\t\tif (!wrapper) return;\n\t\t\t\t\tvar board = wrapper.querySelector('.st-board');\n\t\t\t\t\tif (!board) return;\n\t\t\t\t\tvar sl = board.scrollLeft > 0;\n\t\t\t\t\tvar sr = board.scrollLeft + board.clientWidth < board.scrollWidth - 1;\n\t\t\t\t\twrapper.classList.toggle('scroll-left', sl);\n\t\t\t\t\twrapper.classList.toggle('scroll-right', sr);\n\t\t\t\t}\n\t\t\t\tfunction pulseSessionDots(runID, hookName, ticketID) {\n\t\t\t\t\tif (!runID && ticketID) {\n\t\t\t\t\t\trunID = stRunIDForTicket(ticketID);\n\t\t\t\t\t}\n\t\t\t\t\tif (!runID) return;\n\t\t\t\t\tif (!window._stLastHookByRunID) {\n\t\t\t\t\t\twindow._stLastHookByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\twindow._stLastHookByRunID[runID] = Date.now();\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id=\"' + runID + '\"]').forEach(function(dot) {\n\t\t\t\t\t\tdot.setAttribute('data-last-hook-ts-ms', String(window._stLastHookByRunID[runID]));\n\t\t\t\t\t});\n\t\t\t\t\tdocument.querySelectorAll('.st-stalled-icon[data-run-id=\"' + runID + '\"]').forEach(function(icon) {\n\t\t\t\t\t\ticon.setAttribute('data-last-hook-ts-ms', String(window._stLastHookByRunID[runID]));\n\t\t\t\t\t});\n\t\t\t\t\tif (hookName) {\n\t\t\t\t\t\tif (!window._stLastHookNameByRunID) window._stLastHookNameByRunID = {};\n\t\t\t\t\t\twindow._stLastHookNameByRunID[runID] = hookName;\n\t\t\t\t\t}\n\t\t\t\t\tupdateDotActivityStates(runID);\n\t\t\t\t\tstUpdateStalledIndicators(runID);\n\t\t\t\t\tupdateAssigneePillTooltips(runID);\n\t\t\t\t\tif (hookName) {\n\t\t\t\t\t\tstUpdateAlertIcons(runID, hookName, ticketID);\n\t\t\t\t\t\tstPlayHookSound(hookName);\n\t\t\t\t\t}\n\t\t\t\t\t// Trigger a one-shot flash on each event.\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id=\"' + runID + '\"]').forEach(function(dot) {\n\t\t\t\t\t\tdot.classList.remove('st-session-dot-flash');\n\t\t\t\t\t\tvoid dot.offsetWidth; // reflow to restart animation\n\t\t\t\t\t\tdot.classList.add('st-session-dot-flash');\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tfunction sessionDotStateClass(msAgo) {\n\t\t\t\t\tif (msAgo <= 60 * 1000) return 'st-session-dot-hot';\n\t\t\t\t\tif (msAgo <= 2 * 60 * 1000) return 'st-session-dot-warm';\n\t\t\t\t\treturn 'st-session-dot-cold';\n\t\t\t\t}\n\t\t\t\tfunction updateDotActivityStates(runID) {\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id]').forEach(function(dot) {\n\t\t\t\t\t\tvar dotRunID = dot.getAttribute('data-run-id');\n\t\t\t\t\t\tif (runID && dotRunID !== runID) return;\n\t\t\t\t\t\tvar ts = window._stLastHookByRunID && dotRunID ? window._stLastHookByRunID[dotRunID] : 0;\n\t\t\t\t\t\tvar msAgo = ts ? (Date.now() - ts) : Number.MAX_SAFE_INTEGER;\n\t\t\t\t\t\tdot.classList.remove('st-session-dot-hot', 'st-session-dot-warm', 'st-session-dot-cold');\n\t\t\t\t\t\tdot.classList.add(sessionDotStateClass(msAgo));\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tfunction formatHookAge(msAgo) {\n\t\t\t\t\tif (msAgo < 1000) return 'just now';\n\t\t\t\t\tif (msAgo < 60 * 1000) return Math.floor(msAgo / 1000) + 's ago';\n\t\t\t\t\tif (msAgo < 60 * 60 * 1000) return Math.floor(msAgo / (60 * 1000)) + 'm ago';\n\t\t\t\t\treturn Math.floor(msAgo / (60 * 60 * 1000)) + 'h ago';\n\t\t\t\t}\n\t\t\t\tfunction buildSessionTooltip(sessionID, sourceLabel, runID) {\n\t\t\t\t\tvar source = sourceLabel || 'Unknown';\n\t\t\t\t\tvar session = sessionID || runID || 'unknown';\n\t\t\t\t\tvar lastHook = 'waiting for activity';\n\t\t\t\t\tif (window._stLastHookByRunID && runID && window._stLastHookByRunID[runID]) {\n\t\t\t\t\t\tvar hookAge = formatHookAge(Date.now() - window._stLastHookByRunID[runID]);\n\t\t\t\t\t\tvar hookName = (window._stLastHookNameByRunID && window._stLastHookNameByRunID[runID]) || '';\n\t\t\t\t\t\tlastHook = hookName ? hookName + ' (' + hookAge + ')' : hookAge;\n\t\t\t\t\t}\n\t\t\t\t\treturn 'Session: ' + session + '\\nSource: ' + source + '\\nLast hook: ' + lastHook;\n\t\t\t\t}\n\t\t\t\tfunction updateAssigneePillTooltips(runID) {\n\t\t\t\t\tdocument.querySelectorAll('.st-assignee-pill[data-run-id]').forEach(function(pill) {\n\t\t\t\t\t\tif (runID && pill.getAttribute('data-run-id') !== runID) return;\n\t\t\t\t\t\tvar pillRunID = pill.getAttribute('data-run-id') || '';\n\t\t\t\t\t\tvar sessionID = pill.getAttribute('data-session-id') || '';\n\t\t\t\t\t\tvar sourceLabel = pill.getAttribute('data-source-label') || '';\n\t\t\t\t\t\tvar tooltip = buildSessionTooltip(sessionID, sourceLabel, pillRunID);\n\t\t\t\t\t\tpill.setAttribute('title', tooltip);\n\t\t\t\t\t\tpill.setAttribute('aria-label', tooltip.replace(/\\n/g, ', '));\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tfunction syncAgentActivityState() {\n\t\t\t\t\tvar wanted = {};\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id]').forEach(function(dot) {\n\t\t\t\t\t\tvar runID = dot.getAttribute('data-run-id');\n\t\t\t\t\t\tif (runID) wanted[runID] = true;\n\t\t\t\t\t});\n\t\t\t\t\twindow._stWantedRunIDs = wanted;\n\t\t\t\t\tseedLastHookStateFromDOM();\n\n\t\t\t\t\tif (window._stLastHookByRunID) {\n\t\t\t\t\t\tObject.keys(window._stLastHookByRunID).forEach(function(runID) {\n\t\t\t\t\t\t\tif (wanted[runID]) return;\n\t\t\t\t\t\t\tdelete window._stLastHookByRunID[runID];\n\t\t\t\t\t\t\tif (window._stLastHookNameByRunID) delete window._stLastHookNameByRunID[runID];\n\t\t\t\t\t\t\tif (window._stRunStalledState) delete window._stRunStalledState[runID];\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\t\t\t\t\tupdateDotActivityStates();\n\t\t\t\t\tstUpdateStalledIndicators();\n\t\t\t\t\tupdateAssigneePillTooltips();\n\t\t\t\t}\n\t\t\t\tfunction handleAgentPing(e) {\n\t\t\t\t\tvar payload = {};\n\t\t\t\t\tif (e.detail && e.detail.data) {\n\t\t\t\t\t\ttry {\n\t\t\t\t\t\t\tpayload = JSON.parse(e.detail.data);\n\t\t\t\t\t\t} catch (_) {\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tvar activeRunIDs = window._stWantedRunIDs || {};\n\t\t\t\t\tvar runID = payload.run_id || '';\n\t\t\t\t\tif (!runID || !activeRunIDs[runID]) {\n\t\t\t\t\t\trunID = stRunIDForTicket(payload.ticket || '');\n\t\t\t\t\t}\n\t\t\t\t\tif (!runID || !activeRunIDs[runID]) return;\n\t\t\t\t\tpulseSessionDots(runID, payload.hook || '', payload.ticket || '');\n\t\t\t\t}\n\n\t\t\t\t// Guard: register all event listeners exactly once.\n\t\t\t\tif (!window._stInitialized) {\n\t\t\t\twindow._stInitialized = true;\n\n\t\t\t\t// Auto-inject project param into all HTMX requests.\n\t\t\t\tdocument.body.addEventListener('htmx:configRequest', function(e) {\n\t\t\t\t\tvar sel = document.getElementById('st-project-select');\n\t\t\t\t\tif (!sel || !sel.value) return;\n\t\t\t\t\tif (!e.detail.parameters['project']) {\n\t\t\t\t\t\te.detail.parameters['project'] = sel.value;\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tdocument.addEventListener('animationend', function(e) {\n\t\t\t\t\tif (e.target.classList.contains('st-session-dot-flash')) {\n\t\t\t\t\t\te.target.classList.remove('st-session-dot-flash');\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tdocument.addEventListener('mouseover', function(e) {\n\t\t\t\t\tvar pill = e.target.closest('.st-assignee-pill[data-run-id]');\n\t\t\t\t\tif (!pill) return;\n\t\t\t\t\tupdateAssigneePillTooltips(pill.getAttribute('data-run-id'));\n\t\t\t\t});\n\n\t\t\t\t// Listen for agent-ping events on the unified SSE stream.\n\t\t\t\t// The HTMX SSE extension stores the EventSource internally;\n\t\t\t\t// htmx:sseOpen fires when the connection is (re)established.\n\t\t\t\tdocument.body.addEventListener('htmx:sseOpen', function(e) {\n\t\t\t\t\tvar source = e.detail && e.detail.source;\n\t\t\t\t\tif (!source || source._stPingBound) return;\n\t\t\t\t\tsource._stPingBound = true;\n\t\t\t\t\tsource.addEventListener('ping', function(evt) {\n\t\t\t\t\t\thandleAgentPing({detail: evt});\n\t\t\t\t\t});\n\t\t\t\t});\n\n\t\t\t\tvar _ticketModalOpen = false;\n\n\t\t\t\t// Close modal on form success (HX-Trigger: closeModal).\n\t\t\t\tdocument.body.addEventListener('closeModal', function() {\n\t\t\t\t\tdocument.getElementById('ticket-modal').close();\n\t\t\t\t});\n\n\t\t\t\t// Click delegation: copy-to-clipboard and table row modal opens.\n\t\t\t\tdocument.addEventListener('click', function(e) {\n\t\t\t\t\tvar copyTarget = e.target.closest('[data-copy-ticket-id]');\n\t\t\t\t\tif (copyTarget) {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\te.stopImmediatePropagation();\n\t\t\t\t\t\tvar ticketID = copyTarget.getAttribute('data-copy-ticket-id');\n\t\t\t\t\t\tif (!ticketID) return;\n\t\t\t\t\t\tfunction copiedNotice() {\n\t\t\t\t\t\t\tvar toast = document.createElement('div');\n\t\t\t\t\t\t\ttoast.className = 'toast toast-end z-50';\n\t\t\t\t\t\t\ttoast.innerHTML = '<div class=\"alert alert-info\"><span>Copied ' + ticketID + '</span></div>';\n\t\t\t\t\t\t\tdocument.body.appendChild(toast);\n\t\t\t\t\t\t\tsetTimeout(function() { toast.remove(); }, 1200);\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (navigator.clipboard && navigator.clipboard.writeText) {\n\t\t\t\t\t\t\tnavigator.clipboard.writeText(ticketID).then(copiedNotice);\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar area = document.createElement('textarea');\n\t\t\t\t\t\tarea.value = ticketID;\n\t\t\t\t\t\tarea.style.position = 'fixed';\n\t\t\t\t\t\tarea.style.opacity = '0';\n\t\t\t\t\t\tdocument.body.appendChild(area);\n\t\t\t\t\t\tarea.focus();\n\t\t\t\t\t\tarea.select();\n\t\t\t\t\t\ttry {\n\t\t\t\t\t\t\tdocument.execCommand('copy');\n\t\t\t\t\t\t\tcopiedNotice();\n\t\t\t\t\t\t} finally {\n\t\t\t\t\t\t\tdocument.body.removeChild(area);\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tvar row = e.target.closest('tr[data-href]');\n\t\t\t\t\tif (row && !e.target.closest('a')) {\n\t\t\t\t\t\tvar partial = row.dataset.partial;\n\t\t\t\t\t\tif (partial) {\n\t\t\t\t\t\t\thtmx.ajax('GET', partial, {target: '#ticket-modal-body', swap: 'innerHTML'});\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Unlock audio on first user interaction (browser autoplay policy).\n\t\t\t\tdocument.addEventListener('click', function() {\n\t\t\t\t\tif (!_stAudioUnlocked) _stAudioUnlocked = true;\n\t\t\t\t}, { once: true });\n\n\t\t\t\t// Initial scroll indicator setup.\n\t\t\t\tdocument.addEventListener('DOMContentLoaded', function() {\n\t\t\t\t\tvar board = document.querySelector('.st-board');\n\t\t\t\t\tif (board) board.addEventListener('scroll', updateScrollIndicators);\n\t\t\t\t\tupdateScrollIndicators();\n\t\t\t\t\tsyncAgentActivityState();\n\t\t\t\t\tstSyncMuteIcon();\n\t\t\t\t\tsetInterval(function() {\n\t\t\t\t\t\tupdateDotActivityStates();\n\t\t\t\t\t\tstUpdateStalledIndicators();\n\t\t\t\t\t}, 5000);\n\t\t\t\t});\n\n\t\t\t\t// Consolidated htmx:afterSwap handler.\n\t\t\t\tdocument.addEventListener('htmx:afterSwap', function(e) {\n\t\t\t\t\t// Re-bind scroll indicators when board is swapped in.\n\t\t\t\t\tvar board = document.querySelector('.st-board');\n\t\t\t\t\tif (board) board.addEventListener('scroll', updateScrollIndicators);\n\t\t\t\t\tupdateScrollIndicators();\n\t\t\t\t\tsyncAgentActivityState();\n\n\t\t\t\t\t// Scroll to top on content navigation (not SSE refresh).\n\t\t\t\t\tif (e.detail.target && e.detail.target.id === 'content') {\n\t\t\t\t\t\tvar main = e.detail.target.closest('main');\n\t\t\t\t\t\tif (main) main.scrollTop = 0;\n\t\t\t\t\t}\n\n\t\t\t\t\t// Ticket modal management.\n\t\t\t\t\tif (e.detail.target && (e.detail.target.id === 'ticket-modal-body' || e.detail.target.closest('#ticket-modal-body'))) {\n\t\t\t\t\t\tif (!_ticketModalOpen) {\n\t\t\t\t\t\t\tdocument.getElementById('ticket-modal').showModal();\n\t\t\t\t\t\t\t_ticketModalOpen = true;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Update active nav link and page title on htmx navigation.\n\t\t\t\tdocument.addEventListener('htmx:pushedIntoHistory', function(e) {\n\t\t\t\t\tvar path = e.detail.path || window.location.pathname;\n\t\t\t\t\tdocument.querySelectorAll('.st-main-nav a.tab').forEach(function(tab) {\n\t\t\t\t\t\tvar linkPath = new URL(tab.href, window.location.origin).pathname;\n\t\t\t\t\t\tif (linkPath === path || (linkPath === '/' && path === '/')) {\n\t\t\t\t\t\t\ttab.classList.add('tab-active');\n\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\ttab.classList.remove('tab-active');\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t\tvar titleMap = {'/': 'Board', '/list': 'Tickets', '/sessions': 'Sessions', '/activity': 'Activity', '/critical-path': 'Critical Path', '/projects': 'Projects', '/rules': 'Rules'};\n\t\t\t\t\tvar title = titleMap[path];\n\t\t\t\t\tif (!title && path.startsWith('/ticket/')) {\n\t\t\t\t\t\tvar h1 = document.querySelector('#content h1');\n\t\t\t\t\t\ttitle = h1 ? h1.textContent.trim() : 'Ticket';\n\t\t\t\t\t}\n\t\t\t\t\tif (title) document.title = title + ' — smoovtask';\n\t\t\t\t\t// Preserve project param in pushed URL.\n\t\t\t\t\tvar projectSel = document.getElementById('st-project-select');\n\t\t\t\t\tif (projectSel && projectSel.value) {\n\t\t\t\t\t\tvar pushUrl = new URL(window.location);\n\t\t\t\t\t\tif (!pushUrl.searchParams.has('project')) {\n\t\t\t\t\t\t\tpushUrl.searchParams.set('project', projectSel.value);\n\t\t\t\t\t\t\thistory.replaceState({}, '', pushUrl);\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Handle browser back/forward.\n\t\t\t\twindow.addEventListener('popstate', function() {\n\t\t\t\t\tvar modalEl = document.getElementById('ticket-modal');\n\t\t\t\t\tif (modalEl && _ticketModalOpen) {\n\t\t\t\t\t\tmodalEl.close();\n\t\t\t\t\t}\n\t\t\t\t\tvar path = window.location.pathname;\n\t\t\t\t\tvar partialMap = {'/': '/partials/board', '/list': '/partials/list', '/sessions': '/partials/sessions', '/activity': '/partials/activity', '/critical-path': '/partials/critical-path', '/projects': '/partials/projects', '/rules': '/partials/rules'};\n\t\t\t\t\tvar partial = partialMap[path];\n\t\t\t\t\tif (!partial && path.startsWith('/ticket/')) {\n\t\t\t\t\t\tpartial = '/partials' + path;\n\t\t\t\t\t}\n\t\t\t\t\tif (partial) {\n\t\t\t\t\t\tvar url = partial;\n\t\t\t\t\t\tif (window.location.search) url += window.location.search;\n\t\t\t\t\t\thtmx.ajax('GET', url, {target: '#content', swap: 'innerHTML'});\n\t\t\t\t\t\tdocument.querySelectorAll('.st-main-nav a.tab').forEach(function(tab) {\n\t\t\t\t\t\t\tvar linkPath = new URL(tab.href, window.location.origin).pathname;\n\t\t\t\t\t\t\tif (linkPath === path) {\n\t\t\t\t\t\t\t\ttab.classList.add('tab-active');\n\t\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\t\ttab.classList.remove('tab-active');\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t});\n\t\t\t\t\t\tsyncAgentActivityState();\n\t\t\t\t\t}\n\t\t\t\t\t// Sync project dropdown from URL on back/forward.\n\t\t\t\t\tvar projectSel = document.getElementById('st-project-select');\n\t\t\t\t\tif (projectSel) {\n\t\t\t\t\t\tvar urlProject = new URL(window.location).searchParams.get('project') || '';\n\t\t\t\t\t\tprojectSel.value = urlProject;\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Modal hidden cleanup.\n\t\t\t\tdocument.getElementById('ticket-modal').addEventListener('close', function() {\n\t\t\t\t\t_ticketModalOpen = false;\n\t\t\t\t\tdocument.getElementById('ticket-modal-header').innerHTML = '';\n\t\t\t\t\tdocument.getElementById('ticket-modal-body').innerHTML = '';\n\t\t\t\t});\n\n\t\t\t\t// Search modal: Cmd+K / Ctrl+K to open.\n\t\t\t\tdocument.addEventListener('keydown', function(e) {\n\t\t\t\t\tif ((e.metaKey || e.ctrlKey) && e.key === 'k') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tstOpenSearch();\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Search modal: keyboard navigation.\n\t\t\t\tdocument.getElementById('search-input').addEventListener('keydown', function(e) {\n\t\t\t\t\tvar items = document.querySelectorAll('.st-search-item[data-ticket-id]');\n\t\t\t\t\tif (e.key === 'ArrowDown') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tif (_stSearchIndex < items.length - 1) {\n\t\t\t\t\t\t\t_stSearchIndex++;\n\t\t\t\t\t\t\tstRenderSearchResults(stSearchFilter(this.value, _stSearchTickets || [], stGetCurrentProject()));\n\t\t\t\t\t\t}\n\t\t\t\t\t} else if (e.key === 'ArrowUp') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tif (_stSearchIndex > 0) {\n\t\t\t\t\t\t\t_stSearchIndex--;\n\t\t\t\t\t\t\tstRenderSearchResults(stSearchFilter(this.value, _stSearchTickets || [], stGetCurrentProject()));\n\t\t\t\t\t\t}\n\t\t\t\t\t} else if (e.key === 'Enter') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tstSelectSearchResult();\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Search modal: input filtering.\n\t\t\t\tdocument.getElementById('search-input').addEventListener('input', function() {\n\t\t\t\t\t_stSearchIndex = 0;\n\t\t\t\t\tstRenderSearchResults(stSearchFilter(this.value, _stSearchTickets || [], stGetCurrentProject()));\n\t\t\t\t});\n\n\t\t\t\t// Search modal: click to select result.\n\t\t\t\tdocument.getElementById('search-results').addEventListener('click', function(e) {\n\t\t\t\t\tvar item = e.target.closest('.st-search-item[data-ticket-id]');\n\t\t\t\t\tif (!item) return;\n\t\t\t\t\tvar idx = parseInt(item.getAttribute('data-search-idx'), 10);\n\t\t\t\t\tif (!isNaN(idx)) _stSearchIndex = idx;\n\t\t\t\t\tstSelectSearchResult();\n\t\t\t\t});\n\n\t\t\t\t// Invalidate search cache on SSE ticket changes.\n\t\t\t\tdocument.body.addEventListener('htmx:sseMessage', function() {\n\t\t\t\t\t_stSearchTickets = null;\n\t\t\t\t});\n\n\t\t\t\t// Search modal cleanup on close.\n\t\t\t\tdocument.getElementById('search-modal').addEventListener('close', function() {\n\t\t\t\t\tdocument.getElementById('search-input').value = '';\n\t\t\t\t\tdocument.getElementById('search-results').innerHTML = '';\n\t\t\t\t});\n\n\t\t\t\t// --- Search modal ---\n\t\t\tvar _stSearchTickets = null;\n\t\t\tvar _stSearchIndex = 0;\n\n\t\t\tfunction stSearchScore(query, tk) {\n\t\t\t\tvar q = query.toLowerCase();\n\t\t\t\tvar idLower = tk.id.toLowerCase();\n\t\t\t\tvar titleLower = tk.title.toLowerCase();\n\t\t\t\tvar projectLower = tk.project.toLowerCase();\n\n\t\t\t\t// Exact ID match.\n\t\t\t\tif (idLower === q) return 10000;\n\t\t\t\t// ID starts with query.\n\t\t\t\tif (idLower.indexOf(q) === 0) return 5000;\n\t\t\t\t// ID contains query.\n\t\t\t\tif (idLower.indexOf(q) >= 0) return 3000;\n\t\t\t\t// Title starts with query.\n\t\t\t\tif (titleLower.indexOf(q) === 0) return 2000;\n\t\t\t\t// Title contains query (word boundary bonus).\n\t\t\t\tvar titleIdx = titleLower.indexOf(q);\n\t\t\t\tif (titleIdx >= 0) {\n\t\t\t\t\tvar wordBonus = (titleIdx === 0 || titleLower[titleIdx - 1] === ' ') ? 500 : 0;\n\t\t\t\t\treturn 1000 + wordBonus;\n\t\t\t\t}\n\t\t\t\t// Project contains query.\n\t\t\t\tif (projectLower.indexOf(q) >= 0) return 500;\n\t\t\t\t// Multi-word: all terms must appear somewhere.\n\t\t\t\tvar terms = q.split(/\\s+/);\n\t\t\t\tif (terms.length > 1) {\n\t\t\t\t\tvar combined = idLower + ' ' + titleLower + ' ' + projectLower;\n\t\t\t\t\tvar allMatch = true;\n\t\t\t\t\tfor (var i = 0; i < terms.length; i++) {\n\t\t\t\t\t\tif (combined.indexOf(terms[i]) < 0) { allMatch = false; break; }\n\t\t\t\t\t}\n\t\t\t\t\tif (allMatch) return 200;\n\t\t\t\t}\n\t\t\t\treturn 0;\n\t\t\t}\n\n\t\t\tfunction stSearchFilter(query, tickets, currentProject) {\n\t\t\t\tif (!query.trim()) {\n\t\t\t\t\t// No query: current project first, then priority asc, then updated desc.\n\t\t\t\t\tvar sorted = tickets.slice();\n\t\t\t\t\tsorted.sort(function(a, b) {\n\t\t\t\t\t\tif (currentProject) {\n\t\t\t\t\t\t\tvar aMatch = a.project === currentProject ? 0 : 1;\n\t\t\t\t\t\t\tvar bMatch = b.project === currentProject ? 0 : 1;\n\t\t\t\t\t\t\tif (aMatch !== bMatch) return aMatch - bMatch;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (a.priority < b.priority) return -1;\n\t\t\t\t\t\tif (a.priority > b.priority) return 1;\n\t\t\t\t\t\tif (a.updated > b.updated) return -1;\n\t\t\t\t\t\tif (a.updated < b.updated) return 1;\n\t\t\t\t\t\treturn 0;\n\t\t\t\t\t});\n\t\t\t\t\treturn sorted.slice(0, 50);\n\t\t\t\t}\n\n\t\t\t\tvar scored = [];\n\t\t\t\tfor (var i = 0; i < tickets.length; i++) {\n\t\t\t\t\tvar s = stSearchScore(query, tickets[i]);\n\t\t\t\t\tif (s > 0) {\n\t\t\t\t\t\t// Boost current project results.\n\t\t\t\t\t\tvar projectBoost = (currentProject && tickets[i].project === currentProject) ? 100 : 0;\n\t\t\t\t\t\tscored.push({ ticket: tickets[i], score: s + projectBoost });\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\tscored.sort(function(a, b) { return b.score - a.score; });\n\t\t\t\tvar result = [];\n\t\t\t\tfor (var j = 0; j < Math.min(scored.length, 50); j++) {\n\t\t\t\t\tresult.push(scored[j].ticket);\n\t\t\t\t}\n\t\t\t\treturn result;\n\t\t\t}\n\n\t\t\tfunction stRenderSearchResults(tickets) {\n\t\t\t\tvar container = document.getElementById('search-results');\n\t\t\t\tif (!container) return;\n\t\t\t\tif (tickets.length === 0) {\n\t\t\t\t\tcontainer.innerHTML = '<div class=\"st-search-empty\">No matching tickets</div>';\n\t\t\t\t\treturn;\n\t\t\t\t}\n\t\t\t\tvar html = '';\n\t\t\t\tfor (var i = 0; i < tickets.length; i++) {\n\t\t\t\t\tvar tk = tickets[i];\n\t\t\t\t\tvar activeClass = i === _stSearchIndex ? ' st-search-active' : '';\n\t\t\t\t\thtml += '<div class=\"st-search-item' + activeClass + '\" data-search-idx=\"' + i + '\" data-ticket-id=\"' + tk.id + '\">';\n\t\t\t\t\thtml += '<span class=\"badge badge-sm st-priority-' + tk.priority.toLowerCase() + '\">' + tk.priority + '</span>';\n\t\t\t\t\thtml += '<span class=\"st-search-item-title\">' + stEscapeHtml(tk.title) + '</span>';\n\t\t\t\t\thtml += '<span class=\"st-search-item-meta\"><span>' + tk.id + '</span><span>' + stEscapeHtml(tk.project) + '</span></span>';\n\t\t\t\t\thtml += '</div>';\n\t\t\t\t}\n\t\t\t\thtml += '<div class=\"st-search-hint\">\\u2191\\u2193 navigate \\u00b7 enter to open \\u00b7 esc to close</div>';\n\t\t\t\tcontainer.innerHTML = html;\n\t\t\t\tstScrollActiveIntoView();\n\t\t\t}\n\n\t\t\tfunction stEscapeHtml(s) {\n\t\t\t\tvar d = document.createElement('div');\n\t\t\t\td.textContent = s;\n\t\t\t\treturn d.innerHTML;\n\t\t\t}\n\n\t\t\tfunction stScrollActiveIntoView() {\n\t\t\t\tvar active = document.querySelector('.st-search-item.st-search-active');\n\t\t\t\tif (active) active.scrollIntoView({ block: 'nearest' });\n\t\t\t}\n\n\t\t\tfunction stGetCurrentProject() {\n\t\t\t\tvar sel = document.getElementById('st-project-select');\n\t\t\t\treturn (sel && sel.value) ? sel.value : '';\n\t\t\t}\n\n\t\t\tfunction stOpenSearch() {\n\t\t\t\tvar modal = document.getElementById('search-modal');\n\t\t\t\tvar input = document.getElementById('search-input');\n\t\t\t\tif (!modal || !input) return;\n\t\t\t\tmodal.showModal();\n\t\t\t\tinput.value = '';\n\t\t\t\t_stSearchIndex = 0;\n\t\t\t\t// Fetch tickets if not cached.\n\t\t\t\tif (!_stSearchTickets) {\n\t\t\t\t\tdocument.getElementById('search-results').innerHTML = '<div class=\"st-search-empty\">Loading...</div>';\n\t\t\t\t\tfetch('/api/search-tickets')\n\t\t\t\t\t\t.then(function(r) { return r.json(); })\n\t\t\t\t\t\t.then(function(data) {\n\t\t\t\t\t\t\t_stSearchTickets = data;\n\t\t\t\t\t\t\tstRenderSearchResults(stSearchFilter('', data, stGetCurrentProject()));\n\t\t\t\t\t\t})\n\t\t\t\t\t\t.catch(function() {\n\t\t\t\t\t\t\tdocument.getElementById('search-results').innerHTML = '<div class=\"st-search-empty\">Failed to load tickets</div>';\n\t\t\t\t\t\t});\n\t\t\t\t} else {\n\t\t\t\t\tstRenderSearchResults(stSearchFilter('', _stSearchTickets, stGetCurrentProject()));\n\t\t\t\t}\n\t\t\t\tinput.focus();\n\t\t\t}\n\n\t\t\tfunction stCloseSearch() {\n\t\t\t\tvar modal = document.getElementById('search-modal');\n\t\t\t\tif (modal) modal.close();\n\t\t\t}\n\n\t\t\tfunction stSelectSearchResult() {\n\t\t\t\tvar items = document.querySelectorAll('.st-search-item[data-ticket-id]');\n\t\t\t\tif (_stSearchIndex >= 0 && _stSearchIndex < items.length) {\n\t\t\t\t\tvar ticketID = items[_stSearchIndex].getAttribute('data-ticket-id');\n\t\t\t\t\tstCloseSearch();\n\t\t\t\t\thtmx.ajax('GET', '/partials/ticket/' + ticketID, { target: '#ticket-modal-body', swap: 'innerHTML' });\n\t\t\t\t}\n\t\t\t}\n\n\t\t\twindow.addEventListener('beforeunload', function() {\n\t\t\t\t\tif (window._stLastHookByRunID) {\n\t\t\t\t\t\twindow._stLastHookByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\tif (window._stLastHookNameByRunID) {\n\t\t\t\t\t\twindow._stLastHookNameByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\tif (window._stWantedRunIDs) {\n\t\t\t\t\t\twindow._stWantedRunIDs = {};\n\t\t\t\t\t}\n\t\t\t\t\tif (window._stRunStalledState) {\n\t\t\t\t\t\twindow._stRunStalledState = {};\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t\t}\n\t\t\t</script></body></html>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 33, "</div></main><dialog id=\"search-modal\" class=\"modal\"><div class=\"modal-box st-search-modal\"><div class=\"st-search-header\"><svg class=\"st-search-icon\" width=\"18\" height=\"18\" viewBox=\"0 0 24 24\" fill=\"none\" stroke=\"currentColor\" stroke-width=\"2\" stroke-linecap=\"round\" stroke-linejoin=\"round\"><circle cx=\"11\" cy=\"11\" r=\"8\"></circle><line x1=\"21\" y1=\"21\" x2=\"16.65\" y2=\"16.65\"></line></svg> <input id=\"search-input\" type=\"text\" class=\"st-search-input\" placeholder=\"Search tickets...\" autocomplete=\"off\" spellcheck=\"false\"> <kbd class=\"st-search-kbd\">esc</kbd></div><div id=\"search-results\" class=\"st-search-results\"></div></div><form method=\"dialog\" class=\"modal-backdrop\"><button>close</button></form></dialog> <dialog id=\"ticket-modal\" class=\"modal\"><div class=\"modal-box st-modal-ticket\"><button class=\"btn btn-sm btn-circle btn-ghost absolute right-3 top-3 z-10\" type=\"button\" onclick=\"document.getElementById('ticket-modal').close()\">✕</button><div class=\"st-modal-header\" id=\"ticket-modal-header\"></div><div class=\"st-modal-body overflow-auto\" id=\"ticket-modal-body\"></div></div><form method=\"dialog\" class=\"modal-backdrop\"><button>close</button></form></dialog><script>\n\t\t\t\t// Global function definitions (safe to re-declare on script re-evaluation).\n\n\t\t\t\t// --- Project selector ---\n\t\t\t\tfunction stSelectProject(select) {\n\t\t\t\t\tfetch('/api/project', {\n\t\t\t\t\t\tmethod: 'POST',\n\t\t\t\t\t\theaders: {'Content-Type': 'application/json'},\n\t\t\t\t\t\tbody: JSON.stringify({project: select.value})\n\t\t\t\t\t});\n\t\t\t\t\tvar url = new URL(window.location);\n\t\t\t\t\tif (select.value) {\n\t\t\t\t\t\turl.searchParams.set('project', select.value);\n\t\t\t\t\t} else {\n\t\t\t\t\t\turl.searchParams.delete('project');\n\t\t\t\t\t}\n\t\t\t\t\twindow.location = url.toString();\n\t\t\t\t}\n\n\t\t\t\t// --- Audio alert system ---\n\t\t\t\tvar _stSoundMap = {\n\t\t\t\t\t'hook.permission-request': { cat: 'deny', files: ['deny-1.wav', 'deny-2.wav'] },\n\t\t\t\t\t'hook.session-start':      { cat: 'start', files: ['start-1.wav'] },\n\t\t\t\t\t'hook.stop':               { cat: 'complete', files: ['complete-1.wav', 'complete-2.wav'] },\n\t\t\t\t\t'hook.session-end':        { cat: 'complete', files: ['complete-1.wav', 'complete-2.wav'] },\n\t\t\t\t\t'hook.task-completed':     { cat: 'event', files: ['event-4.wav', 'event-7.wav', 'event-9.wav'] },\n\t\t\t\t\t'hook.pre-tool':           { cat: 'event', files: ['event-4.wav', 'event-7.wav', 'event-9.wav'] },\n\t\t\t\t\t'hook.post-tool':          { cat: 'event', files: ['event-4.wav', 'event-7.wav', 'event-9.wav'] }\n\t\t\t\t};\n\t\t\t\tvar _stStallThresholdMs = 2 * 60 * 1000;\n\t\t\t\tvar _stStallSpeakCooldownMs = 30 * 1000;\n\t\t\t\tvar _stLastSoundByCategory = {};\n\t\t\t\tvar _stAudioUnlocked = false;\n\t\t\t\tvar _stLastStallSpeakAt = 0;\n\n\t\t\t\tfunction stIsAudioMuted() {\n\t\t\t\t\treturn localStorage.getItem('st-audio-muted') === '1';\n\t\t\t\t}\n\t\t\t\tfunction stToggleAudioMute() {\n\t\t\t\t\tvar muted = !stIsAudioMuted();\n\t\t\t\t\tlocalStorage.setItem('st-audio-muted', muted ? '1' : '0');\n\t\t\t\t\tstSyncMuteIcon();\n\t\t\t\t\t// Unlock audio on first interaction\n\t\t\t\t\tif (!muted && !_stAudioUnlocked) _stAudioUnlocked = true;\n\t\t\t\t}\n\t\t\t\tfunction stSyncMuteIcon() {\n\t\t\t\t\tvar muted = stIsAudioMuted();\n\t\t\t\t\tvar iconOn = document.getElementById('st-audio-icon-on');\n\t\t\t\t\tvar iconOff = document.getElementById('st-audio-icon-off');\n\t\t\t\t\tif (iconOn) iconOn.style.display = muted ? 'none' : '';\n\t\t\t\t\tif (iconOff) iconOff.style.display = muted ? '' : 'none';\n\t\t\t\t}\n\t\t\t\tfunction stPlayHookSound(hookName) {\n\t\t\t\t\tif (stIsAudioMuted()) return;\n\t\t\t\t\tvar entry = _stSoundMap[hookName];\n\t\t\t\t\tif (!entry) return;\n\t\t\t\t\tvar now = Date.now();\n\t\t\t\t\tif (_stLastSoundByCategory[entry.cat] && now - _stLastSoundByCategory[entry.cat] < 400) return;\n\t\t\t\t\t_stLastSoundByCategory[entry.cat] = now;\n\t\t\t\t\tvar file = entry.files[Math.floor(Math.random() * entry.files.length)];\n\t\t\t\t\ttry {\n\t\t\t\t\t\tvar audio = new Audio('/static/sounds/' + file);\n\t\t\t\t\t\taudio.volume = 0.4;\n\t\t\t\t\t\taudio.play().catch(function() {});\n\t\t\t\t\t} catch (_) {}\n\t\t\t\t}\n\n\t\t\t\t// --- Alert icon toggle ---\n\t\t\t\tfunction stUpdateAlertIcons(runID, hookName, ticketID) {\n\t\t\t\t\tvar active = hookName === 'hook.permission-request';\n\t\t\t\t\tvar selector = '.st-alert-icon[data-run-id=\"' + runID + '\"]';\n\t\t\t\t\tif (ticketID) {\n\t\t\t\t\t\tselector += ', .st-alert-icon[data-ticket-id=\"' + ticketID + '\"]';\n\t\t\t\t\t}\n\t\t\t\t\tdocument.querySelectorAll(selector).forEach(function(el) {\n\t\t\t\t\t\tel.classList.toggle('st-alert-active', active);\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction stRunIDForTicket(ticketID) {\n\t\t\t\t\tif (!ticketID) return '';\n\t\t\t\t\tvar dot = document.querySelector('.st-session-dot[data-ticket-id=\"' + ticketID + '\"][data-run-id]');\n\t\t\t\t\tif (!dot) return '';\n\t\t\t\t\treturn dot.getAttribute('data-run-id') || '';\n\t\t\t\t}\n\n\t\t\t\tfunction seedLastHookStateFromDOM() {\n\t\t\t\t\tif (!window._stLastHookByRunID) {\n\t\t\t\t\t\twindow._stLastHookByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id][data-last-hook-ts-ms]').forEach(function(dot) {\n\t\t\t\t\t\tvar runID = dot.getAttribute('data-run-id') || '';\n\t\t\t\t\t\tif (!runID) return;\n\t\t\t\t\t\tvar ts = Number(dot.getAttribute('data-last-hook-ts-ms') || '0');\n\t\t\t\t\t\tif (!Number.isFinite(ts) || ts <= 0) return;\n\t\t\t\t\t\tif (!window._stLastHookByRunID[runID] || ts > window._stLastHookByRunID[runID]) {\n\t\t\t\t\t\t\twindow._stLastHookByRunID[runID] = ts;\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\tfunction stIsRunStalled(runID) {\n\t\t\t\t\tif (!runID) return false;\n\t\t\t\t\tvar ts = window._stLastHookByRunID && window._stLastHookByRunID[runID];\n\t\t\t\t\tif (!ts) return true;\n\t\t\t\t\treturn (Date.now() - ts) > _stStallThresholdMs;\n\t\t\t\t}\n\n\t\t\t\tfunction stSpeakStalledAgent() {\n\t\t\t\t\tif (stIsAudioMuted()) return;\n\t\t\t\t\tif (!window.speechSynthesis || typeof window.SpeechSynthesisUtterance !== 'function') return;\n\t\t\t\t\tvar now = Date.now();\n\t\t\t\t\tif (_stLastStallSpeakAt && (now - _stLastStallSpeakAt) < _stStallSpeakCooldownMs) return;\n\t\t\t\t\t_stLastStallSpeakAt = now;\n\t\t\t\t\ttry {\n\t\t\t\t\t\twindow.speechSynthesis.speak(new SpeechSynthesisUtterance('stalled agent'));\n\t\t\t\t\t} catch (_) {}\n\t\t\t\t}\n\n\t\t\t\tfunction stUpdateStalledIndicators(runID) {\n\t\t\t\t\tif (!window._stRunStalledState) {\n\t\t\t\t\t\twindow._stRunStalledState = {};\n\t\t\t\t\t}\n\t\t\t\t\tvar shouldSpeak = false;\n\t\t\t\t\tdocument.querySelectorAll('.st-stalled-icon[data-run-id]').forEach(function(el) {\n\t\t\t\t\t\tvar currentRunID = el.getAttribute('data-run-id') || '';\n\t\t\t\t\t\tif (runID && currentRunID !== runID) return;\n\t\t\t\t\t\tvar stalled = stIsRunStalled(currentRunID);\n\t\t\t\t\t\tel.classList.toggle('st-alert-active', stalled);\n\t\t\t\t\t\tvar previous = window._stRunStalledState[currentRunID];\n\t\t\t\t\t\tif (typeof previous === 'boolean' && !previous && stalled) {\n\t\t\t\t\t\t\tshouldSpeak = true;\n\t\t\t\t\t\t}\n\t\t\t\t\t\twindow._stRunStalledState[currentRunID] = stalled;\n\t\t\t\t\t});\n\t\t\t\t\tif (shouldSpeak) {\n\t\t\t\t\t\tstSpeakStalledAgent();\n\t\t\t\t\t}\n\t\t\t\t}\n\n\t\t\t\tfunction toggleDone(btn) {\n\t\t\t\t\tvar col = btn.closest('.st-column');\n\t\t\t\t\tcol.classList.toggle('st-column-collapsed');\n\t\t\t\t\tvar count = btn.getAttribute('data-count');\n\t\t\t\t\tif (col.classList.contains('st-column-collapsed')) {\n\t\t\t\t\t\tbtn.textContent = 'Show all (' + count + ')';\n\t\t\t\t\t} else {\n\t\t\t\t\t\tbtn.textContent = 'Collapse';\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\tfunction updateScrollIndicators() {\n\t\t\t\t\tvar wrapper = document.querySelector('.st-board-wrapper');\n\t\t\t\t\tif (!wrapper) return;\n\t\t\t\t\tvar board = wrapper.querySelector('.st-board');\n\t\t\t\t\tif (!board) return;\n\t\t\t\t\tvar sl = board.scrollLeft > 0;\n\t\t\t\t\tvar sr = board.scrollLeft + board.clientWidth < board.scrollWidth - 1;\n\t\t\t\t\twrapper.classList.toggle('scroll-left', sl);\n\t\t\t\t\twrapper.classList.toggle('scroll-right', sr);\n\t\t\t\t}\n\t\t\t\tfunction pulseSessionDots(runID, hookName, ticketID) {\n\t\t\t\t\tif (!runID && ticketID) {\n\t\t\t\t\t\trunID = stRunIDForTicket(ticketID);\n\t\t\t\t\t}\n\t\t\t\t\tif (!runID) return;\n\t\t\t\t\tif (!window._stLastHookByRunID) {\n\t\t\t\t\t\twindow._stLastHookByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\twindow._stLastHookByRunID[runID] = Date.now();\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id=\"' + runID + '\"]').forEach(function(dot) {\n\t\t\t\t\t\tdot.setAttribute('data-last-hook-ts-ms', String(window._stLastHookByRunID[runID]));\n\t\t\t\t\t});\n\t\t\t\t\tdocument.querySelectorAll('.st-stalled-icon[data-run-id=\"' + runID + '\"]').forEach(function(icon) {\n\t\t\t\t\t\ticon.setAttribute('data-last-hook-ts-ms', String(window._stLastHookByRunID[runID]));\n\t\t\t\t\t});\n\t\t\t\t\tif (hookName) {\n\t\t\t\t\t\tif (!window._stLastHookNameByRunID) window._stLastHookNameByRunID = {};\n\t\t\t\t\t\twindow._stLastHookNameByRunID[runID] = hookName;\n\t\t\t\t\t}\n\t\t\t\t\tupdateDotActivityStates(runID);\n\t\t\t\t\tstUpdateStalledIndicators(runID);\n\t\t\t\t\tupdateAssigneePillTooltips(runID);\n\t\t\t\t\tif (hookName) {\n\t\t\t\t\t\tstUpdateAlertIcons(runID, hookName, ticketID);\n\t\t\t\t\t\tstPlayHookSound(hookName);\n\t\t\t\t\t}\n\t\t\t\t\t// Trigger a one-shot flash on each event.\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id=\"' + runID + '\"]').forEach(function(dot) {\n\t\t\t\t\t\tdot.classList.remove('st-session-dot-flash');\n\t\t\t\t\t\tvoid dot.offsetWidth; // reflow to restart animation\n\t\t\t\t\t\tdot.classList.add('st-session-dot-flash');\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tfunction sessionDotStateClass(msAgo) {\n\t\t\t\t\tif (msAgo <= 60 * 1000) return 'st-session-dot-hot';\n\t\t\t\t\tif (msAgo <= 2 * 60 * 1000) return 'st-session-dot-warm';\n\t\t\t\t\treturn 'st-session-dot-cold';\n\t\t\t\t}\n\t\t\t\tfunction updateDotActivityStates(runID) {\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id]').forEach(function(dot) {\n\t\t\t\t\t\tvar dotRunID = dot.getAttribute('data-run-id');\n\t\t\t\t\t\tif (runID && dotRunID !== runID) return;\n\t\t\t\t\t\tvar ts = window._stLastHookByRunID && dotRunID ? window._stLastHookByRunID[dotRunID] : 0;\n\t\t\t\t\t\tvar msAgo = ts ? (Date.now() - ts) : Number.MAX_SAFE_INTEGER;\n\t\t\t\t\t\tdot.classList.remove('st-session-dot-hot', 'st-session-dot-warm', 'st-session-dot-cold');\n\t\t\t\t\t\tdot.classList.add(sessionDotStateClass(msAgo));\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tfunction formatHookAge(msAgo) {\n\t\t\t\t\tif (msAgo < 1000) return 'just now';\n\t\t\t\t\tif (msAgo < 60 * 1000) return Math.floor(msAgo / 1000) + 's ago';\n\t\t\t\t\tif (msAgo < 60 * 60 * 1000) return Math.floor(msAgo / (60 * 1000)) + 'm ago';\n\t\t\t\t\treturn Math.floor(msAgo / (60 * 60 * 1000)) + 'h ago';\n\t\t\t\t}\n\t\t\t\tfunction buildSessionTooltip(sessionID, sourceLabel, runID) {\n\t\t\t\t\tvar source = sourceLabel || 'Unknown';\n\t\t\t\t\tvar session = sessionID || runID || 'unknown';\n\t\t\t\t\tvar lastHook = 'waiting for activity';\n\t\t\t\t\tif (window._stLastHookByRunID && runID && window._stLastHookByRunID[runID]) {\n\t\t\t\t\t\tvar hookAge = formatHookAge(Date.now() - window._stLastHookByRunID[runID]);\n\t\t\t\t\t\tvar hookName = (window._stLastHookNameByRunID && window._stLastHookNameByRunID[runID]) || '';\n\t\t\t\t\t\tlastHook = hookName ? hookName + ' (' + hookAge + ')' : hookAge;\n\t\t\t\t\t}\n\t\t\t\t\treturn 'Session: ' + session + '\\nSource: ' + source + '\\nLast hook: ' + lastHook;\n\t\t\t\t}\n\t\t\t\tfunction updateAssigneePillTooltips(runID) {\n\t\t\t\t\tdocument.querySelectorAll('.st-assignee-pill[data-run-id]').forEach(function(pill) {\n\t\t\t\t\t\tif (runID && pill.getAttribute('data-run-id') !== runID) return;\n\t\t\t\t\t\tvar pillRunID = pill.getAttribute('data-run-id') || '';\n\t\t\t\t\t\tvar sessionID = pill.getAttribute('data-session-id') || '';\n\t\t\t\t\t\tvar sourceLabel = pill.getAttribute('data-source-label') || '';\n\t\t\t\t\t\tvar tooltip = buildSessionTooltip(sessionID, sourceLabel, pillRunID);\n\t\t\t\t\t\tpill.setAttribute('title', tooltip);\n\t\t\t\t\t\tpill.setAttribute('aria-label', tooltip.replace(/\\n/g, ', '));\n\t\t\t\t\t});\n\t\t\t\t}\n\t\t\t\tfunction syncAgentActivityState() {\n\t\t\t\t\tvar wanted = {};\n\t\t\t\t\tdocument.querySelectorAll('.st-session-dot[data-run-id]').forEach(function(dot) {\n\t\t\t\t\t\tvar runID = dot.getAttribute('data-run-id');\n\t\t\t\t\t\tif (runID) wanted[runID] = true;\n\t\t\t\t\t});\n\t\t\t\t\twindow._stWantedRunIDs = wanted;\n\t\t\t\t\tseedLastHookStateFromDOM();\n\n\t\t\t\t\tif (window._stLastHookByRunID) {\n\t\t\t\t\t\tObject.keys(window._stLastHookByRunID).forEach(function(runID) {\n\t\t\t\t\t\t\tif (wanted[runID]) return;\n\t\t\t\t\t\t\tdelete window._stLastHookByRunID[runID];\n\t\t\t\t\t\t\tif (window._stLastHookNameByRunID) delete window._stLastHookNameByRunID[runID];\n\t\t\t\t\t\t\tif (window._stRunStalledState) delete window._stRunStalledState[runID];\n\t\t\t\t\t\t});\n\t\t\t\t\t}\n\t\t\t\t\tupdateDotActivityStates();\n\t\t\t\t\tstUpdateStalledIndicators();\n\t\t\t\t\tupdateAssigneePillTooltips();\n\t\t\t\t}\n\t\t\t\tfunction handleAgentPing(e) {\n\t\t\t\t\tvar payload = {};\n\t\t\t\t\tif (e.detail && e.detail.data) {\n\t\t\t\t\t\ttry {\n\t\t\t\t\t\t\tpayload = JSON.parse(e.detail.data);\n\t\t\t\t\t\t} catch (_) {\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t\tvar activeRunIDs = window._stWantedRunIDs || {};\n\t\t\t\t\tvar runID = payload.run_id || '';\n\t\t\t\t\tif (!runID || !activeRunIDs[runID]) {\n\t\t\t\t\t\trunID = stRunIDForTicket(payload.ticket || '');\n\t\t\t\t\t}\n\t\t\t\t\tif (!runID || !activeRunIDs[runID]) return;\n\t\t\t\t\tpulseSessionDots(runID, payload.hook || '', payload.ticket || '');\n\t\t\t\t}\n\n\t\t\t\t// Guard: register all event listeners exactly once.\n\t\t\t\tif (!window._stInitialized) {\n\t\t\t\twindow._stInitialized = true;\n\n\t\t\t\t// --- Inbox: read/unread state (localStorage) ---\n\t\t\t\tvar _stInboxReadKey = 'st-inbox-read';\n\t\t\t\tfunction stGetReadItems() {\n\t\t\t\t\ttry { return JSON.parse(localStorage.getItem(_stInboxReadKey) || '{}'); } catch(_) { return {}; }\n\t\t\t\t}\n\t\t\t\tfunction stMarkRead(id) {\n\t\t\t\t\tvar read = stGetReadItems();\n\t\t\t\t\tread[id] = Date.now();\n\t\t\t\t\t// Prune entries older than 7 days.\n\t\t\t\t\tvar cutoff = Date.now() - 7 * 24 * 60 * 60 * 1000;\n\t\t\t\t\tObject.keys(read).forEach(function(k) { if (read[k] < cutoff) delete read[k]; });\n\t\t\t\t\tlocalStorage.setItem(_stInboxReadKey, JSON.stringify(read));\n\t\t\t\t}\n\t\t\t\tfunction stApplyInboxReadState() {\n\t\t\t\t\tvar read = stGetReadItems();\n\t\t\t\t\tdocument.querySelectorAll('.st-inbox-row[data-inbox-id]').forEach(function(row) {\n\t\t\t\t\t\tif (read[row.getAttribute('data-inbox-id')]) {\n\t\t\t\t\t\t\trow.classList.add('st-inbox-read');\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\t// --- Inbox: local timezone formatting ---\n\t\t\t\tfunction stFormatLocalTimes() {\n\t\t\t\t\tdocument.querySelectorAll('.st-local-time').forEach(function(el) {\n\t\t\t\t\t\tvar iso = el.getAttribute('datetime');\n\t\t\t\t\t\tif (!iso) return;\n\t\t\t\t\t\tvar d = new Date(iso);\n\t\t\t\t\t\tif (isNaN(d.getTime())) return;\n\t\t\t\t\t\tvar date = d.getFullYear() + '-' + String(d.getMonth()+1).padStart(2,'0') + '-' + String(d.getDate()).padStart(2,'0');\n\t\t\t\t\t\tvar time = String(d.getHours()).padStart(2,'0') + ':' + String(d.getMinutes()).padStart(2,'0');\n\t\t\t\t\t\tel.innerHTML = '<span>' + date + '</span><br/><span>' + time + '</span>';\n\t\t\t\t\t});\n\t\t\t\t}\n\n\t\t\t\t// --- Browser Notifications ---\n\t\t\t\tvar _stNotifPermission = typeof Notification !== 'undefined' ? Notification.permission : 'denied';\n\t\t\t\tvar _stInboxHookTypes = {\n\t\t\t\t\t'hook.permission-request': 'Permission requested',\n\t\t\t\t\t'hook.stop': 'Agent stopped',\n\t\t\t\t\t'hook.task-completed': 'Task completed'\n\t\t\t\t};\n\t\t\t\tfunction stRequestNotifPermission() {\n\t\t\t\t\tif (typeof Notification === 'undefined') return;\n\t\t\t\t\tif (Notification.permission === 'default') {\n\t\t\t\t\t\tNotification.requestPermission().then(function(p) { _stNotifPermission = p; });\n\t\t\t\t\t}\n\t\t\t\t}\n\t\t\t\tfunction stSendInboxNotification(hookName, ticketID) {\n\t\t\t\t\tif (_stNotifPermission !== 'granted') return;\n\t\t\t\t\tvar title = _stInboxHookTypes[hookName];\n\t\t\t\t\tif (!title) return;\n\t\t\t\t\tvar body = ticketID ? ticketID : 'An agent needs your attention';\n\t\t\t\t\ttry {\n\t\t\t\t\t\tvar n = new Notification('smoovtask: ' + title, {\n\t\t\t\t\t\t\tbody: body,\n\t\t\t\t\t\t\ticon: '/static/logo.png',\n\t\t\t\t\t\t\ttag: 'st-inbox-' + hookName + '-' + (ticketID || ''),\n\t\t\t\t\t\t\trenotify: true\n\t\t\t\t\t\t});\n\t\t\t\t\t\tn.onclick = function() { window.focus(); n.close(); };\n\t\t\t\t\t} catch(_) {}\n\t\t\t\t}\n\n\t\t\t\t// Auto-inject project param into all HTMX requests.\n\t\t\t\tdocument.body.addEventListener('htmx:configRequest', function(e) {\n\t\t\t\t\tvar sel = document.getElementById('st-project-select');\n\t\t\t\t\tif (!sel || !sel.value) return;\n\t\t\t\t\tif (!e.detail.parameters['project']) {\n\t\t\t\t\t\te.detail.parameters['project'] = sel.value;\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tdocument.addEventListener('animationend', function(e) {\n\t\t\t\t\tif (e.target.classList.contains('st-session-dot-flash')) {\n\t\t\t\t\t\te.target.classList.remove('st-session-dot-flash');\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\tdocument.addEventListener('mouseover', function(e) {\n\t\t\t\t\tvar pill = e.target.closest('.st-assignee-pill[data-run-id]');\n\t\t\t\t\tif (!pill) return;\n\t\t\t\t\tupdateAssigneePillTooltips(pill.getAttribute('data-run-id'));\n\t\t\t\t});\n\n\t\t\t\t// Listen for agent-ping events on the unified SSE stream.\n\t\t\t\t// The HTMX SSE extension stores the EventSource internally;\n\t\t\t\t// htmx:sseOpen fires when the connection is (re)established.\n\t\t\t\tdocument.body.addEventListener('htmx:sseOpen', function(e) {\n\t\t\t\t\tvar source = e.detail && e.detail.source;\n\t\t\t\t\tif (!source || source._stPingBound) return;\n\t\t\t\t\tsource._stPingBound = true;\n\t\t\t\t\tsource.addEventListener('ping', function(evt) {\n\t\t\t\t\t\thandleAgentPing({detail: evt});\n\t\t\t\t\t\t// Browser notification for inbox-worthy events.\n\t\t\t\t\t\ttry {\n\t\t\t\t\t\t\tvar p = JSON.parse(evt.data || '{}');\n\t\t\t\t\t\t\tif (p.hook && _stInboxHookTypes[p.hook]) {\n\t\t\t\t\t\t\t\tstSendInboxNotification(p.hook, p.ticket || '');\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t} catch(_) {}\n\t\t\t\t\t});\n\t\t\t\t});\n\n\t\t\t\tvar _ticketModalOpen = false;\n\n\t\t\t\t// Close modal on form success (HX-Trigger: closeModal).\n\t\t\t\tdocument.body.addEventListener('closeModal', function() {\n\t\t\t\t\tdocument.getElementById('ticket-modal').close();\n\t\t\t\t});\n\n\t\t\t\t// Click delegation: copy-to-clipboard and table row modal opens.\n\t\t\t\tdocument.addEventListener('click', function(e) {\n\t\t\t\t\tvar copyTarget = e.target.closest('[data-copy-ticket-id]');\n\t\t\t\t\tif (copyTarget) {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\te.stopImmediatePropagation();\n\t\t\t\t\t\tvar ticketID = copyTarget.getAttribute('data-copy-ticket-id');\n\t\t\t\t\t\tif (!ticketID) return;\n\t\t\t\t\t\tfunction copiedNotice() {\n\t\t\t\t\t\t\tvar toast = document.createElement('div');\n\t\t\t\t\t\t\ttoast.className = 'toast toast-end z-50';\n\t\t\t\t\t\t\ttoast.innerHTML = '<div class=\"alert alert-info\"><span>Copied ' + ticketID + '</span></div>';\n\t\t\t\t\t\t\tdocument.body.appendChild(toast);\n\t\t\t\t\t\t\tsetTimeout(function() { toast.remove(); }, 1200);\n\t\t\t\t\t\t}\n\t\t\t\t\t\tif (navigator.clipboard && navigator.clipboard.writeText) {\n\t\t\t\t\t\t\tnavigator.clipboard.writeText(ticketID).then(copiedNotice);\n\t\t\t\t\t\t\treturn;\n\t\t\t\t\t\t}\n\t\t\t\t\t\tvar area = document.createElement('textarea');\n\t\t\t\t\t\tarea.value = ticketID;\n\t\t\t\t\t\tarea.style.position = 'fixed';\n\t\t\t\t\t\tarea.style.opacity = '0';\n\t\t\t\t\t\tdocument.body.appendChild(area);\n\t\t\t\t\t\tarea.focus();\n\t\t\t\t\t\tarea.select();\n\t\t\t\t\t\ttry {\n\t\t\t\t\t\t\tdocument.execCommand('copy');\n\t\t\t\t\t\t\tcopiedNotice();\n\t\t\t\t\t\t} finally {\n\t\t\t\t\t\t\tdocument.body.removeChild(area);\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\t// Inbox row click: mark as read and open modal.\n\t\t\t\t\tvar inboxRow = e.target.closest('.st-inbox-row[data-inbox-id]');\n\t\t\t\t\tif (inboxRow) {\n\t\t\t\t\t\tstMarkRead(inboxRow.getAttribute('data-inbox-id'));\n\t\t\t\t\t\tinboxRow.classList.add('st-inbox-read');\n\t\t\t\t\t\tvar partial = inboxRow.dataset.partial;\n\t\t\t\t\t\tif (partial) {\n\t\t\t\t\t\t\thtmx.ajax('GET', partial, {target: '#ticket-modal-body', swap: 'innerHTML'});\n\t\t\t\t\t\t}\n\t\t\t\t\t\treturn;\n\t\t\t\t\t}\n\t\t\t\t\tvar row = e.target.closest('tr[data-href]');\n\t\t\t\t\tif (row && !e.target.closest('a')) {\n\t\t\t\t\t\tvar partial = row.dataset.partial;\n\t\t\t\t\t\tif (partial) {\n\t\t\t\t\t\t\thtmx.ajax('GET', partial, {target: '#ticket-modal-body', swap: 'innerHTML'});\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Unlock audio on first user interaction (browser autoplay policy).\n\t\t\t\tdocument.addEventListener('click', function() {\n\t\t\t\t\tif (!_stAudioUnlocked) _stAudioUnlocked = true;\n\t\t\t\t}, { once: true });\n\n\t\t\t\t// Initial scroll indicator setup.\n\t\t\t\tdocument.addEventListener('DOMContentLoaded', function() {\n\t\t\t\t\tvar board = document.querySelector('.st-board');\n\t\t\t\t\tif (board) board.addEventListener('scroll', updateScrollIndicators);\n\t\t\t\t\tupdateScrollIndicators();\n\t\t\t\t\tstApplyInboxReadState();\n\t\t\t\t\tstFormatLocalTimes();\n\t\t\t\t\tstRequestNotifPermission();\n\t\t\t\t\tsyncAgentActivityState();\n\t\t\t\t\tstSyncMuteIcon();\n\t\t\t\t\tsetInterval(function() {\n\t\t\t\t\t\tupdateDotActivityStates();\n\t\t\t\t\t\tstUpdateStalledIndicators();\n\t\t\t\t\t}, 5000);\n\t\t\t\t});\n\n\t\t\t\t// Consolidated htmx:afterSwap handler.\n\t\t\t\tdocument.addEventListener('htmx:afterSwap', function(e) {\n\t\t\t\t\t// Re-bind scroll indicators when board is swapped in.\n\t\t\t\t\tvar board = document.querySelector('.st-board');\n\t\t\t\t\tif (board) board.addEventListener('scroll', updateScrollIndicators);\n\t\t\t\t\tupdateScrollIndicators();\n\t\t\t\t\tsyncAgentActivityState();\n\t\t\t\t\tstApplyInboxReadState();\n\t\t\t\t\tstFormatLocalTimes();\n\n\t\t\t\t\t// Scroll to top on content navigation (not SSE refresh).\n\t\t\t\t\tif (e.detail.target && e.detail.target.id === 'content') {\n\t\t\t\t\t\tvar main = e.detail.target.closest('main');\n\t\t\t\t\t\tif (main) main.scrollTop = 0;\n\t\t\t\t\t}\n\n\t\t\t\t\t// Ticket modal management.\n\t\t\t\t\tif (e.detail.target && (e.detail.target.id === 'ticket-modal-body' || e.detail.target.closest('#ticket-modal-body'))) {\n\t\t\t\t\t\tif (!_ticketModalOpen) {\n\t\t\t\t\t\t\tdocument.getElementById('ticket-modal').showModal();\n\t\t\t\t\t\t\t_ticketModalOpen = true;\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Update active nav link and page title on htmx navigation.\n\t\t\t\tdocument.addEventListener('htmx:pushedIntoHistory', function(e) {\n\t\t\t\t\tvar path = e.detail.path || window.location.pathname;\n\t\t\t\t\tdocument.querySelectorAll('.st-main-nav a.tab').forEach(function(tab) {\n\t\t\t\t\t\tvar linkPath = new URL(tab.href, window.location.origin).pathname;\n\t\t\t\t\t\tif (linkPath === path || (linkPath === '/' && path === '/')) {\n\t\t\t\t\t\t\ttab.classList.add('tab-active');\n\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\ttab.classList.remove('tab-active');\n\t\t\t\t\t\t}\n\t\t\t\t\t});\n\t\t\t\t\tvar titleMap = {'/': 'Board', '/inbox': 'Inbox', '/sessions': 'Sessions', '/activity': 'Activity', '/critical-path': 'Critical Path', '/projects': 'Projects', '/rules': 'Rules'};\n\t\t\t\t\tvar title = titleMap[path];\n\t\t\t\t\tif (!title && path.startsWith('/ticket/')) {\n\t\t\t\t\t\tvar h1 = document.querySelector('#content h1');\n\t\t\t\t\t\ttitle = h1 ? h1.textContent.trim() : 'Ticket';\n\t\t\t\t\t}\n\t\t\t\t\tif (title) document.title = title + ' — smoovtask';\n\t\t\t\t\t// Preserve project param in pushed URL.\n\t\t\t\t\tvar projectSel = document.getElementById('st-project-select');\n\t\t\t\t\tif (projectSel && projectSel.value) {\n\t\t\t\t\t\tvar pushUrl = new URL(window.location);\n\t\t\t\t\t\tif (!pushUrl.searchParams.has('project')) {\n\t\t\t\t\t\t\tpushUrl.searchParams.set('project', projectSel.value);\n\t\t\t\t\t\t\thistory.replaceState({}, '', pushUrl);\n\t\t\t\t\t\t}\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Handle browser back/forward.\n\t\t\t\twindow.addEventListener('popstate', function() {\n\t\t\t\t\tvar modalEl = document.getElementById('ticket-modal');\n\t\t\t\t\tif (modalEl && _ticketModalOpen) {\n\t\t\t\t\t\tmodalEl.close();\n\t\t\t\t\t}\n\t\t\t\t\tvar path = window.location.pathname;\n\t\t\t\t\tvar partialMap = {'/': '/partials/board', '/inbox': '/partials/inbox', '/sessions': '/partials/sessions', '/activity': '/partials/activity', '/critical-path': '/partials/critical-path', '/projects': '/partials/projects', '/rules': '/partials/rules'};\n\t\t\t\t\tvar partial = partialMap[path];\n\t\t\t\t\tif (!partial && path.startsWith('/ticket/')) {\n\t\t\t\t\t\tpartial = '/partials' + path;\n\t\t\t\t\t}\n\t\t\t\t\tif (partial) {\n\t\t\t\t\t\tvar url = partial;\n\t\t\t\t\t\tif (window.location.search) url += window.location.search;\n\t\t\t\t\t\thtmx.ajax('GET', url, {target: '#content', swap: 'innerHTML'});\n\t\t\t\t\t\tdocument.querySelectorAll('.st-main-nav a.tab').forEach(function(tab) {\n\t\t\t\t\t\t\tvar linkPath = new URL(tab.href, window.location.origin).pathname;\n\t\t\t\t\t\t\tif (linkPath === path) {\n\t\t\t\t\t\t\t\ttab.classList.add('tab-active');\n\t\t\t\t\t\t\t} else {\n\t\t\t\t\t\t\t\ttab.classList.remove('tab-active');\n\t\t\t\t\t\t\t}\n\t\t\t\t\t\t});\n\t\t\t\t\t\tsyncAgentActivityState();\n\t\t\t\t\t}\n\t\t\t\t\t// Sync project dropdown from URL on back/forward.\n\t\t\t\t\tvar projectSel = document.getElementById('st-project-select');\n\t\t\t\t\tif (projectSel) {\n\t\t\t\t\t\tvar urlProject = new URL(window.location).searchParams.get('project') || '';\n\t\t\t\t\t\tprojectSel.value = urlProject;\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Modal hidden cleanup.\n\t\t\t\tdocument.getElementById('ticket-modal').addEventListener('close', function() {\n\t\t\t\t\t_ticketModalOpen = false;\n\t\t\t\t\tdocument.getElementById('ticket-modal-header').innerHTML = '';\n\t\t\t\t\tdocument.getElementById('ticket-modal-body').innerHTML = '';\n\t\t\t\t});\n\n\t\t\t\t// Search modal: Cmd+K / Ctrl+K to open.\n\t\t\t\tdocument.addEventListener('keydown', function(e) {\n\t\t\t\t\tif ((e.metaKey || e.ctrlKey) && e.key === 'k') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tstOpenSearch();\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Search modal: keyboard navigation.\n\t\t\t\tdocument.getElementById('search-input').addEventListener('keydown', function(e) {\n\t\t\t\t\tvar items = document.querySelectorAll('.st-search-item[data-ticket-id]');\n\t\t\t\t\tif (e.key === 'ArrowDown') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tif (_stSearchIndex < items.length - 1) {\n\t\t\t\t\t\t\t_stSearchIndex++;\n\t\t\t\t\t\t\tstRenderSearchResults();\n\t\t\t\t\t\t}\n\t\t\t\t\t} else if (e.key === 'ArrowUp') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tif (_stSearchIndex > 0) {\n\t\t\t\t\t\t\t_stSearchIndex--;\n\t\t\t\t\t\t\tstRenderSearchResults();\n\t\t\t\t\t\t}\n\t\t\t\t\t} else if (e.key === 'Enter') {\n\t\t\t\t\t\te.preventDefault();\n\t\t\t\t\t\tstSelectSearchResult();\n\t\t\t\t\t}\n\t\t\t\t});\n\n\t\t\t\t// Search modal: full-text search as you type.\n\t\t\t\tdocument.getElementById('search-input').addEventListener('input', function() {\n\t\t\t\t\tstRunSearch(this.value);\n\t\t\t\t});\n\n\t\t\t\t// Search modal: click to select result.\n\t\t\t\tdocument.getElementById('search-results').addEventListener('click', function(e) {\n\t\t\t\t\tvar item = e.target.closest('.st-search-item[data-ticket-id]');\n\t\t\t\t\tif (!item) return;\n\t\t\t\t\tvar idx = parseInt(item.getAttribute('data-search-idx'), 10);\n\t\t\t\t\tif (!isNaN(idx)) _stSearchIndex = idx;\n\t\t\t\t\tstSelectSearchResult();\n\t\t\t\t});\n\n\t\t\t\t// Search modal cleanup on close.\n\t\t\t\tdocument.getElementById('search-modal').addEventListener('close', function() {\n\t\t\t\t\tdocument.getElementById('search-input').value = '';\n\t\t\t\t\tdocument.getElementById('search-results').innerHTML = '';\n\t\t\t\t});\n\n\t\t\t\t// --- Search modal ---\n\t\t\tvar _stSearchResults = [];\n\t\t\tvar _stSearchQuery = '';\n\t\t\tvar _stSearchIndex = 0;\n\t\t\tvar _stSearchTimer = null;\n\t\t\tvar _stSearchSeq = 0;\n\n\t\t\t// stRunSearch queries the full-text index (debounced), scoped to the\n\t\t\t// selected project. Stale responses are dropped.\n\t\t\tfunction stRunSearch(query) {\n\t\t\t\tclearTimeout(_stSearchTimer);\n\t\t\t\t_stSearchTimer = setTimeout(function() {\n\t\t\t\t\tvar seq = ++_stSearchSeq;\n\t\t\t\t\tvar params = new URLSearchParams({ q: query, limit: '50' });\n\t\t\t\t\tvar project = stGetCurrentProject();\n\t\t\t\t\tif (project) params.set('project', project);\n\t\t\t\t\tfetch('/api/search-tickets?' + params.toString())\n\t\t\t\t\t\t.then(function(r) {\n\t\t\t\t\t\t\tif (!r.ok) return r.text().then(function(t) { throw new Error(t); });\n\t\t\t\t\t\t\treturn r.json();\n\t\t\t\t\t\t})\n\t\t\t\t\t\t.then(function(data) {\n\t\t\t\t\t\t\tif (seq !== _stSearchSeq) return;\n\t\t\t\t\t\t\t_stSearchResults = data || [];\n\t\t\t\t\t\t\t_stSearchQuery = query;\n\t\t\t\t\t\t\t_stSearchIndex = 0;\n\t\t\t\t\t\t\tstRenderSearchResults();\n\t\t\t\t\t\t})\n\t\t\t\t\t\t.catch(function(err) {\n\t\t\t\t\t\t\tif (seq !== _stSearchSeq) return;\n\t\t\t\t\t\t\t_stSearchResults = [];\n\t\t\t\t\t\t\tdocument.getElementById('search-results').innerHTML = '<div class=\"st-search-empty\">' + stEscapeHtml(err.message || 'Search failed') + '</div>';\n\t\t\t\t\t\t});\n\t\t\t\t}, 120);\n\t\t\t}\n\n\t\t\tfunction stRenderSearchResults() {\n\t\t\t\tvar container = document.getElementById('search-results');\n\t\t\t\tif (!container) return;\n\t\t\t\tvar tickets = _stSearchResults;\n\t\t\t\tif (tickets.length === 0) {\n\t\t\t\t\tcontainer.innerHTML = '<div class=\"st-search-empty\">No matching tickets</div>';\n\t\t\t\t\treturn;\n\t\t\t\t}\n\t\t\t\tvar html = '';\n\t\t\t\tfor (var i = 0; i < tickets.length; i++) {\n\t\t\t\t\tvar tk = tickets[i];\n\t\t\t\t\tvar activeClass = i === _stSearchIndex ? ' st-search-active' : '';\n\t\t\t\t\thtml += '<div class=\"st-search-item' + activeClass + '\" data-search-idx=\"' + i + '\" data-ticket-id=\"' + tk.id + '\">';\n\t\t\t\t\thtml += '<span class=\"badge badge-sm st-priority-' + tk.priority.toLowerCase() + '\">' + tk.priority + '</span>';\n\t\t\t\t\thtml += '<div class=\"st-search-item-body\"><div class=\"st-search-item-title\">' + stEscapeHtml(tk.title) + '</div>';\n\t\t\t\t\tif (tk.snippet && tk.section !== 'Title') {\n\t\t\t\t\t\thtml += '<div class=\"st-search-item-snippet\">' + stEscapeHtml(tk.section + ': ' + tk.snippet) + '</div>';\n\t\t\t\t\t}\n\t\t\t\t\thtml += '</div>';\n\t\t\t\t\thtml += '<span class=\"st-search-item-meta\"><span>' + tk.id + '</span><span>' + stEscapeHtml(tk.project) + '</span></span>';\n\t\t\t\t\thtml += '</div>';\n\t\t\t\t}\n\t\t\t\tvar all = '/search?q=' + encodeURIComponent(_stSearchQuery);\n\t\t\t\thtml += '<div class=\"st-search-hint\">\\u2191\\u2193 navigate \\u00b7 enter to open \\u00b7 esc to close';\n\t\t\t\tif (_stSearchQuery.trim()) html += ' \\u00b7 <a class=\"link\" href=\"' + all + '\">all results</a>';\n\t\t\t\thtml += '</div>';\n\t\t\t\tcontainer.innerHTML = html;\n\t\t\t\tstScrollActiveIntoView();\n\t\t\t}\n\n\t\t\tfunction stEscapeHtml(s) {\n\t\t\t\tvar d = document.createElement('div');\n\t\t\t\td.textContent = s;\n\t\t\t\treturn d.innerHTML;\n\t\t\t}\n\n\t\t\tfunction stScrollActiveIntoView() {\n\t\t\t\tvar active = document.querySelector('.st-search-item.st-search-active');\n\t\t\t\tif (active) active.scrollIntoView({ block: 'nearest' });\n\t\t\t}\n\n\t\t\tfunction stGetCurrentProject() {\n\t\t\t\tvar sel = document.getElementById('st-project-select');\n\t\t\t\treturn (sel && sel.value) ? sel.value : '';\n\t\t\t}\n\n\t\t\tfunction stOpenSearch() {\n\t\t\t\tvar modal = document.getElementById('search-modal');\n\t\t\t\tvar input = document.getElementById('search-input');\n\t\t\t\tif (!modal || !input) return;\n\t\t\t\tmodal.showModal();\n\t\t\t\tinput.value = '';\n\t\t\t\t_stSearchIndex = 0;\n\t\t\t\tdocument.getElementById('search-results').innerHTML = '<div class=\"st-search-empty\">Loading...</div>';\n\t\t\t\tstRunSearch('');\n\t\t\t\tinput.focus();\n\t\t\t}\n\n\t\t\tfunction stCloseSearch() {\n\t\t\t\tvar modal = document.getElementById('search-modal');\n\t\t\t\tif (modal) modal.close();\n\t\t\t}\n\n\t\t\tfunction stSelectSearchResult() {\n\t\t\t\tvar items = document.querySelectorAll('.st-search-item[data-ticket-id]');\n\t\t\t\tif (_stSearchIndex >= 0 && _stSearchIndex < items.length) {\n\t\t\t\t\tvar ticketID = items[_stSearchIndex].getAttribute('data-ticket-id');\n\t\t\t\t\tstCloseSearch();\n\t\t\t\t\thtmx.ajax('GET', '/partials/ticket/' + ticketID, { target: '#ticket-modal-body', swap: 'innerHTML' });\n\t\t\t\t}\n\t\t\t}\n\n\t\t\twindow.addEventListener('beforeunload', function() {\n\t\t\t\t\tif (window._stLastHookByRunID) {\n\t\t\t\t\t\twindow._stLastHookByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\tif (window._stLastHookNameByRunID) {\n\t\t\t\t\t\twindow._stLastHookNameByRunID = {};\n\t\t\t\t\t}\n\t\t\t\t\tif (window._stWantedRunIDs) {\n\t\t\t\t\t\twindow._stWantedRunIDs = {};\n\t\t\t\t\t}\n\t\t\t\t\tif (window._stRunStalledState) {\n\t\t\t\t\t\twindow._stRunStalledState = {};\n\t\t\t\t\t}\n\t\t\t\t});\n\t\t\t\t}\n\t\t\t</script></body></html>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -35,14 +35,29 @@ func CanTransition(from, to ticket.Status) bool {
 	return slices.Contains(allowed, to)
 }
 
-// ValidateTransition checks if the transition is valid and returns an error with guidance if not.
-func ValidateTransition(from, to ticket.Status) error {
-	if from == to {
-		return fmt.Errorf("ticket is already %s", from)
+// Allowed returns the statuses a ticket in from may move to. BLOCKED
+// returns to its prior status instead, so it has none.
+func Allowed(from ticket.Status) []ticket.Status {
+	return slices.Clone(transitions[from])
+}
+
+// TransitionError reports a status change the workflow does not allow.
+type TransitionError struct {
+	From, To ticket.Status
+}
+
+func (e *TransitionError) Error() string {
+	if e.From == e.To {
+		return fmt.Sprintf("ticket is already %s", e.From)
 	}
+	return fmt.Sprintf("cannot move from %s to %s", e.From, e.To)
+}
 
-	if !CanTransition(from, to) {
-		return fmt.Errorf("cannot move from %s to %s", from, to)
+// ValidateTransition checks if the transition is valid and returns a
+// *TransitionError if not.
+func ValidateTransition(from, to ticket.Status) error {
+	if from == to || !CanTransition(from, to) {
+		return &TransitionError{From: from, To: to}
 	}
 
 	return nil
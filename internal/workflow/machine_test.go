@@ -1,6 +1,8 @@
 package workflow
 
 import (
+	"errors"
+	"slices"
 	"testing"
 
 	"github.com/boozedog/smoovtask/internal/ticket"
@@ -122,3 +124,20 @@ func TestStatusFromAlias(t *testing.T) {
 		t.Error("expected error for invalid alias")
 	}
 }
+
+func TestValidateTransition_Error(t *testing.T) {
+	err := ValidateTransition(ticket.StatusOpen, ticket.StatusDone)
+	var te *TransitionError
+	if !errors.As(err, &te) {
+		t.Fatalf("err = %v, want *TransitionError", err)
+	}
+	if te.From != ticket.StatusOpen || te.To != ticket.StatusDone {
+		t.Errorf("TransitionError = %+v", te)
+	}
+	if err.Error() != "cannot move from OPEN to DONE" {
+		t.Errorf("message = %q", err.Error())
+	}
+	if !slices.Contains(Allowed(ticket.StatusOpen), ticket.StatusInProgress) {
+		t.Errorf("Allowed(OPEN) = %v, want IN-PROGRESS included", Allowed(ticket.StatusOpen))
+	}
+}
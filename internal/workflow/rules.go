@@ -15,6 +15,13 @@ func RequiresAssignee(to ticket.Status) bool {
 	return to == ticket.StatusInProgress
 }
 
+// RequiresSections returns true if the target status requires the required
+// sections of the ticket's template to be filled in (see
+// ticket.MissingSections).
+func RequiresSections(to ticket.Status) bool {
+	return to == ticket.StatusOpen
+}
+
 // RequiresNote returns true if the transition requires that a note was added
 // since the ticket entered its current status.
 func RequiresNote(from, to ticket.Status) bool {